@@ -1,21 +1,79 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/x/term"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/ensigniasec/run-mcp/internal/allowlist"
 	api "github.com/ensigniasec/run-mcp/internal/api"
+	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
+	"github.com/ensigniasec/run-mcp/internal/config"
+	"github.com/ensigniasec/run-mcp/internal/diagnose"
+	"github.com/ensigniasec/run-mcp/internal/inspect"
+	"github.com/ensigniasec/run-mcp/internal/integrations"
+	"github.com/ensigniasec/run-mcp/internal/metrics"
+	"github.com/ensigniasec/run-mcp/internal/proxy"
 	"github.com/ensigniasec/run-mcp/internal/scanner"
+	"github.com/ensigniasec/run-mcp/internal/signing"
 	"github.com/ensigniasec/run-mcp/internal/storage"
+	"github.com/ensigniasec/run-mcp/internal/telemetry"
 	"github.com/ensigniasec/run-mcp/internal/tui"
+	"github.com/ensigniasec/run-mcp/internal/update"
 	"github.com/ensigniasec/run-mcp/internal/validate"
 )
 
+const defaultInspectTimeout = 30 * time.Second
+
+// defaultScanTimeout bounds a scan, including waiting for ratings, unless --timeout is given.
+const defaultScanTimeout = 30 * time.Second
+
+// uploadTimeout bounds how long scan --upload may delay exit, including API client start-up.
+const uploadTimeout = 10 * time.Second
+
+// uploadEnv enables scan --upload by default when set to a true value.
+const uploadEnv = "RUN_MCP_UPLOAD"
+
+// apiURLEnv overrides the ratings API base URL, e.g. for staging or a local mock server.
+const apiURLEnv = "RUN_MCP_API_URL"
+
+// apiCACertEnv names a PEM file of extra CA certificates to trust for the ratings API.
+const apiCACertEnv = "RUN_MCP_API_CA_CERT"
+
+// azdoRunnerEnv is set by Azure Pipelines agents; scan then defaults to --format azdo.
+const azdoRunnerEnv = "SYSTEM_COLLECTIONURI"
+
+// releasesURLEnv overrides the latest-release URL used by scan --update-check, e.g. for a mirror.
+const releasesURLEnv = "RUN_MCP_RELEASES_URL"
+
+// Exit codes for scan gating flags (--check, --check-secrets, --fail-on-severity).
+const (
+	exitClean     = 0
+	exitFindings  = 1
+	exitScanError = 2
+)
+
+// exitVerifyFailed is returned by 'scan verify' when a signature cannot be verified.
+const exitVerifyFailed = 2
+
 //nolint:gochecknoglobals // Cobra requires package-level vars for flag bindings in current structure.
 var (
 	// Version metadata populated at build time via -ldflags.
@@ -24,13 +82,129 @@ var (
 	date           = "unknown"
 
 	// Used for flags.
-	storageFile = "~/Library/Application Support/run-mcp/results.json" // hardcoded default storage path. wiring for --storage-file flag left in place in case we care to add it back.
+	storageFile = storage.DefaultStoragePath() // OS-specific default storage path. wiring for --storage-file flag left in place in case we care to add it back.
 	verbose     bool
 	jsonOutput  bool
 	offline     bool
 	orgUUID     string
 	anonymous   bool
 	tuiMode     bool
+	noBanner    bool
+
+	// Scan-only flags.
+	listWellKnown bool
+	listAll       bool
+	noWellKnown   bool
+	wellKnownOnly bool
+	projectOnly   bool
+	systemOnly    bool
+	noProjPaths   bool
+	validateOnly  bool
+	outputFile    string
+	reportDir     string
+	reportStamp   bool
+	outputFormat  string
+	anonPaths     bool
+	anonPathsSalt string
+	redactNames   bool
+	redactSalt    string
+	verboseJSON   bool
+	cacheTTL      time.Duration
+	noCache       bool
+	failOnSev     string
+	checkMode     bool
+	checkSecrets  bool
+	quiet         bool
+	countsOnly    bool
+	since         string
+	otelEndpoint  string
+	environment   string
+	suppressFile  string
+	rulesFile     string
+	annotateFile  string
+	metricsAddr   string
+	signKeyFile   string
+	sbomFile      string
+	redactEnv     bool
+	category      string
+	scanTimeout   time.Duration
+	scanFormat    string
+	stdinInput    bool
+	stdinFormat   string
+	upload        bool
+	contextLines  int
+	noSecrets     bool
+	secretsOnly   bool
+	noUpload      bool
+	minConfidence string
+	entropyBits   float64
+	mockAPIFile   string
+	ghPRComment   bool
+	slackWebhook  string
+	scanWorkers   int
+	explain       bool
+	scanInclude   []string
+	scanExclude   []string
+	dryRun        bool
+	normalizeName bool
+	aggSecrets    bool
+	maxFileSize   string
+	scanProfile   bool
+	noColor       bool
+	updateCheck   bool
+	suppressErrs  bool
+	perPage       int
+	scanLabels    []string
+	hashAlgorithm string
+	sortOrder     string
+	interactive   bool
+	skipServers   []string
+	showStats     bool
+	maxDepth      int
+
+	// Scan aggregate flags.
+	aggregateGlobs []string
+
+	// Keygen flags.
+	keygenOut string
+
+	// Scan history flags.
+	historyPruneBefore string
+
+	// Scan suppress flags.
+	suppressKind    string
+	suppressReason  string
+	suppressExpires string
+
+	// Inspect-only flags.
+	inspectTimeout time.Duration
+	inspectConfigs []string
+
+	// Proxy-only flags.
+	proxyServer        string
+	proxyConfigs       []string
+	proxyLogFile       string
+	proxyRedactSecrets bool
+	proxyEnforcePolicy bool
+
+	// Allowlist import/export flags.
+	allowlistFormat  string
+	allowlistFile    string
+	allowlistMerge   bool
+	allowlistReplace bool
+
+	// Storage import/export flags.
+	storageFormat     string
+	storageTransferIO string
+	storageMerge      bool
+	storageDryRun     bool
+
+	// Completion-only flags.
+	completionInstall bool
+
+	// Config flags.
+	configDir   string
+	configForce bool
 
 	rootCmd = &cobra.Command{
 		Use:   "run-mcp",
@@ -47,6 +221,8 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable detailed logging output")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output results in JSON format instead of rich text")
 	rootCmd.PersistentFlags().BoolVar(&tuiMode, "tui", false, "Enable interactive TUI mode with real-time progress")
+	rootCmd.PersistentFlags().
+		BoolVar(&noBanner, "no-banner", false, "Do not print the ANSI art banner (also implied by NO_COLOR or TERM=dumb)")
 	rootCmd.PersistentFlags().
 		BoolVar(&offline, "offline", false, "Optional: Run the scanner in offline mode, only outputs findings without security ratings")
 	rootCmd.PersistentFlags().
@@ -55,17 +231,242 @@ func init() {
 		BoolVar(&anonymous, "anonymous", false, "Optional: Do not send any UUIDs or tracking information")
 	// Alias for --anonymous
 	rootCmd.PersistentFlags().BoolVar(&anonymous, "anon", false, "Alias of --anonymous")
+	rootCmd.PersistentPreRunE = loadConfig
+
+	scanCmd.Flags().
+		BoolVar(&listWellKnown, "list-well-known", false, "Print the well-known config paths that exist on this system without scanning")
+	scanCmd.Flags().
+		BoolVar(&listAll, "all", false, "With --list-well-known, also print paths that do not exist")
+	scanCmd.Flags().
+		BoolVar(&stdinInput, "stdin", false, "Scan a single config read from standard input (reported as <stdin>)")
+	scanCmd.Flags().
+		StringVar(&stdinFormat, "stdin-format", "json", "Format of the config read with --stdin: json or yaml")
+	scanCmd.Flags().
+		BoolVar(&noWellKnown, "no-well-known", false, "Only scan the given paths; never fall back to well-known config paths")
+	scanCmd.Flags().
+		BoolVar(&wellKnownOnly, "well-known-only", false, "Only scan well-known config paths, ignoring any given paths")
+	scanCmd.Flags().
+		BoolVar(&projectOnly, "project-only", false, "Limit well-known config paths to project-level ones (working directory and git root)")
+	scanCmd.Flags().
+		BoolVar(&systemOnly, "system-only", false, "Limit well-known config paths to OS- and user-level ones, excluding project paths")
+	scanCmd.Flags().
+		BoolVar(&noProjPaths, "no-project-paths", false, "Skip the project-level well-known config paths of the working directory and git root, e.g. when auditing a whole machine")
+	scanCmd.Flags().
+		BoolVar(&validateOnly, "validate-configs", false, "Only validate config files and report problems; exits 1 if any file is invalid. No network access")
+	scanCmd.Flags().
+		StringVar(&outputFile, "output-file", "", "Also write the results to this file, independent of stdout output")
+	scanCmd.Flags().
+		StringVar(&reportDir, "report-dir", "", "Also write report.json and report.txt to this directory, creating it if needed")
+	scanCmd.Flags().
+		BoolVar(&reportStamp, "report-timestamp", false, "Suffix the --report-dir directory name with the scan start time, e.g. reports-20250601T120000Z")
+	scanCmd.Flags().
+		StringVar(&outputFormat, "output-format", scanner.FormatJSON, "Format for --output-file: json or text")
+	scanCmd.Flags().
+		BoolVar(&verboseJSON, "verbose-json", false, "Output the full scan result, including raw server configs, as JSON (unstable schema)")
+	scanCmd.Flags().
+		DurationVar(&cacheTTL, "ratings-cache-ttl", scanner.DefaultRatingsCacheTTL, "Reuse cached ratings younger than this instead of resubmitting")
+	scanCmd.Flags().
+		BoolVar(&noCache, "no-ratings-cache", false, "Ignore and do not update the local ratings cache")
+	scanCmd.Flags().
+		StringVar(&failOnSev, "fail-on-severity", "", "Exit 1 if any rated server or launch misconfiguration is at or above this severity: low, medium, high, critical")
+	scanCmd.Flags().
+		BoolVar(&checkMode, "check", false, "Alias for --fail-on-severity=low: exit 1 on any rated finding or launch misconfiguration")
+	scanCmd.Flags().
+		BoolVar(&checkSecrets, "check-secrets", false, "Exit 1 if any secret is found")
+	scanCmd.Flags().
+		BoolVarP(&quiet, "quiet", "q", false, "Suppress scan output; only the exit code is reported")
+	scanCmd.Flags().
+		BoolVar(&countsOnly, "severity-counts-only", false, "Only print the number of servers and launch misconfigurations per risk tier and of secrets (one line, or an object with --json)")
+	scanCmd.Flags().
+		StringVar(&since, "since", "", "Only report findings that are new since an RFC 3339 timestamp or a git ref")
+	scanCmd.Flags().
+		StringVar(&otelEndpoint, "otel-endpoint", "", "Export OpenTelemetry traces to this OTLP gRPC endpoint (host:port or URL)")
+	scanCmd.Flags().
+		StringVar(&environment, "environment", "", "Tag the results with an environment name (e.g. dev, staging, prod); informational only")
+	scanCmd.Flags().
+		StringArrayVar(&scanLabels, "label", nil, "Attach key=value metadata to the results, e.g. repo=api or branch=main (repeatable; shown in --json, --upload and the TUI badge)")
+	scanCmd.Flags().
+		StringVar(&hashAlgorithm, "hash-algorithm", scanner.HashSHA256, "Digest for server fingerprints and secret value hashes: sha256, sha3-256 or blake2b-256")
+	scanCmd.Flags().
+		BoolVar(&interactive, "interactive", false, "Prompt on stderr to allow, deny or skip each server not yet on the local allowlist or denylist, and record the decisions")
+	scanCmd.Flags().
+		BoolVar(&showStats, "stats", false, "Print file, server, identifier and rating counts and phase timings to stderr after the results (JSON with --json)")
+	scanCmd.Flags().
+		BoolVar(&anonPaths, "anonymize-paths", false, "Replace file paths in the output with short stable hashes")
+	scanCmd.Flags().
+		StringVar(&anonPathsSalt, "anonymize-paths-salt", "", "Salt for --anonymize-paths hashes, for reproducible values across runs")
+	scanCmd.Flags().
+		BoolVar(&redactNames, "redact-server-names", false, "Replace server names in the output with short stable hashes")
+	scanCmd.Flags().
+		StringVar(&redactSalt, "redact-salt", "", "Salt for --redact-server-names hashes, for reproducible values across runs")
 
+	scanCmd.Flags().
+		BoolVar(&noSecrets, "no-secrets", false, "Skip secret detection; only servers and their ratings are reported")
+	scanCmd.Flags().
+		BoolVar(&secretsOnly, "secrets-only", false, "Only report secret findings; no identifiers are submitted for rating")
+	scanCmd.Flags().
+		IntVar(&contextLines, "context", 0, "Show N lines of masked config around each secret finding")
+	scanCmd.Flags().
+		StringVar(&minConfidence, "min-confidence", scanner.ConfidenceLow, "Only report secrets at or above this confidence: HIGH (provider-matched) or LOW (also entropy-based)")
+	scanCmd.Flags().
+		Float64Var(&entropyBits, "min-confidence-entropy-threshold", scanner.DefaultEntropyThreshold, "Entropy in bits per character above which unrecognised values are reported as LOW confidence secrets")
+	scanCmd.Flags().
+		StringVar(&mockAPIFile, "mock-api", "", "Rate servers from a JSON file mapping server names to ratings instead of the ratings API; works offline")
+	scanCmd.Flags().
+		BoolVar(&ghPRComment, "github-pr-comment", false, "Post the results as a comment on the GitHub pull request being built, updating it on later runs (requires $GITHUB_TOKEN, $GITHUB_REPOSITORY and $GITHUB_SHA)")
+	scanCmd.Flags().
+		StringVar(&slackWebhook, "slack-webhook", "", "Post a summary of critical and high risk servers and exposed secrets to this Slack incoming webhook URL")
+	scanCmd.Flags().
+		IntVar(&scanWorkers, "workers", scanner.DefaultWorkers, "Number of config files to scan concurrently")
+	scanCmd.Flags().
+		StringArrayVar(&scanInclude, "include", nil, "Only scan files whose path or base name matches this glob (repeatable)")
+	scanCmd.Flags().
+		StringArrayVar(&scanExclude, "exclude", nil, "Skip files whose path or base name matches this glob; takes priority over --include (repeatable)")
+	scanCmd.Flags().
+		IntVar(&maxDepth, "max-depth", 0, "Do not walk directory targets more than this many levels deep; 1 covers a directory and its immediate subdirectories [Defaults to no limit]")
+	scanCmd.Flags().
+		BoolVar(&dryRun, "dry-run", false, "Print the files that would be scanned, after --include/--exclude and --max-depth, without scanning or rating them")
+	scanCmd.Flags().
+		StringArrayVar(&skipServers, "skip-server", nil, "Leave out servers with this name (case-insensitive; repeatable); they are listed as SkippedServers in --json")
+	scanCmd.Flags().
+		BoolVar(&normalizeName, "normalize-server-names", false, "Fold case, dashes and underscores and an mcp- prefix or -mcp suffix in server names, so one server named differently across configs is rated and reported once")
+	scanCmd.Flags().
+		BoolVar(&aggSecrets, "aggregate-secrets-by-value", false, "Report each unique secret value once, listing every file and line it appears in")
+	scanCmd.Flags().
+		StringVar(&maxFileSize, "max-file-size", "10MB", "Skip config files larger than this many bytes; accepts KB and MB suffixes (e.g. 5MB)")
+	scanCmd.Flags().
+		BoolVar(&scanProfile, "profile", false, "Time each config file and report the slowest ones (ProfileReport in --json)")
+	scanCmd.Flags().
+		BoolVar(&noColor, "no-color", false, "Disable ANSI colour in text and TUI output (also implied by NO_COLOR or TERM=dumb)")
+	scanCmd.Flags().
+		BoolVar(&updateCheck, "update-check", false, "Warn on stderr when a newer run-mcp release is available (checked at most once a day)")
+	scanCmd.Flags().
+		BoolVar(&suppressErrs, "suppress-file-errors", false, "Skip missing or unreadable files without logging; they are counted in SuppressedErrors")
+	scanCmd.Flags().
+		IntVar(&perPage, "findings-per-page", 0, "List at most N servers in each risk tier section of the text report; --json always has them all [Defaults to no limit]")
+	scanCmd.Flags().
+		StringVar(&sortOrder, "sort", "", "Order servers within each risk tier of the text report by name, risk (highest first) or path [Defaults to the order found]")
+	scanCmd.Flags().
+		BoolVar(&explain, "explain", false, "Add an explanation and remediation guidance to each server and secret finding")
+	uploadDefault, _ := strconv.ParseBool(os.Getenv(uploadEnv))
+	scanCmd.Flags().
+		BoolVar(&upload, "upload", uploadDefault, "Upload a condensed scan record to your organization (requires --org-uuid) [env "+uploadEnv+"]")
+	scanCmd.Flags().
+		BoolVar(&noUpload, "no-upload", false, "Disable --upload, e.g. when enabled via "+uploadEnv)
+	scanCmd.PersistentFlags().
+		StringVar(&suppressFile, "suppress-file", scanner.DefaultSuppressFile, "YAML file of accepted-risk suppressions")
+	scanCmd.Flags().
+		StringVar(&metricsAddr, "metrics-addr", "", "Serve scan metrics for Prometheus at http://<host:port>/metrics while the scan runs [Disabled by default]")
+	scanCmd.Flags().
+		StringVar(&signKeyFile, "sign", "", "Sign the JSON output (--json or a JSON --output-file) with this Ed25519 private key PEM file (see 'run-mcp keygen')")
+	scanCmd.Flags().
+		StringVar(&sbomFile, "sbom", "", "Also write a CycloneDX 1.5 JSON SBOM of the discovered servers to this file")
+	scanCmd.Flags().
+		BoolVar(&redactEnv, "redact-env", false, "Replace every value in server env blocks with \"***\" in the output")
+	scanCmd.Flags().
+		StringVar(&scanFormat, "format", "", "Output format: text, json (same as --json), cyclonedx (a CycloneDX 1.5 VEX report of ratings and secrets), checkstyle (XML for IDE annotations), spdx (an SPDX 2.3 tag-value SBOM), codeclimate (GitLab Code Quality JSON), gitlab-sast (a GitLab SAST report), csv (server and secret tables for spreadsheets), table (an aligned server table) or azdo (Azure Pipelines logging commands, the default when SYSTEM_COLLECTIONURI is set)")
+	scanCmd.Flags().
+		DurationVar(&scanTimeout, "timeout", defaultScanTimeout, "Maximum time for the scan, including waiting for ratings; partial results are reported when it expires")
+	scanCmd.Flags().
+		StringVar(&category, "category", "", "Only report servers of this type: npm, python, docker or binary (local commands without a package or image)")
+	scanCmd.Flags().
+		StringVar(&rulesFile, "rules-file", "", "YAML file of custom policy rules checked against each server config")
+	scanCmd.Flags().
+		StringVar(&annotateFile, "annotations-file", "", "YAML file of notes (ticket, owner, due date) matched to servers by name and to secrets by kind, exactly or by glob")
+
+	scanSuppressCmd.Flags().
+		StringVar(&suppressKind, "kind", "", "Only suppress the server when it has a secret of this kind [Defaults to the whole server]")
+	scanSuppressCmd.Flags().
+		StringVar(&suppressReason, "reason", "", "Why the risk is accepted [Prompted for when omitted]")
+	scanSuppressCmd.Flags().
+		StringVar(&suppressExpires, "expires", "", "Expiry date as YYYY-MM-DD [Prompted for when omitted; empty never expires]")
+	scanCmd.AddCommand(scanSuppressCmd)
+
+	scanHistoryPruneCmd.Flags().
+		StringVar(&historyPruneBefore, "before", "", "Only remove entries recorded before this RFC 3339 timestamp [Defaults to all]")
+	scanHistoryCmd.AddCommand(scanHistoryPruneCmd)
+	scanCmd.AddCommand(scanHistoryCmd)
+	scanCmd.AddCommand(scanCompareCmd)
+	scanCmd.AddCommand(scanVerifyCmd)
+	scanAggregateCmd.Flags().
+		StringArrayVar(&aggregateGlobs, "input-glob", nil, "Also read result files matching this glob (repeatable)")
+	scanCmd.AddCommand(scanAggregateCmd)
+	keygenCmd.Flags().
+		StringVar(&keygenOut, "out", "run-mcp", "Write the key pair to <out>.key and <out>.pub")
+	rootCmd.AddCommand(keygenCmd)
 	rootCmd.AddCommand(scanCmd)
 	rootCmd.AddCommand(experimentalCmd)
 	rootCmd.AddCommand(orgCmd)
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(diagnoseCmd)
+	rootCmd.AddCommand(storageCmd)
+	rootCmd.AddCommand(configCmd)
+
+	configInitCmd.Flags().
+		StringVar(&configDir, "path", "", "Directory to create config.yaml in (default: the OS user config directory)")
+	configInitCmd.Flags().BoolVar(&configForce, "force", false, "Overwrite an existing config file")
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configShowCmd)
+
+	// Wire up completion subcommands.
+	completionCmd.PersistentFlags().
+		BoolVar(&completionInstall, "install", false, "Write the completion script to your shell's profile instead of stdout")
+	completionCmd.AddCommand(completionBashCmd)
+	completionCmd.AddCommand(completionZshCmd)
+	completionCmd.AddCommand(completionFishCmd)
+	completionCmd.AddCommand(completionPowerShellCmd)
+	_ = rootCmd.RegisterFlagCompletionFunc("org-uuid", completeOrgUUID)
 
 	allowlistCmd.AddCommand(allowlistAddCmd)
 	allowlistCmd.AddCommand(allowlistResetCmd)
+	allowlistExportCmd.Flags().
+		StringVar(&allowlistFormat, "format", allowlist.FormatJSON, "Export format: json or yaml")
+	allowlistExportCmd.Flags().
+		StringVar(&allowlistFile, "file", "", "Write to this file instead of stdout")
+	allowlistCmd.AddCommand(allowlistExportCmd)
+	allowlistImportCmd.Flags().
+		StringVar(&allowlistFile, "file", "", "Read from this file instead of stdin (JSON or YAML)")
+	allowlistImportCmd.Flags().
+		BoolVar(&allowlistMerge, "merge", false, "Add imported entries to the existing allowlist [Default]")
+	allowlistImportCmd.Flags().
+		BoolVar(&allowlistReplace, "replace", false, "Replace the existing allowlist with the imported entries")
+	allowlistImportCmd.MarkFlagsMutuallyExclusive("merge", "replace")
+	allowlistCmd.AddCommand(allowlistImportCmd)
+	allowlistBulkAddCmd.Flags().
+		StringVar(&allowlistFile, "file", "", "Read tab-separated TYPE, NAME, HASH lines from this file instead of stdin")
+	allowlistCmd.AddCommand(allowlistBulkAddCmd)
 	experimentalCmd.AddCommand(allowlistCmd)
 
+	// Wire up storage subcommands.
+	storageExportCmd.Flags().
+		StringVar(&storageFormat, "format", storage.FormatJSON, "Export format: json or yaml")
+	storageExportCmd.Flags().
+		StringVar(&storageTransferIO, "file", "", "Write to this file instead of stdout")
+	storageCmd.AddCommand(storageExportCmd)
+	storageImportCmd.Flags().
+		StringVar(&storageTransferIO, "file", "", "Read from this file instead of stdin (JSON or YAML)")
+	storageImportCmd.Flags().
+		BoolVar(&storageMerge, "merge", false, "Merge into the existing storage instead of replacing it")
+	storageImportCmd.Flags().
+		BoolVar(&storageDryRun, "dry-run", false, "Print what would change without writing")
+	storageCmd.AddCommand(storageImportCmd)
+
 	// Wire up experimental subcommands.
+	experimentalInspectCmd.Flags().
+		DurationVar(&inspectTimeout, "timeout", defaultInspectTimeout, "Maximum time to wait for the server before killing it")
+	experimentalInspectCmd.Flags().
+		StringSliceVar(&inspectConfigs, "config", nil, "Config file(s) to look up the server in [Defaults to well-known locations]")
 	experimentalCmd.AddCommand(experimentalInspectCmd)
+	experimentalProxyCmd.Flags().StringVar(&proxyServer, "server", "", "Name of the MCP server to launch and proxy")
+	experimentalProxyCmd.Flags().
+		StringSliceVar(&proxyConfigs, "config", nil, "Config file(s) to look up the server in [Defaults to well-known locations]")
+	experimentalProxyCmd.Flags().
+		StringVar(&proxyLogFile, "log-file", "", "Append the JSON-RPC conversation to this file [Defaults to stderr]")
+	experimentalProxyCmd.Flags().
+		BoolVar(&proxyRedactSecrets, "redact-secrets", false, "Mask known provider tokens in logged payloads")
+	experimentalProxyCmd.Flags().
+		BoolVar(&proxyEnforcePolicy, "enforce-policy", false, "Refuse denylisted servers and block tool calls not permitted by the local allow/deny lists")
+	_ = experimentalProxyCmd.MarkFlagRequired("server")
 	experimentalCmd.AddCommand(experimentalProxyCmd)
 	experimentalCmd.AddCommand(experimentalDeepScanCmd)
 
@@ -73,6 +474,7 @@ func init() {
 	orgCmd.AddCommand(orgRegisterCmd)
 	orgCmd.AddCommand(orgClearCmd)
 	orgCmd.AddCommand(orgShowCmd)
+	orgCmd.AddCommand(orgValidateCmd)
 
 	// Built-in version flag: set version string and a custom template.
 	rootCmd.Version = releaseVersion
@@ -81,6 +483,33 @@ func init() {
 
 }
 
+// loadConfig applies the config file and RUNMCP_<SETTING> environment variables to the
+// flags not given on the command line. The config commands read the file themselves so
+// that "config show" can report where each value came from.
+func loadConfig(cmd *cobra.Command, _ []string) error {
+	if cmd.Parent() == configCmd {
+		return nil
+	}
+	// A bad config is not a usage error; Execute reports it.
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	path, err := config.DefaultPath()
+	if err != nil {
+		logrus.Debugf("No config file location: %v", err)
+		return nil
+	}
+	vals, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	if err := config.Apply(cmd.Flags(), cmd.Name(), vals); err != nil {
+		return err
+	}
+	if v, source := vals.Value("storage_file"); source != "" {
+		storageFile = v
+	}
+	return nil
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		logrus.Fatal(err)
@@ -91,29 +520,292 @@ func Execute() {
 var scanCmd = &cobra.Command{
 	Use:   "scan [CONFIG_FILE...]",
 	Short: "Scan one or more MCP config files. [Defaults to well-known locations]",
-	Long:  "Scan one or more MCP configuration files for security issues. If no files are specified, well-known config locations will be checked.",
+	Long: "Scan one or more MCP configuration files for security issues. If no files are specified, well-known config locations will be checked." + `
+
+With --check, --check-secrets or --fail-on-severity the exit code reports the outcome:
+  0  clean: nothing matched the requested checks
+  1  findings present
+  2  scan error (e.g. a given file does not exist, or ratings could not be fetched)`,
 	Run: func(cmd *cobra.Command, args []string) {
+		scanner.SetColorEnabled(!colorDisabled())
+
 		// Check for conflicting flags
+		switch scanFormat {
+		case "code-climate":
+			scanFormat = scanner.FormatCodeClimate
+		case "azdo-pipelines":
+			scanFormat = scanner.FormatAzDO
+		case "":
+			// Annotate Azure Pipelines runs unless another output was asked for.
+			if os.Getenv(azdoRunnerEnv) != "" && !jsonOutput && !verboseJSON && !tuiMode && !countsOnly {
+				scanFormat = scanner.FormatAzDO
+			}
+		}
+		switch scanFormat {
+		case "", scanner.FormatText:
+		case scanner.FormatJSON:
+			jsonOutput = true
+		case scanner.FormatCycloneDX, scanner.FormatCheckstyle, scanner.FormatSPDX, scanner.FormatCodeClimate,
+			scanner.FormatGitLabSAST, scanner.FormatAzDO, scanner.FormatCSV, scanner.FormatTable:
+			if jsonOutput || verboseJSON || tuiMode {
+				logrus.Fatalf("Cannot combine --format %s with --json, --verbose-json or --tui", scanFormat)
+			}
+		default:
+			logrus.Fatalf("Invalid --format %q: must be %q, %q, %q, %q, %q, %q, %q, %q, %q or %q", scanFormat,
+				scanner.FormatText, scanner.FormatJSON, scanner.FormatCycloneDX, scanner.FormatCheckstyle, scanner.FormatSPDX,
+				scanner.FormatCodeClimate, scanner.FormatGitLabSAST, scanner.FormatCSV, scanner.FormatTable, scanner.FormatAzDO)
+		}
+		cyclonedxOutput := scanFormat == scanner.FormatCycloneDX
+		checkstyleOutput := scanFormat == scanner.FormatCheckstyle
+		spdxOutput := scanFormat == scanner.FormatSPDX
+		codeClimateOutput := scanFormat == scanner.FormatCodeClimate
+		gitlabSASTOutput := scanFormat == scanner.FormatGitLabSAST
+		azdoOutput := scanFormat == scanner.FormatAzDO
+		csvOutput := scanFormat == scanner.FormatCSV
+		tableOutput := scanFormat == scanner.FormatTable
 		if jsonOutput && tuiMode {
 			logrus.Fatal("Cannot use --json and --tui flags together")
 		}
+		if showStats && tuiMode {
+			logrus.Fatal("Cannot use --stats with --tui")
+		}
+		if interactive && (jsonOutput || tuiMode || stdinInput) {
+			// --stdin would leave nothing to read the answers from.
+			logrus.Fatal("Cannot use --interactive with --json, --tui or --stdin")
+		}
+		if noWellKnown && wellKnownOnly {
+			logrus.Fatal("Cannot use --no-well-known and --well-known-only together")
+		}
+		if projectOnly && (systemOnly || noProjPaths) {
+			logrus.Fatal("Cannot use --project-only with --system-only or --no-project-paths")
+		}
+		if noWellKnown && (projectOnly || systemOnly || noProjPaths) {
+			logrus.Fatal("Cannot use --project-only, --system-only or --no-project-paths with --no-well-known")
+		}
+		wellKnown := scanner.AllWellKnownPaths()
+		wellKnown.IncludeSystem = !projectOnly
+		wellKnown.IncludeProject = !systemOnly && !noProjPaths
+		if stdinInput {
+			if len(args) > 0 || wellKnownOnly {
+				logrus.Fatal("Cannot use --stdin with file arguments or --well-known-only")
+			}
+			if tuiMode {
+				logrus.Fatal("Cannot use --stdin and --tui flags together")
+			}
+			if stdinFormat != "json" && stdinFormat != "yaml" {
+				logrus.Fatalf("Invalid --stdin-format %q: must be json or yaml", stdinFormat)
+			}
+		}
+		gating := checkMode || checkSecrets || failOnSev != ""
+		if gating {
+			// Keep scan failures distinguishable from policy failures.
+			logrus.StandardLogger().ExitFunc = func(int) { os.Exit(exitScanError) }
+		}
+		if checkMode && failOnSev == "" {
+			failOnSev = "low"
+		}
+		if failOnSev != "" {
+			tier, err := scanner.ParseSeverity(failOnSev)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			failOnSev = tier
+		}
+		if gating && tuiMode {
+			logrus.Fatal("Cannot use --check, --check-secrets or --fail-on-severity with --tui")
+		}
+		if countsOnly && (verboseJSON || tuiMode || (scanFormat != "" && scanFormat != scanner.FormatText && scanFormat != scanner.FormatJSON)) {
+			logrus.Fatal("Cannot combine --severity-counts-only with --verbose-json, --tui or a report --format")
+		}
+		if verboseJSON && (jsonOutput || tuiMode) {
+			logrus.Fatal("Cannot combine --verbose-json with --json or --tui")
+		}
+		if verboseJSON && anonPaths {
+			// Raw server configs routinely embed paths (e.g. filesystem server args) that cannot be reliably hashed.
+			logrus.Fatal("Cannot use --anonymize-paths with --verbose-json")
+		}
+		if verboseJSON && redactNames {
+			// Raw server configs often name the server in args, env or URLs as well.
+			logrus.Fatal("Cannot use --redact-server-names with --verbose-json")
+		}
+		if outputFile != "" && tuiMode {
+			logrus.Fatal("Cannot use --output-file and --tui flags together")
+		}
+		if reportDir != "" && tuiMode {
+			logrus.Fatal("Cannot use --report-dir and --tui flags together")
+		}
+		if reportStamp && reportDir == "" {
+			logrus.Fatal("--report-timestamp requires --report-dir")
+		}
+		if noSecrets && secretsOnly {
+			logrus.Fatal("Cannot use --no-secrets and --secrets-only together")
+		}
+		if noSecrets && (verboseJSON || checkSecrets || contextLines > 0) {
+			// Without the secret pass, raw configs are never redacted.
+			logrus.Fatal("Cannot use --no-secrets with --verbose-json, --check-secrets or --context")
+		}
+		if scanTimeout <= 0 {
+			logrus.Fatalf("Invalid --timeout %s: must be positive", scanTimeout)
+		}
+		if contextLines < 0 {
+			logrus.Fatalf("Invalid --context %d: must not be negative", contextLines)
+		}
+		confidence, err := scanner.ParseConfidence(minConfidence)
+		if err != nil {
+			logrus.Fatalf("Invalid --min-confidence: %v", err)
+		}
+		if entropyBits <= 0 {
+			logrus.Fatalf("Invalid --min-confidence-entropy-threshold %g: must be positive", entropyBits)
+		}
+		if scanWorkers < 1 {
+			logrus.Fatalf("Invalid --workers %d: must be at least 1", scanWorkers)
+		}
+		labels, err := scanner.ParseLabels(scanLabels)
+		if err != nil {
+			logrus.Fatalf("Invalid --label: %v", err)
+		}
+		hashFunc, hashName, err := scanner.NewHashFunc(hashAlgorithm)
+		if err != nil {
+			logrus.Fatalf("Invalid --hash-algorithm: %v", err)
+		}
+		if hashName != scanner.HashSHA256 && tuiMode {
+			// TUI allowlisting records fingerprints as sha256:<hex>.
+			logrus.Fatal("Cannot use --hash-algorithm with --tui")
+		}
+		if maxDepth < 0 {
+			logrus.Fatalf("Invalid --max-depth %d: must not be negative", maxDepth)
+		}
+		if perPage < 0 {
+			logrus.Fatalf("Invalid --findings-per-page %d: must not be negative", perPage)
+		}
+		if sortOrder, err = scanner.ParseSortOrder(sortOrder); err != nil {
+			logrus.Fatalf("Invalid --sort: %v", err)
+		}
+		scanner.SetTextLayout(scanner.TextLayout{FindingsPerPage: perPage, SortBy: sortOrder})
+		maxFileBytes, err := scanner.ParseFileSize(maxFileSize)
+		if err != nil {
+			logrus.Fatalf("Invalid --max-file-size: %v", err)
+		}
+		for _, pattern := range append(append([]string{}, scanInclude...), scanExclude...) {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				logrus.Fatalf("Invalid glob %q: %v", pattern, err)
+			}
+		}
+		var signKey ed25519.PrivateKey
+		if signKeyFile != "" {
+			if !jsonOutput && (outputFile == "" || outputFormat != scanner.FormatJSON) {
+				logrus.Fatal("--sign requires --json or --output-file with --output-format json")
+			}
+			if signKey, err = signing.LoadPrivateKey(signKeyFile); err != nil {
+				logrus.Fatalf("Invalid --sign key: %v", err)
+			}
+		}
+		var rules []scanner.Rule
+		if rulesFile != "" {
+			if rules, err = scanner.LoadRules(rulesFile); err != nil {
+				logrus.Fatalf("Invalid --rules-file: %v", err)
+			}
+		}
+		var annotations []scanner.Annotation
+		if annotateFile != "" {
+			if tuiMode {
+				logrus.Fatal("Cannot use --annotations-file with --tui")
+			}
+			if annotations, err = scanner.LoadAnnotations(annotateFile); err != nil {
+				logrus.Fatalf("Invalid --annotations-file: %v", err)
+			}
+		}
+		if category != "" {
+			if tuiMode {
+				logrus.Fatal("Cannot use --category with --tui")
+			}
+			if category, err = scanner.ParseCategory(category); err != nil {
+				logrus.Fatalf("Invalid --category: %v", err)
+			}
+		}
+		uploading := upload && !noUpload
+		if uploading && tuiMode {
+			logrus.Fatal("Cannot use --upload with --tui")
+		}
+		if uploading && anonymous {
+			logrus.Fatal("Cannot use --upload with --anonymous")
+		}
+		if uploading && secretsOnly {
+			logrus.Fatal("Cannot use --upload with --secrets-only")
+		}
+		if outputFormat != scanner.FormatJSON && outputFormat != scanner.FormatText {
+			logrus.Fatalf("Invalid --output-format %q: must be %q or %q", outputFormat, scanner.FormatJSON, scanner.FormatText)
+		}
 
 		// Set log level based on flags
-		if (jsonOutput || tuiMode) && !verbose {
+		if (jsonOutput || verboseJSON || tuiMode || cyclonedxOutput || checkstyleOutput || spdxOutput || codeClimateOutput ||
+			gitlabSASTOutput || azdoOutput || csvOutput || countsOnly) && !verbose {
 			logrus.SetLevel(logrus.WarnLevel)
 		} else if verbose {
 			logrus.SetLevel(logrus.DebugLevel)
 		}
 
+		if listWellKnown {
+			printWellKnownPaths(wellKnown, listAll, jsonOutput)
+			return
+		}
+
+		if validateOnly {
+			if !validateConfigs(args, wellKnown, jsonOutput) {
+				os.Exit(1)
+			}
+			return
+		}
+
+		// Explicitly named files must exist when gating on the result.
+		if gating {
+			for _, a := range args {
+				if _, err := os.Stat(a); err != nil {
+					logrus.Fatalf("Cannot scan %s: %v", a, err)
+				}
+			}
+		}
+
+		// A config from stdin is scanned from a temp file, renamed to <stdin> in the output.
+		var stdinFile string
+		if stdinInput {
+			if stdinFile, err = writeStdinConfig(os.Stdin, stdinFormat); err != nil {
+				logrus.Fatalf("Failed to read config from stdin: %v", err)
+			}
+			defer os.Remove(stdinFile)
+			args = []string{stdinFile}
+		}
+
 		// Default to scanning well-known paths if no arguments are provided.
-		if len(args) == 0 {
-			args = scanner.GetWellKnownMCPPaths()
+		switch {
+		case wellKnownOnly:
+			if len(args) > 0 {
+				logrus.Warnf("Ignoring %d path(s) given with --well-known-only", len(args))
+			}
+			args = scanner.GetWellKnownMCPPaths(wellKnown)
+		case len(args) == 0 && !noWellKnown:
+			args = scanner.GetWellKnownMCPPaths(wellKnown)
+		}
+
+		// A dry run only resolves the file selection: no API client, TUI or secret detection.
+		if dryRun {
+			files := scanner.NewMCPScanner(args, storageFile).WithFileFilter(scanInclude, scanExclude).WithMaxDepth(maxDepth).ListFiles()
+			for i, f := range files {
+				if f == stdinFile {
+					files[i] = scanner.StdinPath
+				}
+			}
+			printPaths(files, jsonOutput)
+			return
 		}
 		// Resolve host identity from storage, creating new storage if none exists yet.
 		st, err := storage.NewOrExistingStorage(storageFile)
 		if err != nil {
 			logrus.Fatalf("Unable to open or create storage: %v", err)
 		}
+		if updateCheck {
+			checkForUpdate(cmd.Context(), st)
+		}
 
 		// If not anonymous, build optional Identity for attaching to requests.
 		ctx := cmd.Context()
@@ -121,48 +813,330 @@ var scanCmd = &cobra.Command{
 			if orgUUID == "" {
 				orgUUID = st.Data.OrgUUID
 			}
+			if uploading && orgUUID == "" {
+				logrus.Fatal("--upload requires --org-uuid or a registered organization (see 'run-mcp org register')")
+			}
 			hostUUID := st.Data.HostUUID
 			ctx = api.WithIdentity(ctx, api.Identity{OrgUUID: orgUUID, HostUUID: hostUUID})
 		}
 
+		// Tracing stays on the global no-op provider unless an endpoint is given.
+		flushTraces := func() {}
+		if otelEndpoint != "" {
+			shutdown, err := telemetry.Setup(ctx, otelEndpoint)
+			if err != nil {
+				logrus.Fatalf("Failed to initialise tracing: %v", err)
+			}
+			flushTraces = func() {
+				if err := shutdown(context.Background()); err != nil {
+					logrus.Warnf("Failed to flush traces: %v", err)
+				}
+			}
+		}
+
+		// The metrics server, if enabled, lives until the scan command completes.
+		var scanMetrics *metrics.Registry
+		stopMetrics := func() {}
+		if metricsAddr != "" {
+			scanMetrics = metrics.NewRegistry()
+			shutdown, err := metrics.Serve(metricsAddr, scanMetrics)
+			if err != nil {
+				logrus.Fatalf("Failed to start metrics server on %s: %v", metricsAddr, err)
+			}
+			stopMetrics = func() {
+				if err := shutdown(context.Background()); err != nil {
+					logrus.Warnf("Failed to stop metrics server: %v", err)
+				}
+			}
+		}
+
+		// The deadline covers scanning and ratings requests; --upload keeps its own timeout.
+		scanCtx, cancelScan := context.WithTimeout(ctx, scanTimeout)
+		defer cancelScan()
+
 		// Create RatingsCollector first with no client to allow immediate TUI launch.
-		rc := scanner.NewRatingsCollector(ctx, nil, st)
+		rc := scanner.NewRatingsCollector(scanCtx, nil, st, scanner.WithMetrics(scanMetrics), scanner.WithHashAlgorithm(hashName))
+		if mockAPIFile != "" {
+			// Mock ratings are served by name and are never cached.
+			mock, err := scanner.LoadMockRatings(mockAPIFile)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			rc.SetClient(mock)
+		} else if !noCache {
+			rc.WithRatingsCache(cacheTTL)
+		}
 		// Start the scan of local files
-		s := scanner.NewMCPScanner(args, storageFile).WithRatingsCollector(rc)
+		s := scanner.NewMCPScanner(args, storageFile).WithContext(scanCtx)
+		if !secretsOnly {
+			s.WithRatingsCollector(rc)
+		}
+		if noSecrets {
+			s.WithoutSecretScanning()
+		}
+		s.WithEntropyThreshold(entropyBits)
+		s.WithHashFunc(hashFunc)
+		s.WithWorkers(scanWorkers)
+		s.WithFileFilter(scanInclude, scanExclude)
+		s.WithMaxFileSize(maxFileBytes)
+		if scanProfile {
+			s.WithProfiling()
+		}
+		if suppressErrs {
+			s.WithSuppressedFileErrors()
+		}
+		if normalizeName {
+			s.WithNormalizedServerNames()
+		}
+		s.WithSkippedServers(skipServers)
+		s.WithMaxDepth(maxDepth)
+		s.WithMetrics(scanMetrics)
 
 		// If online mode, initialize API client in the background and attach to collector when ready.
-		if !offline {
-			go func() {
-				opts := []api.ClientOption{}
-				if cl, err := api.NewClient(opts...); err == nil {
+		// The client (nil on failure) is also handed to --upload. A severity gate cannot be
+		// evaluated without ratings, so it waits for the client and fails the scan without one.
+		clientCh := make(chan *api.Client, 1)
+		if !offline && !secretsOnly && mockAPIFile == "" {
+			connect := func() {
+				cl, err := api.NewClient(apiClientOptions()...)
+				switch {
+				case err == nil:
 					rc.SetClient(cl)
-				} else if errors.Is(err, api.ErrOffline) {
+					clientCh <- cl
+					return
+				case failOnSev != "":
+					logrus.Fatalf("API client setup failed: %v", err)
+				case errors.Is(err, api.ErrOffline):
 					logrus.Debug("remote health unavailable; continuing in offline mode")
-				} else {
-					logrus.Debugf("api client init failed: %v", err)
+				default:
+					logrus.Warnf("Continuing offline, API client setup failed: %v", err)
 				}
-			}()
+				clientCh <- nil
+			}
+			if failOnSev != "" {
+				connect()
+			} else {
+				go connect()
+			}
+		} else if uploading {
+			logrus.Warn("Skipping --upload in offline mode")
+			uploading = false
 		}
 
 		// Choose output mode BEFORE scanning for real-time streaming
 		if tuiMode {
 			// Run TUI mode with real-time streaming
-			if err := tui.Run(ctx, args, s, rc); err != nil {
+			opts := tui.Options{
+				Environment:       environment,
+				Label:             firstLabelValue(scanLabels),
+				NoBanner:          bannerDisabled(),
+				NoColor:           colorDisabled(),
+				Deadline:          scanTimeout,
+				StoragePath:       storageFile,
+				RedactServerNames: redactNames,
+				RedactSalt:        redactSalt,
+			}
+			if err := tui.Run(ctx, args, s, rc, opts); err != nil {
 				logrus.Fatalf("TUI mode failed: %v", err)
 			}
+			flushTraces()
+			stopMetrics()
 		} else {
 			// Traditional mode - scan then display results
 			result, err := s.Scan()
-			if err != nil {
+			switch {
+			case errors.Is(err, context.DeadlineExceeded):
+				logrus.Warnf("Scan timed out after %s; reporting partial results", scanTimeout)
+			case err != nil:
 				logrus.Fatal(err)
 			}
+			scanner.FilterSecretsByConfidence(result, confidence)
+			if redactEnv {
+				scanner.RedactEnv(result)
+			}
 
-			summary := scanner.GenerateSummary(*result)
-			// Apply any policies/ratings gathered during scanning.
-			rc.ApplyToSummary(&summary)
+			scanner.AttachSecretContext(result, contextLines)
+			if stdinFile != "" {
+				scanner.ReplaceResultPath(result, stdinFile, scanner.StdinPath)
+				// Exits below skip deferred calls.
+				os.Remove(stdinFile)
+			}
+			suppressions, err := scanner.LoadSuppressions(suppressFile)
+			if err != nil {
+				logrus.Fatalf("Invalid --suppress-file: %v", err)
+			}
+			summary := scanner.GenerateSummary(*result, suppressions...)
+			if aggSecrets {
+				scanner.AggregateSecretsByValue(&summary)
+			}
+			summary.Environment = environment
+			summary.Labels = labels
+			summary.HashAlgorithm = hashName
+			if scanProfile {
+				summary.ProfileReport = scanner.NewProfileReport(*result)
+			}
+			scanner.ApplyRules(&summary, *result, rules)
+			scanner.Annotate(&summary, annotations)
 			// Ensure any pending batches are flushed and workers stopped before printing.
+			ratingsStart := time.Now()
 			rc.FlushAndStop()
-			scanner.PrintSummary(summary, jsonOutput)
+			reportStart := time.Now()
+			// Apply any policies/ratings gathered during scanning.
+			rc.ApplyToSummary(&summary)
+			flushTraces()
+			scanMetrics.SetServers(scanner.ServerTierCounts(summary))
+			scanMetrics.SetSecrets(len(summary.Secrets))
+
+			// Upload in the background while the report is rendered; waited on before exit.
+			uploadDone := make(chan struct{})
+			var scanURL string
+			if uploading {
+				record := api.ScanUpload{
+					HostUUID:      st.Data.HostUUID,
+					ScanStartedAt: result.StartedAt,
+					Duration:      result.Duration.Seconds(),
+					ServerCount:   len(result.Servers),
+					Environment:   environment,
+					Labels:        labels,
+					Identifiers:   rc.Identifiers(),
+				}
+				go func() {
+					defer close(uploadDone)
+					scanURL = uploadScan(ctx, clientCh, record)
+				}()
+			} else {
+				close(uploadDone)
+			}
+
+			// Record the full result before any --since filtering.
+			entry := scanner.NewHistoryEntry(summary, result.StartedAt)
+			if since != "" {
+				if err := filterSince(&summary, st, since); err != nil {
+					logrus.Fatalf("Invalid --since %q: %v", since, err)
+				}
+			}
+			if secretsOnly {
+				// Servers are still counted, but only secrets are reported.
+				summary.Servers = []scanner.ServerReport{}
+			}
+			if category != "" {
+				// Ratings were already requested for every server; this only narrows the report.
+				scanner.FilterByCategory(&summary, category)
+			}
+			st.AppendScanHistory(entry)
+			if err := st.Save(); err != nil {
+				logrus.Warnf("Failed to record scan history: %v", err)
+			}
+
+			if interactive {
+				v := &allowlist.Verifier{Storage: st}
+				if err := promptServerPolicies(os.Stderr, bufio.NewReader(os.Stdin), &summary, v); err != nil {
+					logrus.Fatalf("Failed to record decision: %v", err)
+				}
+			}
+			if explain {
+				scanner.Explain(&summary)
+			}
+			if anonPaths {
+				scanner.AnonymizePaths(&summary, anonPathsSalt)
+			}
+			if redactNames {
+				scanner.RedactServerNames(&summary, redactSalt)
+				scanner.RedactResultServerNames(result, redactSalt)
+			}
+			switch {
+			case quiet:
+			case countsOnly:
+				printSeverityCounts(scanner.NewSeverityCounts(summary), jsonOutput)
+			case verboseJSON:
+				if err := scanner.WriteVerboseJSON(os.Stdout, *result); err != nil {
+					logrus.Fatal(err)
+				}
+			case cyclonedxOutput:
+				vex := scanner.NewCycloneDXVEX(summary, sbomHost(st.Data.HostUUID), releaseVersion, time.Now())
+				if err := scanner.WriteCycloneDX(os.Stdout, vex); err != nil {
+					logrus.Fatal(err)
+				}
+			case checkstyleOutput:
+				if err := scanner.WriteCheckstyle(os.Stdout, scanner.NewCheckstyleReport(summary)); err != nil {
+					logrus.Fatal(err)
+				}
+			case spdxOutput:
+				doc := scanner.NewSPDXDocument(*result, summary, sbomHost(st.Data.HostUUID), releaseVersion, time.Now())
+				if err := scanner.WriteSPDX(os.Stdout, doc); err != nil {
+					logrus.Fatal(err)
+				}
+			case codeClimateOutput:
+				if err := scanner.WriteCodeClimate(os.Stdout, scanner.NewCodeClimateReport(summary)); err != nil {
+					logrus.Fatal(err)
+				}
+			case gitlabSASTOutput:
+				report := scanner.NewGitLabSASTReport(summary, releaseVersion, time.Now())
+				if err := scanner.WriteGitLabSAST(os.Stdout, report); err != nil {
+					logrus.Fatal(err)
+				}
+			case azdoOutput:
+				if err := scanner.WriteAzDO(os.Stdout, scanner.NewAzDOReport(summary)); err != nil {
+					logrus.Fatal(err)
+				}
+			case csvOutput:
+				if err := scanner.WriteCSV(os.Stdout, summary); err != nil {
+					logrus.Fatal(err)
+				}
+			case tableOutput:
+				if err := scanner.WriteTable(os.Stdout, summary, stdoutWidth()); err != nil {
+					logrus.Fatal(err)
+				}
+			case jsonOutput && signKey != nil:
+				if err := writeSignedSummary(os.Stdout, summary, signKey); err != nil {
+					logrus.Fatalf("Failed to sign scan result: %v", err)
+				}
+			default:
+				scanner.PrintSummary(summary, jsonOutput, bannerDisabled())
+			}
+			if sbomFile != "" {
+				if err := writeSBOMFile(sbomFile, *result, st.Data.HostUUID); err != nil {
+					logrus.Fatalf("Failed to write --sbom: %v", err)
+				}
+			}
+			if outputFile != "" {
+				if err := writeSummaryFile(outputFile, outputFormat, summary, signKey); err != nil {
+					logrus.Fatalf("Failed to write --output-file: %v", err)
+				}
+			}
+			if reportDir != "" {
+				dir := reportDir
+				if reportStamp {
+					dir = timestampedReportDir(reportDir, result.StartedAt)
+				}
+				if err := writeReportDir(dir, summary, signKey); err != nil {
+					logrus.Fatalf("Failed to write --report-dir: %v", err)
+				}
+				logrus.Infof("Reports written to %s", dir)
+			}
+			if showStats {
+				stats := s.Stats()
+				rc.ApplyStats(&stats)
+				stats.PhaseTimings[scanner.PhaseRatings] = reportStart.Sub(ratingsStart)
+				stats.PhaseTimings[scanner.PhaseReport] = time.Since(reportStart)
+				if err := scanner.WriteStats(os.Stderr, stats, jsonOutput); err != nil {
+					logrus.Fatal(err)
+				}
+			}
+			if ghPRComment {
+				postPRComment(ctx, summary)
+			}
+			<-uploadDone
+			if slackWebhook != "" {
+				postSlack(ctx, slackWebhook, summary, scanURL)
+			}
+			stopMetrics()
+			if gating {
+				if n := rc.LookupFailures(); failOnSev != "" && n > 0 {
+					logrus.Fatalf("Rating lookup failed for %d batch(es); cannot apply the severity gate", n)
+				}
+				os.Exit(checkExitCode(summary, failOnSev, checkSecrets))
+			}
 		}
 
 		/*
@@ -172,39 +1146,604 @@ var scanCmd = &cobra.Command{
 	},
 }
 
-//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure
-var allowlistCmd = &cobra.Command{
-	Use:   "allowlist",
-	Short: "Manage the local allowlist of approved entities",
-	Long:  "View, add, or reset local allowlisted entities. Allowlisted entities bypass security checks during scans.",
+// uploadScan posts record once the API client is ready, printing the resulting scan URL to stderr
+// and returning it. Failures are reported as warnings so they never change the scan outcome.
+func uploadScan(ctx context.Context, clientCh <-chan *api.Client, record api.ScanUpload) string {
+	ctx, cancel := context.WithTimeout(ctx, uploadTimeout)
+	defer cancel()
+
+	var cl *api.Client
+	select {
+	case cl = <-clientCh:
+	case <-ctx.Done():
+	}
+	if cl == nil {
+		logrus.Warn("Scan upload skipped: ratings API unavailable")
+		return ""
+	}
+	resp, err := cl.UploadScan(ctx, record)
+	if err != nil {
+		logrus.Warnf("Scan upload failed: %v", err)
+		return ""
+	}
+	fmt.Fprintf(os.Stderr, "Scan uploaded: %s\n", resp.ScanURL)
+	return resp.ScanURL
+}
+
+// postPRComment creates or updates the run-mcp comment on the GitHub pull request being built.
+// Failures are reported as warnings so they never change the scan outcome.
+func postPRComment(ctx context.Context, summary scanner.ScanSummary) {
+	gh, err := integrations.NewGitHubClientFromEnv(os.Getenv)
+	if err != nil {
+		logrus.Warnf("Skipping --github-pr-comment: %v", err)
+		return
+	}
+	url, err := gh.PostPRComment(ctx, summary)
+	if err != nil {
+		logrus.Warnf("GitHub PR comment failed: %v", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "PR comment posted: %s\n", url)
+}
+
+// postSlack sends the scan notification to a Slack incoming webhook.
+// Failures are reported as warnings so they never change the scan outcome.
+func postSlack(ctx context.Context, webhookURL string, summary scanner.ScanSummary, scanURL string) {
+	if err := integrations.PostSlack(ctx, webhookURL, integrations.NewSlackMessage(summary, scanURL)); err != nil {
+		logrus.Warnf("Slack notification failed: %v", err)
+	}
+}
+
+// filterSince drops findings already reported before a timestamp, or outside files changed since a git ref.
+func filterSince(summary *scanner.ScanSummary, st *storage.Storage, ref string) error {
+	if ts, err := time.Parse(time.RFC3339, ref); err == nil {
+		scanner.FilterSeenBefore(summary, st.Data.ScanHistory, ts)
+		return nil
+	}
+	changed, err := scanner.GitChangedFiles(ref)
+	if err != nil {
+		return err
+	}
+	scanner.FilterChangedFiles(summary, changed)
+	return nil
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var scanSuppressCmd = &cobra.Command{
+	Use:   "suppress <server-name>",
+	Short: "Mark a server's findings as accepted risk in the suppression file",
+	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		v, err := allowlist.NewVerifier(storageFile)
-		if err != nil {
+		in := bufio.NewReader(cmd.InOrStdin())
+		entry := scanner.Suppression{Server: args[0], Kind: suppressKind, Reason: suppressReason, Expires: suppressExpires}
+		if !cmd.Flags().Changed("reason") {
+			entry.Reason = prompt(os.Stdout, in, "Reason: ")
+		}
+		if !cmd.Flags().Changed("expires") {
+			entry.Expires = prompt(os.Stdout, in, "Expires (YYYY-MM-DD, empty for never): ")
+		}
+		if entry.Reason == "" {
+			logrus.Fatal("A reason is required to suppress a server")
+		}
+		if err := scanner.AppendSuppression(suppressFile, entry); err != nil {
 			logrus.Fatal(err)
 		}
-		v.ViewAllowlist(os.Stdout)
+		fmt.Fprintf(os.Stdout, "Suppressed %q in %s\n", entry.Server, suppressFile)
 	},
 }
 
+// prompt writes label to w and returns the next trimmed line from in.
+func prompt(w io.Writer, in *bufio.Reader, label string) string {
+	fmt.Fprint(w, label)
+	line, _ := in.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+// promptServerPolicies asks on w whether to allow, deny or skip each server of the summary
+// without a local policy, records the answers read from in with v, and sets LocalPolicy to
+// match. Servers are recorded by name, which is what scans and the proxy match on. Prompting
+// stops at the end of input, leaving the remaining servers undecided.
+func promptServerPolicies(w io.Writer, in *bufio.Reader, summary *scanner.ScanSummary, v *allowlist.Verifier) error {
+	for i := range summary.Servers {
+		sr := &summary.Servers[i]
+		if sr.LocalPolicy == "allowed" || sr.LocalPolicy == "denied" {
+			continue
+		}
+		name := cmp.Or(sr.CanonicalName, sr.Name)
+		risk := "unrated"
+		if sr.Rating != nil {
+			risk = fmt.Sprintf("%s (score %.1f)", scanner.RiskTier(sr.Rating.RiskScore), sr.Rating.RiskScore)
+		}
+		fmt.Fprintf(w, "\nServer: %q (%s)\n    Risk: %s, secrets: %d\n", name, sr.Path, risk, len(sr.Secrets))
+		for {
+			answer := prompt(w, in, "Allow/Deny/Skip (a/d/s)? ")
+			switch strings.ToLower(answer) {
+			case "a", "allow":
+				if err := v.AddToAllowlist("server", name, name); err != nil {
+					return err
+				}
+				sr.LocalPolicy = "allowed"
+			case "d", "deny":
+				if err := v.AddToDenylist("server", name, name); err != nil {
+					return err
+				}
+				sr.LocalPolicy = "denied"
+			case "s", "skip":
+			case "":
+				if _, err := in.Peek(1); err != nil {
+					fmt.Fprintln(w)
+					return nil
+				}
+				continue
+			default:
+				continue
+			}
+			break
+		}
+	}
+	return nil
+}
+
 //nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
-var allowlistAddCmd = &cobra.Command{
-	Use:   "add [TYPE] [NAME] [HASH]",
-	Short: "Add an entity to the local allowlist",
-	Long:  "Add a MCP Server to the local allowlist.",
-	Args:  cobra.ExactArgs(3), //nolint:mnd // Allowlist 'add' requires exactly 3 arguments by CLI contract
+var scanHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the recorded scan history used by --since",
+	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		v, err := allowlist.NewVerifier(storageFile)
+		s, err := storage.NewOrExistingStorage(storageFile)
 		if err != nil {
 			logrus.Fatal(err)
 		}
-		if err := v.AddToAllowlist(args[0], args[1], args[2]); err != nil {
-			logrus.Fatal(err)
+		if jsonOutput {
+			out, err := json.MarshalIndent(s.Data.ScanHistory, "", "  ")
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			fmt.Fprintln(os.Stdout, string(out))
+			return
+		}
+		if len(s.Data.ScanHistory) == 0 {
+			fmt.Fprintln(os.Stdout, "No scan history recorded")
+			return
+		}
+		for _, e := range s.Data.ScanHistory {
+			fmt.Fprintf(os.Stdout, "%s  %d servers\n", e.ScannedAt.Format(time.RFC3339), len(e.Servers))
 		}
 	},
 }
 
 //nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
-var allowlistResetCmd = &cobra.Command{
+var scanHistoryPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove recorded scan history entries",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		var cutoff time.Time
+		if historyPruneBefore != "" {
+			ts, err := time.Parse(time.RFC3339, historyPruneBefore)
+			if err != nil {
+				logrus.Fatalf("Invalid --before %q: expected an RFC 3339 timestamp", historyPruneBefore)
+			}
+			cutoff = ts
+		}
+		s, err := storage.NewOrExistingStorage(storageFile)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		removed := s.PruneScanHistory(cutoff)
+		if err := s.Save(); err != nil {
+			logrus.Fatal(err)
+		}
+		fmt.Fprintf(os.Stdout, "Removed %d scan history entries\n", removed)
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var scanCompareCmd = &cobra.Command{
+	Use:   "compare <baseline.json> <current.json>",
+	Short: "Show how the security posture changed between two 'scan --json' results",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		baseline, err := readSummaryFile(args[0])
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		current, err := readSummaryFile(args[1])
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		diff := scanner.CompareSummaries(baseline, current)
+		if jsonOutput {
+			out, err := json.MarshalIndent(diff, "", "  ")
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			fmt.Fprintln(os.Stdout, string(out))
+			return
+		}
+		scanner.WriteScanDiff(os.Stdout, diff, !colorDisabled())
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var scanAggregateCmd = &cobra.Command{
+	Use:   "aggregate [result.json...]",
+	Short: "Merge 'scan --json' results from sharded scans into one report",
+	Long: "Merge ScanSummary JSON files, e.g. from CI matrix jobs that each scan part of the well-known paths, " +
+		"into one report. Servers are deduplicated by name and path and secrets by value hash.",
+	Run: func(cmd *cobra.Command, args []string) {
+		paths := slices.Clone(args)
+		for _, pattern := range aggregateGlobs {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				logrus.Fatalf("Invalid --input-glob %q: %v", pattern, err)
+			}
+			paths = append(paths, matches...)
+		}
+		if len(paths) == 0 {
+			logrus.Fatal("No result files given: pass files or --input-glob")
+		}
+		summaries := make([]scanner.ScanSummary, 0, len(paths))
+		seen := make(map[string]struct{}, len(paths))
+		for _, p := range paths {
+			// A file named and matched by a glob is only counted once.
+			if _, ok := seen[filepath.Clean(p)]; ok {
+				continue
+			}
+			seen[filepath.Clean(p)] = struct{}{}
+			summary, err := readSummaryFile(p)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			summaries = append(summaries, summary)
+		}
+		scanner.PrintSummary(scanner.MergeSummaries(summaries...), jsonOutput, bannerDisabled())
+	},
+}
+
+// readSummaryFile loads a ScanSummary written by 'scan --json' or '--output-file'.
+func readSummaryFile(path string) (scanner.ScanSummary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return scanner.ScanSummary{}, err
+	}
+	var summary scanner.ScanSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return scanner.ScanSummary{}, fmt.Errorf("%s is not a scan JSON result: %w", path, err)
+	}
+	return summary, nil
+}
+
+// writeSBOMFile writes a CycloneDX SBOM of the servers in result to path.
+func writeSBOMFile(path string, result scanner.ScanResult, hostUUID string) error {
+	bom := scanner.NewCycloneDXBOM(result, sbomHost(hostUUID), releaseVersion, time.Now())
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := scanner.WriteCycloneDX(f, bom); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeStdinConfig copies r to a new temp file with the extension of format so the parser
+// recognises it, and returns the file's path.
+func writeStdinConfig(r io.Reader, format string) (string, error) {
+	f, err := os.CreateTemp("", "run-mcp-stdin-*."+format)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// sbomHost identifies this machine in CycloneDX metadata.
+func sbomHost(hostUUID string) scanner.SBOMHost {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return scanner.SBOMHost{Name: hostname, UUID: hostUUID}
+}
+
+// writeSignedSummary writes the summary as JSON with a trailing Ed25519 "signature" field.
+func writeSignedSummary(w io.Writer, summary scanner.ScanSummary, key ed25519.PrivateKey) error {
+	var buf bytes.Buffer
+	if err := scanner.WriteSummary(&buf, summary, scanner.FormatJSON); err != nil {
+		return err
+	}
+	signed, err := signing.SignJSON(buf.Bytes(), key)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(signed)
+	return err
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var scanVerifyCmd = &cobra.Command{
+	Use:   "verify <result.json> <public-key.pem>",
+	Short: "Verify the signature of a 'scan --sign' result",
+	Long:  "Verify the signature of a 'scan --sign' result. Exits 0 when the signature is valid and 2 otherwise.",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := verifySignedResult(args[0], args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Verification failed: %v\n", err)
+			os.Exit(exitVerifyFailed)
+		}
+		fmt.Fprintf(os.Stdout, "Signature OK: %s\n", args[0])
+	},
+}
+
+// verifySignedResult checks the signature of the result file against the public key file.
+func verifySignedResult(resultPath, keyPath string) error {
+	key, err := signing.LoadPublicKey(keyPath)
+	if err != nil {
+		return err
+	}
+	doc, err := os.ReadFile(resultPath)
+	if err != nil {
+		return err
+	}
+	return signing.VerifyJSON(doc, key)
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var keygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate an Ed25519 key pair for 'scan --sign' and 'scan verify'",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		privPath, pubPath := keygenOut+".key", keygenOut+".pub"
+		for _, p := range []string{privPath, pubPath} {
+			if _, err := os.Stat(p); err == nil {
+				logrus.Fatalf("%s already exists; remove it or choose another --out", p)
+			}
+		}
+		pub, priv, err := signing.GenerateKey()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		if err := os.WriteFile(privPath, priv, 0o600); err != nil {
+			logrus.Fatal(err)
+		}
+		if err := os.WriteFile(pubPath, pub, 0o644); err != nil { //nolint:gosec // Public keys are meant to be shared.
+			logrus.Fatal(err)
+		}
+		fmt.Fprintf(os.Stdout, "Wrote private key to %s and public key to %s\n", privPath, pubPath)
+	},
+}
+
+// checkExitCode returns exitFindings when the summary trips the requested gates, else exitClean.
+func checkExitCode(summary scanner.ScanSummary, severity string, secrets bool) int {
+	if severity != "" && scanner.HasFindingsAtOrAbove(summary, severity) {
+		return exitFindings
+	}
+	if secrets && len(summary.Secrets) > 0 {
+		return exitFindings
+	}
+	return exitClean
+}
+
+// writeSummaryFile writes the summary to path in the given format, replacing any existing file.
+// JSON output is signed when key is set.
+func writeSummaryFile(path, format string, summary scanner.ScanSummary, key ed25519.PrivateKey) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	write := func() error { return scanner.WriteSummary(f, summary, format) }
+	if key != nil && format == scanner.FormatJSON {
+		write = func() error { return writeSignedSummary(f, summary, key) }
+	}
+	if err := write(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// timestampedReportDir suffixes dir with the scan start time in ISO 8601 basic format,
+// which is safe in file names on every platform.
+func timestampedReportDir(dir string, startedAt time.Time) string {
+	return filepath.Clean(dir) + "-" + startedAt.UTC().Format("20060102T150405Z")
+}
+
+// writeReportDir creates dir and writes the summary to it as report.json, signed when key is
+// set, and as the plain text report.txt. Each file is written to a .tmp sibling and renamed
+// into place, so readers never see a partial report.
+func writeReportDir(dir string, summary scanner.ScanSummary, key ed25519.PrivateKey) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	reports := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{"report.json", func(w io.Writer) error {
+			if key != nil {
+				return writeSignedSummary(w, summary, key)
+			}
+			return scanner.WriteSummary(w, summary, scanner.FormatJSON)
+		}},
+		{"report.txt", func(w io.Writer) error { return scanner.WriteSummary(w, summary, scanner.FormatText) }},
+	}
+	for _, r := range reports {
+		if err := writeFileAtomic(filepath.Join(dir, r.name), r.write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic writes path through write into path.tmp, then renames it over path.
+func writeFileAtomic(path string, write func(io.Writer) error) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp) // no-op after a successful rename
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// printWellKnownPaths prints the well-known config paths selected by wellKnown for this OS, one
+// per line or as a JSON array. Unless all is set, only paths that exist on disk are printed.
+func printWellKnownPaths(wellKnown scanner.WellKnownPathsConfig, all bool, asJSON bool) {
+	paths := []string{}
+	for _, p := range scanner.GetWellKnownMCPPaths(wellKnown) {
+		if !all {
+			if _, err := os.Stat(p); err != nil {
+				continue
+			}
+		}
+		paths = append(paths, p)
+	}
+	printPaths(paths, asJSON)
+}
+
+// printPaths prints paths one per line, or as a JSON array.
+func printPaths(paths []string, asJSON bool) {
+	if asJSON {
+		out, err := json.MarshalIndent(paths, "", "  ")
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		fmt.Fprintln(os.Stdout, string(out))
+		return
+	}
+	for _, p := range paths {
+		fmt.Fprintln(os.Stdout, p)
+	}
+}
+
+// firstLabelValue returns the value of the first key=value pair in labels, or "" if none.
+func firstLabelValue(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	_, value, _ := strings.Cut(labels[0], "=")
+	return value
+}
+
+// bannerDisabled reports whether the 24-bit colour banner should be skipped: on request,
+// or when the environment signals limited colour support.
+func bannerDisabled() bool {
+	return noBanner || colorDisabled()
+}
+
+// colorDisabled reports whether colour was turned off with --no-color, NO_COLOR is set or
+// the terminal is dumb.
+// stdoutWidth returns the width of the terminal on stdout, or 0 when stdout is not a terminal.
+func stdoutWidth() int {
+	if !term.IsTerminal(os.Stdout.Fd()) {
+		return 0
+	}
+	width, _, err := term.GetSize(os.Stdout.Fd())
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+func colorDisabled() bool {
+	return noColor || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb"
+}
+
+// validateConfigs lints each target, or the existing well-known paths selected by wellKnown when
+// none are given, and prints the results as text or a JSON array. It reports whether every file
+// is valid.
+func validateConfigs(targets []string, wellKnown scanner.WellKnownPathsConfig, asJSON bool) bool {
+	if len(targets) == 0 {
+		for _, p := range scanner.GetWellKnownMCPPaths(wellKnown) {
+			if _, err := os.Stat(p); err == nil {
+				targets = append(targets, p)
+			}
+		}
+	}
+	results := make([]scanner.ConfigValidation, 0, len(targets))
+	ok := true
+	for _, t := range targets {
+		res := scanner.ValidateConfigFile(t)
+		ok = ok && res.Valid
+		results = append(results, res)
+	}
+
+	if asJSON {
+		out, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		fmt.Fprintln(os.Stdout, string(out))
+		return ok
+	}
+	for _, res := range results {
+		if res.Valid {
+			fmt.Fprintf(os.Stdout, "✔ %s (%s)\n", res.File, res.Kind)
+			continue
+		}
+		for _, d := range res.Diagnostics {
+			if d.Line > 0 {
+				fmt.Fprintf(os.Stdout, "✖ %s:%d: %s\n", d.File, d.Line, d.Message)
+			} else {
+				fmt.Fprintf(os.Stdout, "✖ %s: %s\n", d.File, d.Message)
+			}
+		}
+	}
+	return ok
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure
+var allowlistCmd = &cobra.Command{
+	Use:   "allowlist",
+	Short: "Manage the local allowlist of approved entities",
+	Long:  "View, add, reset, export or import local allowlisted entities. Allowlisted entities bypass security checks during scans.",
+	Run: func(cmd *cobra.Command, args []string) {
+		v, err := allowlist.NewVerifier(storageFile)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		v.ViewAllowlist(os.Stdout)
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var allowlistAddCmd = &cobra.Command{
+	Use:   "add [TYPE] [NAME] [HASH]",
+	Short: "Add an entity to the local allowlist",
+	Long:  "Add a MCP Server to the local allowlist.",
+	Args:  cobra.ExactArgs(3), //nolint:mnd // Allowlist 'add' requires exactly 3 arguments by CLI contract
+	Run: func(cmd *cobra.Command, args []string) {
+		v, err := allowlist.NewVerifier(storageFile)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		if err := v.AddToAllowlist(args[0], args[1], args[2]); err != nil {
+			logrus.Fatal(err)
+		}
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var allowlistResetCmd = &cobra.Command{
 	Use:   "reset",
 	Short: "Reset the local allowlist",
 	Long:  "Reset the entire local allowlist.",
@@ -219,6 +1758,158 @@ var allowlistResetCmd = &cobra.Command{
 	},
 }
 
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var allowlistExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the local allowlist as JSON or YAML",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		v, err := allowlist.NewVerifier(storageFile)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := v.Export(&buf, allowlistFormat); err != nil {
+			logrus.Fatal(err)
+		}
+		if allowlistFile == "" {
+			_, _ = os.Stdout.Write(buf.Bytes())
+			return
+		}
+		if err := os.WriteFile(allowlistFile, buf.Bytes(), 0o600); err != nil {
+			logrus.Fatal(err)
+		}
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var allowlistImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import allowlist entries exported with 'allowlist export'",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		v, err := allowlist.NewVerifier(storageFile)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		in := cmd.InOrStdin()
+		if allowlistFile != "" {
+			data, err := os.ReadFile(allowlistFile)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			in = bytes.NewReader(data)
+		}
+		n, err := v.Import(in, allowlistReplace)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		fmt.Fprintf(os.Stdout, "Imported %d allowlist entries\n", n)
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var allowlistBulkAddCmd = &cobra.Command{
+	Use:   "bulk-add",
+	Short: "Add many allowlist entries from a tab-separated file",
+	Long: "Add allowlist entries from lines of the form TYPE<TAB>NAME<TAB>HASH. Lines starting with # are comments. " +
+		"Entries already on the allowlist are skipped; malformed lines are reported and the exit code is 1 if there were any.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		v, err := allowlist.NewVerifier(storageFile)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		in := cmd.InOrStdin()
+		if allowlistFile != "" {
+			f, err := os.Open(allowlistFile)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			defer f.Close()
+			in = f
+		}
+		res, err := v.BulkAdd(in)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		for _, e := range res.Errors {
+			fmt.Fprintln(os.Stderr, e)
+		}
+		fmt.Fprintf(os.Stdout, "%d added, %d skipped (already present), %d errors\n", res.Added, res.Skipped, len(res.Errors))
+		if len(res.Errors) > 0 {
+			os.Exit(1) //nolint:gocritic // Valid entries are already saved; the exit code flags the rest.
+		}
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Export or import local state for moving to another machine",
+	Long:  "Export or import the local storage file: host and organization UUIDs, allow/deny lists, cached ratings and scan history.",
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var storageExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the local storage as JSON or YAML",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := storage.NewOrExistingStorage(storageFile)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := s.Export(&buf, storageFormat); err != nil {
+			logrus.Fatal(err)
+		}
+		if storageTransferIO == "" {
+			_, _ = os.Stdout.Write(buf.Bytes())
+			return
+		}
+		if err := os.WriteFile(storageTransferIO, buf.Bytes(), 0o600); err != nil {
+			logrus.Fatal(err)
+		}
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var storageImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import storage exported with 'storage export', replacing the local storage unless --merge is set",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := storage.NewOrExistingStorage(storageFile)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		in := cmd.InOrStdin()
+		if storageTransferIO != "" {
+			data, err := os.ReadFile(storageTransferIO)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			in = bytes.NewReader(data)
+		}
+		changes, err := s.Import(in, storageMerge, storageDryRun)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		if len(changes) == 0 {
+			changes = []string{"no changes"}
+		}
+		if storageDryRun {
+			fmt.Fprintln(os.Stdout, "Dry run, nothing written. Would change:")
+		} else {
+			fmt.Fprintln(os.Stdout, "Imported storage:")
+		}
+		for _, c := range changes {
+			fmt.Fprintf(os.Stdout, "  %s\n", c)
+		}
+	},
+}
+
 //nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
 var experimentalCmd = &cobra.Command{
 	Use:   "experimental",
@@ -228,25 +1919,126 @@ var experimentalCmd = &cobra.Command{
 
 //nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
 var experimentalInspectCmd = &cobra.Command{
-	Use:   "inspect",
+	Use:   "inspect [TYPE] [NAME]",
 	Short: "Actively enumerates a given MCP Server to discover tool calls (experimental).",
+	Long: "Launch a stdio MCP server found in the scanned configs and list its tools, resources and prompts over JSON-RPC. " +
+		"The server is only enumerated; no tools are called.",
+	Args: cobra.RangeArgs(1, 2), //nolint:mnd // [TYPE] is optional
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Fprintln(
-			os.Stdout,
-			"This command is under construction. Thanks for your interest. Please let Frenchie know if this would be useful for you!",
-		)
+		if verbose {
+			logrus.SetLevel(logrus.DebugLevel)
+		} else {
+			logrus.SetLevel(logrus.WarnLevel)
+		}
+		name := args[len(args)-1]
+		if len(args) == 2 && args[0] != "server" { //nolint:mnd // TYPE NAME form
+			logrus.Fatalf("Unsupported type %q: only \"server\" can be inspected", args[0])
+		}
+
+		paths := inspectConfigs
+		if len(paths) == 0 {
+			paths = scanner.GetWellKnownMCPPaths(scanner.AllWellKnownPaths())
+		}
+		server, err := findServerConfig(name, paths)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		spec, err := inspect.NewServerSpec(name, server)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), inspectTimeout)
+		defer cancel()
+		report, err := inspect.Inspect(ctx, spec)
+		if err != nil {
+			logrus.Fatalf("Inspect %s failed: %v", name, err)
+		}
+		if err := inspect.PrintReport(os.Stdout, report, jsonOutput); err != nil {
+			logrus.Fatal(err)
+		}
 	},
 }
 
+// findServerConfig scans paths and returns the raw (unredacted) config of the first server named name.
+func findServerConfig(name string, paths []string) (scanner.Server, error) {
+	result, err := scanner.NewMCPScanner(paths, storageFile).Scan()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range result.Files {
+		for _, srv := range file.Servers {
+			if srv.Name != name {
+				continue
+			}
+			// Scan results are redacted; reload the file to get the real launch environment.
+			servers, err := scanner.LoadServers(file.Path)
+			if err != nil {
+				return nil, err
+			}
+			if server, ok := servers[name]; ok {
+				return server, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("server %q not found in scanned configs", name)
+}
+
 //nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
 var experimentalProxyCmd = &cobra.Command{
 	Use:   "proxy",
 	Short: "Proxy tool_calls to/from this MCP server (experimental).",
+	Long: "Launch a stdio MCP server found in the scanned configs and bridge it to this process's stdin/stdout, " +
+		"logging every JSON-RPC message with a timestamp. Point your MCP client at `run-mcp experimental proxy --server NAME` " +
+		"instead of the server's own command.",
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Fprintln(
-			os.Stdout,
-			"This command is under construction. Thanks for your interest. Please let Frenchie know if this would be useful for you!",
-		)
+		if verbose {
+			logrus.SetLevel(logrus.DebugLevel)
+		} else {
+			logrus.SetLevel(logrus.WarnLevel)
+		}
+
+		paths := proxyConfigs
+		if len(paths) == 0 {
+			paths = scanner.GetWellKnownMCPPaths(scanner.AllWellKnownPaths())
+		}
+		server, err := findServerConfig(proxyServer, paths)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		spec, err := inspect.NewServerSpec(proxyServer, server)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		opts := []proxy.Option{proxy.WithLog(os.Stderr), proxy.WithRedactSecrets(proxyRedactSecrets)}
+		if proxyLogFile != "" {
+			f, err := os.OpenFile(proxyLogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+			if err != nil {
+				logrus.Fatalf("Failed to open log file: %v", err)
+			}
+			defer f.Close()
+			opts = append(opts, proxy.WithLog(f))
+		}
+		if proxyEnforcePolicy {
+			st, err := storage.NewOrExistingStorage(storageFile)
+			if err != nil {
+				logrus.Fatalf("Failed to load storage: %v", err)
+			}
+			policy, err := proxy.NewPolicy(st, proxyServer)
+			if err != nil {
+				logrus.Fatalf("Refusing to proxy %s: %v", proxyServer, err)
+			}
+			opts = append(opts, proxy.WithPolicy(policy))
+		}
+
+		ctx := cmd.Context()
+		serverCmd := spec.Cmd(ctx)
+		serverCmd.Stderr = os.Stderr
+		if err := proxy.New(opts...).Run(ctx, serverCmd, os.Stdin, os.Stdout); err != nil {
+			logrus.Fatalf("Proxy %s failed: %v", proxyServer, err)
+		}
 	},
 }
 
@@ -329,3 +2121,368 @@ var orgShowCmd = &cobra.Command{
 		fmt.Fprintf(os.Stdout, "%s\n", s.Data.OrgUUID)
 	},
 }
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var orgValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check that the API accepts the persisted organization UUID",
+	Long:  "Make an authenticated request to the API with the persisted organization UUID. Exits 1 if the organization is not accepted.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if offline {
+			logrus.Fatal("Cannot use --offline with org validate: it needs to contact the API")
+		}
+		s, err := storage.NewOrExistingStorage(storageFile)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		if s.Data.OrgUUID == "" {
+			logrus.Fatal("No organization UUID set; register one with 'run-mcp org register <UUID>'")
+		}
+
+		cl, err := api.NewClient(apiClientOptions()...)
+		if err != nil {
+			logrus.Fatalf("Cannot reach the API: %v", err)
+		}
+		ctx := api.WithIdentity(cmd.Context(), api.Identity{OrgUUID: s.Data.OrgUUID})
+		err = cl.ValidateOrg(ctx)
+		switch {
+		case err == nil:
+			fmt.Fprintf(os.Stdout, "Organization %s is recognised by the API\n", s.Data.OrgUUID)
+		case errors.Is(err, api.ErrUnauthorized):
+			fmt.Fprintf(os.Stderr, "The API rejected organization %s (401 Unauthorized). The UUID may not be provisioned yet; "+
+				"check it with your administrator or re-register it with 'run-mcp org register <UUID>'.\n", s.Data.OrgUUID)
+			os.Exit(1)
+		default:
+			logrus.Fatalf("Organization check failed: %v", err)
+		}
+	},
+}
+
+// printSeverityCounts prints counts as a single line, or as a JSON object when asJSON is set.
+func printSeverityCounts(counts scanner.SeverityCounts, asJSON bool) {
+	if !asJSON {
+		fmt.Fprintln(os.Stdout, counts)
+		return
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(counts); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+// checkForUpdate prints a one-line warning to stderr when a newer release than
+// releaseVersion is available. Lookup failures are silent.
+func checkForUpdate(ctx context.Context, st *storage.Storage) {
+	url := update.LatestReleaseURL
+	if u := os.Getenv(releasesURLEnv); u != "" {
+		url = u
+	}
+	if latest, ok := update.Check(ctx, st, url, releaseVersion, time.Now()); ok {
+		fmt.Fprintf(os.Stderr, "A newer run-mcp is available: %s (running %s), see %s\n", latest, releaseVersion, update.LatestReleaseURL)
+	}
+	if err := st.Save(); err != nil {
+		logrus.Debugf("Failed to record update check: %v", err)
+	}
+}
+
+// apiClientOptions returns the API client options shared by all commands.
+func apiClientOptions() []api.ClientOption {
+	var opts []api.ClientOption
+	if u := os.Getenv(apiURLEnv); u != "" {
+		opts = append(opts, api.WithBaseURL(u))
+	}
+	if p := os.Getenv(apiCACertEnv); p != "" {
+		opts = append(opts, api.WithCACert(p))
+	}
+	return opts
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var diagnoseCmd = &cobra.Command{
+	Use:   "diagnose",
+	Short: "Check the installation: storage access, API connectivity and config locations",
+	Long: "Run self-checks and report each as PASS, WARN or FAIL. " +
+		"The exit code is the number of failed checks, so 0 means nothing failed.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if verbose {
+			logrus.SetLevel(logrus.DebugLevel)
+		} else {
+			logrus.SetLevel(logrus.WarnLevel)
+		}
+
+		opts := diagnose.Options{
+			Version:          releaseVersion,
+			Commit:           commit,
+			Date:             date,
+			StoragePath:      storageFile,
+			SystemConfigPath: storage.SystemConfigPath,
+			WellKnownPaths:   scanner.GetWellKnownMCPPaths(scanner.AllWellKnownPaths()),
+		}
+		if !offline {
+			opts.Probe = probeAPIHealth
+		}
+		report := diagnose.Run(cmd.Context(), opts)
+		if err := diagnose.PrintReport(os.Stdout, report, jsonOutput); err != nil {
+			logrus.Fatal(err)
+		}
+		os.Exit(report.Failures) //nolint:gocritic // Failure count is the documented exit code.
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the run-mcp config file",
+	Long: "The config file supplies defaults for flags. Each setting can also be given as a " +
+		"RUNMCP_<SETTING> environment variable, or as RUN_MCP_<SETTING> like the other RUN_MCP_ variables; " +
+		"RUNMCP_ wins if both are set. Flags win over the environment, which wins over the file. " +
+		"Set " + config.PathEnv + " to use a different file.",
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a config file with every supported setting commented out",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := config.DefaultPath()
+		if configDir != "" {
+			path, err = filepath.Join(configDir, config.FileName), nil
+		}
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		if _, err := os.Stat(path); err == nil && !configForce {
+			logrus.Fatalf("%s already exists; use --force to overwrite it", path)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			logrus.Fatal(err)
+		}
+		if err := os.WriteFile(path, config.Template(), 0o600); err != nil {
+			logrus.Fatal(err)
+		}
+		fmt.Fprintf(os.Stdout, "Wrote %s\n", path)
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the resolved configuration and where each value comes from",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := config.DefaultPath()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		vals, err := config.Load(path)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		// Scan settings resolve against the scan flag defaults.
+		flags := pflag.NewFlagSet("config", pflag.ContinueOnError)
+		flags.AddFlagSet(cmd.Flags())
+		flags.AddFlagSet(scanCmd.Flags())
+		settings := config.Resolve(flags, vals)
+		for i, s := range settings {
+			if s.Key == "storage_file" && s.Source == config.SourceDefault {
+				settings[i].Value = storageFile
+			}
+		}
+
+		if jsonOutput {
+			out := struct {
+				Path     string           `json:"path"`
+				Exists   bool             `json:"exists"`
+				Settings []config.Setting `json:"settings"`
+			}{Path: path, Exists: vals.Found, Settings: settings}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(out); err != nil {
+				logrus.Fatal(err)
+			}
+			return
+		}
+		if !vals.Found {
+			fmt.Fprintf(os.Stdout, "Config file: %s (not found)\n\n", path)
+		} else {
+			fmt.Fprintf(os.Stdout, "Config file: %s\n\n", path)
+		}
+		for _, s := range settings {
+			value := s.Value
+			if value == "" {
+				value = "(unset)"
+			}
+			fmt.Fprintf(os.Stdout, "%-18s %-40s %s\n", s.Key, value, s.Source)
+		}
+	},
+}
+
+// probeAPIHealth measures a /health round-trip against the ratings API.
+func probeAPIHealth(ctx context.Context) (time.Duration, error) {
+	cl, err := api.NewClient(apiClientOptions()...)
+	if err != nil && !errors.Is(err, api.ErrOffline) {
+		return 0, err
+	}
+	status, latency, err := cl.ProbeHealth(ctx)
+	if err == nil && status != apigen.Healthy {
+		err = fmt.Errorf("API reports status %q", status)
+	}
+	return latency, err
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var completionCmd = &cobra.Command{
+	Use:   "completion",
+	Short: "Generate shell completion scripts",
+	Long: "Generate a completion script for bash, zsh, fish or PowerShell and print it to stdout. " +
+		"With --install and no shell given, the shell is detected from $SHELL.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !completionInstall {
+			_ = cmd.Help()
+			return
+		}
+		shell := filepath.Base(os.Getenv("SHELL"))
+		if _, ok := completionGenerators[shell]; !ok {
+			logrus.Fatalf("Cannot detect a supported shell from $SHELL (%q); run `run-mcp completion [bash|zsh|fish|powershell] --install`", shell)
+		}
+		runCompletion(shell)
+	},
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var completionBashCmd = &cobra.Command{
+	Use:   "bash",
+	Short: "Generate the bash completion script",
+	Args:  cobra.NoArgs,
+	Run:   func(cmd *cobra.Command, args []string) { runCompletion("bash") },
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var completionZshCmd = &cobra.Command{
+	Use:   "zsh",
+	Short: "Generate the zsh completion script",
+	Args:  cobra.NoArgs,
+	Run:   func(cmd *cobra.Command, args []string) { runCompletion("zsh") },
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var completionFishCmd = &cobra.Command{
+	Use:   "fish",
+	Short: "Generate the fish completion script",
+	Args:  cobra.NoArgs,
+	Run:   func(cmd *cobra.Command, args []string) { runCompletion("fish") },
+}
+
+//nolint:gochecknoglobals // Cobra command is defined at package scope in current structure.
+var completionPowerShellCmd = &cobra.Command{
+	Use:   "powershell",
+	Short: "Generate the PowerShell completion script",
+	Args:  cobra.NoArgs,
+	Run:   func(cmd *cobra.Command, args []string) { runCompletion("powershell") },
+}
+
+//nolint:gochecknoglobals // Lookup table keyed by shell name.
+var completionGenerators = map[string]func(w io.Writer) error{
+	"bash":       func(w io.Writer) error { return rootCmd.GenBashCompletion(w) },
+	"zsh":        func(w io.Writer) error { return rootCmd.GenZshCompletion(w) },
+	"fish":       func(w io.Writer) error { return rootCmd.GenFishCompletion(w, true) },
+	"powershell": func(w io.Writer) error { return rootCmd.GenPowerShellCompletionWithDesc(w) },
+	"pwsh":       func(w io.Writer) error { return rootCmd.GenPowerShellCompletionWithDesc(w) },
+}
+
+// runCompletion prints the completion script for shell, or installs it when --install is set.
+func runCompletion(shell string) {
+	gen := completionGenerators[shell]
+	if !completionInstall {
+		if err := gen(os.Stdout); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+	path, err := installCompletion(shell, gen)
+	if err != nil {
+		logrus.Fatalf("Failed to install %s completion: %v", shell, err)
+	}
+	fmt.Fprintf(os.Stdout, "Installed %s completion to %s. Restart your shell to enable it.\n", shell, path)
+}
+
+// installCompletion writes the completion script for shell and returns the file that loads it.
+// Fish picks scripts up from its completions directory; other shells get a script under
+// ~/.config/run-mcp/completions plus a line in their profile that sources it.
+func installCompletion(shell string, gen func(w io.Writer) error) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := gen(&buf); err != nil {
+		return "", err
+	}
+
+	if shell == "fish" {
+		path := filepath.Join(home, ".config", "fish", "completions", "run-mcp.fish")
+		return path, writeFileMkdir(path, buf.Bytes())
+	}
+
+	script := filepath.Join(home, ".config", "run-mcp", "completions", "run-mcp."+shell)
+	if err := writeFileMkdir(script, buf.Bytes()); err != nil {
+		return "", err
+	}
+
+	var profile, line string
+	switch shell {
+	case "bash":
+		profile, line = filepath.Join(home, ".bashrc"), fmt.Sprintf("source %q", script)
+	case "zsh":
+		profile, line = filepath.Join(home, ".zshrc"), fmt.Sprintf("source %q", script)
+	default: // powershell, pwsh
+		profile = filepath.Join(home, ".config", "powershell", "Microsoft.PowerShell_profile.ps1")
+		if runtime.GOOS == "windows" {
+			profile = filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1")
+		}
+		line = fmt.Sprintf(". '%s'", script)
+	}
+	return profile, appendLineOnce(profile, line)
+}
+
+// writeFileMkdir writes data to path, creating parent directories as needed.
+func writeFileMkdir(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644) //nolint:gosec // Completion scripts are meant to be world-readable.
+}
+
+// appendLineOnce appends line to the file at path unless it is already present.
+func appendLineOnce(path, line string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if strings.Contains(string(existing), line) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644) //nolint:gosec // Shell profiles are user-readable.
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "\n# run-mcp shell completion\n%s\n", line)
+	return err
+}
+
+// completeOrgUUID proposes the organization UUID persisted in the storage file.
+func completeOrgUUID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// NewStorage only reads; completion must never create or modify the storage file.
+	s, err := storage.NewStorage(storageFile)
+	if err != nil || s.Data.OrgUUID == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return []string{s.Data.OrgUUID}, cobra.ShellCompDirectiveNoFileComp
+}
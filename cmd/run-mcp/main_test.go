@@ -2,16 +2,30 @@ package main
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ensigniasec/run-mcp/internal/config"
+	"github.com/ensigniasec/run-mcp/internal/scanner"
 )
 
 //nolint:gochecknoglobals // test binary path is set in TestMain
@@ -38,12 +52,21 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// setCmdHome points the command's home directory, and the per-OS data directories derived
+// from it, at home.
 func setCmdHome(cmd *exec.Cmd, home string) {
-	cmd.Env = append(os.Environ(), "HOME="+home)
+	cmd.Env = append(os.Environ(), "HOME="+home, "XDG_DATA_HOME=", "XDG_CONFIG_HOME=", config.PathEnv+"=", "SYSTEM_COLLECTIONURI=", "APPDATA="+filepath.Join(home, "AppData", "Roaming"))
 }
 
 func defaultStoragePath(home string) string {
-	return filepath.Join(home, "Library", "Application Support", "run-mcp", "results.json")
+	switch runtime.GOOS {
+	case "linux":
+		return filepath.Join(home, ".local", "share", "run-mcp", "results.json")
+	case "windows":
+		return filepath.Join(home, "AppData", "Roaming", "run-mcp", "results.json")
+	default:
+		return filepath.Join(home, "Library", "Application Support", "run-mcp", "results.json")
+	}
 }
 
 // Build the binary for testing.
@@ -630,3 +653,2457 @@ func TestCLI_OrgRegisterInvalid(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, string(output), "Invalid organization UUID:")
 }
+
+func TestCLI_ListWellKnown(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+
+	// Create one well-known file under a temp HOME so at least one path exists.
+	existing := filepath.Join(home, ".cursor", "mcp.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(existing), 0o700))
+	require.NoError(t, os.WriteFile(existing, []byte(`{"mcpServers": {}}`), 0o600))
+
+	// Plain output lists only existing paths.
+	cmd := newCmd(binary, "scan", "--list-well-known")
+	setCmdHome(cmd, home)
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), existing)
+	assert.NotContains(t, string(output), filepath.Join(home, ".codex", "mcp.json"))
+
+	// JSON output with --all includes non-existent paths too.
+	cmd = newCmd(binary, "scan", "--list-well-known", "--all", "--json")
+	setCmdHome(cmd, home)
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	var paths []string
+	require.NoError(t, json.Unmarshal(output, &paths), "Output should be a JSON array: %s", string(output))
+	assert.Contains(t, paths, existing)
+	assert.Greater(t, len(paths), 1)
+
+	// --project-only and --system-only split the list.
+	cmd = newCmd(binary, "scan", "--list-well-known", "--all", "--json", "--project-only")
+	setCmdHome(cmd, home)
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	var project []string
+	require.NoError(t, json.Unmarshal(output, &project))
+	assert.True(t, slices.ContainsFunc(project, func(p string) bool { return filepath.Base(p) == ".mcp.json" }))
+	assert.NotContains(t, project, existing)
+
+	cmd = newCmd(binary, "scan", "--list-well-known", "--all", "--json", "--system-only")
+	setCmdHome(cmd, home)
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	var system []string
+	require.NoError(t, json.Unmarshal(output, &system))
+	assert.Contains(t, system, existing)
+	for _, p := range system {
+		assert.NotEqual(t, ".mcp.json", filepath.Base(p), "no project paths with --system-only")
+	}
+
+	cmd = newCmd(binary, "scan", "--list-well-known", "--all", "--json", "--no-project-paths")
+	setCmdHome(cmd, home)
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	var noProject []string
+	require.NoError(t, json.Unmarshal(output, &noProject))
+	assert.Equal(t, system, noProject, "--no-project-paths drops the same paths as --system-only")
+
+	for _, flag := range []string{"--system-only", "--no-project-paths"} {
+		cmd = newCmd(binary, "scan", "--list-well-known", "--project-only", flag)
+		output, err = cmd.CombinedOutput()
+		require.Error(t, err)
+		assert.Contains(t, string(output), "Cannot use --project-only with --system-only or --no-project-paths")
+	}
+}
+
+func TestCLI_ExperimentalInspect(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell as the mock MCP server")
+	}
+	binary := buildTestBinary(t)
+	tempDir := t.TempDir()
+
+	// A canned stdio server: replies to initialize (id 1) and tools/list (id 2), then waits for EOF.
+	script := `printf '%s\n%s\n' ` +
+		`'{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2025-06-18","serverInfo":{"name":"canned","version":"0.1"},"capabilities":{"tools":{}}}}' ` +
+		`'{"jsonrpc":"2.0","id":2,"result":{"tools":[{"name":"echo","description":"Echo input"}]}}'; cat >/dev/null`
+	config := map[string]interface{}{
+		"mcpServers": map[string]interface{}{
+			"canned": map[string]interface{}{"command": "sh", "args": []string{"-c", script}},
+		},
+	}
+	b, err := json.Marshal(config)
+	require.NoError(t, err)
+	configFile := filepath.Join(tempDir, "mcp.json")
+	require.NoError(t, os.WriteFile(configFile, b, 0o600))
+
+	cmd := newCmd(binary, "experimental", "inspect", "--json", "--config", configFile, "server", "canned")
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &report), "Output should be valid JSON: %s", string(output))
+	assert.Equal(t, "canned", report["server"])
+	tools, ok := report["tools"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, tools, 1)
+
+	// Unknown servers are an error.
+	cmd = newCmd(binary, "experimental", "inspect", "--config", configFile, "missing")
+	output, err = cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "not found")
+}
+
+func TestCLI_ExperimentalProxy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses cat as the mock MCP server")
+	}
+	binary := buildTestBinary(t)
+	tempDir := t.TempDir()
+
+	// cat echoes every request straight back, which is enough to observe both directions.
+	config := map[string]interface{}{
+		"mcpServers": map[string]interface{}{
+			"echo": map[string]interface{}{"command": "cat"},
+		},
+	}
+	b, err := json.Marshal(config)
+	require.NoError(t, err)
+	configFile := filepath.Join(tempDir, "mcp.json")
+	require.NoError(t, os.WriteFile(configFile, b, 0o600))
+	logFile := filepath.Join(tempDir, "proxy.log")
+
+	request := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"read_file","arguments":{"token":"AKIA1234567890ABCDEF"}}}`
+	cmd := newCmd(binary, "experimental", "proxy", "--server", "echo", "--config", configFile,
+		"--log-file", logFile, "--redact-secrets")
+	cmd.Stdin = strings.NewReader(request + "\n")
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, request+"\n", string(output), "traffic should be forwarded unmodified")
+
+	logged, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(logged), "client->server")
+	assert.Contains(t, string(logged), "server->client")
+	assert.NotContains(t, string(logged), "AKIA1234567890ABCDEF")
+
+	// --server is required.
+	cmd = newCmd(binary, "experimental", "proxy")
+	_, err = cmd.CombinedOutput()
+	require.Error(t, err)
+}
+
+func TestCLI_Completion(t *testing.T) {
+	binary := buildTestBinary(t)
+
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			output, err := newCmd(binary, "completion", shell).Output()
+			require.NoError(t, err)
+			assert.Contains(t, string(output), "run-mcp")
+		})
+	}
+
+	output, err := newCmd(binary, "completion", "bash").Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "scan")
+}
+
+func TestCLI_CompletionOrgUUID(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+	orgID := "123e4567-e89b-12d3-a456-426614174000"
+
+	cmd := newCmd(binary, "org", "register", orgID)
+	setCmdHome(cmd, home)
+	require.NoError(t, cmd.Run())
+
+	cmd = exec.Command(binary, "__complete", "scan", "--org-uuid", "")
+	setCmdHome(cmd, home)
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), orgID)
+}
+
+func TestCLI_CompletionInstall(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+
+	for range 2 {
+		cmd := newCmd(binary, "completion", "bash", "--install")
+		setCmdHome(cmd, home)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	script := filepath.Join(home, ".config", "run-mcp", "completions", "run-mcp.bash")
+	b, err := os.ReadFile(script)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "run-mcp")
+
+	profile, err := os.ReadFile(filepath.Join(home, ".bashrc"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(profile), script), "profile line should be added once")
+
+	cmd := newCmd(binary, "completion", "--install")
+	cmd.Env = append(os.Environ(), "HOME="+home, "SHELL=/usr/bin/fish")
+	require.NoError(t, cmd.Run())
+	_, err = os.Stat(filepath.Join(home, ".config", "fish", "completions", "run-mcp.fish"))
+	require.NoError(t, err)
+}
+
+func TestCLI_Diagnose(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+
+	cmd := newCmd(binary, "diagnose", "--json")
+	setCmdHome(cmd, home)
+	output, err := cmd.Output()
+	require.NoError(t, err, "no failed checks should exit 0")
+
+	var report struct {
+		OS       string `json:"os"`
+		Version  string `json:"version"`
+		Failures int    `json:"failures"`
+		Checks   []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+		} `json:"checks"`
+	}
+	require.NoError(t, json.Unmarshal(output, &report), "Output should be valid JSON: %s", string(output))
+	assert.Equal(t, runtime.GOOS, report.OS)
+	assert.Equal(t, 0, report.Failures)
+	statuses := map[string]string{}
+	for _, c := range report.Checks {
+		statuses[c.Name] = c.Status
+	}
+	assert.Equal(t, "PASS", statuses["storage"])
+	assert.Equal(t, "WARN", statuses["api"], "offline mode skips the API probe")
+
+	// An unusable storage path is a failure, reported through the exit code.
+	require.NoError(t, os.MkdirAll(defaultStoragePath(home), 0o755))
+	cmd = newCmd(binary, "diagnose")
+	setCmdHome(cmd, home)
+	output, err = cmd.Output()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Contains(t, string(output), "[FAIL] storage")
+}
+
+func TestCLI_ScanOutputFile(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	// Rich text on stdout, JSON artifact on disk.
+	outFile := filepath.Join(home, "results.json")
+	cmd := newCmd(binary, "scan", "--output-file", outFile, claudePath)
+	setCmdHome(cmd, home)
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "RUN-MCP SCAN REPORT")
+
+	b, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	var summary map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &summary), "output file should be JSON: %s", string(b))
+	assert.Contains(t, summary, "Servers")
+
+	// Text format to file, JSON on stdout.
+	textFile := filepath.Join(home, "results.txt")
+	cmd = newCmd(binary, "scan", "--json", "--output-file", textFile, "--output-format", "text", claudePath)
+	setCmdHome(cmd, home)
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.True(t, json.Valid(output), "stdout should stay JSON: %s", string(output))
+	b, err = os.ReadFile(textFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "RUN-MCP SCAN REPORT")
+	assert.NotContains(t, string(b), "\x1b[", "file output should not contain ANSI escapes")
+
+	// Unknown formats are rejected.
+	cmd = newCmd(binary, "scan", "--output-file", outFile, "--output-format", "xml", claudePath)
+	setCmdHome(cmd, home)
+	output, err = cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "--output-format")
+}
+
+func TestCLI_ScanAnonymizePaths(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+
+	// A distinctive directory name that must not leak into the output.
+	dir := filepath.Join(home, "secret-project-dir")
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	configFile := filepath.Join(dir, "mcp.json")
+	config := `{"mcpServers": {"openai": {"command": "npx", "args": ["openai-mcp"], "env": {"OPENAI_API_KEY": "sk-proj-abcdefT3BlbkFJ0123456789abcdef"}}}}`
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0o600))
+
+	run := func(args ...string) []byte {
+		cmd := newCmd(binary, append([]string{"scan", "--json", "--anonymize-paths"}, args...)...)
+		setCmdHome(cmd, home)
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		return output
+	}
+
+	output := run(configFile)
+	require.True(t, json.Valid(output), string(output))
+	// Short temp-dir segments such as "001" can occur by chance in hashes and timestamps, so
+	// only the distinctive parts of the path are checked.
+	assert.NotContains(t, string(output), filepath.ToSlash(dir))
+	assert.NotContains(t, string(output), "secret-project-dir")
+	assert.Regexp(t, `"[0-9a-f]{8}\.json"`, string(output))
+
+	// The salt changes the hash; the same salt reproduces it.
+	var a, b, c struct {
+		Servers []struct {
+			Path string `json:"path"`
+		} `json:"Servers"`
+	}
+	require.NoError(t, json.Unmarshal(output, &a))
+	require.NoError(t, json.Unmarshal(run("--anonymize-paths-salt", "ci", configFile), &b))
+	require.NoError(t, json.Unmarshal(run("--anonymize-paths-salt", "ci", configFile), &c))
+	require.Len(t, a.Servers, 1)
+	assert.NotEqual(t, a.Servers[0].Path, b.Servers[0].Path)
+	assert.Equal(t, b.Servers[0].Path, c.Servers[0].Path)
+}
+
+func TestCLI_ScanVerboseJSON(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+
+	configFile := filepath.Join(home, "mcp.json")
+	config := `{"mcpServers": {"openai": {"command": "npx", "args": ["openai-mcp"], "env": {"OPENAI_API_KEY": "sk-proj-abcdefT3BlbkFJ0123456789abcdef"}}}}`
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0o600))
+
+	cmd := newCmd(binary, "scan", "--verbose-json", configFile)
+	setCmdHome(cmd, home)
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	var result struct {
+		Files []struct {
+			Path    string `json:"path"`
+			Servers []struct {
+				Name   string                 `json:"name"`
+				Server map[string]interface{} `json:"server"`
+			} `json:"servers"`
+			SecretFindings []map[string]interface{} `json:"secret_findings"`
+		} `json:"files"`
+	}
+	require.NoError(t, json.Unmarshal(output, &result), "Output should be valid JSON: %s", string(output))
+	require.Len(t, result.Files, 1)
+	require.Len(t, result.Files[0].Servers, 1)
+	assert.Equal(t, "npx", result.Files[0].Servers[0].Server["command"])
+	require.Len(t, result.Files[0].SecretFindings, 1)
+	assert.NotEmpty(t, result.Files[0].SecretFindings[0]["value_hash"])
+	assert.NotContains(t, result.Files[0].SecretFindings[0], "value")
+	assert.NotContains(t, string(output), "sk-proj-abcdefT3BlbkFJ0123456789abcdef")
+
+	// --verbose-json and --json are mutually exclusive.
+	cmd = newCmd(binary, "scan", "--verbose-json", "--json", configFile)
+	setCmdHome(cmd, home)
+	output, err = cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "--verbose-json")
+}
+
+func TestCLI_ScanCheckExitCodes(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+
+	secretFile := filepath.Join(home, "secret.json")
+	require.NoError(t, os.WriteFile(secretFile,
+		[]byte(`{"mcpServers": {"openai": {"command": "npx", "args": ["openai-mcp"], "env": {"OPENAI_API_KEY": "sk-proj-abcdefT3BlbkFJ0123456789abcdef"}}}}`), 0o600))
+	cleanFile := filepath.Join(home, "clean.json")
+	require.NoError(t, os.WriteFile(cleanFile, []byte(`{"mcpServers": {"fs": {"command": "npx", "args": ["server-filesystem"]}}}`), 0o600))
+
+	exitCode := func(args ...string) (int, string) {
+		cmd := newCmd(binary, append([]string{"scan"}, args...)...)
+		setCmdHome(cmd, home)
+		out, err := cmd.Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), string(out)
+		}
+		require.NoError(t, err)
+		return 0, string(out)
+	}
+
+	code, out := exitCode("--check-secrets", "--quiet", secretFile)
+	assert.Equal(t, 1, code)
+	assert.Empty(t, out, "--quiet suppresses output")
+
+	code, out = exitCode("--check-secrets", "--json", cleanFile)
+	assert.Equal(t, 0, code)
+	assert.True(t, json.Valid([]byte(out)))
+
+	// Offline scans have no ratings, so --check passes even with secrets present.
+	code, _ = exitCode("--check", "--quiet", secretFile)
+	assert.Equal(t, 0, code)
+
+	code, _ = exitCode("--check", filepath.Join(home, "missing.json"))
+	assert.Equal(t, 2, code)
+
+	code, _ = exitCode("--fail-on-severity", "urgent", cleanFile)
+	assert.Equal(t, 2, code)
+
+	// Launch misconfigurations count as findings.
+	code, _ = exitCode("--check", "--quiet", filepath.Join("..", "..", "testdata", "docker_privileged_config.json"))
+	assert.Equal(t, 1, code)
+
+	// Online, --check cannot pass without ratings: an API client that fails to set up is a scan error.
+	cmd := exec.Command(binary, "scan", "--check", "--quiet", cleanFile)
+	setCmdHome(cmd, home)
+	cmd.Env = append(cmd.Env, apiCACertEnv+"="+filepath.Join(home, "missing-ca.pem"))
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.ExitCode())
+}
+
+func TestCLI_ScanSinceAndHistory(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	scanJSON := func(args ...string) map[string]interface{} {
+		cmd := newCmd(binary, append([]string{"scan", "--json"}, args...)...)
+		setCmdHome(cmd, home)
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var summary map[string]interface{}
+		require.NoError(t, json.Unmarshal(output, &summary), string(output))
+		return summary
+	}
+
+	first := scanJSON(claudePath)
+	require.NotZero(t, first["TotalServers"])
+	cutoff := time.Now().UTC().Add(time.Second).Format(time.RFC3339)
+
+	// Everything was already reported by the first scan.
+	second := scanJSON("--since", cutoff, claudePath)
+	assert.InDelta(t, 0, second["TotalServers"], 0)
+
+	cmd := newCmd(binary, "scan", "history", "--json")
+	setCmdHome(cmd, home)
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var history []map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &history), string(output))
+	assert.Len(t, history, 2)
+
+	cmd = newCmd(binary, "scan", "history", "prune")
+	setCmdHome(cmd, home)
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "Removed 2")
+}
+
+func TestCLI_ScanSuppress(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+	suppressions := filepath.Join(t.TempDir(), "suppressions.yaml")
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	// Reason and expiry are prompted for on stdin.
+	cmd := newCmd(binary, "scan", "suppress", "git", "--suppress-file", suppressions)
+	setCmdHome(cmd, home)
+	cmd.Stdin = strings.NewReader("reviewed by security\n2099-12-31\n")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	assert.Contains(t, string(output), `Suppressed "git"`)
+
+	data, err := os.ReadFile(suppressions)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "reason: reviewed by security")
+	assert.Contains(t, string(data), "expires: \"2099-12-31\"")
+
+	cmd = newCmd(binary, "scan", "--json", "--suppress-file", suppressions, claudePath)
+	setCmdHome(cmd, home)
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	var summary struct {
+		Servers    []map[string]interface{}
+		Suppressed []map[string]interface{}
+	}
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	require.Len(t, summary.Suppressed, 1)
+	assert.Equal(t, "git", summary.Suppressed[0]["name"])
+	assert.Equal(t, "reviewed by security", summary.Suppressed[0]["suppression_reason"])
+	require.Len(t, summary.Servers, 1)
+	assert.Equal(t, "filesystem", summary.Servers[0]["name"])
+
+	t.Run("invalid expiry", func(t *testing.T) {
+		cmd := newCmd(binary, "scan", "suppress", "git", "--suppress-file", suppressions,
+			"--reason", "x", "--expires", "soon")
+		setCmdHome(cmd, home)
+		require.Error(t, cmd.Run())
+	})
+}
+
+func TestCLI_ScanUploadFlags(t *testing.T) {
+	binary := buildTestBinary(t)
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	orgID := "8b0f7c9e-4f4d-4e0a-9a52-3c1f1e2d3a4b"
+
+	tests := []struct {
+		name     string
+		args     []string
+		env      string
+		wantErr  bool
+		contains string
+	}{
+		{"requires org", []string{"--upload"}, "", true, "--upload requires --org-uuid"},
+		{"env enables upload", nil, "RUN_MCP_UPLOAD=true", true, "--upload requires --org-uuid"},
+		{"no-upload overrides env", []string{"--no-upload"}, "RUN_MCP_UPLOAD=true", false, ""},
+		{"skipped offline", []string{"--upload", "--org-uuid", orgID}, "", false, "Skipping --upload in offline mode"},
+		{"conflicts with anonymous", []string{"--upload", "--org-uuid", orgID, "--anonymous"}, "", true, "--anonymous"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"scan", "--json"}, tt.args...)
+			cmd := newCmd(binary, append(args, claudePath)...)
+			setCmdHome(cmd, t.TempDir())
+			if tt.env != "" {
+				cmd.Env = append(cmd.Env, tt.env)
+			}
+			output, err := cmd.CombinedOutput()
+			if tt.wantErr {
+				require.Error(t, err, string(output))
+			} else {
+				require.NoError(t, err, string(output))
+			}
+			assert.Contains(t, string(output), tt.contains)
+		})
+	}
+}
+
+func TestCLI_ScanSecretContext(t *testing.T) {
+	binary := buildTestBinary(t)
+	secretsPath := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	cmd := newCmd(binary, "scan", "--json", "--context", "2", secretsPath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	var summary struct {
+		Secrets []struct {
+			Context string `json:"context"`
+		}
+	}
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	require.NotEmpty(t, summary.Secrets)
+	for _, s := range summary.Secrets {
+		assert.NotEmpty(t, s.Context)
+	}
+	assert.NotContains(t, string(output), "sbp_ab794c03758f962f0ad993b0cd6578b13b4ec407")
+
+	cmd = newCmd(binary, "scan", "--context", "1", secretsPath)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "        >")
+}
+
+func TestCLI_ScanHashAlgorithm(t *testing.T) {
+	binary := buildTestBinary(t)
+	secretsPath := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	type hashes struct {
+		HashAlgorithm string
+		Servers       []struct {
+			Fingerprint string `json:"fingerprint"`
+		}
+		Secrets []struct {
+			ValueHash string `json:"value_hash"`
+		}
+	}
+	scan := func(t *testing.T, args ...string) hashes {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"scan", "--json"}, append(args, secretsPath)...)...)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var out hashes
+		require.NoError(t, json.Unmarshal(output, &out), string(output))
+		require.NotEmpty(t, out.Servers)
+		require.NotEmpty(t, out.Secrets)
+		return out
+	}
+
+	sha256 := scan(t)
+	assert.Equal(t, "sha256", sha256.HashAlgorithm)
+	for _, algorithm := range []string{"sha3-256", "blake2b-256"} {
+		got := scan(t, "--hash-algorithm", algorithm)
+		assert.Equal(t, algorithm, got.HashAlgorithm)
+		assert.Len(t, got.Servers[0].Fingerprint, 64)
+		assert.NotEqual(t, sha256.Servers[0].Fingerprint, got.Servers[0].Fingerprint, algorithm)
+		assert.Len(t, got.Secrets[0].ValueHash, 64)
+		assert.NotEqual(t, sha256.Secrets[0].ValueHash, got.Secrets[0].ValueHash, algorithm)
+	}
+
+	cmd := newCmd(binary, "scan", "--json", "--hash-algorithm", "md5", secretsPath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Invalid --hash-algorithm")
+}
+
+func TestCLI_ScanSecretModes(t *testing.T) {
+	binary := buildTestBinary(t)
+	secretsPath := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	type summary struct {
+		Servers      []map[string]interface{}
+		Secrets      []map[string]interface{}
+		TotalServers int
+	}
+	scanJSON := func(t *testing.T, flag string) summary {
+		t.Helper()
+		cmd := newCmd(binary, "scan", "--json", flag, secretsPath)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var s summary
+		require.NoError(t, json.Unmarshal(output, &s), string(output))
+		return s
+	}
+
+	t.Run("no-secrets", func(t *testing.T) {
+		s := scanJSON(t, "--no-secrets")
+		assert.Empty(t, s.Secrets)
+		assert.NotEmpty(t, s.Servers)
+	})
+
+	t.Run("secrets-only", func(t *testing.T) {
+		s := scanJSON(t, "--secrets-only")
+		assert.NotEmpty(t, s.Secrets)
+		assert.Empty(t, s.Servers, "no rated or discovered server entries")
+		assert.NotZero(t, s.TotalServers)
+	})
+
+	t.Run("mutually exclusive", func(t *testing.T) {
+		cmd := newCmd(binary, "scan", "--no-secrets", "--secrets-only", secretsPath)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.CombinedOutput()
+		require.Error(t, err)
+		assert.Contains(t, string(output), "Cannot use --no-secrets and --secrets-only together")
+	})
+}
+
+func TestCLI_AllowlistExportImport(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"experimental", "allowlist"}, args...)...)
+		setCmdHome(cmd, home)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return string(output)
+	}
+
+	run("add", "server", "filesystem", "hash123")
+	run("add", "server", "git", "hash456")
+	before := run("export")
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "allowlist."+format)
+			run("export", "--format", format, "--file", file)
+			run("reset")
+			assert.Contains(t, run(), "Allowlist is empty")
+
+			assert.Contains(t, run("import", "--file", file, "--replace"), "Imported 2 allowlist entries")
+			assert.Equal(t, before, run("export"))
+		})
+	}
+
+	t.Run("rejects malformed entries", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "bad.json")
+		require.NoError(t, os.WriteFile(file, []byte(`[{"type": "server", "hash": "h"}]`), 0o600))
+		cmd := newCmd(binary, "experimental", "allowlist", "import", "--file", file)
+		setCmdHome(cmd, home)
+		require.Error(t, cmd.Run())
+		assert.Equal(t, before, run("export"))
+	})
+
+	t.Run("merge and replace are exclusive", func(t *testing.T) {
+		cmd := newCmd(binary, "experimental", "allowlist", "import", "--merge", "--replace")
+		setCmdHome(cmd, home)
+		require.Error(t, cmd.Run())
+	})
+}
+
+func TestCLI_ScanValidateConfigs(t *testing.T) {
+	binary := buildTestBinary(t)
+	validPath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	malformedPath := filepath.Join("..", "..", "testdata", "malformed.yaml")
+
+	t.Run("all valid", func(t *testing.T) {
+		cmd := newCmd(binary, "scan", "--validate-configs", validPath)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		assert.Contains(t, string(output), "✔ "+validPath)
+	})
+
+	t.Run("invalid file as JSON", func(t *testing.T) {
+		home := t.TempDir()
+		cmd := newCmd(binary, "scan", "--validate-configs", "--json", validPath, malformedPath)
+		setCmdHome(cmd, home)
+		output, err := cmd.Output()
+		var exitErr *exec.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 1, exitErr.ExitCode())
+
+		var results []struct {
+			File        string
+			Valid       bool
+			Diagnostics []struct {
+				File    string
+				Line    int
+				Message string
+			}
+		}
+		require.NoError(t, json.Unmarshal(output, &results), string(output))
+		require.Len(t, results, 2)
+		assert.True(t, results[0].Valid)
+		assert.False(t, results[1].Valid)
+		require.Len(t, results[1].Diagnostics, 1)
+		assert.Equal(t, malformedPath, results[1].Diagnostics[0].File)
+		assert.Equal(t, 3, results[1].Diagnostics[0].Line)
+
+		_, statErr := os.Stat(defaultStoragePath(home))
+		assert.True(t, os.IsNotExist(statErr), "validation must not touch storage")
+	})
+}
+
+func TestCLI_OrgValidate(t *testing.T) {
+	binary := buildTestBinary(t)
+	const known = "123e4567-e89b-12d3-a456-426614174000"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The unauthenticated start-up probe carries no org header.
+		if org := r.Header.Get("X-Org-Uuid"); org != "" && org != known {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	validate := func(t *testing.T, org string) (string, error) {
+		t.Helper()
+		home := t.TempDir()
+		reg := newCmd(binary, "org", "register", org)
+		setCmdHome(reg, home)
+		require.NoError(t, reg.Run())
+
+		cmd := exec.Command(binary, "org", "validate")
+		setCmdHome(cmd, home)
+		cmd.Env = append(cmd.Env, "RUN_MCP_API_URL="+srv.URL+"/api/v1")
+		output, err := cmd.CombinedOutput()
+		return string(output), err
+	}
+
+	t.Run("known org", func(t *testing.T) {
+		output, err := validate(t, known)
+		require.NoError(t, err, output)
+		assert.Contains(t, output, "Organization "+known+" is recognised by the API")
+	})
+
+	t.Run("unknown org", func(t *testing.T) {
+		output, err := validate(t, "00000000-0000-4000-8000-000000000000")
+		var exitErr *exec.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 1, exitErr.ExitCode())
+		assert.Contains(t, output, "may not be provisioned")
+	})
+
+	t.Run("no org", func(t *testing.T) {
+		cmd := exec.Command(binary, "org", "validate")
+		setCmdHome(cmd, t.TempDir())
+		cmd.Env = append(cmd.Env, "RUN_MCP_API_URL="+srv.URL+"/api/v1")
+		output, err := cmd.CombinedOutput()
+		require.Error(t, err)
+		assert.Contains(t, string(output), "No organization UUID set")
+	})
+}
+
+func TestCLI_ScanEnvironment(t *testing.T) {
+	binary := buildTestBinary(t)
+	configPath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	scanJSON := func(t *testing.T, args ...string) map[string]interface{} {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"scan", "--json"}, args...)...)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var summary map[string]interface{}
+		require.NoError(t, json.Unmarshal(output, &summary), string(output))
+		return summary
+	}
+
+	summary := scanJSON(t, "--environment", "staging", configPath)
+	assert.Equal(t, "staging", summary["Environment"])
+
+	summary = scanJSON(t, configPath)
+	assert.NotContains(t, summary, "Environment", "omitted when not set")
+}
+
+func TestCLI_ScanLabels(t *testing.T) {
+	binary := buildTestBinary(t)
+	configPath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	cmd := newCmd(binary, "scan", "--json", "--label", "repo=api", "--label", "branch=feature=x",
+		"--label", "repo=web", configPath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var summary map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	assert.Equal(t, map[string]interface{}{"repo": "web", "branch": "feature=x"}, summary["Labels"],
+		"a repeated key keeps its last value and values may contain '='")
+
+	for _, bad := range []string{"no-equals", "=value", "key=multi\nline"} {
+		cmd = newCmd(binary, "scan", "--json", "--label", bad, configPath)
+		setCmdHome(cmd, t.TempDir())
+		output, err = cmd.CombinedOutput()
+		require.Error(t, err, bad)
+		assert.Contains(t, string(output), "Invalid --label")
+	}
+}
+
+func TestCLI_ScanNoBanner(t *testing.T) {
+	binary := buildTestBinary(t)
+	configPath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	const bannerEscape = "\x1b[38;2;"
+
+	scanText := func(t *testing.T, env []string, args ...string) string {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"scan"}, args...)...)
+		setCmdHome(cmd, t.TempDir())
+		cmd.Env = append(cmd.Env, "NO_COLOR=", "TERM=xterm-256color")
+		cmd.Env = append(cmd.Env, env...)
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		require.Contains(t, string(output), "RUN-MCP SCAN REPORT")
+		return string(output)
+	}
+
+	assert.Contains(t, scanText(t, nil, configPath), bannerEscape)
+	assert.NotContains(t, scanText(t, nil, "--no-banner", configPath), bannerEscape)
+	assert.NotContains(t, scanText(t, []string{"NO_COLOR=1"}, configPath), bannerEscape)
+	assert.NotContains(t, scanText(t, []string{"TERM=dumb"}, configPath), bannerEscape)
+}
+
+func TestCLI_ScanNoColor(t *testing.T) {
+	binary := buildTestBinary(t)
+	configPath := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	cmd := newCmd(binary, "scan", "--no-color", configPath)
+	setCmdHome(cmd, t.TempDir())
+	cmd.Env = append(cmd.Env, "NO_COLOR=", "TERM=xterm-256color")
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	require.Contains(t, string(output), "RUN-MCP SCAN REPORT")
+	assert.NotContains(t, string(output), "\x1b[", "no ANSI escape codes with --no-color")
+}
+
+func TestCLI_ScanUpdateCheck(t *testing.T) {
+	binary := buildTestBinary(t)
+	configPath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Redirect(w, r, "/releases/tag/v99.0.0", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	home := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"scan", "--json"}, args...)...)
+		setCmdHome(cmd, home)
+		cmd.Env = append(cmd.Env, "RUN_MCP_RELEASES_URL="+srv.URL)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		require.NoError(t, cmd.Run(), stderr.String())
+		return stderr.String()
+	}
+
+	run(configPath)
+	assert.Zero(t, requests, "the check is off by default")
+
+	// Development builds are never outdated, but the check time is still cached.
+	assert.NotContains(t, run("--update-check", configPath), "newer run-mcp")
+	assert.Equal(t, 1, requests)
+	data, err := os.ReadFile(defaultStoragePath(home))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"last_update_check"`)
+
+	run("--update-check", configPath)
+	assert.Equal(t, 1, requests, "checked at most once a day")
+}
+
+func TestCLI_ScanAnnotationsFile(t *testing.T) {
+	binary := buildTestBinary(t)
+	annotations := filepath.Join(t.TempDir(), "annotations.yaml")
+	require.NoError(t, os.WriteFile(annotations, []byte(`
+- match: git
+  note: "JIRA-123: pin the version"
+  owner: team-alpha
+  due: "2099-12-31"
+`), 0o600))
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	cmd := newCmd(binary, "scan", "--json", "--annotations-file", annotations, claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var summary scanner.ScanSummary
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	for _, s := range summary.Servers {
+		if s.Name != "git" {
+			assert.Nil(t, s.Annotation, s.Name)
+			continue
+		}
+		require.NotNil(t, s.Annotation)
+		assert.Equal(t, "team-alpha", s.Annotation.Owner)
+	}
+
+	cmd = newCmd(binary, "scan", "--no-banner", "--annotations-file", annotations, claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "Note: JIRA-123: pin the version")
+	assert.Contains(t, string(output), "Due: 2099-12-31")
+
+	cmd = newCmd(binary, "scan", "--annotations-file", filepath.Join(t.TempDir(), "missing.yaml"), claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Invalid --annotations-file")
+}
+
+func TestCLI_ScanSkipServer(t *testing.T) {
+	binary := buildTestBinary(t)
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	cmd := newCmd(binary, "scan", "--json", "--skip-server", "GIT", claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var summary scanner.ScanSummary
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	require.Len(t, summary.Servers, 1)
+	assert.Equal(t, "filesystem", summary.Servers[0].Name)
+	assert.Equal(t, 1, summary.TotalServers)
+	assert.Equal(t, []string{"git"}, summary.SkippedServers)
+}
+
+func TestCLI_ScanStats(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	cmd := newCmd(binary, "scan", "--json", "--stats", config)
+	setCmdHome(cmd, t.TempDir())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	require.NoError(t, err, stderr.String())
+
+	var summary scanner.ScanSummary
+	require.NoError(t, json.Unmarshal(output, &summary), "stdout only holds the results")
+	var stats scanner.StatsReport
+	require.NoError(t, json.Unmarshal(stderr.Bytes(), &stats), stderr.String())
+	assert.Equal(t, 1, stats.FilesScanned)
+	assert.Equal(t, summary.TotalServers, stats.ServersFound)
+	assert.Positive(t, stats.IdentifiersExtracted)
+	assert.Zero(t, stats.BatchesSubmitted, "nothing is submitted offline")
+	for _, phase := range []string{scanner.PhaseDiscover, scanner.PhaseScan, scanner.PhaseRatings, scanner.PhaseReport} {
+		assert.Contains(t, stats.PhaseTimings, phase)
+	}
+
+	cmd = newCmd(binary, "scan", "--stats", config)
+	setCmdHome(cmd, t.TempDir())
+	stderr.Reset()
+	cmd.Stderr = &stderr
+	require.NoError(t, cmd.Run())
+	assert.Contains(t, stderr.String(), "Scan statistics:")
+}
+
+func TestCLI_ScanSuppressFileErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks are not reliable on Windows")
+	}
+	binary := buildTestBinary(t)
+	dir := t.TempDir()
+	require.NoError(t, os.Symlink(filepath.Join(dir, "gone.json"), filepath.Join(dir, "mcp.json")))
+
+	cmd := newCmd(binary, "scan", "--json", "--verbose", "--suppress-file-errors", dir)
+	setCmdHome(cmd, t.TempDir())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	require.NoError(t, err, stderr.String())
+	assert.NotContains(t, stderr.String(), "File not found", "suppressed errors are not logged")
+
+	var summary scanner.ScanSummary
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	assert.Equal(t, 1, summary.SuppressedErrors)
+}
+
+func TestCLI_StorageExportImport(t *testing.T) {
+	binary := buildTestBinary(t)
+	run := func(t *testing.T, home string, args ...string) string {
+		t.Helper()
+		cmd := newCmd(binary, args...)
+		setCmdHome(cmd, home)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return string(output)
+	}
+
+	oldHome := t.TempDir()
+	run(t, oldHome, "org", "register", "123e4567-e89b-12d3-a456-426614174000")
+	run(t, oldHome, "experimental", "allowlist", "add", "server", "filesystem", "hash123")
+	before := run(t, oldHome, "storage", "export")
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "storage."+format)
+			run(t, oldHome, "storage", "export", "--format", format, "--file", file)
+
+			newHome := t.TempDir()
+			dryRun := run(t, newHome, "storage", "import", "--file", file, "--dry-run")
+			assert.Contains(t, dryRun, "org_uuid: (none) -> 123e4567-e89b-12d3-a456-426614174000")
+			assert.Contains(t, run(t, newHome, "org", "show"), "No organization UUID set")
+
+			run(t, newHome, "storage", "import", "--file", file)
+			assert.Equal(t, before, run(t, newHome, "storage", "export"))
+		})
+	}
+
+	t.Run("merge keeps local entries", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "storage.json")
+		run(t, oldHome, "storage", "export", "--file", file)
+
+		newHome := t.TempDir()
+		run(t, newHome, "experimental", "allowlist", "add", "server", "git", "hash456")
+		run(t, newHome, "storage", "import", "--file", file, "--merge")
+		list := run(t, newHome, "experimental", "allowlist", "export")
+		assert.Contains(t, list, "hash123")
+		assert.Contains(t, list, "hash456")
+	})
+
+	t.Run("rejects invalid uuid", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "bad.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{"host_uuid": "nope"}`), 0o600))
+		cmd := newCmd(binary, "storage", "import", "--file", file)
+		setCmdHome(cmd, oldHome)
+		output, err := cmd.CombinedOutput()
+		require.Error(t, err)
+		assert.Contains(t, string(output), "invalid host_uuid")
+		assert.Equal(t, before, run(t, oldHome, "storage", "export"))
+	})
+}
+
+func TestCLI_ScanMinConfidence(t *testing.T) {
+	binary := buildTestBinary(t)
+	secretsPath := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	type summary struct {
+		Secrets       []struct{ Confidence string }
+		TotalFindings int
+	}
+	scanJSON := func(t *testing.T, args ...string) summary {
+		t.Helper()
+		cmd := newCmd(binary, append(append([]string{"scan", "--json"}, args...), secretsPath)...)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var s summary
+		require.NoError(t, json.Unmarshal(output, &s), string(output))
+		return s
+	}
+	count := func(s summary, confidence string) int {
+		n := 0
+		for _, f := range s.Secrets {
+			if f.Confidence == confidence {
+				n++
+			}
+		}
+		return n
+	}
+
+	all := scanJSON(t)
+	require.Positive(t, count(all, "LOW"), "fixture has entropy-based findings")
+	require.Positive(t, count(all, "HIGH"))
+	assert.Equal(t, len(all.Secrets), all.TotalFindings)
+
+	for name, args := range map[string][]string{
+		"high only":            {"--min-confidence", "high"},
+		"raised entropy floor": {"--min-confidence-entropy-threshold", "6.5"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := scanJSON(t, args...)
+			assert.Zero(t, count(s, "LOW"))
+			assert.Equal(t, count(all, "HIGH"), count(s, "HIGH"))
+			assert.Equal(t, len(s.Secrets), s.TotalFindings)
+		})
+	}
+
+	t.Run("invalid confidence", func(t *testing.T) {
+		cmd := newCmd(binary, "scan", "--min-confidence", "medium", secretsPath)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.CombinedOutput()
+		require.Error(t, err)
+		assert.Contains(t, string(output), "Invalid --min-confidence")
+	})
+}
+
+func TestCLI_ScanWorkers(t *testing.T) {
+	binary := buildTestBinary(t)
+	testdata := filepath.Join("..", "..", "testdata")
+
+	type counts struct {
+		TotalServers  int
+		ScannedFiles  int
+		TotalFindings int
+	}
+	byWorkers := map[string]counts{}
+	for _, workers := range []string{"1", "8"} {
+		cmd := newCmd(binary, "scan", "--json", "--workers", workers, testdata)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var c counts
+		require.NoError(t, json.Unmarshal(output, &c), string(output))
+		require.Positive(t, c.TotalServers)
+		byWorkers[workers] = c
+	}
+	assert.Equal(t, byWorkers["1"], byWorkers["8"], "results do not depend on the number of workers")
+
+	cmd := newCmd(binary, "scan", "--workers", "0", testdata)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Invalid --workers 0")
+}
+
+func TestCLI_ScanRulesFile(t *testing.T) {
+	binary := buildTestBinary(t)
+	rules := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(rules, []byte(`
+- name: no-git-server
+  match: args.contains("server-git")
+  severity: medium
+  description: The git server is not approved
+`), 0o600))
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	cmd := newCmd(binary, "scan", "--json", "--rules-file", rules, claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var summary struct {
+		Servers []struct {
+			Name           string
+			CustomFindings []map[string]string `json:"custom_findings"`
+		}
+	}
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	for _, s := range summary.Servers {
+		if s.Name == "git" {
+			require.Len(t, s.CustomFindings, 1)
+			assert.Equal(t, "no-git-server", s.CustomFindings[0]["rule"])
+			assert.Equal(t, "MEDIUM", s.CustomFindings[0]["severity"])
+		} else {
+			assert.Empty(t, s.CustomFindings)
+		}
+	}
+
+	cmd = newCmd(binary, "scan", "--no-banner", "--rules-file", rules, claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "CUSTOM POLICY FINDINGS")
+	assert.Contains(t, string(output), "[MEDIUM] no-git-server: The git server is not approved")
+
+	t.Run("invalid rule", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(rules, []byte("- name: bad\n  match: cwd.equals(\"/\")\n  severity: low\n"), 0o600))
+		cmd := newCmd(binary, "scan", "--rules-file", rules, claudePath)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.CombinedOutput()
+		require.Error(t, err)
+		assert.Contains(t, string(output), "Invalid --rules-file")
+	})
+}
+
+func TestCLI_ScanMetricsAddr(t *testing.T) {
+	binary := buildTestBinary(t)
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	cmd := newCmd(binary, "scan", "--json", "--metrics-addr", "127.0.0.1:0", claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	cmd = newCmd(binary, "scan", "--metrics-addr", "not-an-address", claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Failed to start metrics server")
+}
+
+func TestCLI_ScanCompare(t *testing.T) {
+	binary := buildTestBinary(t)
+	dir := t.TempDir()
+	scanTo := func(name, target string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		cmd := newCmd(binary, "scan", "--json", target)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, output, 0o600))
+		return path
+	}
+	baseline := scanTo("baseline.json", filepath.Join("..", "..", "testdata", "claude_desktop_config.json"))
+	current := scanTo("current.json", filepath.Join("..", "..", "testdata", "test_secrets_config.json"))
+
+	cmd := newCmd(binary, "scan", "compare", "--json", baseline, current)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var diff struct {
+		NewServers      []map[string]interface{} `json:"new_servers"`
+		RemovedServers  []map[string]interface{} `json:"removed_servers"`
+		NewSecrets      []map[string]interface{} `json:"new_secrets"`
+		ResolvedSecrets []map[string]interface{} `json:"resolved_secrets"`
+	}
+	require.NoError(t, json.Unmarshal(output, &diff), string(output))
+	assert.Len(t, diff.RemovedServers, 2)
+	assert.NotEmpty(t, diff.NewServers)
+	assert.NotEmpty(t, diff.NewSecrets)
+	assert.Empty(t, diff.ResolvedSecrets)
+
+	cmd = newCmd(binary, "scan", "compare", baseline, baseline)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "No changes between the two scans")
+
+	t.Run("invalid file", func(t *testing.T) {
+		cmd := newCmd(binary, "scan", "compare", baseline, filepath.Join("..", "..", "README.md"))
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.CombinedOutput()
+		require.Error(t, err)
+		assert.Contains(t, string(output), "is not a scan JSON result")
+	})
+}
+
+func TestCLI_ScanSignVerify(t *testing.T) {
+	binary := buildTestBinary(t)
+	dir := t.TempDir()
+	keyPrefix := filepath.Join(dir, "audit")
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	cmd := newCmd(binary, "keygen", "--out", keyPrefix)
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	info, err := os.Stat(keyPrefix + ".key")
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+	require.Error(t, newCmd(binary, "keygen", "--out", keyPrefix).Run(), "refuses to overwrite keys")
+
+	cmd = newCmd(binary, "scan", "--json", "--sign", keyPrefix+".key", claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	var summary map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	assert.NotEmpty(t, summary["signature"])
+	result := filepath.Join(dir, "result.json")
+	require.NoError(t, os.WriteFile(result, output, 0o600))
+
+	cmd = newCmd(binary, "scan", "verify", result, keyPrefix+".pub")
+	output, err = cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	assert.Contains(t, string(output), "Signature OK")
+
+	data, err := os.ReadFile(result)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(result, bytes.Replace(data, []byte(`"TotalServers": 2`), []byte(`"TotalServers": 1`), 1), 0o600))
+	cmd = newCmd(binary, "scan", "verify", result, keyPrefix+".pub")
+	output, err = cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.ExitCode())
+	assert.Contains(t, string(output), "Verification failed")
+
+	t.Run("requires JSON output", func(t *testing.T) {
+		cmd := newCmd(binary, "scan", "--sign", keyPrefix+".key", claudePath)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.CombinedOutput()
+		require.Error(t, err)
+		assert.Contains(t, string(output), "--sign requires --json")
+	})
+}
+
+func TestCLI_ScanSBOM(t *testing.T) {
+	binary := buildTestBinary(t)
+	sbom := filepath.Join(t.TempDir(), "sbom.json")
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+
+	cmd := newCmd(binary, "scan", "--json", "--sbom", sbom, claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	data, err := os.ReadFile(sbom)
+	require.NoError(t, err)
+	var bom struct {
+		BOMFormat   string `json:"bomFormat"`
+		SpecVersion string `json:"specVersion"`
+		Components  []struct {
+			Type string `json:"type"`
+			PURL string `json:"purl"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &bom))
+	assert.Equal(t, "CycloneDX", bom.BOMFormat)
+	assert.Equal(t, "1.5", bom.SpecVersion)
+	purls := []string{}
+	for _, c := range bom.Components {
+		purls = append(purls, c.PURL)
+	}
+	assert.Contains(t, purls, "pkg:npm/@modelcontextprotocol/server-filesystem")
+	assert.Contains(t, purls, "pkg:npm/@modelcontextprotocol/server-git")
+}
+
+func TestCLI_AllowlistBulkAdd(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+	entries := filepath.Join(t.TempDir(), "entries.tsv")
+	require.NoError(t, os.WriteFile(entries, []byte(
+		"# bulk entries\nserver\tfilesystem\thash123\nserver\tgit\thash456\n"), 0o600))
+
+	cmd := newCmd(binary, "experimental", "allowlist", "bulk-add", "--file", entries)
+	setCmdHome(cmd, home)
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	assert.Contains(t, string(output), "2 added, 0 skipped (already present), 0 errors")
+
+	// Re-running is idempotent; malformed lines are reported and fail the command.
+	require.NoError(t, os.WriteFile(entries, []byte(
+		"server\tfilesystem\thash123\nserver\tgit\nserver\tfetch\thash789\n"), 0o600))
+	cmd = newCmd(binary, "experimental", "allowlist", "bulk-add", "--file", entries)
+	setCmdHome(cmd, home)
+	output, err = cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Contains(t, string(output), "line 2:")
+	assert.Contains(t, string(output), "1 added, 1 skipped (already present), 1 errors")
+
+	cmd = newCmd(binary, "experimental", "allowlist")
+	setCmdHome(cmd, home)
+	output, err = cmd.CombinedOutput()
+	require.NoError(t, err)
+	for _, hash := range []string{"hash123", "hash456", "hash789"} {
+		assert.Contains(t, string(output), hash)
+	}
+}
+
+func TestCLI_ScanRedactEnv(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join(t.TempDir(), "mcp.json")
+	require.NoError(t, os.WriteFile(config, []byte(`{"mcpServers": {"internal": {
+		"command": "npx",
+		"args": ["-y", "@acme/mcp-server"],
+		"env": {"API_BASE": "https://internal.example.com", "FEATURE_FLAGS": "beta"}
+	}}}`), 0o600))
+
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := newCmd(binary, append(append([]string{"scan"}, args...), config)...)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		return string(output)
+	}
+
+	assert.Contains(t, run("--verbose-json"), "https://internal.example.com")
+
+	redacted := run("--verbose-json", "--redact-env")
+	assert.NotContains(t, redacted, "https://internal.example.com")
+	assert.NotContains(t, redacted, "beta")
+	assert.Contains(t, redacted, `"API_BASE": "***"`)
+	assert.Contains(t, redacted, "@acme/mcp-server", "non-env values are kept")
+
+	assert.NotContains(t, run("--json", "--redact-env"), "https://internal.example.com")
+}
+
+func TestCLI_ScanAggregate(t *testing.T) {
+	binary := buildTestBinary(t)
+	dir := t.TempDir()
+	shard := func(name, target string) {
+		t.Helper()
+		cmd := newCmd(binary, "scan", "--json", target)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), output, 0o600))
+	}
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	shard("shard-1.json", claudePath)
+	shard("shard-2.json", filepath.Join("..", "..", "testdata", "test_secrets_config.json"))
+	shard("shard-3.json", claudePath) // overlaps shard 1
+
+	type summary struct {
+		Servers       []map[string]interface{}
+		Secrets       []map[string]interface{}
+		TotalFindings int
+	}
+	read := func(name string) summary {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		var s summary
+		require.NoError(t, json.Unmarshal(data, &s))
+		return s
+	}
+	one, two := read("shard-1.json"), read("shard-2.json")
+
+	cmd := newCmd(binary, "scan", "aggregate", "--json", "--input-glob", filepath.Join(dir, "shard-*.json"))
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var merged summary
+	require.NoError(t, json.Unmarshal(output, &merged), string(output))
+	assert.Len(t, merged.Servers, len(one.Servers)+len(two.Servers), "overlapping servers are deduplicated")
+	assert.Equal(t, two.TotalFindings, merged.TotalFindings)
+
+	cmd = newCmd(binary, "scan", "aggregate", "--no-banner", filepath.Join(dir, "shard-1.json"), filepath.Join(dir, "shard-2.json"))
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "RUN-MCP SCAN REPORT")
+
+	t.Run("no inputs", func(t *testing.T) {
+		cmd := newCmd(binary, "scan", "aggregate", "--input-glob", filepath.Join(dir, "none-*.json"))
+		setCmdHome(cmd, t.TempDir())
+		require.Error(t, cmd.Run())
+	})
+}
+
+func TestCLI_ScanCategory(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join(t.TempDir(), "mcp.json")
+	require.NoError(t, os.WriteFile(config, []byte(`{"mcpServers": {
+		"files": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem"]},
+		"github": {"command": "docker", "args": ["run", "-i", "--rm", "ghcr.io/github/github-mcp-server"]},
+		"local": {"command": "/opt/mcp/bin/server"}
+	}}`), 0o600))
+
+	run := func(args ...string) (map[string]interface{}, error) {
+		t.Helper()
+		cmd := newCmd(binary, append(append([]string{"scan", "--json"}, args...), config)...)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, err
+		}
+		var summary map[string]interface{}
+		require.NoError(t, json.Unmarshal(output, &summary), string(output))
+		return summary, nil
+	}
+
+	all, err := run()
+	require.NoError(t, err)
+	categories := map[string]string{}
+	for _, s := range all["Servers"].([]interface{}) {
+		sr := s.(map[string]interface{})
+		categories[sr["name"].(string)], _ = sr["category"].(string)
+	}
+	assert.Equal(t, map[string]string{"files": "npm", "github": "docker", "local": "binary"}, categories)
+
+	docker, err := run("--category", "docker")
+	require.NoError(t, err)
+	servers := docker["Servers"].([]interface{})
+	require.Len(t, servers, 1)
+	assert.Equal(t, "github", servers[0].(map[string]interface{})["name"])
+	assert.EqualValues(t, 3, docker["TotalServers"], "totals still cover every server")
+
+	_, err = run("--category", "rust")
+	assert.Error(t, err)
+}
+
+func TestCLI_ScanNoWellKnown(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+	// A well-known config that a fallback to discovery would pick up.
+	existing := filepath.Join(home, ".cursor", "mcp.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(existing), 0o700))
+	require.NoError(t, os.WriteFile(existing,
+		[]byte(`{"mcpServers": {"files": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem"]}}}`), 0o600))
+
+	run := func(args ...string) map[string]interface{} {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"scan", "--json"}, args...)...)
+		setCmdHome(cmd, home)
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var summary map[string]interface{}
+		require.NoError(t, json.Unmarshal(output, &summary), string(output))
+		return summary
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	summary := run("--no-well-known", missing)
+	assert.EqualValues(t, 0, summary["ScannedFiles"])
+	assert.Empty(t, summary["Servers"])
+
+	summary = run("--no-well-known")
+	assert.EqualValues(t, 0, summary["ScannedFiles"], "no fallback to well-known paths")
+
+	summary = run("--well-known-only", missing)
+	assert.EqualValues(t, 1, summary["TotalServers"], "explicit paths are ignored")
+
+	cmd := newCmd(binary, "scan", "--no-well-known", "--well-known-only")
+	setCmdHome(cmd, home)
+	require.Error(t, cmd.Run())
+}
+
+func TestCLI_ScanTimeout(t *testing.T) {
+	binary := buildTestBinary(t)
+	dir := t.TempDir()
+	const files = 500
+	for i := range files {
+		config := fmt.Sprintf(`{"mcpServers": {"server-%d": {"command": "npx", "args": ["-y", "@acme/mcp-%d"]}}}`, i, i)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("mcp-%03d.json", i)), []byte(config), 0o600))
+	}
+
+	cmd := newCmd(binary, "scan", "--json", "--timeout", "1ms", dir)
+	setCmdHome(cmd, t.TempDir())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	require.NoError(t, err, stderr.String())
+	assert.Contains(t, stderr.String(), "Scan timed out after 1ms")
+
+	var summary map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &summary), "partial results are still printed: %s", output)
+	assert.Less(t, summary["TotalServers"], float64(files))
+
+	cmd = newCmd(binary, "scan", "--timeout", "0s", dir)
+	setCmdHome(cmd, t.TempDir())
+	require.Error(t, cmd.Run())
+}
+
+func TestCLI_ScanFormatCycloneDX(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	cmd := newCmd(binary, "scan", "--format", "cyclonedx", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	var vex struct {
+		BOMFormat   string `json:"bomFormat"`
+		SpecVersion string `json:"specVersion"`
+		Components  []struct {
+			Name               string `json:"name"`
+			ExternalReferences []struct {
+				Type string `json:"type"`
+				URL  string `json:"url"`
+			} `json:"externalReferences"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(output, &vex), string(output))
+	assert.Equal(t, "CycloneDX", vex.BOMFormat)
+	assert.Equal(t, "1.5", vex.SpecVersion)
+	require.NotEmpty(t, vex.Components)
+	evidence := 0
+	for _, c := range vex.Components {
+		for _, ref := range c.ExternalReferences {
+			assert.Equal(t, "evidence", ref.Type)
+			assert.True(t, strings.HasPrefix(ref.URL, "file:"), ref.URL)
+			evidence++
+		}
+	}
+	assert.Positive(t, evidence, "secret findings are recorded as evidence")
+
+	cmd = newCmd(binary, "scan", "--format", "cyclonedx", "--json", config)
+	setCmdHome(cmd, t.TempDir())
+	require.Error(t, cmd.Run())
+
+	cmd = newCmd(binary, "scan", "--format", "sarif", config)
+	setCmdHome(cmd, t.TempDir())
+	require.Error(t, cmd.Run())
+}
+
+func TestCLI_ScanFormatCheckstyle(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	cmd := newCmd(binary, "scan", "--format", "checkstyle", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	var report struct {
+		Files []struct {
+			Name   string `xml:"name,attr"`
+			Errors []struct {
+				Line     int    `xml:"line,attr"`
+				Severity string `xml:"severity,attr"`
+				Source   string `xml:"source,attr"`
+			} `xml:"error"`
+		} `xml:"file"`
+	}
+	require.NoError(t, xml.Unmarshal(output, &report), string(output))
+	require.Len(t, report.Files, 1)
+	assert.Equal(t, "test_secrets_config.json", filepath.Base(report.Files[0].Name))
+	require.NotEmpty(t, report.Files[0].Errors)
+	for _, e := range report.Files[0].Errors {
+		assert.Positive(t, e.Line)
+		assert.Contains(t, []string{"error", "warning"}, e.Severity)
+		assert.Equal(t, "run-mcp", e.Source)
+	}
+}
+
+func TestCLI_ScanFormatCodeClimate(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	for _, format := range []string{"codeclimate", "code-climate"} {
+		cmd := newCmd(binary, "scan", "--format", format, config)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err, format)
+
+		var issues []struct {
+			Type       string   `json:"type"`
+			Categories []string `json:"categories"`
+			Severity   string   `json:"severity"`
+			Location   struct {
+				Path  string `json:"path"`
+				Lines struct {
+					Begin int `json:"begin"`
+				} `json:"lines"`
+			} `json:"location"`
+		}
+		require.NoError(t, json.Unmarshal(output, &issues), string(output))
+		require.NotEmpty(t, issues)
+		for _, i := range issues {
+			assert.Equal(t, "issue", i.Type)
+			assert.Equal(t, []string{"Security"}, i.Categories)
+			assert.Equal(t, "critical", i.Severity)
+			assert.Equal(t, "test_secrets_config.json", filepath.Base(i.Location.Path))
+			assert.Positive(t, i.Location.Lines.Begin)
+		}
+	}
+}
+
+func TestCLI_ScanFormatAzDO(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+	scan := func(t *testing.T, env []string, args ...string) string {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"scan"}, append(args, config)...)...)
+		setCmdHome(cmd, t.TempDir())
+		cmd.Env = append(cmd.Env, env...)
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		return string(output)
+	}
+	pattern := regexp.MustCompile(`^##vso\[task\.logissue type=error;sourcepath=[^;\]]*test_secrets_config\.json;linenumber=\d+\]\S`)
+
+	for _, format := range []string{"azdo", "azdo-pipelines"} {
+		lines := strings.Split(strings.TrimSpace(scan(t, nil, "--format", format)), "\n")
+		require.NotEmpty(t, lines, format)
+		for _, line := range lines {
+			assert.Regexp(t, pattern, line)
+		}
+	}
+
+	// Azure Pipelines runs default to logging commands unless another output is chosen.
+	detected := []string{"SYSTEM_COLLECTIONURI=https://dev.azure.com/example/"}
+	assert.Regexp(t, `(?m)^##vso\[task\.logissue `, scan(t, detected))
+	assert.NotContains(t, scan(t, detected, "--format", "text"), "##vso[")
+	assert.NotContains(t, scan(t, detected, "--json"), "##vso[")
+}
+
+func TestCLI_ScanFormatGitLabSAST(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	cmd := newCmd(binary, "scan", "--format", "gitlab-sast", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	var report scanner.GitLabSASTReport
+	require.NoError(t, json.Unmarshal(output, &report), string(output))
+	assert.Equal(t, "run-mcp", report.Scan.Scanner.ID)
+	assert.Equal(t, "sast", report.Scan.Type)
+	require.NotEmpty(t, report.Vulnerabilities)
+	for _, v := range report.Vulnerabilities {
+		assert.Equal(t, "sast", v.Category)
+		assert.Equal(t, "Critical", v.Severity)
+		assert.Equal(t, "test_secrets_config.json", filepath.Base(v.Location.File))
+		assert.Positive(t, v.Location.StartLine)
+	}
+
+	cmd = newCmd(binary, "scan", "--format", "gitlab-sast", "--json", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Cannot combine --format gitlab-sast")
+}
+
+func TestCLI_ScanFormatCSV(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	cmd := newCmd(binary, "scan", "--format", "csv", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	sections := strings.Split(string(output), "\n\n")
+	require.Len(t, sections, 2, string(output))
+	servers, err := csv.NewReader(strings.NewReader(sections[0])).ReadAll()
+	require.NoError(t, err)
+	require.Greater(t, len(servers), 1)
+	assert.Equal(t, "ServerName", servers[0][0])
+	for _, row := range servers[1:] {
+		assert.Equal(t, "test_secrets_config.json", filepath.Base(row[1]))
+	}
+	secrets, err := csv.NewReader(strings.NewReader(sections[1])).ReadAll()
+	require.NoError(t, err)
+	require.Greater(t, len(secrets), 1, "the fixture has secrets")
+	assert.Equal(t, []string{"ServerName", "Kind", "Key", "RedactedValue", "Files", "Confidence"}, secrets[0])
+}
+
+func TestCLI_ScanFormatTable(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	cmd := newCmd(binary, "scan", "--format", "table", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	require.Greater(t, len(lines), 1, string(output))
+	assert.Equal(t, []string{"#", "NAME", "PATH", "RISK", "SCORE", "POLICY"}, strings.Fields(lines[0]))
+	for i, line := range lines[1:] {
+		fields := strings.Fields(line)
+		require.Len(t, fields, 6, line)
+		assert.Equal(t, strconv.Itoa(i+1), fields[0])
+		assert.Equal(t, "test_secrets_config.json", filepath.Base(fields[2]))
+	}
+}
+
+func TestCLI_ScanInteractive(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+	config := filepath.Join(t.TempDir(), "mcp.json")
+	require.NoError(t, os.WriteFile(config, []byte(`{"mcpServers": {
+		"alpha": {"command": "npx", "args": ["-y", "@acme/alpha-mcp"]},
+		"beta": {"command": "npx", "args": ["-y", "@acme/beta-mcp"]},
+		"gamma": {"command": "npx", "args": ["-y", "@acme/gamma-mcp"]}
+	}}`), 0o600))
+	scan := func(t *testing.T, answers string) (string, string) {
+		t.Helper()
+		cmd := newCmd(binary, "scan", "--interactive", "--no-banner", config)
+		setCmdHome(cmd, home)
+		stdin, err := cmd.StdinPipe()
+		require.NoError(t, err)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		require.NoError(t, cmd.Start())
+		_, err = io.WriteString(stdin, answers)
+		require.NoError(t, err)
+		require.NoError(t, stdin.Close())
+		require.NoError(t, cmd.Wait(), stderr.String())
+		return stdout.String(), stderr.String()
+	}
+
+	asked := func(stderr string) []string {
+		var names []string
+		for _, m := range regexp.MustCompile(`Server: "(\w+)"`).FindAllStringSubmatch(stderr, -1) {
+			names = append(names, m[1])
+		}
+		return names
+	}
+
+	// An unknown answer is asked again.
+	stdout, stderr := scan(t, "x\na\nd\ns\n")
+	assert.Equal(t, 4, strings.Count(stderr, "Allow/Deny/Skip (a/d/s)? "))
+	names := asked(stderr)
+	require.Len(t, names, 3)
+	assert.NotContains(t, stdout, "Allow/Deny/Skip", "prompts go to stderr")
+	assert.Contains(t, stdout, "Allowed       : 1 servers")
+	assert.Contains(t, stdout, "Denied        : 1 servers")
+
+	data, err := os.ReadFile(defaultStoragePath(home))
+	require.NoError(t, err)
+	var stored struct {
+		Allowlist map[string][]string `json:"allowlist"`
+		Denylist  map[string][]string `json:"denylist"`
+	}
+	require.NoError(t, json.Unmarshal(data, &stored))
+	assert.Equal(t, []string{names[0]}, stored.Allowlist["server"])
+	assert.Equal(t, []string{names[1]}, stored.Denylist["server"])
+
+	// Decided servers are not asked about again, and input may end early.
+	stdout, stderr = scan(t, "")
+	assert.Equal(t, 1, strings.Count(stderr, "Allow/Deny/Skip (a/d/s)? "))
+	assert.Equal(t, names[2:], asked(stderr))
+	assert.Contains(t, stdout, "Allowed       : 1 servers")
+	assert.Contains(t, stdout, "Denied        : 1 servers")
+
+	cmd := newCmd(binary, "scan", "--interactive", "--json", config)
+	setCmdHome(cmd, home)
+	output, err := cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Cannot use --interactive with --json, --tui or --stdin")
+}
+
+func TestCLI_ScanFormatSPDX(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	cmd := newCmd(binary, "scan", "--format", "spdx", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	text := string(output)
+	assert.True(t, strings.HasPrefix(text, "SPDXVersion: SPDX-2.3\n"), text)
+	assert.Contains(t, text, "SPDXID: SPDXRef-DOCUMENT\n")
+	assert.Contains(t, text, "ExternalRef: PACKAGE-MANAGER purl pkg:")
+	assert.Contains(t, text, "Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-")
+}
+
+func TestCLI_ScanExplain(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	secrets := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+	ratings := filepath.Join("..", "..", "testdata", "mock_ratings.json")
+
+	cmd := newCmd(binary, "scan", "--json", "--explain", "--mock-api", ratings, config, secrets)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var summary struct {
+		Servers []struct {
+			Name        string `json:"name"`
+			Remediation string `json:"remediation"`
+		}
+		Secrets []struct {
+			Kind        string `json:"kind"`
+			Explanation string `json:"explanation"`
+			Remediation string `json:"remediation"`
+		}
+	}
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	for _, s := range summary.Servers {
+		if s.Name == "filesystem" {
+			assert.Contains(t, s.Remediation, "Remove the server")
+		}
+	}
+	require.NotEmpty(t, summary.Secrets)
+	for _, s := range summary.Secrets {
+		assert.NotEmpty(t, s.Explanation, s.Kind)
+		assert.NotEmpty(t, s.Remediation, s.Kind)
+	}
+
+	cmd = newCmd(binary, "scan", "--explain", "--mock-api", ratings, config)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "    Fix: Remove the server")
+
+	cmd = newCmd(binary, "scan", "--json", "--mock-api", ratings, config)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.NotContains(t, string(output), `"remediation"`, "guidance is only added with --explain")
+}
+
+func TestCLI_ScanSeverityCountsOnly(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	secrets := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+	ratings := filepath.Join("..", "..", "testdata", "mock_ratings.json")
+
+	cmd := newCmd(binary, "scan", "--severity-counts-only", "--mock-api", ratings, config, secrets)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Regexp(t, `^CRITICAL=1 HIGH=1 MEDIUM=0 LOW=0 SECRETS=[1-9]\d*\n$`, string(output))
+
+	cmd = newCmd(binary, "scan", "--json", "--severity-counts-only", "--fail-on-severity", "high", "--mock-api", ratings, config)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode(), "exit codes are unchanged")
+	assert.JSONEq(t, `{"critical":1,"high":1,"medium":0,"low":0,"secrets":0}`, string(output))
+
+	cmd = newCmd(binary, "scan", "--severity-counts-only", "--format", "checkstyle", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Cannot combine --severity-counts-only")
+}
+
+func TestCLI_MockAPI(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	ratings := filepath.Join("..", "..", "testdata", "mock_ratings.json")
+
+	cmd := newCmd(binary, "scan", "--mock-api", ratings, config)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	text := string(output)
+	require.Contains(t, text, "CRITICAL FINDINGS")
+	require.Contains(t, text, "HIGH RISK FINDINGS")
+	critical := text[strings.Index(text, "CRITICAL FINDINGS"):strings.Index(text, "HIGH RISK FINDINGS")]
+	assert.Contains(t, critical, "filesystem")
+	assert.NotContains(t, critical, "git")
+
+	cmd = newCmd(binary, "scan", "--json", "--mock-api", ratings, config)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	var summary struct {
+		Servers []struct {
+			Name   string `json:"name"`
+			Rating *struct {
+				Category  string  `json:"category"`
+				RiskScore float64 `json:"risk_score"`
+			} `json:"rating"`
+		}
+	}
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	got := map[string]float64{}
+	for _, s := range summary.Servers {
+		require.NotNil(t, s.Rating, s.Name)
+		got[s.Name] = s.Rating.RiskScore
+	}
+	assert.Equal(t, map[string]float64{"filesystem": 9.4, "git": 7.2}, got)
+
+	cmd = newCmd(binary, "scan", "--mock-api", filepath.Join(t.TempDir(), "missing.json"), config)
+	setCmdHome(cmd, t.TempDir())
+	require.Error(t, cmd.Run())
+}
+
+func TestCLI_ScanMisconfigFindings(t *testing.T) {
+	binary := buildTestBinary(t)
+	config := filepath.Join("..", "..", "testdata", "docker_privileged_config.json")
+
+	cmd := newCmd(binary, "scan", "--json", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var summary struct {
+		MisconfigFindings []struct {
+			ServerName string `json:"server_name"`
+			Rule       string `json:"rule"`
+			Severity   string `json:"severity"`
+		}
+	}
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	assert.Len(t, summary.MisconfigFindings, 5)
+
+	cmd = newCmd(binary, "scan", "--no-banner", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "LAUNCH MISCONFIGURATIONS")
+	assert.Contains(t, string(output), "--cap-add SYS_ADMIN")
+
+	cmd = newCmd(binary, "scan", "--severity-counts-only", config)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "CRITICAL=3 HIGH=2 MEDIUM=0 LOW=0 SECRETS=0\n", string(output))
+
+	// Misconfigurations trip --fail-on-severity without any rated server.
+	cmd = newCmd(binary, "scan", "--quiet", "--fail-on-severity", "critical", config)
+	setCmdHome(cmd, t.TempDir())
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+}
+
+func TestCLI_ScanStdin(t *testing.T) {
+	binary := buildTestBinary(t)
+	config, err := os.ReadFile(filepath.Join("..", "..", "testdata", "test_secrets_config.json"))
+	require.NoError(t, err)
+
+	cmd := newCmd(binary, "scan", "--json", "--stdin")
+	setCmdHome(cmd, t.TempDir())
+	cmd.Stdin = bytes.NewReader(config)
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var summary struct {
+		Servers []struct {
+			Path string `json:"path"`
+		}
+		Secrets []struct {
+			Occurrences map[string][]int `json:"occurrences"`
+		}
+	}
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	require.NotEmpty(t, summary.Servers)
+	for _, s := range summary.Servers {
+		assert.Equal(t, "<stdin>", s.Path)
+	}
+	require.NotEmpty(t, summary.Secrets)
+	for _, s := range summary.Secrets {
+		assert.Contains(t, s.Occurrences, "<stdin>")
+	}
+	assert.NotContains(t, string(output), "run-mcp-stdin-", "temp paths are not leaked")
+
+	yamlConfig, err := os.ReadFile(filepath.Join("..", "..", "testdata", "goose_config.yaml"))
+	require.NoError(t, err)
+	cmd = newCmd(binary, "scan", "--json", "--stdin", "--stdin-format", "yaml")
+	setCmdHome(cmd, t.TempDir())
+	cmd.Stdin = bytes.NewReader(yamlConfig)
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	require.NotEmpty(t, summary.Servers)
+	assert.Equal(t, "<stdin>", summary.Servers[0].Path)
+
+	t.Run("rejects file arguments", func(t *testing.T) {
+		cmd := newCmd(binary, "scan", "--stdin", filepath.Join("..", "..", "testdata", "claude_desktop_config.json"))
+		setCmdHome(cmd, t.TempDir())
+		cmd.Stdin = bytes.NewReader(config)
+		require.Error(t, cmd.Run())
+	})
+}
+
+func TestCLI_Config(t *testing.T) {
+	binary := buildTestBinary(t)
+	home := t.TempDir()
+	dir := filepath.Join(home, "conf")
+	run := func(env []string, args ...string) (string, error) {
+		cmd := newCmd(binary, args...)
+		setCmdHome(cmd, home)
+		cmd.Env = append(cmd.Env, config.PathEnv+"="+filepath.Join(dir, config.FileName))
+		cmd.Env = append(cmd.Env, env...)
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+
+	output, err := run(nil, "config", "init", "--path", dir)
+	require.NoError(t, err, output)
+	data, err := os.ReadFile(filepath.Join(dir, config.FileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# fail_on_severity: high")
+
+	output, err = run(nil, "config", "init", "--path", dir)
+	require.Error(t, err, "an existing file needs --force")
+	assert.Contains(t, output, "--force")
+	output, err = run(nil, "config", "init", "--path", dir, "--force")
+	require.NoError(t, err, output)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.FileName),
+		[]byte("org_uuid: 123e4567-e89b-12d3-a456-426614174000\nmin_confidence: HIGH\ntimeout: 5s\n"), 0o600))
+	output, err = run([]string{"RUNMCP_TIMEOUT=1m"}, "config", "show", "--json", "--anon")
+	require.NoError(t, err, output)
+	var shown struct {
+		Exists   bool             `json:"exists"`
+		Settings []config.Setting `json:"settings"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &shown))
+	assert.True(t, shown.Exists)
+	got := map[string]config.Setting{}
+	for _, s := range shown.Settings {
+		got[s.Key] = s
+	}
+	assert.Equal(t, config.Setting{Key: "org_uuid", Value: "123e4567-e89b-12d3-a456-426614174000", Source: config.SourceFile}, got["org_uuid"])
+	assert.Equal(t, config.Setting{Key: "anonymous", Value: "true", Source: config.SourceFlag}, got["anonymous"])
+	assert.Equal(t, config.Setting{Key: "timeout", Value: "1m", Source: config.SourceEnv}, got["timeout"])
+	assert.Equal(t, config.Setting{Key: "fail_on_severity", Source: config.SourceDefault}, got["fail_on_severity"])
+
+	// The file and environment supply flag defaults to other commands; flags still win.
+	output, err = run([]string{"RUNMCP_JSON=true", "RUNMCP_MIN_CONFIDENCE=LOW"}, "scan", "--no-banner", filepath.Join(home, "none.json"))
+	require.NoError(t, err, output)
+	assert.Contains(t, output, `"TotalServers": 0`, "RUNMCP_JSON selects JSON output")
+	output, err = run([]string{"RUNMCP_JSON=true"}, "scan", "--no-banner", "--json=false", filepath.Join(home, "none.json"))
+	require.NoError(t, err, output)
+	assert.NotContains(t, output, `"TotalServers"`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.FileName), []byte("min_confidence: bogus\n"), 0o600))
+	output, err = run(nil, "scan", "--no-banner", filepath.Join(home, "none.json"))
+	require.Error(t, err)
+	assert.Contains(t, output, "Invalid --min-confidence")
+
+	// ignore_patterns supplies --exclude globs; the environment and then --exclude win.
+	targets := filepath.Join(home, "targets")
+	require.NoError(t, os.MkdirAll(targets, 0o755))
+	for _, name := range []string{"mcp.json", "settings.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(targets, name), []byte(`{}`), 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.FileName), []byte("workers: 2\nignore_patterns: [\"settings.json\"]\n"), 0o600))
+	output, err = run(nil, "scan", "--dry-run", targets)
+	require.NoError(t, err, output)
+	assert.Equal(t, filepath.Join(targets, "mcp.json")+"\n", output)
+	output, err = run([]string{"RUNMCP_IGNORE_PATTERNS=mcp.json"}, "scan", "--dry-run", targets)
+	require.NoError(t, err, output)
+	assert.Equal(t, filepath.Join(targets, "settings.json")+"\n", output)
+	output, err = run([]string{"RUNMCP_IGNORE_PATTERNS=mcp.json"}, "scan", "--dry-run", "--exclude", "none", targets)
+	require.NoError(t, err, output)
+	assert.Equal(t, filepath.Join(targets, "mcp.json")+"\n"+filepath.Join(targets, "settings.json")+"\n", output)
+}
+
+func TestCLI_ScanIncludeExclude(t *testing.T) {
+	binary := buildTestBinary(t)
+	dir := t.TempDir()
+	server := `{"mcpServers": {"%s": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem"]}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "mcp.json"), []byte(fmt.Sprintf(server, "kept")), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "settings.json"), []byte(fmt.Sprintf(server, "dropped")), 0o600))
+
+	scan := func(args ...string) (names []string, files int) {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"scan", "--json"}, append(args, dir)...)...)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var summary struct {
+			Servers      []struct{ Name string }
+			ScannedFiles int
+		}
+		require.NoError(t, json.Unmarshal(output, &summary), string(output))
+		for _, s := range summary.Servers {
+			names = append(names, s.Name)
+		}
+		return names, summary.ScannedFiles
+	}
+
+	names, files := scan()
+	assert.ElementsMatch(t, []string{"kept", "dropped"}, names)
+	assert.Equal(t, 2, files)
+
+	names, files = scan("--include", "mcp.json")
+	assert.Equal(t, []string{"kept"}, names)
+	assert.Equal(t, 1, files)
+
+	names, _ = scan("--include", filepath.Join(dir, "*.json"), "--exclude", "mcp.json")
+	assert.Equal(t, []string{"dropped"}, names, "--exclude takes priority over --include")
+
+	cmd := newCmd(binary, "scan", "--include", "[", dir)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Invalid glob")
+	assert.Contains(t, string(output), "syntax error in pattern")
+}
+
+func TestCLI_ScanDryRun(t *testing.T) {
+	binary := buildTestBinary(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "mcp.json"), []byte(`{"mcpServers": {"fs": {"command": "npx"}}}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "settings.json"), []byte(`{}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a config"), 0o600))
+
+	cmd := newCmd(binary, "scan", "--dry-run", dir)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "mcp.json")+"\n"+filepath.Join(dir, "settings.json")+"\n", string(output))
+
+	cmd = newCmd(binary, "scan", "--dry-run", "--json", "--exclude", "settings.json", dir)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	var files []string
+	require.NoError(t, json.Unmarshal(output, &files), string(output))
+	assert.Equal(t, []string{filepath.Join(dir, "mcp.json")}, files)
+
+	// Nothing to scan is still a successful dry run.
+	cmd = newCmd(binary, "scan", "--dry-run", "--json", "--include", "*.toml", dir)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(output))
+}
+
+func TestCLI_ScanMaxDepth(t *testing.T) {
+	binary := buildTestBinary(t)
+	root := t.TempDir()
+	dir := root
+	var levels []string
+	for i := 1; i <= 5; i++ {
+		dir = filepath.Join(dir, fmt.Sprintf("l%d", i))
+		levels = append(levels, filepath.Join(dir, "mcp.json"))
+	}
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	for _, p := range levels {
+		require.NoError(t, os.WriteFile(p, []byte(`{"mcpServers": {"fs": {"command": "npx"}}}`), 0o600))
+	}
+
+	cmd := newCmd(binary, "scan", "--dry-run", "--json", "--max-depth=2", root)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var files []string
+	require.NoError(t, json.Unmarshal(output, &files), string(output))
+	assert.ElementsMatch(t, levels[:2], files)
+
+	cmd = newCmd(binary, "scan", "--json", "--max-depth=2", root)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	var summary scanner.ScanSummary
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	assert.Equal(t, 2, summary.ScannedFiles)
+
+	cmd = newCmd(binary, "scan", "--max-depth=-1", root)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Invalid --max-depth -1")
+}
+
+func TestCLI_ScanNormalizeServerNames(t *testing.T) {
+	binary := buildTestBinary(t)
+	dir := t.TempDir()
+	for sub, name := range map[string]string{"a": "github", "b": "GitHub"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, sub), 0o755))
+		config := fmt.Sprintf(`{"mcpServers": {"%s": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-github"]}}}`, name)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, sub, "mcp.json"), []byte(config), 0o600))
+	}
+
+	scan := func(args ...string) map[string]any {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"scan", "--json"}, append(args, dir)...)...)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var summary map[string]any
+		require.NoError(t, json.Unmarshal(output, &summary), string(output))
+		return summary
+	}
+
+	assert.InDelta(t, 2, scan()["TotalServers"], 0)
+
+	summary := scan("--normalize-server-names")
+	assert.InDelta(t, 1, summary["TotalServers"], 0)
+	servers, ok := summary["Servers"].([]any)
+	require.True(t, ok)
+	require.Len(t, servers, 1)
+	server, ok := servers[0].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "github", server["canonical_name"])
+}
+
+func TestCLI_ScanAggregateSecretsByValue(t *testing.T) {
+	binary := buildTestBinary(t)
+	dir := t.TempDir()
+	config := `{"mcpServers": {"supabase": {"command": "npx", "env": {"SUPABASE_ACCESS_TOKEN": "sbp_0123456789abcdef0123456789abcdef01234567"}}}}`
+	for _, sub := range []string{"a", "b"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, sub), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, sub, "mcp.json"), []byte(config), 0o600))
+	}
+
+	type secret struct {
+		ValueHash   string           `json:"value_hash"`
+		Occurrences map[string][]int `json:"occurrences"`
+	}
+	scan := func(args ...string) []secret {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"scan", "--json", "--secrets-only"}, append(args, dir)...)...)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var summary struct{ Secrets []secret }
+		require.NoError(t, json.Unmarshal(output, &summary), string(output))
+		return summary.Secrets
+	}
+
+	assert.Len(t, scan(), 2)
+	merged := scan("--aggregate-secrets-by-value")
+	require.Len(t, merged, 1)
+	assert.NotEmpty(t, merged[0].ValueHash)
+	assert.Len(t, merged[0].Occurrences, 2)
+}
+
+func TestCLI_ScanMaxFileSize(t *testing.T) {
+	binary := buildTestBinary(t)
+	dir := t.TempDir()
+	config := fmt.Sprintf(`{"mcpServers": {"fs": {"command": "npx", "args": ["%s"]}}}`, strings.Repeat("a", 1500))
+	path := filepath.Join(dir, "mcp.json")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	servers := func(size string) float64 {
+		t.Helper()
+		cmd := newCmd(binary, "scan", "--json", "--max-file-size", size, path)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var summary map[string]any
+		require.NoError(t, json.Unmarshal(output, &summary), string(output))
+		total, ok := summary["TotalServers"].(float64)
+		require.True(t, ok)
+		return total
+	}
+	assert.InDelta(t, 1, servers("2KB"), 0)
+	assert.InDelta(t, 0, servers("1KB"), 0, "files over the limit are skipped")
+
+	cmd := newCmd(binary, "scan", "--max-file-size", "10GB", path)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Invalid --max-file-size")
+}
+
+func TestCLI_ScanRedactServerNames(t *testing.T) {
+	binary := buildTestBinary(t)
+	secretsPath := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	scan := func(args ...string) (names, secretServers []string) {
+		t.Helper()
+		cmd := newCmd(binary, append([]string{"scan", "--json"}, append(args, secretsPath)...)...)
+		setCmdHome(cmd, t.TempDir())
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		var summary struct {
+			Servers []struct{ Name string }
+			Secrets []struct {
+				ServerName string `json:"server_name"`
+			}
+		}
+		require.NoError(t, json.Unmarshal(output, &summary), string(output))
+		for _, s := range summary.Servers {
+			names = append(names, s.Name)
+		}
+		for _, s := range summary.Secrets {
+			secretServers = append(secretServers, s.ServerName)
+		}
+		return names, secretServers
+	}
+
+	plain, _ := scan()
+	require.NotEmpty(t, plain)
+	redacted, secretServers := scan("--redact-server-names", "--redact-salt", "ci")
+	require.Len(t, redacted, len(plain))
+	for _, name := range plain {
+		assert.Contains(t, redacted, scanner.RedactServerName(name, "ci"))
+	}
+	require.NotEmpty(t, secretServers)
+	for _, name := range secretServers {
+		assert.Regexp(t, `^[0-9a-f]{8}$`, name)
+	}
+	again, _ := scan("--redact-server-names", "--redact-salt", "ci")
+	assert.ElementsMatch(t, redacted, again, "hashes are reproducible with the same salt")
+
+	cmd := newCmd(binary, "scan", "--verbose-json", "--redact-server-names", secretsPath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "Cannot use --redact-server-names with --verbose-json")
+}
+
+func TestCLI_ScanReportDir(t *testing.T) {
+	binary := buildTestBinary(t)
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	dir := filepath.Join(t.TempDir(), "reports", "latest")
+
+	cmd := newCmd(binary, "scan", "--report-dir", dir, claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	data, err := os.ReadFile(filepath.Join(dir, "report.json"))
+	require.NoError(t, err)
+	var summary map[string]any
+	require.NoError(t, json.Unmarshal(data, &summary), string(data))
+	assert.Contains(t, summary, "Servers")
+
+	text, err := os.ReadFile(filepath.Join(dir, "report.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(text), "filesystem")
+	assert.NotContains(t, string(text), "\x1b[", "the text report has no banner")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary files are left behind")
+
+	base := filepath.Join(t.TempDir(), "run")
+	cmd = newCmd(binary, "scan", "--report-dir", base, "--report-timestamp", claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	matches, err := filepath.Glob(base + "-*T*Z")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Regexp(t, `-\d{8}T\d{6}Z$`, matches[0])
+	assert.FileExists(t, filepath.Join(matches[0], "report.json"))
+
+	cmd = newCmd(binary, "scan", "--report-timestamp", claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "--report-timestamp requires --report-dir")
+}
+
+func TestCLI_ScanProfile(t *testing.T) {
+	binary := buildTestBinary(t)
+	claudePath := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	secretsPath := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	cmd := newCmd(binary, "scan", "--json", "--profile", claudePath, secretsPath)
+	setCmdHome(cmd, t.TempDir())
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	var summary struct {
+		ProfileReport []struct {
+			Path        string
+			Duration    int64
+			ServerCount int
+		}
+	}
+	require.NoError(t, json.Unmarshal(output, &summary), string(output))
+	require.Len(t, summary.ProfileReport, 2)
+	for _, p := range summary.ProfileReport {
+		assert.Positive(t, p.Duration, p.Path)
+		assert.Positive(t, p.ServerCount, p.Path)
+	}
+	assert.GreaterOrEqual(t, summary.ProfileReport[0].Duration, summary.ProfileReport[1].Duration, "slowest first")
+
+	cmd = newCmd(binary, "scan", "--profile", "--no-banner", claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "SLOWEST FILES")
+	assert.Contains(t, string(output), claudePath)
+
+	cmd = newCmd(binary, "scan", "--json", claudePath)
+	setCmdHome(cmd, t.TempDir())
+	output, err = cmd.Output()
+	require.NoError(t, err)
+	assert.NotContains(t, string(output), "ProfileReport")
+}
@@ -3,6 +3,7 @@ package allowlist
 import (
 	"fmt"
 	"io"
+	"slices"
 
 	"github.com/sirupsen/logrus"
 
@@ -42,7 +43,22 @@ func (v *Verifier) ViewAllowlist(w io.Writer) {
 // AddToAllowlist adds an entity to the allowlist.
 func (v *Verifier) AddToAllowlist(entityType, name, hash string) error {
 	logrus.Debugf("Adding to allowlist: type=%s, name=%s, hash=%s", entityType, name, hash)
-	v.Storage.Data.Allowlist[entityType] = append(v.Storage.Data.Allowlist[entityType], hash)
+	v.addEntry(Entry{Type: entityType, Name: name, Hash: hash})
+	return v.Storage.Save()
+}
+
+// AddToDenylist adds an entity to the denylist. Both the hash and the name are recorded,
+// since scans and the proxy match denied servers and tools by name.
+func (v *Verifier) AddToDenylist(entityType, name, hash string) error {
+	logrus.Debugf("Adding to denylist: type=%s, name=%s, hash=%s", entityType, name, hash)
+	if v.Storage.Data.Denylist == nil {
+		v.Storage.Data.Denylist = make(map[string][]string)
+	}
+	for _, key := range []string{hash, name} {
+		if key != "" && !slices.Contains(v.Storage.Data.Denylist[entityType], key) {
+			v.Storage.Data.Denylist[entityType] = append(v.Storage.Data.Denylist[entityType], key)
+		}
+	}
 	return v.Storage.Save()
 }
 
@@ -50,5 +66,6 @@ func (v *Verifier) AddToAllowlist(entityType, name, hash string) error {
 func (v *Verifier) ResetAllowlist() error {
 	logrus.Debug("Resetting allowlist")
 	v.Storage.Data.Allowlist = make(map[string][]string)
+	v.Storage.Data.AllowlistNames = nil
 	return v.Storage.Save()
 }
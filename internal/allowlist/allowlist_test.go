@@ -74,6 +74,24 @@ func TestAddToAllowlist_Persists(t *testing.T) {
 	assert.Contains(t, out, "hash123")
 }
 
+func TestAddToDenylist_Persists(t *testing.T) {
+	t.Parallel()
+
+	storagePath := filepath.Join(t.TempDir(), "storage.json")
+	v, err := NewVerifier(storagePath)
+	require.NoError(t, err)
+	require.NoError(t, v.AddToDenylist("server", "filesystem", "filesystem"))
+	require.NoError(t, v.AddToDenylist("server", "filesystem", "filesystem"))
+
+	v2, err := NewVerifier(storagePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"filesystem"}, v2.Storage.Data.Denylist["server"], "duplicates are not recorded")
+	assert.Empty(t, v2.Storage.Data.Allowlist["server"])
+
+	require.NoError(t, v2.AddToDenylist("tool", "delete_file", "sha256:0f1e2d"))
+	assert.Equal(t, []string{"sha256:0f1e2d", "delete_file"}, v2.Storage.Data.Denylist["tool"], "name is recorded alongside the hash")
+}
+
 func TestResetAllowlist_ClearsEntries(t *testing.T) {
 	t.Parallel()
 
@@ -99,3 +117,114 @@ func TestResetAllowlist_ClearsEntries(t *testing.T) {
 	out := buf.String()
 	assert.Contains(t, out, "Allowlist is empty.")
 }
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+			src, err := NewVerifier(filepath.Join(t.TempDir(), "storage.json"))
+			require.NoError(t, err)
+			require.NoError(t, src.AddToAllowlist("server", "filesystem", "hash123"))
+			require.NoError(t, src.AddToAllowlist("server", "git", "hash456"))
+			require.NoError(t, src.AddToAllowlist("tool", "search", "hash789"))
+
+			buf := captureBuffer()
+			require.NoError(t, src.Export(buf, format))
+
+			dst, err := NewVerifier(filepath.Join(t.TempDir(), "storage.json"))
+			require.NoError(t, err)
+			n, err := dst.Import(bytes.NewReader(buf.Bytes()), false)
+			require.NoError(t, err)
+			assert.Equal(t, 3, n)
+			assert.Equal(t, src.Entries(), dst.Entries())
+			assert.Equal(t, src.Storage.Data.Allowlist, dst.Storage.Data.Allowlist)
+		})
+	}
+}
+
+func TestImport_MergeAndReplace(t *testing.T) {
+	t.Parallel()
+
+	v, err := NewVerifier(filepath.Join(t.TempDir(), "storage.json"))
+	require.NoError(t, err)
+	require.NoError(t, v.AddToAllowlist("server", "filesystem", "hash123"))
+
+	input := `[{"type": "server", "name": "filesystem", "hash": "hash123"}, {"type": "server", "name": "git", "hash": "hash456"}]`
+	_, err = v.Import(bytes.NewBufferString(input), false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hash123", "hash456"}, v.Storage.Data.Allowlist["server"], "merge dedupes hashes")
+
+	_, err = v.Import(bytes.NewBufferString("- {type: tool, name: search, hash: hash789}\n"), true)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{{Type: "tool", Name: "search", Hash: "hash789"}}, v.Entries())
+}
+
+func TestImport_RejectsMalformedEntries(t *testing.T) {
+	t.Parallel()
+
+	v, err := NewVerifier(filepath.Join(t.TempDir(), "storage.json"))
+	require.NoError(t, err)
+	require.NoError(t, v.AddToAllowlist("server", "filesystem", "hash123"))
+
+	for _, input := range []string{
+		`[{"type": "server", "name": "git"}]`,
+		`[{"type": "", "name": "git", "hash": "h"}]`,
+		`{"not": "a list"}`,
+	} {
+		_, err := v.Import(bytes.NewBufferString(input), true)
+		require.Error(t, err, input)
+	}
+	assert.Equal(t, []string{"hash123"}, v.Storage.Data.Allowlist["server"], "allowlist untouched on error")
+}
+
+func TestEntries_LegacyWithoutNames(t *testing.T) {
+	t.Parallel()
+
+	v, err := NewVerifier(filepath.Join(t.TempDir(), "storage.json"))
+	require.NoError(t, err)
+	v.Storage.Data.Allowlist["server"] = []string{"hash123"}
+
+	assert.Equal(t, []Entry{{Type: "server", Name: "hash123", Hash: "hash123"}}, v.Entries())
+}
+
+func TestBulkAdd(t *testing.T) {
+	t.Parallel()
+
+	v, err := NewVerifier(filepath.Join(t.TempDir(), "storage.json"))
+	require.NoError(t, err)
+	require.NoError(t, v.AddToAllowlist("server", "filesystem", "hash123"))
+
+	path := filepath.Join(t.TempDir(), "entries.tsv")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"# type\tname\thash\n"+
+			"server\tgit\thash456\n"+
+			"\n"+
+			"server\tfilesystem\thash123\n"+ // already present
+			"tool\tsearch\thash789\r\n"+
+			"server\tgit\thash456\n"+ // duplicate within the file
+			"server git hash000\n"+ // not tab-separated
+			"server\t\thash111\n"+ // empty name
+			"server\ta\tb\tc\n",
+	), 0o600))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	res, err := v.BulkAdd(f)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Added)
+	assert.Equal(t, 2, res.Skipped)
+	require.Len(t, res.Errors, 3)
+	assert.Contains(t, res.Errors[0].Error(), "line 7:")
+
+	// The additions are persisted.
+	reloaded, err := NewVerifier(v.Storage.Path)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Type: "server", Name: "filesystem", Hash: "hash123"},
+		{Type: "server", Name: "git", Hash: "hash456"},
+		{Type: "tool", Name: "search", Hash: "hash789"},
+	}, reloaded.Entries())
+}
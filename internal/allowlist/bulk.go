@@ -0,0 +1,67 @@
+package allowlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// bulkFields is the number of tab-separated fields on a bulk-add line.
+const bulkFields = 3
+
+// BulkResult reports the outcome of BulkAdd.
+type BulkResult struct {
+	Added   int
+	Skipped int
+	// Errors describes each malformed line, prefixed with its line number.
+	Errors []error
+}
+
+// BulkAdd adds entries read from r, one TYPE<TAB>NAME<TAB>HASH per line. Blank lines and
+// lines starting with # are ignored. Entries whose hash is already allowlisted for the type
+// are skipped and malformed lines are collected in Errors without stopping the import.
+// The allowlist is saved once at the end if anything was added.
+func (v *Verifier) BulkAdd(r io.Reader) (BulkResult, error) {
+	var res BulkResult
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		e, err := parseBulkLine(line)
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Errorf("line %d: %w", n, err))
+			continue
+		}
+		if slices.Contains(v.Storage.Data.Allowlist[e.Type], e.Hash) {
+			res.Skipped++
+			continue
+		}
+		v.addEntry(e)
+		res.Added++
+	}
+	if err := sc.Err(); err != nil {
+		return res, err
+	}
+	if res.Added == 0 {
+		return res, nil
+	}
+	return res, v.Storage.Save()
+}
+
+// parseBulkLine splits a bulk-add line into an entry, applying the same checks as 'allowlist add'.
+func parseBulkLine(line string) (Entry, error) {
+	fields := strings.Split(line, "\t")
+	if len(fields) != bulkFields {
+		return Entry{}, fmt.Errorf("expected %d tab-separated fields (TYPE, NAME, HASH), got %d", bulkFields, len(fields))
+	}
+	e := Entry{
+		Type: strings.TrimSpace(fields[0]),
+		Name: strings.TrimSpace(fields[1]),
+		Hash: strings.TrimSpace(fields[2]),
+	}
+	return e, e.Validate()
+}
@@ -0,0 +1,119 @@
+package allowlist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Export formats accepted by Export.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Entry is one allowlisted entity in an export file.
+type Entry struct {
+	Type string `json:"type" yaml:"type"`
+	Name string `json:"name" yaml:"name"`
+	Hash string `json:"hash" yaml:"hash"`
+}
+
+// Validate checks that every field is set.
+func (e Entry) Validate() error {
+	if e.Type == "" || e.Name == "" || e.Hash == "" {
+		return fmt.Errorf("allowlist entry %+v: type, name and hash must be non-empty", e)
+	}
+	return nil
+}
+
+// nameKey is the AllowlistNames key for an entry.
+func nameKey(entityType, hash string) string { return entityType + "|" + hash }
+
+// addEntry records e unless its hash is already allowlisted for the type.
+func (v *Verifier) addEntry(e Entry) {
+	if v.Storage.Data.Allowlist == nil {
+		v.Storage.Data.Allowlist = make(map[string][]string)
+	}
+	if v.Storage.Data.AllowlistNames == nil {
+		v.Storage.Data.AllowlistNames = make(map[string]string)
+	}
+	if !slices.Contains(v.Storage.Data.Allowlist[e.Type], e.Hash) {
+		v.Storage.Data.Allowlist[e.Type] = append(v.Storage.Data.Allowlist[e.Type], e.Hash)
+	}
+	v.Storage.Data.AllowlistNames[nameKey(e.Type, e.Hash)] = e.Name
+}
+
+// Entries returns the allowlist ordered by type, then insertion order. Entries added
+// before names were recorded use their hash as the name.
+func (v *Verifier) Entries() []Entry {
+	types := make([]string, 0, len(v.Storage.Data.Allowlist))
+	for t := range v.Storage.Data.Allowlist {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	out := []Entry{}
+	for _, t := range types {
+		for _, h := range v.Storage.Data.Allowlist[t] {
+			name := v.Storage.Data.AllowlistNames[nameKey(t, h)]
+			if name == "" {
+				name = h
+			}
+			out = append(out, Entry{Type: t, Name: name, Hash: h})
+		}
+	}
+	return out
+}
+
+// Export writes the allowlist to w as a list of entries in the given format.
+func (v *Verifier) Export(w io.Writer, format string) error {
+	entries := v.Entries()
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	case FormatYAML:
+		return yaml.NewEncoder(w).Encode(entries)
+	default:
+		return fmt.Errorf("unsupported allowlist format %q (want %s or %s)", format, FormatJSON, FormatYAML)
+	}
+}
+
+// ParseEntries decodes a JSON or YAML list of entries and validates each one.
+func ParseEntries(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	// YAML is a superset of JSON, so one decoder handles both formats.
+	if err := yaml.NewDecoder(r).Decode(&entries); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse allowlist: %w", err)
+	}
+	for _, e := range entries {
+		if err := e.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// Import reads entries from r and merges them into the allowlist, or replaces it
+// entirely when replace is set. Nothing is written if any entry is invalid.
+func (v *Verifier) Import(r io.Reader, replace bool) (int, error) {
+	entries, err := ParseEntries(r)
+	if err != nil {
+		return 0, err
+	}
+	if replace {
+		v.Storage.Data.Allowlist = make(map[string][]string)
+		v.Storage.Data.AllowlistNames = nil
+	}
+	for _, e := range entries {
+		v.addEntry(e)
+	}
+	return len(entries), v.Storage.Save()
+}
@@ -177,3 +177,31 @@ func TestCheckHealth_Parallel_Once(t *testing.T) {
 
 	require.Equal(t, 1, healthHits)
 }
+
+func TestProbeHealth_BypassesCache(t *testing.T) {
+	var healthHits int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/health" {
+			healthHits++
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(WithBaseURL(srv.URL + "/api/v1"))
+	require.NoError(t, err)
+	require.Equal(t, 1, healthHits)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	status, latency, err := c.ProbeHealth(ctx)
+	require.NoError(t, err)
+	require.Equal(t, apigen.Healthy, status)
+	require.Positive(t, latency)
+	require.Equal(t, 2, healthHits)
+}
@@ -3,10 +3,13 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -226,3 +229,107 @@ func TestSubmitBatchRatings_400(t *testing.T) {
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, ErrValidation))
 }
+
+// newTestProxy starts a forward proxy stand-in that answers every request with a rating and
+// records the host it was asked to reach.
+func newTestProxy(t *testing.T, seenHost *string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*seenHost = r.URL.Host
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(apigen.RatingResponse{Ratings: []apigen.SecurityRating{{
+			Name:           "x",
+			Classification: apigen.Benign,
+			LastUpdated:    time.Now().UTC(),
+			Source:         apigen.Heuristic,
+		}}})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_HTTPProxyFromEnvironment(t *testing.T) {
+	var seenHost string
+	proxy := newTestProxy(t, &seenHost)
+	t.Setenv("HTTP_PROXY", proxy.URL)
+	t.Setenv("NO_PROXY", "")
+	t.Setenv("no_proxy", "")
+
+	// Requests to localhost bypass the proxy, so the API host must not be loopback.
+	c, err := NewClient(WithBaseURL("http://ratings.example.test/api/v1"), withSkipHealthProbe())
+	require.NoError(t, err)
+	_, err = c.GetRating(context.Background(), PURLTarget{PURL: "pkg:npm/a@1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "ratings.example.test", seenHost)
+}
+
+func TestWithHTTPProxy(t *testing.T) {
+	var seenHost, envHost string
+	proxy := newTestProxy(t, &seenHost)
+	t.Setenv("HTTP_PROXY", newTestProxy(t, &envHost).URL)
+
+	c, err := NewClient(
+		WithBaseURL("http://ratings.example.test/api/v1"),
+		WithHTTPProxy(proxy.URL),
+		withSkipHealthProbe(),
+	)
+	require.NoError(t, err)
+	_, err = c.GetRating(context.Background(), PURLTarget{PURL: "pkg:npm/a@1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "ratings.example.test", seenHost)
+	assert.Empty(t, envHost, "an explicit proxy wins over HTTP_PROXY")
+}
+
+// newTLSTestServer starts a TLS server answering every request with a rating and writes its
+// self-signed certificate to a PEM file.
+func newTLSTestServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(apigen.RatingResponse{Ratings: []apigen.SecurityRating{{
+			Name:           "x",
+			Classification: apigen.Benign,
+			LastUpdated:    time.Now().UTC(),
+			Source:         apigen.Heuristic,
+		}}})
+	}))
+	t.Cleanup(srv.Close)
+
+	pemPath := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}
+	require.NoError(t, os.WriteFile(pemPath, pem.EncodeToMemory(block), 0o600))
+	return srv, pemPath
+}
+
+func TestWithCACert(t *testing.T) {
+	srv, pemPath := newTLSTestServer(t)
+	target := PURLTarget{PURL: "pkg:npm/a@1.0.0"}
+
+	untrusted, err := NewClient(WithBaseURL(srv.URL+"/api/v1"), withSkipHealthProbe())
+	require.NoError(t, err)
+	_, err = untrusted.GetRating(context.Background(), target)
+	require.Error(t, err, "a self-signed certificate is rejected by default")
+
+	c, err := NewClient(WithBaseURL(srv.URL+"/api/v1"), WithCACert(pemPath), withSkipHealthProbe())
+	require.NoError(t, err)
+	_, err = c.GetRating(context.Background(), target)
+	require.NoError(t, err)
+}
+
+func TestWithCACert_Invalid(t *testing.T) {
+	_, err := NewClient(WithCACert(filepath.Join(t.TempDir(), "missing.pem")), withSkipHealthProbe())
+	require.ErrorContains(t, err, "read CA certificate")
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(empty, []byte("not a certificate"), 0o600))
+	_, err = NewClient(WithCACert(empty), withSkipHealthProbe())
+	require.ErrorContains(t, err, "no PEM certificates found")
+}
+
+func TestWithTLSSkipVerify(t *testing.T) {
+	srv, _ := newTLSTestServer(t)
+	c, err := NewClient(WithBaseURL(srv.URL+"/api/v1"), WithTLSSkipVerify(true), withSkipHealthProbe())
+	require.NoError(t, err)
+	_, err = c.GetRating(context.Background(), PURLTarget{PURL: "pkg:npm/a@1.0.0"})
+	require.NoError(t, err)
+}
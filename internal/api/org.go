@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+// ValidateOrg makes an authenticated GET /health carrying the request identity's X-Org-Uuid
+// header and reports whether the server accepts the organization. An unknown organization
+// yields an error wrapping ErrUnauthorized.
+func (c *Client) ValidateOrg(ctx context.Context) error {
+	req, err := c.newRequest(ctx, http.MethodGet, c.buildURL("/health", nil), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return handleHTTPError(resp)
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOrg(t *testing.T) {
+	t.Parallel()
+
+	const known = "123e4567-e89b-12d3-a456-426614174000"
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/health", r.URL.Path)
+		if r.Header.Get("X-Org-Uuid") != known {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	c := newTestClient(t, h)
+
+	ctx := WithIdentity(context.Background(), Identity{OrgUUID: known})
+	require.NoError(t, c.ValidateOrg(ctx))
+
+	ctx = WithIdentity(context.Background(), Identity{OrgUUID: "00000000-0000-4000-8000-000000000000"})
+	require.ErrorIs(t, c.ValidateOrg(ctx), ErrUnauthorized)
+}
@@ -13,7 +13,10 @@ import (
 	"time"
 
 	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
+	"github.com/ensigniasec/run-mcp/internal/telemetry"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // handleHTTPError processes common HTTP error status codes and returns appropriate errors.
@@ -163,29 +166,76 @@ func (c *Client) SubmitBatchRatings(ctx context.Context, reqBody apigen.BatchRat
 	}
 }
 
-// GetScanStatus implements GET /scan-status/{scanId}.
+// nextPageHeader carries the token of the next page of ScanStatus.Targets. The token is sent
+// back as the pageTokenParam query parameter; an absent or empty header marks the last page.
+const (
+	nextPageHeader = "X-Next-Page"
+	pageTokenParam = "page_token"
+)
+
+// GetScanStatus implements GET /scan-status/{scanId}, following X-Next-Page tokens and
+// merging the Targets of all pages into the returned status.
 func (c *Client) GetScanStatus(ctx context.Context, scanID uuid.UUID) (apigen.ScanStatus, error) {
-	full := c.buildURL("/scan-status/"+url.PathEscape(scanID.String()), url.Values{})
-	req, err := c.newRequest(ctx, http.MethodGet, full, nil)
+	st, next, err := c.GetScanStatusPage(ctx, scanID, "")
+	if err != nil {
+		return apigen.ScanStatus{}, err
+	}
+	err = c.forEachScanStatusPage(ctx, scanID, next, func(page apigen.ScanStatus) {
+		st.Targets = append(st.Targets, page.Targets...)
+	})
 	if err != nil {
 		return apigen.ScanStatus{}, err
 	}
+	return st, nil
+}
+
+// GetScanStatusPage fetches a single page of GET /scan-status/{scanId}, the first when
+// pageToken is empty, and returns it with the token of the next page ("" on the last page).
+func (c *Client) GetScanStatusPage(ctx context.Context, scanID uuid.UUID, pageToken string) (apigen.ScanStatus, string, error) {
+	q := url.Values{}
+	if pageToken != "" {
+		q.Set(pageTokenParam, pageToken)
+	}
+	full := c.buildURL("/scan-status/"+url.PathEscape(scanID.String()), q)
+	req, err := c.newRequest(ctx, http.MethodGet, full, nil)
+	if err != nil {
+		return apigen.ScanStatus{}, "", err
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return apigen.ScanStatus{}, err
+		return apigen.ScanStatus{}, "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusOK {
 		var scanStatus apigen.ScanStatus
 		if err := decodeJSON(resp.Body, &scanStatus); err != nil {
-			return apigen.ScanStatus{}, err
+			return apigen.ScanStatus{}, "", err
 		}
-		return scanStatus, nil
+		return scanStatus, resp.Header.Get(nextPageHeader), nil
 	}
 
-	return apigen.ScanStatus{}, handleHTTPError(resp)
+	return apigen.ScanStatus{}, "", handleHTTPError(resp)
+}
+
+// forEachScanStatusPage calls fn with every page from the one named by next onwards. A token
+// seen twice is an error, so a misbehaving server cannot keep the client paging forever.
+func (c *Client) forEachScanStatusPage(ctx context.Context, scanID uuid.UUID, next string, fn func(apigen.ScanStatus)) error {
+	seen := make(map[string]bool)
+	for next != "" {
+		if seen[next] {
+			return fmt.Errorf("scan status: page token %q repeated", next)
+		}
+		seen[next] = true
+		page, token, err := c.GetScanStatusPage(ctx, scanID, next)
+		if err != nil {
+			return err
+		}
+		fn(page)
+		next = token
+	}
+	return nil
 }
 
 // WaitForScanCompletion polls a scan until completion and returns ratings for all completed targets.
@@ -198,8 +248,17 @@ func (c *Client) WaitForScanCompletion(ctx context.Context, ref string, pollEver
 
 	ticker := time.NewTicker(pollEvery)
 	defer ticker.Stop()
-	for {
-		st, err := c.GetScanStatus(ctx, scanUUID)
+	for iteration := 1; ; iteration++ {
+		pollCtx, span := telemetry.Tracer().Start(ctx, telemetry.SpanPoll,
+			trace.WithAttributes(attribute.Int("poll.iteration", iteration)))
+		// Only the first page is polled; the remaining pages are fetched once the scan is done.
+		st, next, err := c.GetScanStatusPage(pollCtx, scanUUID, "")
+		if err == nil {
+			span.SetAttributes(attribute.String("scan.status", string(st.Status)))
+		} else {
+			span.RecordError(err)
+		}
+		span.End()
 		if err != nil {
 			if d, ok := shouldWaitBeforeRetry(err); ok {
 				select {
@@ -215,7 +274,7 @@ func (c *Client) WaitForScanCompletion(ctx context.Context, ref string, pollEver
 		if done, failErr := evaluateScanStatus(st); failErr != nil {
 			return nil, failErr
 		} else if done {
-			return c.fetchAllCompletedRatings(ctx, st)
+			return c.fetchAllCompletedRatings(ctx, scanUUID, st, next)
 		}
 
 		select {
@@ -271,9 +330,19 @@ func parseScanUUID(ref string) (uuid.UUID, error) {
 	return u, nil
 }
 
-func (c *Client) fetchAllCompletedRatings(ctx context.Context, st apigen.ScanStatus) ([]apigen.SecurityRating, error) {
+// fetchAllCompletedRatings fetches the ratings of the completed targets of st and of every
+// later page, starting with the page named by next.
+func (c *Client) fetchAllCompletedRatings(ctx context.Context, scanID uuid.UUID, st apigen.ScanStatus, next string) ([]apigen.SecurityRating, error) {
+	targets := st.Targets
+	err := c.forEachScanStatusPage(ctx, scanID, next, func(page apigen.ScanStatus) {
+		targets = append(targets, page.Targets...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	var results []apigen.SecurityRating
-	for _, t := range st.Targets {
+	for _, t := range targets {
 		if t.Status == apigen.Completed && t.RatingUrl != nil && *t.RatingUrl != "" {
 			r, err := c.fetchRatingRelative(ctx, *t.RatingUrl)
 			if err != nil {
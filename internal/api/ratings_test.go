@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -472,8 +473,96 @@ func TestFetchAllCompletedRatings(t *testing.T) {
 		{Status: apigen.Completed}, // missing url, ignored
 	}}
 
-	ratings, err := c.fetchAllCompletedRatings(context.Background(), st)
+	ratings, err := c.fetchAllCompletedRatings(context.Background(), uuid.New(), st, "")
 	require.NoError(t, err)
 	require.Len(t, ratings, 1)
 	assert.Equal(t, "x", ratings[0].Name)
 }
+
+// pagedScanStatusHandler serves a completed scan whose targets are split over three pages,
+// each completed target linking to a rating named after it.
+func pagedScanStatusHandler(t *testing.T, scanID uuid.UUID, statusRequests *int) http.Handler {
+	t.Helper()
+	pages := map[string][]string{"": {"a", "b"}, "p2": {"c", "d"}, "p3": {"e"}}
+	next := map[string]string{"": "p2", "p2": "p3"}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/scan-status/"+scanID.String() {
+			*statusRequests++
+			token := r.URL.Query().Get("page_token")
+			names, ok := pages[token]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			var targets []apigen.ScanTarget
+			for _, name := range names {
+				ratingURL := "/ratings/purl/pkg:npm/" + name + "@1.0.0"
+				targets = append(targets, apigen.ScanTarget{Status: apigen.Completed, RatingUrl: &ratingURL})
+			}
+			if n := next[token]; n != "" {
+				w.Header().Set("X-Next-Page", n)
+			}
+			_ = json.NewEncoder(w).Encode(apigen.ScanStatus{ScanId: scanID, Status: apigen.ScanStatusStatusCompleted, Targets: targets})
+			return
+		}
+		if name, ok := strings.CutPrefix(r.URL.Path, "/api/v1/ratings/purl/pkg:npm/"); ok {
+			_ = json.NewEncoder(w).Encode(apigen.RatingResponse{Ratings: []apigen.SecurityRating{{
+				Name:           strings.TrimSuffix(name, "@1.0.0"),
+				Classification: apigen.Benign,
+				LastUpdated:    time.Now().UTC(),
+				Source:         apigen.Heuristic,
+			}}})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
+
+func TestWaitForScanCompletion_Paginated(t *testing.T) {
+	t.Parallel()
+
+	scanID := uuid.New()
+	var statusRequests int
+	c := newTestClient(t, pagedScanStatusHandler(t, scanID, &statusRequests))
+
+	ratings, err := c.WaitForScanCompletion(context.Background(), scanID.String(), 10*time.Millisecond)
+	require.NoError(t, err)
+	names := make([]string, 0, len(ratings))
+	for _, r := range ratings {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, names)
+	assert.Equal(t, 3, statusRequests)
+}
+
+func TestGetScanStatus_MergesPages(t *testing.T) {
+	t.Parallel()
+
+	scanID := uuid.New()
+	var statusRequests int
+	c := newTestClient(t, pagedScanStatusHandler(t, scanID, &statusRequests))
+
+	st, err := c.GetScanStatus(context.Background(), scanID)
+	require.NoError(t, err)
+	assert.Len(t, st.Targets, 5)
+
+	page, next, err := c.GetScanStatusPage(context.Background(), scanID, "p2")
+	require.NoError(t, err)
+	assert.Len(t, page.Targets, 2)
+	assert.Equal(t, "p3", next)
+}
+
+func TestGetScanStatus_RepeatedPageToken(t *testing.T) {
+	t.Parallel()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Next-Page", "loop")
+		_ = json.NewEncoder(w).Encode(apigen.ScanStatus{Status: apigen.ScanStatusStatusRunning})
+	})
+	c := newTestClient(t, h)
+
+	_, err := c.GetScanStatus(context.Background(), uuid.New())
+	require.ErrorContains(t, err, `page token "loop" repeated`)
+}
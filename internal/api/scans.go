@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
+)
+
+// ScanUpload is the condensed scan record posted to /scans.
+// The endpoint is not yet part of docs/api-spec.yaml, so the models live here rather than in apigen.
+type ScanUpload struct {
+	HostUUID      string                    `json:"host_uuid"`
+	ScanStartedAt time.Time                 `json:"scan_started_at"`
+	Duration      float64                   `json:"duration"` // seconds
+	ServerCount   int                       `json:"server_count"`
+	Environment   string                    `json:"environment,omitempty"`
+	Labels        map[string]string         `json:"labels,omitempty"`
+	Identifiers   []apigen.TargetIdentifier `json:"identifiers"`
+}
+
+// ScanUploadResponse is returned when an upload is accepted.
+type ScanUploadResponse struct {
+	ScanURL string `json:"scan_url"`
+}
+
+// UploadScan implements POST /scans. The organization is taken from the request identity.
+func (c *Client) UploadScan(ctx context.Context, upload ScanUpload) (ScanUploadResponse, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(upload); err != nil {
+		return ScanUploadResponse{}, err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, c.buildURL("/scans", url.Values{}), buf)
+	if err != nil {
+		return ScanUploadResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return ScanUploadResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated {
+		var out ScanUploadResponse
+		if err := decodeJSON(resp.Body, &out); err != nil {
+			return ScanUploadResponse{}, err
+		}
+		return out, nil
+	}
+	return ScanUploadResponse{}, handleHTTPError(resp)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadScan(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/scans", r.URL.Path)
+		assert.Equal(t, "org-1", r.Header.Get("X-Org-Uuid"))
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "host-1", body["host_uuid"])
+		assert.Equal(t, "2025-06-01T12:00:00Z", body["scan_started_at"])
+		assert.InDelta(t, 1.5, body["duration"], 0)
+		assert.InDelta(t, 2, body["server_count"], 0)
+		assert.Equal(t, "staging", body["environment"])
+		assert.Equal(t, map[string]interface{}{"repo": "api"}, body["labels"])
+		assert.Len(t, body["identifiers"], 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(ScanUploadResponse{ScanURL: "https://example.test/scans/1"})
+	})
+	c := newTestClient(t, h)
+
+	ctx := WithIdentity(context.Background(), Identity{OrgUUID: "org-1", HostUUID: "host-1"})
+	resp, err := c.UploadScan(ctx, ScanUpload{
+		HostUUID:      "host-1",
+		ScanStartedAt: started,
+		Duration:      1.5,
+		ServerCount:   2,
+		Environment:   "staging",
+		Labels:        map[string]string{"repo": "api"},
+		Identifiers:   []apigen.TargetIdentifier{{Kind: apigen.Purl, Value: "pkg:npm/a@1"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.test/scans/1", resp.ScanURL)
+}
+
+func TestUploadScan_Error(t *testing.T) {
+	t.Parallel()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	c := newTestClient(t, h)
+
+	_, err := c.UploadScan(context.Background(), ScanUpload{})
+	require.Error(t, err)
+}
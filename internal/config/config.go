@@ -0,0 +1,311 @@
+// Package config reads the optional run-mcp configuration file, a flat YAML mapping of
+// settings that supply defaults for command-line flags.
+//
+// A setting is resolved from, in order of precedence: the command-line flag, the
+// RUNMCP_<KEY> environment variable, the configuration file and finally the flag default.
+// The environment and file are layered by viper. RUN_MCP_<KEY> is accepted as well, so that
+// settings can be spelled like the older RUN_MCP_UPLOAD and RUN_MCP_API_URL variables;
+// RUNMCP_<KEY> wins when both are set.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// PathEnv overrides the location of the configuration file.
+const PathEnv = "RUN_MCP_CONFIG"
+
+// FileName is the name of the configuration file inside its directory.
+const FileName = "config.yaml"
+
+// EnvPrefix starts the environment variable of every setting, e.g. RUNMCP_ORG_UUID.
+const EnvPrefix = "RUNMCP_"
+
+// legacyEnvPrefix is also accepted, matching RUN_MCP_UPLOAD and RUN_MCP_API_URL. It is
+// consulted after EnvPrefix.
+const legacyEnvPrefix = "RUN_MCP_"
+
+// Sources of a resolved setting.
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// Option is a supported setting.
+type Option struct {
+	Key string
+	// Flag is the command-line flag the setting supplies a default for. Settings without a
+	// flag are applied by the caller.
+	Flag string
+	// Aliases are other flags bound to the same value; giving one counts as giving Flag.
+	Aliases []string
+	// Command limits the setting to one command's flag, e.g. "scan"; empty for global flags.
+	Command string
+	// List settings take a YAML list in the file and space-separated values in the
+	// environment, each passed to a repeatable flag in turn.
+	List        bool
+	Description string
+	Example     string
+}
+
+// Options lists every supported setting in the order they appear in the template.
+//
+//nolint:gochecknoglobals // Fixed table of settings.
+var Options = []Option{
+	{Key: "verbose", Flag: "verbose", Description: "Enable detailed logging output", Example: "false"},
+	{Key: "json", Flag: "json", Description: "Output results in JSON format instead of rich text", Example: "false"},
+	{Key: "tui", Flag: "tui", Description: "Enable interactive TUI mode with real-time progress", Example: "false"},
+	{Key: "no_banner", Flag: "no-banner", Description: "Do not print the ANSI art banner", Example: "false"},
+	{Key: "org_uuid", Flag: "org-uuid", Description: "Organization UUID attached to API requests", Example: "00000000-0000-0000-0000-000000000000"},
+	{Key: "anonymous", Flag: "anonymous", Aliases: []string{"anon"}, Description: "Do not send any UUIDs or tracking information", Example: "false"},
+	{Key: "offline", Flag: "offline", Description: "Never contact the ratings API", Example: "false"},
+	{Key: "storage_file", Description: "Where scan history, ratings cache and allowlist are stored", Example: "~/.local/share/run-mcp/results.json"},
+	{Key: "fail_on_severity", Flag: "fail-on-severity", Command: "scan", Description: "scan: exit 1 if a rated server is at or above this severity (low, medium, high, critical)", Example: "high"},
+	{Key: "min_confidence", Flag: "min-confidence", Command: "scan", Description: "scan: only report secrets at or above this confidence (HIGH or LOW)", Example: "LOW"},
+	{Key: "timeout", Flag: "timeout", Command: "scan", Description: "scan: maximum time for the scan, including waiting for ratings", Example: "30s"},
+	{Key: "workers", Flag: "workers", Command: "scan", Description: "scan: number of config files to scan concurrently", Example: "4"},
+	{Key: "ignore_patterns", Flag: "exclude", Command: "scan", List: true, Description: "scan: skip files whose path or base name matches one of these globs", Example: `["**/node_modules/**", "*.bak"]`},
+}
+
+// Setting is a resolved option value.
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// DefaultPath returns the configuration file location: $RUN_MCP_CONFIG if set, otherwise
+// config.yaml in the run-mcp directory under the OS user configuration directory
+// (~/.config on Linux, ~/Library/Application Support on macOS, %AppData% on Windows).
+func DefaultPath() (string, error) {
+	if p := os.Getenv(PathEnv); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "run-mcp", FileName), nil
+}
+
+// EnvName returns the environment variable that sets key, e.g. RUNMCP_ORG_UUID.
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(key)
+}
+
+// envNames returns the environment variables that set key, in order of precedence.
+func envNames(key string) []string {
+	return []string{EnvName(key), legacyEnvPrefix + strings.ToUpper(key)}
+}
+
+// envName returns the name of the environment variable that sets key, or "" if none does.
+func envName(key string) string {
+	for _, name := range envNames(key) {
+		if os.Getenv(name) != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// Values holds the settings of the configuration file and the environment.
+type Values struct {
+	v *viper.Viper
+	// Found reports whether the configuration file exists.
+	Found bool
+}
+
+// Load reads the configuration file at path and binds every setting to its environment
+// variables. A missing file yields only the environment. Unknown keys, and values that are
+// not a single value (or, for list settings, a list of single values), are rejected.
+func Load(path string) (*Values, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	for _, opt := range Options {
+		if err := v.BindEnv(append([]string{opt.Key}, envNames(opt.Key)...)...); err != nil {
+			return nil, err
+		}
+	}
+	vals := &Values{v: v}
+	if err := v.ReadInConfig(); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return vals, nil
+		}
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	vals.Found = true
+
+	keys := v.AllKeys()
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !v.InConfig(key) {
+			continue
+		}
+		opt, ok := lookup(key)
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown setting %q", path, key)
+		}
+		if err := checkShape(opt, v.Get(key)); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return vals, nil
+}
+
+// checkShape reports whether value, as decoded from the file, fits opt.
+func checkShape(opt Option, value interface{}) error {
+	items, isList := value.([]interface{})
+	switch {
+	case isList && !opt.List:
+		return fmt.Errorf("setting %q must be a single value", opt.Key)
+	case !isList:
+		items = []interface{}{value}
+	}
+	for _, item := range items {
+		switch item.(type) {
+		case []interface{}, map[string]interface{}:
+			if opt.List {
+				return fmt.Errorf("setting %q must be a list of single values", opt.Key)
+			}
+			return fmt.Errorf("setting %q must be a single value", opt.Key)
+		}
+	}
+	return nil
+}
+
+// Value returns the environment or file value of key and its source, or empty strings if
+// neither sets it. List settings are formatted like a repeatable flag, e.g. [a,b].
+func (vals *Values) Value(key string) (string, string) {
+	if opt, ok := lookup(key); ok && opt.List {
+		items, source := vals.List(key)
+		if source == "" {
+			return "", ""
+		}
+		return "[" + strings.Join(items, ",") + "]", source
+	}
+	value := vals.v.GetString(key)
+	switch {
+	case value == "":
+		return "", ""
+	case envName(key) != "":
+		return value, SourceEnv
+	default:
+		return value, SourceFile
+	}
+}
+
+// List returns the environment or file values of a list setting and their source, or nil
+// and an empty source if neither sets it.
+func (vals *Values) List(key string) ([]string, string) {
+	items := vals.v.GetStringSlice(key)
+	switch {
+	case len(items) == 0:
+		return nil, ""
+	case envName(key) != "":
+		return items, SourceEnv
+	default:
+		return items, SourceFile
+	}
+}
+
+// Apply sets every flag of the named command that was not given on the command line from
+// its environment or file value. Settings without a flag are left to the caller.
+func Apply(flags *pflag.FlagSet, command string, vals *Values) error {
+	for _, opt := range Options {
+		if opt.Flag == "" || (opt.Command != "" && opt.Command != command) {
+			continue
+		}
+		f := flags.Lookup(opt.Flag)
+		if f == nil || changed(flags, opt) {
+			continue
+		}
+		var values []string
+		var source string
+		if opt.List {
+			values, source = vals.List(opt.Key)
+		} else if value, s := vals.Value(opt.Key); s != "" {
+			values, source = []string{value}, s
+		}
+		for _, value := range values {
+			if err := flags.Set(opt.Flag, value); err != nil {
+				return fmt.Errorf("invalid %s from %s: %w", opt.Key, sourceName(source, opt.Key), err)
+			}
+		}
+	}
+	return nil
+}
+
+// Resolve returns the value and source of every option. flags holds the flags of every
+// command an option applies to; a flag given on the command line wins over the environment
+// and file, and its default is used when nothing sets the option.
+func Resolve(flags *pflag.FlagSet, vals *Values) []Setting {
+	settings := make([]Setting, 0, len(Options))
+	for _, opt := range Options {
+		s := Setting{Key: opt.Key, Source: SourceDefault}
+		var f *pflag.Flag
+		if opt.Flag != "" {
+			f = flags.Lookup(opt.Flag)
+		}
+		value, source := vals.Value(opt.Key)
+		switch {
+		case f != nil && changed(flags, opt):
+			s.Value, s.Source = f.Value.String(), SourceFlag
+		case source != "":
+			s.Value, s.Source = value, source
+		case f != nil:
+			s.Value = f.DefValue
+		}
+		settings = append(settings, s)
+	}
+	return settings
+}
+
+func changed(flags *pflag.FlagSet, opt Option) bool {
+	for _, name := range append([]string{opt.Flag}, opt.Aliases...) {
+		if f := flags.Lookup(name); f != nil && f.Changed {
+			return true
+		}
+	}
+	return false
+}
+
+func sourceName(source, key string) string {
+	if source == SourceEnv {
+		return envName(key)
+	}
+	return "the config file"
+}
+
+// Template returns the commented-out configuration file written by "config init".
+func Template() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# run-mcp configuration. Uncomment a setting to change its default.\n")
+	buf.WriteString("# Command-line flags and RUNMCP_<SETTING> (or RUN_MCP_<SETTING>) environment variables\n")
+	buf.WriteString("# take precedence.\n")
+	for _, opt := range Options {
+		fmt.Fprintf(&buf, "\n# %s (%s)\n# %s: %s\n", opt.Description, EnvName(opt.Key), opt.Key, opt.Example)
+	}
+	return buf.Bytes()
+}
+
+func lookup(key string) (Option, bool) {
+	for _, opt := range Options {
+		if opt.Key == key {
+			return opt, true
+		}
+	}
+	return Option{}, false
+}
@@ -0,0 +1,195 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// writeConfig writes a config file with the given contents and returns its path.
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), FileName)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+// fileValues returns the settings a config file sets.
+func fileValues(vals *Values) map[string]string {
+	got := map[string]string{}
+	for _, opt := range Options {
+		if v, source := vals.Value(opt.Key); source == SourceFile {
+			got[opt.Key] = v
+		}
+	}
+	return got
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	vals, err := Load(filepath.Join(dir, "missing.yaml"))
+	require.NoError(t, err)
+	assert.False(t, vals.Found, "a missing file is not an error")
+	assert.Empty(t, fileValues(vals))
+
+	path := filepath.Join(dir, FileName)
+	require.NoError(t, os.WriteFile(path, Template(), 0o600))
+	vals, err = Load(path)
+	require.NoError(t, err)
+	assert.True(t, vals.Found)
+	assert.Empty(t, fileValues(vals), "the template sets nothing")
+
+	require.NoError(t, os.WriteFile(path, []byte("offline: true\ntimeout: 5s\n"), 0o600))
+	vals, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"offline": "true", "timeout": "5s"}, fileValues(vals))
+
+	require.NoError(t, os.WriteFile(path, []byte("threads: 4\n"), 0o600))
+	_, err = Load(path)
+	require.ErrorContains(t, err, `unknown setting "threads"`)
+
+	require.NoError(t, os.WriteFile(path, []byte("org_uuid: [a, b]\n"), 0o600))
+	_, err = Load(path)
+	require.ErrorContains(t, err, "must be a single value")
+
+	require.NoError(t, os.WriteFile(path, []byte("ignore_patterns: [\"*.bak\", \"**/node_modules/**\"]\n"), 0o600))
+	vals, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ignore_patterns": "[*.bak,**/node_modules/**]"}, fileValues(vals))
+
+	require.NoError(t, os.WriteFile(path, []byte("ignore_patterns: [[a], b]\n"), 0o600))
+	_, err = Load(path)
+	require.ErrorContains(t, err, "must be a list of single values")
+}
+
+func TestTemplate(t *testing.T) {
+	// Uncommenting every stub yields a valid file.
+	var uncommented []byte
+	for _, opt := range Options {
+		uncommented = append(uncommented, opt.Key+": "+opt.Example+"\n"...)
+	}
+	var raw map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(uncommented, &raw))
+	for _, opt := range Options {
+		assert.Contains(t, string(Template()), "# "+opt.Key+": ")
+		assert.Contains(t, raw, opt.Key)
+	}
+}
+
+func testFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	var anonymous bool
+	flags.String("org-uuid", "", "")
+	flags.BoolVar(&anonymous, "anonymous", false, "")
+	flags.BoolVar(&anonymous, "anon", false, "")
+	flags.Bool("offline", false, "")
+	flags.Duration("timeout", 30*time.Second, "")
+	flags.Int("workers", 4, "")
+	flags.StringArray("exclude", nil, "")
+	return flags
+}
+
+func TestApply(t *testing.T) {
+	vals, err := Load(writeConfig(t, "org_uuid: file-org\noffline: true\nanonymous: false\ntimeout: 5s\n"))
+	require.NoError(t, err)
+	t.Setenv("RUNMCP_OFFLINE", "false")
+
+	flags := testFlags()
+	require.NoError(t, flags.Parse([]string{"--anon"}))
+	require.NoError(t, Apply(flags, "scan", vals))
+	assert.Equal(t, "file-org", flags.Lookup("org-uuid").Value.String())
+	assert.Equal(t, "false", flags.Lookup("offline").Value.String(), "env wins over the file")
+	assert.Equal(t, "true", flags.Lookup("anonymous").Value.String(), "an alias given on the command line wins")
+	assert.Equal(t, "5s", flags.Lookup("timeout").Value.String())
+
+	// Scan settings do not leak into other commands' flags of the same name.
+	flags = testFlags()
+	require.NoError(t, Apply(flags, "inspect", vals))
+	assert.Equal(t, "30s", flags.Lookup("timeout").Value.String())
+
+	vals, err = Load(writeConfig(t, "timeout: soon\n"))
+	require.NoError(t, err)
+	err = Apply(testFlags(), "scan", vals)
+	require.ErrorContains(t, err, "invalid timeout from the config file")
+
+	t.Setenv("RUN_MCP_TIMEOUT", "later")
+	err = Apply(testFlags(), "scan", vals)
+	require.ErrorContains(t, err, "invalid timeout from RUN_MCP_TIMEOUT")
+}
+
+func TestApply_WorkersAndIgnorePatterns(t *testing.T) {
+	vals, err := Load(writeConfig(t, "workers: 2\nignore_patterns: [\"*.bak\", \"**/node_modules/**\"]\n"))
+	require.NoError(t, err)
+
+	// The file supplies both defaults.
+	flags := testFlags()
+	require.NoError(t, Apply(flags, "scan", vals))
+	assert.Equal(t, "2", flags.Lookup("workers").Value.String())
+	assert.Equal(t, "[*.bak,**/node_modules/**]", flags.Lookup("exclude").Value.String())
+
+	// The environment wins over the file; list values are separated by spaces.
+	t.Setenv("RUNMCP_WORKERS", "8")
+	t.Setenv("RUNMCP_IGNORE_PATTERNS", "*.tmp vendor/**")
+	flags = testFlags()
+	require.NoError(t, Apply(flags, "scan", vals))
+	assert.Equal(t, "8", flags.Lookup("workers").Value.String())
+	assert.Equal(t, "[*.tmp,vendor/**]", flags.Lookup("exclude").Value.String())
+
+	// Flags given on the command line win over both.
+	flags = testFlags()
+	require.NoError(t, flags.Parse([]string{"--workers", "1", "--exclude", "*.old"}))
+	require.NoError(t, Apply(flags, "scan", vals))
+	assert.Equal(t, "1", flags.Lookup("workers").Value.String())
+	assert.Equal(t, "[*.old]", flags.Lookup("exclude").Value.String())
+
+	got := map[string]Setting{}
+	for _, s := range Resolve(flags, vals) {
+		got[s.Key] = s
+	}
+	assert.Equal(t, Setting{Key: "ignore_patterns", Value: "[*.old]", Source: SourceFlag}, got["ignore_patterns"])
+	assert.Equal(t, Setting{Key: "workers", Value: "1", Source: SourceFlag}, got["workers"])
+
+	t.Setenv("RUNMCP_WORKERS", "many")
+	err = Apply(testFlags(), "scan", vals)
+	require.ErrorContains(t, err, "invalid workers from RUNMCP_WORKERS")
+}
+
+func TestResolve(t *testing.T) {
+	flags := testFlags()
+	require.NoError(t, flags.Parse([]string{"--org-uuid", "flag-org"}))
+	vals, err := Load(writeConfig(t, "org_uuid: file-org\nstorage_file: /tmp/s.json\n"))
+	require.NoError(t, err)
+	t.Setenv("RUN_MCP_TIMEOUT", "1m") // The RUN_MCP_ spelling is accepted too.
+
+	got := map[string]Setting{}
+	for _, s := range Resolve(flags, vals) {
+		got[s.Key] = s
+	}
+	assert.Len(t, got, len(Options))
+	assert.Equal(t, Setting{Key: "org_uuid", Value: "flag-org", Source: SourceFlag}, got["org_uuid"])
+	assert.Equal(t, Setting{Key: "timeout", Value: "1m", Source: SourceEnv}, got["timeout"])
+	assert.Equal(t, Setting{Key: "storage_file", Value: "/tmp/s.json", Source: SourceFile}, got["storage_file"])
+	assert.Equal(t, Setting{Key: "offline", Value: "false", Source: SourceDefault}, got["offline"])
+	assert.Equal(t, Setting{Key: "min_confidence", Source: SourceDefault}, got["min_confidence"], "flag not defined")
+}
+
+func TestValue_EnvPrecedence(t *testing.T) {
+	vals, err := Load(writeConfig(t, "org_uuid: file-org\n"))
+	require.NoError(t, err)
+
+	t.Setenv("RUN_MCP_ORG_UUID", "legacy-org")
+	value, source := vals.Value("org_uuid")
+	assert.Equal(t, "legacy-org", value)
+	assert.Equal(t, SourceEnv, source)
+
+	t.Setenv("RUNMCP_ORG_UUID", "env-org")
+	value, _ = vals.Value("org_uuid")
+	assert.Equal(t, "env-org", value, "RUNMCP_ wins over RUN_MCP_")
+}
@@ -0,0 +1,187 @@
+// Package diagnose runs self-checks on the local run-mcp installation: storage access,
+// API reachability, well-known config locations and the system-managed config.
+package diagnose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/ensigniasec/run-mcp/internal/storage"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+const (
+	StatusPass Status = "PASS"
+	StatusWarn Status = "WARN"
+	StatusFail Status = "FAIL"
+)
+
+// slowAPILatency is the round-trip above which a healthy API is reported as a warning.
+const slowAPILatency = time.Second
+
+// Check is one line of the diagnose report.
+type Check struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+}
+
+// PathStatus records whether a well-known config path exists on disk.
+type PathStatus struct {
+	Path   string `json:"path"`
+	Exists bool   `json:"exists"`
+}
+
+// Report is the full diagnose result.
+type Report struct {
+	Version        string       `json:"version"`
+	Commit         string       `json:"commit"`
+	Date           string       `json:"date"`
+	OS             string       `json:"os"`
+	Arch           string       `json:"arch"`
+	Checks         []Check      `json:"checks"`
+	WellKnownPaths []PathStatus `json:"well_known_paths"`
+	Failures       int          `json:"failures"`
+}
+
+// HealthProbe performs one API health request and returns its round-trip latency.
+type HealthProbe func(ctx context.Context) (time.Duration, error)
+
+// Options configures Run.
+type Options struct {
+	Version string
+	Commit  string
+	Date    string
+
+	StoragePath      string
+	SystemConfigPath string
+	WellKnownPaths   []string
+	// Probe checks API connectivity. A nil Probe means offline mode; the check is skipped.
+	Probe HealthProbe
+}
+
+// Run executes every check and returns the report. Checks never abort the run.
+func Run(ctx context.Context, opts Options) *Report {
+	r := &Report{
+		Version: opts.Version,
+		Commit:  opts.Commit,
+		Date:    opts.Date,
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+	}
+
+	r.add(checkStorage(opts.StoragePath))
+	r.add(checkAPI(ctx, opts.Probe))
+
+	found := 0
+	for _, p := range opts.WellKnownPaths {
+		_, err := os.Stat(p)
+		exists := err == nil
+		if exists {
+			found++
+		}
+		r.WellKnownPaths = append(r.WellKnownPaths, PathStatus{Path: p, Exists: exists})
+	}
+	if found == 0 {
+		r.add(Check{"well-known paths", StatusWarn, fmt.Sprintf("none of %d known config locations exist; pass config files to `scan` explicitly", len(opts.WellKnownPaths))})
+	} else {
+		r.add(Check{"well-known paths", StatusPass, fmt.Sprintf("%d of %d known config locations exist", found, len(opts.WellKnownPaths))})
+	}
+
+	r.add(checkSystemConfig(opts.SystemConfigPath))
+	return r
+}
+
+func (r *Report) add(c Check) {
+	if c.Status == StatusFail {
+		r.Failures++
+	}
+	r.Checks = append(r.Checks, c)
+}
+
+// checkStorage verifies the storage file can be read and written, or created if it does not exist yet.
+func checkStorage(path string) Check {
+	const name = "storage"
+	expanded, err := storage.ExpandTilde(path)
+	if err != nil {
+		return Check{name, StatusFail, fmt.Sprintf("cannot resolve %s: %v", path, err)}
+	}
+
+	info, err := os.Stat(expanded)
+	switch {
+	case err == nil && info.IsDir():
+		return Check{name, StatusFail, expanded + " is a directory"}
+	case err == nil:
+		if err := tryOpen(expanded, os.O_RDONLY); err != nil {
+			return Check{name, StatusFail, fmt.Sprintf("%s is not readable: %v", expanded, err)}
+		}
+		if err := tryOpen(expanded, os.O_WRONLY); err != nil {
+			return Check{name, StatusFail, fmt.Sprintf("%s is not writable: %v", expanded, err)}
+		}
+		return Check{name, StatusPass, expanded + " is readable and writable"}
+	case !errors.Is(err, os.ErrNotExist):
+		return Check{name, StatusFail, fmt.Sprintf("cannot stat %s: %v", expanded, err)}
+	}
+
+	// The file is created on first use; make sure its nearest existing ancestor accepts new files.
+	dir := filepath.Dir(expanded)
+	for {
+		if _, err := os.Stat(dir); err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	f, err := os.CreateTemp(dir, ".run-mcp-diagnose-*")
+	if err != nil {
+		return Check{name, StatusFail, fmt.Sprintf("%s does not exist and cannot be created: %v", expanded, err)}
+	}
+	f.Close()
+	_ = os.Remove(f.Name())
+	return Check{name, StatusPass, expanded + " does not exist yet; it will be created on first scan"}
+}
+
+// tryOpen opens and immediately closes path with the given flag, without modifying it.
+func tryOpen(path string, flag int) error {
+	f, err := os.OpenFile(path, flag, 0)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// checkAPI reports whether the ratings API answers its health endpoint, and how fast.
+func checkAPI(ctx context.Context, probe HealthProbe) Check {
+	const name = "api"
+	if probe == nil {
+		return Check{name, StatusWarn, "skipped (--offline)"}
+	}
+	latency, err := probe(ctx)
+	if err != nil {
+		return Check{name, StatusFail, fmt.Sprintf("health check failed after %s: %v", latency.Round(time.Millisecond), err)}
+	}
+	if latency > slowAPILatency {
+		return Check{name, StatusWarn, fmt.Sprintf("healthy but slow (%s)", latency.Round(time.Millisecond))}
+	}
+	return Check{name, StatusPass, fmt.Sprintf("healthy (%s)", latency.Round(time.Millisecond))}
+}
+
+// checkSystemConfig reports on the optional system-managed config that pins org/host UUIDs.
+func checkSystemConfig(path string) Check {
+	const name = "system config"
+	f, err := os.Open(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return Check{name, StatusPass, path + " not present (host is not centrally managed)"}
+	case err != nil:
+		return Check{name, StatusWarn, fmt.Sprintf("%s exists but is not readable: %v", path, err)}
+	}
+	f.Close()
+	return Check{name, StatusPass, path + " found"}
+}
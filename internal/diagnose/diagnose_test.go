@@ -0,0 +1,103 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package diagnose
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checkByName(t *testing.T, r *Report, name string) Check {
+	t.Helper()
+	for _, c := range r.Checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("check %q not found", name)
+	return Check{}
+}
+
+func TestRun_AllPassing(t *testing.T) {
+	dir := t.TempDir()
+	storagePath := filepath.Join(dir, "results.json")
+	require.NoError(t, os.WriteFile(storagePath, []byte("{}"), 0o600))
+	existing := filepath.Join(dir, "mcp.json")
+	require.NoError(t, os.WriteFile(existing, []byte("{}"), 0o600))
+
+	r := Run(context.Background(), Options{
+		Version:          "1.2.3",
+		StoragePath:      storagePath,
+		SystemConfigPath: filepath.Join(dir, "missing.yaml"),
+		WellKnownPaths:   []string{existing, filepath.Join(dir, "nope.json")},
+		Probe:            func(context.Context) (time.Duration, error) { return 20 * time.Millisecond, nil },
+	})
+
+	assert.Equal(t, 0, r.Failures)
+	assert.Equal(t, StatusPass, checkByName(t, r, "storage").Status)
+	assert.Equal(t, StatusPass, checkByName(t, r, "api").Status)
+	assert.Contains(t, checkByName(t, r, "api").Message, "20ms")
+	assert.Equal(t, StatusPass, checkByName(t, r, "well-known paths").Status)
+	assert.Equal(t, StatusPass, checkByName(t, r, "system config").Status)
+	assert.Equal(t, []PathStatus{{existing, true}, {filepath.Join(dir, "nope.json"), false}}, r.WellKnownPaths)
+}
+
+func TestRun_FailuresAreCounted(t *testing.T) {
+	dir := t.TempDir()
+	r := Run(context.Background(), Options{
+		StoragePath:      dir, // a directory cannot be used as the storage file
+		SystemConfigPath: filepath.Join(dir, "missing.yaml"),
+		Probe:            func(context.Context) (time.Duration, error) { return 0, errors.New("connection refused") },
+	})
+
+	assert.Equal(t, 2, r.Failures)
+	assert.Equal(t, StatusFail, checkByName(t, r, "storage").Status)
+	assert.Contains(t, checkByName(t, r, "api").Message, "connection refused")
+	assert.Equal(t, StatusWarn, checkByName(t, r, "well-known paths").Status)
+}
+
+func TestCheckStorage_MissingFileIsCreatable(t *testing.T) {
+	dir := t.TempDir()
+	c := checkStorage(filepath.Join(dir, "a", "b", "results.json"))
+	assert.Equal(t, StatusPass, c.Status)
+
+	// The probe must not leave anything behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestCheckAPI(t *testing.T) {
+	assert.Equal(t, StatusWarn, checkAPI(context.Background(), nil).Status)
+	slow := func(context.Context) (time.Duration, error) { return 2 * time.Second, nil }
+	assert.Equal(t, StatusWarn, checkAPI(context.Background(), slow).Status)
+}
+
+func TestPrintReport(t *testing.T) {
+	r := &Report{
+		Version:        "1.2.3",
+		Checks:         []Check{{"storage", StatusFail, "broken"}},
+		WellKnownPaths: []PathStatus{{"/tmp/mcp.json", true}},
+		Failures:       1,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintReport(&buf, r, false))
+	assert.Contains(t, buf.String(), "[FAIL] storage")
+	assert.Contains(t, buf.String(), "✓ /tmp/mcp.json")
+	assert.Contains(t, buf.String(), "1 check(s) failed")
+
+	buf.Reset()
+	require.NoError(t, PrintReport(&buf, r, true))
+	var decoded Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, *r, decoded)
+}
@@ -0,0 +1,50 @@
+package diagnose
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const reportWidth = 80
+
+// PrintReport writes the report as indented JSON or as a human-readable checklist.
+func PrintReport(w io.Writer, r *Report, jsonOutput bool) error {
+	if jsonOutput {
+		out, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	}
+
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	fmt.Fprintln(w, "RUN-MCP DIAGNOSE")
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	fmt.Fprintf(w, "Version: %s (commit %s, built %s)\n", r.Version, r.Commit, r.Date)
+	fmt.Fprintf(w, "Platform: %s/%s\n\n", r.OS, r.Arch)
+
+	for _, c := range r.Checks {
+		fmt.Fprintf(w, "[%s] %-17s %s\n", c.Status, c.Name, c.Message)
+	}
+
+	fmt.Fprintf(w, "\n📁 WELL-KNOWN PATHS (%d)\n", len(r.WellKnownPaths))
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	for _, p := range r.WellKnownPaths {
+		mark := "✗"
+		if p.Exists {
+			mark = "✓"
+		}
+		fmt.Fprintf(w, "    %s %s\n", mark, p.Path)
+	}
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+
+	if r.Failures > 0 {
+		fmt.Fprintf(w, "%d check(s) failed\n", r.Failures)
+	} else {
+		fmt.Fprintln(w, "No checks failed")
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package inspect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ensigniasec/run-mcp/internal/api"
+	"github.com/ensigniasec/run-mcp/internal/scanner"
+)
+
+const (
+	// protocolVersion is the MCP protocol revision advertised during initialize.
+	protocolVersion = "2025-06-18"
+	// killGracePeriod bounds how long we wait for the child to exit after stdin is closed.
+	killGracePeriod = 2 * time.Second
+	// maxListPages bounds how many pages of a paginated list are requested, so a server that
+	// keeps returning a cursor cannot stall the inspection.
+	maxListPages = 100
+)
+
+// ErrUnsupportedTransport is returned for servers that are not launched over stdio.
+var ErrUnsupportedTransport = errors.New("only stdio servers can be inspected")
+
+// ServerSpec describes how to launch a stdio MCP server.
+type ServerSpec struct {
+	Name    string
+	Command string
+	Args    []string
+	Env     map[string]string
+}
+
+// NewServerSpec builds a ServerSpec from a parsed server config.
+// Both the flat form ({"command", "args", "env"}) and the nested "stdio" form are supported.
+func NewServerSpec(name string, server scanner.Server) (ServerSpec, error) {
+	spec := ServerSpec{Name: name}
+	cfg := server
+	if stdio, ok := server["stdio"].(map[string]interface{}); ok {
+		cfg = stdio
+	}
+
+	switch cmd := cfg["command"].(type) {
+	case string:
+		spec.Command = cmd
+	case []interface{}:
+		for i, it := range cmd {
+			s, _ := it.(string)
+			if i == 0 {
+				spec.Command = s
+				continue
+			}
+			spec.Args = append(spec.Args, s)
+		}
+	}
+	if spec.Command == "" {
+		if _, ok := server["url"]; ok {
+			return spec, ErrUnsupportedTransport
+		}
+		return spec, fmt.Errorf("server %q has no command", name)
+	}
+
+	if args, ok := cfg["args"].([]interface{}); ok {
+		for _, a := range args {
+			s, _ := a.(string)
+			spec.Args = append(spec.Args, s)
+		}
+	}
+	if env, ok := cfg["env"].(map[string]interface{}); ok {
+		spec.Env = make(map[string]string, len(env))
+		for k, v := range env {
+			if s, ok := v.(string); ok {
+				spec.Env[k] = s
+			}
+		}
+	}
+	return spec, nil
+}
+
+// Cmd returns an exec.Cmd that launches the server with its configured environment
+// layered over the current process environment. The process is killed when ctx is done.
+func (s ServerSpec) Cmd(ctx context.Context) *exec.Cmd {
+	//nolint:gosec // Launching the user's configured server command is the purpose of this helper.
+	cmd := exec.CommandContext(ctx, s.Command, s.Args...)
+	cmd.Env = os.Environ()
+	for k, v := range s.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	return cmd
+}
+
+// Report is the read-only enumeration of an MCP server's capabilities.
+type Report struct {
+	Server          string     `json:"server"`
+	ServerInfo      ServerInfo `json:"server_info"`
+	ProtocolVersion string     `json:"protocol_version"`
+	Tools           []Tool     `json:"tools"`
+	Resources       []Resource `json:"resources"`
+	Prompts         []Prompt   `json:"prompts"`
+}
+
+// ServerInfo is the implementation info returned by initialize.
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Tool is a tool advertised by tools/list.
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"inputSchema,omitempty"`
+}
+
+// Resource is a resource advertised by resources/list.
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// Prompt is a prompt advertised by prompts/list.
+type Prompt struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// initializeResult mirrors the subset of the initialize result we care about.
+type initializeResult struct {
+	ProtocolVersion string                     `json:"protocolVersion"`
+	ServerInfo      ServerInfo                 `json:"serverInfo"`
+	Capabilities    map[string]json.RawMessage `json:"capabilities"`
+}
+
+// Inspect launches the server described by spec, enumerates its tools, resources and prompts,
+// and shuts it down. The child process is killed when ctx is done.
+func Inspect(ctx context.Context, spec ServerSpec) (*Report, error) {
+	cmd := spec.Cmd(ctx)
+	cmd.Stderr = io.Discard
+	if logrus.IsLevelEnabled(logrus.DebugLevel) {
+		cmd.Stderr = os.Stderr
+	}
+	cmd.WaitDelay = killGracePeriod
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start %s: %w", spec.Command, err)
+	}
+	defer func() {
+		_ = stdin.Close()
+		// Give the server a moment to exit on EOF, then make sure it is gone.
+		done := make(chan struct{})
+		go func() {
+			_ = cmd.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(killGracePeriod):
+			_ = cmd.Process.Kill()
+			<-done
+		}
+	}()
+
+	// Run the conversation in a goroutine so a stalled server cannot outlive ctx.
+	type result struct {
+		report *Report
+		err    error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		r, err := enumerate(newSession(stdout, stdin), spec.Name)
+		resCh <- result{r, err}
+	}()
+
+	select {
+	case res := <-resCh:
+		return res.report, res.err
+	case <-ctx.Done():
+		_ = cmd.Process.Kill()
+		return nil, ctx.Err()
+	}
+}
+
+// enumerate performs the initialize handshake and lists capabilities over an established session.
+func enumerate(s *session, name string) (*Report, error) {
+	var init initializeResult
+	params := map[string]interface{}{
+		"protocolVersion": protocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]string{"name": "run-mcp", "version": api.BuildVersion},
+	}
+	if err := s.call("initialize", params, &init); err != nil {
+		return nil, fmt.Errorf("initialize: %w", err)
+	}
+	if err := s.notify("notifications/initialized", nil); err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Server:          name,
+		ServerInfo:      init.ServerInfo,
+		ProtocolVersion: init.ProtocolVersion,
+		Tools:           []Tool{},
+		Resources:       []Resource{},
+		Prompts:         []Prompt{},
+	}
+
+	if _, ok := init.Capabilities["tools"]; ok {
+		tools, err := listAll[Tool](s, "tools/list", "tools")
+		if err != nil {
+			return nil, err
+		}
+		report.Tools = append(report.Tools, tools...)
+		sort.Slice(report.Tools, func(i, j int) bool { return report.Tools[i].Name < report.Tools[j].Name })
+	}
+	if _, ok := init.Capabilities["resources"]; ok {
+		resources, err := listAll[Resource](s, "resources/list", "resources")
+		if err != nil {
+			return nil, err
+		}
+		report.Resources = append(report.Resources, resources...)
+		sort.Slice(report.Resources, func(i, j int) bool { return report.Resources[i].URI < report.Resources[j].URI })
+	}
+	if _, ok := init.Capabilities["prompts"]; ok {
+		prompts, err := listAll[Prompt](s, "prompts/list", "prompts")
+		if err != nil {
+			return nil, err
+		}
+		report.Prompts = append(report.Prompts, prompts...)
+		sort.Slice(report.Prompts, func(i, j int) bool { return report.Prompts[i].Name < report.Prompts[j].Name })
+	}
+	return report, nil
+}
+
+// listAll calls a paginated list method and collects the items under field from every page,
+// following nextCursor until a page has none. Past maxListPages the rest is dropped with a
+// warning.
+func listAll[T any](s *session, method, field string) ([]T, error) {
+	var items []T
+	params := map[string]interface{}{}
+	for range maxListPages {
+		var page map[string]json.RawMessage
+		if err := s.call(method, params, &page); err != nil {
+			return nil, fmt.Errorf("%s: %w", method, err)
+		}
+		if raw, ok := page[field]; ok {
+			var pageItems []T
+			if err := json.Unmarshal(raw, &pageItems); err != nil {
+				return nil, fmt.Errorf("%s: %w", method, err)
+			}
+			items = append(items, pageItems...)
+		}
+		var cursor string
+		if raw, ok := page["nextCursor"]; ok {
+			if err := json.Unmarshal(raw, &cursor); err != nil {
+				return nil, fmt.Errorf("%s: invalid nextCursor: %w", method, err)
+			}
+		}
+		if cursor == "" {
+			return items, nil
+		}
+		params = map[string]interface{}{"cursor": cursor}
+	}
+	logrus.Warnf("%s: stopped after %d pages", method, maxListPages)
+	return items, nil
+}
@@ -0,0 +1,183 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package inspect
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ensigniasec/run-mcp/internal/scanner"
+)
+
+const helperEnv = "RUN_MCP_INSPECT_HELPER"
+
+// TestHelperMCPServer is not a real test: when re-executed with helperEnv set,
+// the test binary acts as a stdio MCP server for Inspect to talk to.
+func TestHelperMCPServer(t *testing.T) {
+	switch os.Getenv(helperEnv) {
+	case "serve":
+		serveMock(os.Stdin, os.Stdout)
+		os.Exit(0)
+	case "hang":
+		// Never respond; Inspect must kill us on timeout.
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+}
+
+// serveMock implements the initialize/list round-trip of a minimal MCP server.
+func serveMock(r io.Reader, w io.Writer) {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		var req struct {
+			ID     *int64 `json:"id"`
+			Method string `json:"method"`
+			Params struct {
+				Cursor string `json:"cursor"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(sc.Bytes(), &req); err != nil || req.ID == nil {
+			continue // notifications get no response
+		}
+		var result interface{}
+		switch req.Method {
+		case "initialize":
+			result = map[string]interface{}{
+				"protocolVersion": protocolVersion,
+				"serverInfo":      map[string]string{"name": "mock", "version": "1.0.0"},
+				"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}, "prompts": map[string]interface{}{}},
+			}
+		case "tools/list":
+			// Two pages, to exercise nextCursor.
+			if req.Params.Cursor == "" {
+				result = map[string]interface{}{
+					"tools":      []map[string]interface{}{{"name": "write_file", "description": "Write a file"}},
+					"nextCursor": "page-2",
+				}
+				break
+			}
+			result = map[string]interface{}{"tools": []map[string]interface{}{
+				{"name": "read_file", "description": "Read a file"},
+			}}
+		case "prompts/list":
+			result = map[string]interface{}{"prompts": []map[string]interface{}{{"name": "summarize"}}}
+		default:
+			b, _ := json.Marshal(map[string]interface{}{
+				"jsonrpc": "2.0", "id": *req.ID, "error": map[string]interface{}{"code": -32601, "message": "method not found"},
+			})
+			_, _ = w.Write(append(b, '\n'))
+			continue
+		}
+		b, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": *req.ID, "result": result})
+		_, _ = w.Write(append(b, '\n'))
+	}
+}
+
+func helperSpec(mode string) ServerSpec {
+	return ServerSpec{
+		Name:    "mock",
+		Command: os.Args[0],
+		Args:    []string{"-test.run=TestHelperMCPServer"},
+		Env:     map[string]string{helperEnv: mode},
+	}
+}
+
+func TestInspect_EnumeratesCapabilities(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	report, err := Inspect(ctx, helperSpec("serve"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "mock", report.ServerInfo.Name)
+	assert.Equal(t, protocolVersion, report.ProtocolVersion)
+	require.Len(t, report.Tools, 2)
+	assert.Equal(t, "read_file", report.Tools[0].Name) // sorted
+	assert.Empty(t, report.Resources)                  // capability not advertised
+	require.Len(t, report.Prompts, 1)
+}
+
+func TestInspect_TimeoutKillsServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := Inspect(ctx, helperSpec("hang"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestSession_SkipsNoiseAndSurfacesErrors(t *testing.T) {
+	in := bytes.NewBufferString("starting server...\n" +
+		`{"jsonrpc":"2.0","method":"notifications/message","params":{}}` + "\n" +
+		`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"nope"}}` + "\n")
+	var out bytes.Buffer
+	s := newSession(in, &out)
+
+	err := s.call("tools/list", nil, nil)
+	var rpcErr *RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, -32601, rpcErr.Code)
+	assert.Contains(t, out.String(), `"method":"tools/list"`)
+
+	require.ErrorIs(t, s.call("prompts/list", nil, nil), ErrClosed)
+}
+
+func TestListAll_PageCap(t *testing.T) {
+	// Every page points at another one.
+	var in bytes.Buffer
+	for id := 1; id <= maxListPages+1; id++ {
+		fmt.Fprintf(&in, `{"jsonrpc":"2.0","id":%d,"result":{"prompts":[{"name":"p%d"}],"nextCursor":"c%d"}}`+"\n", id, id, id)
+	}
+	var out bytes.Buffer
+	prompts, err := listAll[Prompt](newSession(&in, &out), "prompts/list", "prompts")
+	require.NoError(t, err)
+	assert.Len(t, prompts, maxListPages)
+	assert.Equal(t, maxListPages, strings.Count(out.String(), `"method":"prompts/list"`))
+	assert.Contains(t, out.String(), `"params":{"cursor":"c1"}`)
+}
+
+func TestNewServerSpec(t *testing.T) {
+	spec, err := NewServerSpec("fs", scanner.Server{
+		"command": "npx",
+		"args":    []interface{}{"-y", "@modelcontextprotocol/server-filesystem"},
+		"env":     map[string]interface{}{"TOKEN": "abc"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "npx", spec.Command)
+	assert.Equal(t, []string{"-y", "@modelcontextprotocol/server-filesystem"}, spec.Args)
+	assert.Equal(t, "abc", spec.Env["TOKEN"])
+
+	spec, err = NewServerSpec("nested", scanner.Server{
+		"stdio": map[string]interface{}{"command": []interface{}{"uvx", "mcp-server-git"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "uvx", spec.Command)
+	assert.Equal(t, []string{"mcp-server-git"}, spec.Args)
+
+	_, err = NewServerSpec("remote", scanner.Server{"url": "https://example.com/mcp"})
+	require.ErrorIs(t, err, ErrUnsupportedTransport)
+}
+
+func TestPrintReport(t *testing.T) {
+	r := &Report{Server: "mock", Tools: []Tool{{Name: "read_file", Description: "Read\n  a file"}}}
+	var buf bytes.Buffer
+	require.NoError(t, PrintReport(&buf, r, false))
+	assert.Contains(t, buf.String(), "read_file: Read a file")
+
+	buf.Reset()
+	require.NoError(t, PrintReport(&buf, r, true))
+	var decoded Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "mock", decoded.Server)
+}
@@ -0,0 +1,117 @@
+package inspect
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+const jsonrpcVersion = "2.0"
+
+// maxMessageSize bounds a single newline-delimited JSON-RPC message read from the server.
+const maxMessageSize = 10 * 1024 * 1024
+
+// request is a JSON-RPC 2.0 request or notification (when ID is nil).
+type request struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      *int64      `json:"id,omitempty"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC 2.0 response. Server-initiated requests and notifications
+// are decoded into the same shape and distinguished by a non-empty Method.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError is a JSON-RPC 2.0 error object returned by the server.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// ErrClosed is returned when the server closes its output before responding.
+var ErrClosed = errors.New("server closed the connection")
+
+// session speaks newline-delimited JSON-RPC 2.0, as used by the MCP stdio transport.
+// Calls are sequential: each call writes one request and reads until the matching response.
+type session struct {
+	mu     sync.Mutex
+	w      io.Writer
+	r      *bufio.Scanner
+	nextID int64
+}
+
+func newSession(r io.Reader, w io.Writer) *session {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxMessageSize) //nolint:mnd // initial buffer size
+	return &session{w: w, r: sc}
+}
+
+// call sends a request and decodes the matching result into out (if non-nil).
+func (s *session) call(method string, params interface{}, out interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	id := s.nextID
+	if err := s.write(request{JSONRPC: jsonrpcVersion, ID: &id, Method: method, Params: params}); err != nil {
+		return err
+	}
+	want := fmt.Sprintf("%d", id)
+	for s.r.Scan() {
+		line := s.r.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var resp response
+		if err := json.Unmarshal(line, &resp); err != nil {
+			// Servers occasionally log to stdout; ignore anything that is not JSON-RPC.
+			continue
+		}
+		// Skip server notifications and requests, and responses for other IDs.
+		if resp.Method != "" || string(resp.ID) != want {
+			continue
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if out == nil || len(resp.Result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(resp.Result, out)
+	}
+	if err := s.r.Err(); err != nil {
+		return err
+	}
+	return ErrClosed
+}
+
+// notify sends a notification, which has no ID and receives no response.
+func (s *session) notify(method string, params interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(request{JSONRPC: jsonrpcVersion, Method: method, Params: params})
+}
+
+func (s *session) write(req request) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	_, err = s.w.Write(b)
+	return err
+}
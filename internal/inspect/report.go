@@ -0,0 +1,61 @@
+package inspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const reportWidth = 80
+
+// PrintReport writes the report as indented JSON or as a human-readable table.
+func PrintReport(w io.Writer, r *Report, jsonOutput bool) error {
+	if jsonOutput {
+		out, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	}
+
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	fmt.Fprintf(w, "RUN-MCP INSPECT: %s\n", r.Server)
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	fmt.Fprintf(w, "Server: %s %s (protocol %s)\n", r.ServerInfo.Name, r.ServerInfo.Version, r.ProtocolVersion)
+
+	fmt.Fprintf(w, "\n🛠  TOOLS (%d)\n", len(r.Tools))
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	for _, t := range r.Tools {
+		printRow(w, t.Name, t.Description)
+	}
+
+	fmt.Fprintf(w, "\n📦 RESOURCES (%d)\n", len(r.Resources))
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	for _, res := range r.Resources {
+		name := res.URI
+		if res.Name != "" {
+			name = res.Name + " <" + res.URI + ">"
+		}
+		printRow(w, name, res.Description)
+	}
+
+	fmt.Fprintf(w, "\n💬 PROMPTS (%d)\n", len(r.Prompts))
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	for _, p := range r.Prompts {
+		printRow(w, p.Name, p.Description)
+	}
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	return nil
+}
+
+// printRow prints a name with its description collapsed to a single line.
+func printRow(w io.Writer, name, description string) {
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		fmt.Fprintf(w, "    • %s\n", name)
+		return
+	}
+	fmt.Fprintf(w, "    • %s: %s\n", name, description)
+}
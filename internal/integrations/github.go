@@ -0,0 +1,204 @@
+// Package integrations publishes scan results to third-party services, such as a summary
+// comment on a GitHub pull request.
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ensigniasec/run-mcp/internal/scanner"
+)
+
+// Environment variables read by NewGitHubClientFromEnv. GitHub Actions sets all of them
+// except GITHUB_TOKEN, which workflows pass explicitly.
+const (
+	GitHubTokenEnv      = "GITHUB_TOKEN"
+	GitHubRepositoryEnv = "GITHUB_REPOSITORY"
+	GitHubSHAEnv        = "GITHUB_SHA"
+	GitHubRefEnv        = "GITHUB_REF"
+	GitHubAPIURLEnv     = "GITHUB_API_URL"
+)
+
+// DefaultGitHubAPIURL is used when GITHUB_API_URL is unset.
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// PRCommentMarker identifies the run-mcp comment on a pull request, so later scans edit it
+// instead of adding another.
+const PRCommentMarker = "<!-- run-mcp-scan -->"
+
+// requestTimeout bounds each request to a third-party API.
+const requestTimeout = 10 * time.Second
+
+// commentsPerPage is the page size used when looking for an existing comment.
+const commentsPerPage = 100
+
+// ErrNoPullRequest is returned when the commit being scanned is not part of an open pull request.
+var ErrNoPullRequest = errors.New("no pull request found for commit")
+
+// pullRefPattern matches the GITHUB_REF of pull_request workflows, e.g. refs/pull/42/merge.
+//
+//nolint:gochecknoglobals // Compiled once.
+var pullRefPattern = regexp.MustCompile(`^refs/pull/(\d+)/`)
+
+// GitHubClient posts scan summaries to pull requests of one repository.
+type GitHubClient struct {
+	baseURL    string
+	token      string
+	repo       string
+	sha        string
+	ref        string
+	httpClient *http.Client
+}
+
+// NewGitHubClientFromEnv configures a client from GITHUB_TOKEN, GITHUB_REPOSITORY and
+// GITHUB_SHA, which are required, and the optional GITHUB_REF and GITHUB_API_URL.
+func NewGitHubClientFromEnv(getenv func(string) string) (*GitHubClient, error) {
+	c := &GitHubClient{
+		baseURL:    strings.TrimRight(getenv(GitHubAPIURLEnv), "/"),
+		token:      getenv(GitHubTokenEnv),
+		repo:       getenv(GitHubRepositoryEnv),
+		sha:        getenv(GitHubSHAEnv),
+		ref:        getenv(GitHubRefEnv),
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+	var missing []string
+	for _, name := range []string{GitHubTokenEnv, GitHubRepositoryEnv, GitHubSHAEnv} {
+		if getenv(name) == "" {
+			missing = append(missing, "$"+name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+	if c.baseURL == "" {
+		c.baseURL = DefaultGitHubAPIURL
+	}
+	return c, nil
+}
+
+// issueComment is the subset of a GitHub issue comment used here.
+type issueComment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+}
+
+// PullRequestNumber returns the pull request being built: taken from GITHUB_REF on
+// pull_request workflows, otherwise the first open pull request containing GITHUB_SHA.
+func (c *GitHubClient) PullRequestNumber(ctx context.Context) (int, error) {
+	if m := pullRefPattern.FindStringSubmatch(c.ref); m != nil {
+		return strconv.Atoi(m[1])
+	}
+	var pulls []struct {
+		Number int    `json:"number"`
+		State  string `json:"state"`
+	}
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/commits/%s/pulls", c.repo, c.sha), nil, &pulls); err != nil {
+		return 0, err
+	}
+	for _, p := range pulls {
+		if p.State == "open" {
+			return p.Number, nil
+		}
+	}
+	return 0, fmt.Errorf("%w %s", ErrNoPullRequest, c.sha)
+}
+
+// UpsertPRComment edits the pull request comment carrying PRCommentMarker, or creates one,
+// and returns its URL. The marker is added to body if missing.
+func (c *GitHubClient) UpsertPRComment(ctx context.Context, pr int, body string) (string, error) {
+	if !strings.Contains(body, PRCommentMarker) {
+		body = PRCommentMarker + "\n" + body
+	}
+	existing, err := c.findComment(ctx, pr)
+	if err != nil {
+		return "", err
+	}
+	payload := map[string]string{"body": body}
+	var out issueComment
+	if existing != nil {
+		err = c.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/issues/comments/%d", c.repo, existing.ID), payload, &out)
+	} else {
+		err = c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/issues/%d/comments", c.repo, pr), payload, &out)
+	}
+	return out.HTMLURL, err
+}
+
+// findComment returns the first comment on the pull request that contains PRCommentMarker.
+func (c *GitHubClient) findComment(ctx context.Context, pr int) (*issueComment, error) {
+	for page := 1; ; page++ {
+		var comments []issueComment
+		path := fmt.Sprintf("/repos/%s/issues/%d/comments?per_page=%d&page=%d", c.repo, pr, commentsPerPage, page)
+		if err := c.do(ctx, http.MethodGet, path, nil, &comments); err != nil {
+			return nil, err
+		}
+		for i := range comments {
+			if strings.Contains(comments[i].Body, PRCommentMarker) {
+				return &comments[i], nil
+			}
+		}
+		if len(comments) < commentsPerPage {
+			return nil, nil
+		}
+	}
+}
+
+// PostPRComment renders summary as Markdown and creates or updates the run-mcp comment on
+// the pull request being built. It returns the comment URL.
+func (c *GitHubClient) PostPRComment(ctx context.Context, summary scanner.ScanSummary) (string, error) {
+	pr, err := c.PullRequestNumber(ctx)
+	if err != nil {
+		return "", err
+	}
+	var body bytes.Buffer
+	body.WriteString(PRCommentMarker + "\n")
+	if err := scanner.WriteMarkdown(&body, summary); err != nil {
+		return "", err
+	}
+	return c.UpsertPRComment(ctx, pr, body.String())
+}
+
+// do sends a GitHub API request with an optional JSON payload and decodes the JSON response into out.
+func (c *GitHubClient) do(ctx context.Context, method, path string, payload, out any) error {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("github %s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
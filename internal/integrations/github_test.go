@@ -0,0 +1,123 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ensigniasec/run-mcp/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitHub records comment writes and serves the given existing comments.
+type fakeGitHub struct {
+	mu       sync.Mutex
+	existing []issueComment
+	method   string
+	path     string
+	body     string
+}
+
+func (f *fakeGitHub) handler(t *testing.T) http.Handler {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/tools/commits/abc123/pulls", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"number": 7, "state": "closed"}, {"number": 42, "state": "open"}]`))
+	})
+	mux.HandleFunc("GET /repos/acme/tools/issues/42/comments", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(f.existing)
+	})
+	record := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		var payload struct {
+			Body string `json:"body"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		f.mu.Lock()
+		f.method, f.path, f.body = r.Method, r.URL.Path, payload.Body
+		f.mu.Unlock()
+		_, _ = w.Write([]byte(`{"id": 1, "html_url": "https://github.com/acme/tools/pull/42#issuecomment-1"}`))
+	}
+	mux.HandleFunc("POST /repos/acme/tools/issues/42/comments", record)
+	mux.HandleFunc("PATCH /repos/acme/tools/issues/comments/{id}", record)
+	return mux
+}
+
+func testEnv(baseURL string, extra map[string]string) func(string) string {
+	env := map[string]string{
+		GitHubTokenEnv:      "test-token",
+		GitHubRepositoryEnv: "acme/tools",
+		GitHubSHAEnv:        "abc123",
+		GitHubAPIURLEnv:     baseURL,
+	}
+	for k, v := range extra {
+		env[k] = v
+	}
+	return func(k string) string { return env[k] }
+}
+
+func testSummary() scanner.ScanSummary {
+	return scanner.ScanSummary{
+		TotalServers: 2,
+		ScannedFiles: 1,
+		Servers: []scanner.ServerReport{
+			{Name: "filesystem", Path: "mcp.json", Rating: &scanner.SecurityRating{RiskScore: 9.4, Category: "UNTRUSTED", Vulnerabilities: []string{"CVE-2025-0001"}}},
+			{Name: "git", Path: "mcp.json"},
+		},
+		Secrets: []scanner.SecretFinding{
+			{Kind: "github", Key: "env.GITHUB_TOKEN", ServerName: "git", Confidence: scanner.ConfidenceHigh, Occurrences: map[string][]int{"mcp.json": {12}}},
+		},
+	}
+}
+
+func TestPostPRComment_Creates(t *testing.T) {
+	fake := &fakeGitHub{existing: []issueComment{{ID: 5, Body: "LGTM"}}}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+
+	c, err := NewGitHubClientFromEnv(testEnv(srv.URL, nil))
+	require.NoError(t, err)
+	url, err := c.PostPRComment(context.Background(), testSummary())
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/tools/pull/42#issuecomment-1", url)
+
+	assert.Equal(t, http.MethodPost, fake.method)
+	assert.Equal(t, "/repos/acme/tools/issues/42/comments", fake.path)
+	assert.Contains(t, fake.body, PRCommentMarker)
+	assert.Contains(t, fake.body, "## run-mcp scan")
+	assert.Contains(t, fake.body, "| CRITICAL | 1 |")
+	assert.Contains(t, fake.body, "| UNRATED | 1 |")
+	assert.Contains(t, fake.body, "- **CRITICAL** `filesystem` in `mcp.json`: risk 9.4/10, UNTRUSTED (CVE-2025-0001)")
+	assert.Contains(t, fake.body, "github secret in `env.GITHUB_TOKEN` of server `git` (HIGH confidence): `mcp.json:12`")
+}
+
+func TestPostPRComment_UpdatesExisting(t *testing.T) {
+	fake := &fakeGitHub{existing: []issueComment{{ID: 5, Body: "LGTM"}, {ID: 9, Body: PRCommentMarker + "\nold"}}}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+
+	// The pull request number comes from GITHUB_REF without a commit lookup.
+	c, err := NewGitHubClientFromEnv(testEnv(srv.URL, map[string]string{GitHubRefEnv: "refs/pull/42/merge"}))
+	require.NoError(t, err)
+	_, err = c.PostPRComment(context.Background(), testSummary())
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPatch, fake.method)
+	assert.Equal(t, "/repos/acme/tools/issues/comments/9", fake.path)
+	assert.Contains(t, fake.body, "| CRITICAL | 1 |")
+}
+
+func TestNewGitHubClientFromEnv_Missing(t *testing.T) {
+	_, err := NewGitHubClientFromEnv(func(k string) string {
+		if k == GitHubRepositoryEnv {
+			return "acme/tools"
+		}
+		return ""
+	})
+	require.EqualError(t, err, "missing $GITHUB_TOKEN, $GITHUB_SHA")
+}
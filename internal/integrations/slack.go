@@ -0,0 +1,130 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ensigniasec/run-mcp/internal/scanner"
+)
+
+// Attachment colours of the Slack notification.
+const (
+	SlackColorCritical = "#d00000"
+	SlackColorHigh     = "#ff8c00"
+)
+
+// slackMaxServers caps the servers listed per tier so the message stays within Slack's block limits.
+const slackMaxServers = 10
+
+// SlackMessage is an incoming-webhook payload using Block Kit. Text is the notification fallback.
+type SlackMessage struct {
+	Text        string            `json:"text"`
+	Blocks      []SlackBlock      `json:"blocks"`
+	Attachments []SlackAttachment `json:"attachments,omitempty"`
+}
+
+// SlackAttachment is a coloured bar holding the blocks of one risk tier.
+type SlackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a header, section or context block.
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+// SlackText is a plain_text or mrkdwn text object.
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// NewSlackMessage summarises the critical and high risk servers and the number of exposed
+// secrets. Servers allowed by local policy are left out. scanURL, the --upload result, is
+// linked when set.
+func NewSlackMessage(summary scanner.ScanSummary, scanURL string) SlackMessage {
+	byTier := map[string][]string{}
+	for _, s := range summary.Servers {
+		if s.Rating == nil || s.LocalPolicy == "allowed" {
+			continue
+		}
+		tier := scanner.RiskTier(s.Rating.RiskScore)
+		line := fmt.Sprintf("• *%s* (%s): risk %.1f/10", s.Name, s.Path, s.Rating.RiskScore)
+		if len(s.Rating.Vulnerabilities) > 0 {
+			line += ", " + strings.Join(s.Rating.Vulnerabilities, ", ")
+		}
+		byTier[tier] = append(byTier[tier], line)
+	}
+	critical, high := len(byTier["CRITICAL"]), len(byTier["HIGH"])
+	secrets := len(summary.Secrets)
+
+	headline := fmt.Sprintf("run-mcp: %d critical, %d high risk servers, %d exposed secrets", critical, high, secrets)
+	if summary.Environment != "" {
+		headline += " (" + summary.Environment + ")"
+	}
+	msg := SlackMessage{
+		Text: headline,
+		Blocks: []SlackBlock{
+			{Type: "header", Text: &SlackText{Type: "plain_text", Text: "run-mcp scan"}},
+			{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: fmt.Sprintf(
+				"Scanned %d files and found %d servers.\n*Critical:* %d   *High:* %d   *Secrets:* %d",
+				summary.ScannedFiles, summary.TotalServers, critical, high, secrets)}},
+		},
+	}
+	if summary.Environment != "" {
+		msg.Blocks = append(msg.Blocks, SlackBlock{Type: "context", Elements: []SlackText{{Type: "mrkdwn", Text: "Environment: " + summary.Environment}}})
+	}
+	if scanURL != "" {
+		msg.Blocks = append(msg.Blocks, SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: fmt.Sprintf("<%s|View the full scan>", scanURL)}})
+	}
+
+	for _, t := range []struct{ tier, title, color string }{
+		{"CRITICAL", "Critical risk", SlackColorCritical},
+		{"HIGH", "High risk", SlackColorHigh},
+	} {
+		lines := byTier[t.tier]
+		if len(lines) == 0 {
+			continue
+		}
+		if len(lines) > slackMaxServers {
+			lines = append(lines[:slackMaxServers], fmt.Sprintf("…and %d more", len(byTier[t.tier])-slackMaxServers))
+		}
+		msg.Attachments = append(msg.Attachments, SlackAttachment{
+			Color:  t.color,
+			Blocks: []SlackBlock{{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: "*" + t.title + "*\n" + strings.Join(lines, "\n")}}},
+		})
+	}
+	return msg
+}
+
+// PostSlack sends msg to a Slack incoming webhook. Any non-2xx response is returned as an error.
+func PostSlack(ctx context.Context, webhookURL string, msg SlackMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{Timeout: requestTimeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
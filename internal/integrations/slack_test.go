@@ -0,0 +1,58 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ensigniasec/run-mcp/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostSlack_Blocks(t *testing.T) {
+	var got SlackMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	summary := testSummary()
+	summary.Servers = append(summary.Servers,
+		scanner.ServerReport{Name: "fetch", Path: "mcp.json", Rating: &scanner.SecurityRating{RiskScore: 7.5}},
+		scanner.ServerReport{Name: "memory", Path: "mcp.json", Rating: &scanner.SecurityRating{RiskScore: 9.9}, LocalPolicy: "allowed"},
+		scanner.ServerReport{Name: "time", Path: "mcp.json", Rating: &scanner.SecurityRating{RiskScore: 2}},
+	)
+	msg := NewSlackMessage(summary, "https://app.example.com/scans/1")
+	require.NoError(t, PostSlack(context.Background(), srv.URL, msg))
+
+	assert.Equal(t, "run-mcp: 1 critical, 1 high risk servers, 1 exposed secrets", got.Text)
+	require.Len(t, got.Blocks, 3)
+	assert.Equal(t, "header", got.Blocks[0].Type)
+	assert.Equal(t, "section", got.Blocks[1].Type)
+	assert.Contains(t, got.Blocks[1].Text.Text, "*Critical:* 1   *High:* 1   *Secrets:* 1")
+	assert.Equal(t, "<https://app.example.com/scans/1|View the full scan>", got.Blocks[2].Text.Text)
+
+	require.Len(t, got.Attachments, 2)
+	assert.Equal(t, SlackColorCritical, got.Attachments[0].Color)
+	assert.Contains(t, got.Attachments[0].Blocks[0].Text.Text, "*filesystem*")
+	assert.NotContains(t, got.Attachments[0].Blocks[0].Text.Text, "memory")
+	assert.Equal(t, SlackColorHigh, got.Attachments[1].Color)
+	assert.Contains(t, got.Attachments[1].Blocks[0].Text.Text, "*fetch*")
+}
+
+func TestPostSlack_Non2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	err := PostSlack(context.Background(), srv.URL, NewSlackMessage(scanner.ScanSummary{}, ""))
+	require.ErrorContains(t, err, "403 Forbidden: invalid_token")
+}
@@ -0,0 +1,213 @@
+// Package metrics exposes scan statistics for Prometheus to scrape. Metrics are rendered in
+// the Prometheus text exposition format (version 0.0.4) without a client library dependency.
+// The Registry update methods are safe on a nil receiver so callers need not check whether
+// metrics are enabled.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ContentType is the Content-Type of the /metrics response.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Server tiers reported by run_mcp_servers_total.
+const (
+	TierCritical   = "critical"
+	TierHigh       = "high"
+	TierMedium     = "medium"
+	TierLow        = "low"
+	TierDiscovered = "discovered"
+)
+
+// API request outcomes reported by run_mcp_api_requests_total.
+const (
+	StatusOK          = "ok"
+	StatusError       = "error"
+	StatusRateLimited = "rate_limited"
+)
+
+//nolint:gochecknoglobals // Fixed label orderings so every series is always exported.
+var (
+	tiers    = []string{TierCritical, TierHigh, TierMedium, TierLow, TierDiscovered}
+	statuses = []string{StatusOK, StatusError, StatusRateLimited}
+)
+
+// readHeaderTimeout bounds how long a scrape may take to send its headers.
+const readHeaderTimeout = 5 * time.Second
+
+// Registry holds the current scan statistics.
+type Registry struct {
+	mu           sync.Mutex
+	scanDuration time.Duration
+	servers      map[string]int
+	secrets      int
+	files        int
+	apiRequests  map[string]int
+}
+
+// NewRegistry returns a registry with every metric at zero.
+func NewRegistry() *Registry {
+	return &Registry{
+		servers:     make(map[string]int),
+		apiRequests: make(map[string]int),
+	}
+}
+
+// SetScanDuration records how long the scan has taken so far.
+func (r *Registry) SetScanDuration(d time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.scanDuration = d
+	r.mu.Unlock()
+}
+
+// AddServers adds n servers to tier.
+func (r *Registry) AddServers(tier string, n int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.servers[tier] += n
+	r.mu.Unlock()
+}
+
+// SetServers replaces the per-tier server counts, e.g. once ratings have been applied.
+// Tiers missing from counts are reset to zero.
+func (r *Registry) SetServers(counts map[string]int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.servers = make(map[string]int, len(counts))
+	for tier, n := range counts {
+		r.servers[tier] = n
+	}
+	r.mu.Unlock()
+}
+
+// AddSecrets adds n secret findings.
+func (r *Registry) AddSecrets(n int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.secrets += n
+	r.mu.Unlock()
+}
+
+// SetSecrets replaces the secret finding count, e.g. after findings have been filtered.
+func (r *Registry) SetSecrets(n int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.secrets = n
+	r.mu.Unlock()
+}
+
+// IncFilesScanned counts one scanned config file.
+func (r *Registry) IncFilesScanned() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.files++
+	r.mu.Unlock()
+}
+
+// IncAPIRequest counts one ratings API request with the given outcome (StatusOK, StatusError
+// or StatusRateLimited).
+func (r *Registry) IncAPIRequest(status string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.apiRequests[status]++
+	r.mu.Unlock()
+}
+
+// WriteTo renders all metrics in the Prometheus text format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+	fmt.Fprintln(cw, "# HELP run_mcp_scan_duration_seconds Duration of the current scan in seconds.")
+	fmt.Fprintln(cw, "# TYPE run_mcp_scan_duration_seconds gauge")
+	fmt.Fprintf(cw, "run_mcp_scan_duration_seconds %g\n", r.scanDuration.Seconds())
+
+	fmt.Fprintln(cw, "# HELP run_mcp_servers_total MCP servers found, by risk tier.")
+	fmt.Fprintln(cw, "# TYPE run_mcp_servers_total gauge")
+	for _, tier := range tiers {
+		fmt.Fprintf(cw, "run_mcp_servers_total{tier=%q} %d\n", tier, r.servers[tier])
+	}
+
+	fmt.Fprintln(cw, "# HELP run_mcp_secrets_total Exposed secrets found.")
+	fmt.Fprintln(cw, "# TYPE run_mcp_secrets_total gauge")
+	fmt.Fprintf(cw, "run_mcp_secrets_total %d\n", r.secrets)
+
+	fmt.Fprintln(cw, "# HELP run_mcp_files_scanned_total Config files scanned.")
+	fmt.Fprintln(cw, "# TYPE run_mcp_files_scanned_total counter")
+	fmt.Fprintf(cw, "run_mcp_files_scanned_total %d\n", r.files)
+
+	fmt.Fprintln(cw, "# HELP run_mcp_api_requests_total Ratings API requests, by outcome.")
+	fmt.Fprintln(cw, "# TYPE run_mcp_api_requests_total counter")
+	for _, status := range statuses {
+		fmt.Fprintf(cw, "run_mcp_api_requests_total{status=%q} %d\n", status, r.apiRequests[status])
+	}
+	return cw.n, cw.err
+}
+
+// ServeHTTP serves the metrics page.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_, _ = r.WriteTo(w)
+}
+
+// Serve starts an HTTP server on addr serving the registry at /metrics. The listener is
+// bound before Serve returns so address errors are reported immediately. The returned
+// shutdown func stops the server.
+func Serve(addr string, r *Registry) (func(context.Context) error, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Warnf("Metrics server stopped: %v", err)
+		}
+	}()
+	return srv.Shutdown, nil
+}
+
+// countingWriter tracks bytes written and the first error for WriteTo.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, r *Registry) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+	return rec.Body.String()
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	r := NewRegistry()
+	body := scrape(t, r)
+	assert.Contains(t, body, "# TYPE run_mcp_servers_total gauge")
+	assert.Contains(t, body, `run_mcp_servers_total{tier="critical"} 0`)
+	assert.Contains(t, body, `run_mcp_api_requests_total{status="rate_limited"} 0`)
+
+	r.SetScanDuration(1500 * time.Millisecond)
+	r.IncFilesScanned()
+	r.IncFilesScanned()
+	r.AddServers(TierDiscovered, 3)
+	r.AddSecrets(2)
+	r.IncAPIRequest(StatusOK)
+	r.IncAPIRequest(StatusRateLimited)
+	r.IncAPIRequest(StatusRateLimited)
+
+	body = scrape(t, r)
+	assert.Contains(t, body, "run_mcp_scan_duration_seconds 1.5\n")
+	assert.Contains(t, body, "run_mcp_files_scanned_total 2\n")
+	assert.Contains(t, body, `run_mcp_servers_total{tier="discovered"} 3`)
+	assert.Contains(t, body, "run_mcp_secrets_total 2\n")
+	assert.Contains(t, body, `run_mcp_api_requests_total{status="ok"} 1`)
+	assert.Contains(t, body, `run_mcp_api_requests_total{status="rate_limited"} 2`)
+
+	// Final counts replace the running totals.
+	r.SetServers(map[string]int{TierHigh: 1, TierLow: 2})
+	r.SetSecrets(1)
+	body = scrape(t, r)
+	assert.Contains(t, body, `run_mcp_servers_total{tier="discovered"} 0`)
+	assert.Contains(t, body, `run_mcp_servers_total{tier="high"} 1`)
+	assert.Contains(t, body, `run_mcp_servers_total{tier="low"} 2`)
+	assert.Contains(t, body, "run_mcp_secrets_total 1\n")
+}
+
+func TestRegistry_NilIsNoop(t *testing.T) {
+	var r *Registry
+	assert.NotPanics(t, func() {
+		r.SetScanDuration(time.Second)
+		r.AddServers(TierHigh, 1)
+		r.SetServers(nil)
+		r.AddSecrets(1)
+		r.SetSecrets(1)
+		r.IncFilesScanned()
+		r.IncAPIRequest(StatusError)
+	})
+}
+
+func TestServe(t *testing.T) {
+	r := NewRegistry()
+	r.IncFilesScanned()
+	shutdown, err := Serve("127.0.0.1:0", r)
+	require.NoError(t, err)
+
+	_, err = Serve("not-an-address", r)
+	assert.Error(t, err)
+
+	require.NoError(t, shutdown(context.Background()))
+}
@@ -0,0 +1,83 @@
+package proxy
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// maxMessageSize bounds a single newline-delimited JSON-RPC message.
+const maxMessageSize = 10 * 1024 * 1024
+
+// Message is a single framed JSON-RPC message. Raw is forwarded verbatim;
+// the decoded envelope fields are only used for logging and policy decisions.
+type Message struct {
+	Raw    []byte
+	ID     json.RawMessage
+	Method string
+	Params json.RawMessage
+}
+
+// IsRequest reports whether the message is a request (has both a method and an ID).
+func (m Message) IsRequest() bool { return m.Method != "" && len(m.ID) > 0 }
+
+// envelope is the subset of a JSON-RPC 2.0 message needed to route it.
+type envelope struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Reader reads newline-delimited JSON-RPC messages, as used by the MCP stdio transport.
+type Reader struct {
+	sc *bufio.Scanner
+}
+
+// NewReader returns a Reader over r.
+func NewReader(r io.Reader) *Reader {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxMessageSize) //nolint:mnd // initial buffer size
+	return &Reader{sc: sc}
+}
+
+// Read returns the next message. Lines that are not valid JSON are still returned
+// (with an empty envelope) so the proxy stays transparent. Returns io.EOF at end of input.
+func (r *Reader) Read() (Message, error) {
+	for r.sc.Scan() {
+		line := r.sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		msg := Message{Raw: append([]byte(nil), line...)}
+		var env envelope
+		if err := json.Unmarshal(line, &env); err == nil {
+			msg.ID, msg.Method, msg.Params = env.ID, env.Method, env.Params
+		}
+		return msg, nil
+	}
+	if err := r.sc.Err(); err != nil {
+		return Message{}, err
+	}
+	return Message{}, io.EOF
+}
+
+// Writer writes newline-delimited messages and is safe for concurrent use.
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriter returns a Writer over w.
+func NewWriter(w io.Writer) *Writer { return &Writer{w: w} }
+
+// Write writes raw followed by a newline as a single frame.
+func (w *Writer) Write(raw []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	frame := make([]byte, 0, len(raw)+1)
+	frame = append(frame, raw...)
+	frame = append(frame, '\n')
+	_, err := w.w.Write(frame)
+	return err
+}
@@ -0,0 +1,57 @@
+package proxy
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/ensigniasec/run-mcp/internal/storage"
+)
+
+// ErrServerDenied is returned when the proxied server is on the local denylist.
+var ErrServerDenied = errors.New("server is on the local denylist")
+
+// Policy decides which tool calls may pass through the proxy.
+// The zero value allows everything.
+type Policy struct {
+	deniedTools  map[string]struct{}
+	allowedTools map[string]struct{}
+}
+
+// NewPolicy builds a policy for serverName from the local allow/deny lists.
+// Entries under the "tool" type are matched against tools/call names; when any tools are
+// allowlisted, all other tools are blocked. Returns ErrServerDenied if the server itself is denied.
+func NewPolicy(st *storage.Storage, serverName string) (Policy, error) {
+	var p Policy
+	if st == nil {
+		return p, nil
+	}
+	if slices.Contains(st.Data.Denylist["server"], serverName) {
+		return p, ErrServerDenied
+	}
+	p.deniedTools = toSet(st.Data.Denylist["tool"])
+	p.allowedTools = toSet(st.Data.Allowlist["tool"])
+	return p, nil
+}
+
+// AllowsTool reports whether a tools/call for name may be forwarded.
+func (p Policy) AllowsTool(name string) bool {
+	if _, denied := p.deniedTools[name]; denied {
+		return false
+	}
+	if len(p.allowedTools) > 0 {
+		_, ok := p.allowedTools[name]
+		return ok
+	}
+	return true
+}
+
+func toSet(items []string) map[string]struct{} {
+	if len(items) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(items))
+	for _, it := range items {
+		set[it] = struct{}{}
+	}
+	return set
+}
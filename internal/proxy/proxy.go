@@ -0,0 +1,193 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ensigniasec/run-mcp/internal/scanner"
+)
+
+// Directions used in the conversation log.
+const (
+	ClientToServer = "client->server"
+	ServerToClient = "server->client"
+	ProxyToClient  = "proxy->client"
+)
+
+// errCodePolicyDenied is the JSON-RPC error code returned for blocked tool calls.
+// It sits in the implementation-defined server error range.
+const errCodePolicyDenied = -32001
+
+// errCodeInvalidParams is the standard JSON-RPC code for tools/call requests without a tool name.
+const errCodeInvalidParams = -32602
+
+// Proxy bridges an MCP client and server over stdio, recording every message.
+type Proxy struct {
+	log           io.Writer
+	logMu         sync.Mutex
+	redactSecrets bool
+	policy        Policy
+	now           func() time.Time
+}
+
+// Option configures a Proxy.
+type Option func(*Proxy)
+
+// WithLog sets the destination for the timestamped conversation log.
+func WithLog(w io.Writer) Option {
+	return func(p *Proxy) { p.log = w }
+}
+
+// WithRedactSecrets masks known provider tokens in logged payloads. Forwarded traffic is untouched.
+func WithRedactSecrets(enabled bool) Option {
+	return func(p *Proxy) { p.redactSecrets = enabled }
+}
+
+// WithPolicy enforces the given tool policy on tools/call requests.
+func WithPolicy(policy Policy) Option {
+	return func(p *Proxy) { p.policy = policy }
+}
+
+// New returns a Proxy. Without options it logs nowhere and forwards everything.
+func New(opts ...Option) *Proxy {
+	p := &Proxy{log: io.Discard, now: time.Now}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Run starts cmd and bridges clientIn/clientOut to its stdin/stdout until the server exits
+// or ctx is done. The server is killed on return if it is still running.
+func (p *Proxy) Run(ctx context.Context, cmd *exec.Cmd, clientIn io.Reader, clientOut io.Writer) error {
+	serverIn, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	serverOut, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start server: %w", err)
+	}
+
+	bridgeErr := p.Bridge(ctx, clientIn, clientOut, serverIn, serverOut)
+	if bridgeErr != nil {
+		_ = cmd.Process.Kill()
+	}
+	waitErr := cmd.Wait()
+	if bridgeErr != nil {
+		return bridgeErr
+	}
+	if waitErr != nil {
+		return fmt.Errorf("server exited: %w", waitErr)
+	}
+	return nil
+}
+
+// Bridge pumps messages in both directions until the server closes its output or ctx is done.
+// When the client closes its input, the server's input is closed so it can shut down cleanly.
+func (p *Proxy) Bridge(ctx context.Context, clientIn io.Reader, clientOut io.Writer, serverIn io.WriteCloser, serverOut io.Reader) error {
+	toClient := NewWriter(clientOut)
+	toServer := NewWriter(serverIn)
+
+	done := make(chan error, 1)
+	go func() {
+		err := p.pump(NewReader(serverOut), toClient, ServerToClient, nil)
+		done <- err
+	}()
+	go func() {
+		err := p.pump(NewReader(clientIn), toServer, ClientToServer, toClient)
+		if err != nil {
+			logrus.Debugf("proxy: client stream ended: %v", err)
+		}
+		_ = serverIn.Close()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pump copies messages from r to w, logging each one. For client traffic, reply is used
+// to answer blocked tool calls directly instead of forwarding them.
+func (p *Proxy) pump(r *Reader, w *Writer, direction string, reply *Writer) error {
+	for {
+		msg, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		p.record(direction, msg.Raw)
+
+		if reply != nil {
+			if blocked, resp := p.intercept(msg); blocked {
+				p.record(ProxyToClient, resp)
+				if err := reply.Write(resp); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if err := w.Write(msg.Raw); err != nil {
+			return err
+		}
+	}
+}
+
+// intercept returns a JSON-RPC error response when msg is a tools/call the policy blocks.
+// Calls whose params do not name a tool are rejected rather than forwarded unchecked.
+func (p *Proxy) intercept(msg Message) (bool, []byte) {
+	if !msg.IsRequest() || msg.Method != "tools/call" {
+		return false, nil
+	}
+	var params struct {
+		Name string `json:"name"`
+	}
+	code, message := errCodePolicyDenied, ""
+	switch err := json.Unmarshal(msg.Params, &params); {
+	case err != nil || params.Name == "":
+		code, message = errCodeInvalidParams, "tools/call params must name a tool"
+	case !p.policy.AllowsTool(params.Name):
+		message = fmt.Sprintf("tool %q blocked by run-mcp local policy", params.Name)
+	default:
+		return false, nil
+	}
+	resp, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      msg.ID,
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+	if err != nil {
+		return false, nil
+	}
+	return true, resp
+}
+
+// record writes one timestamped log line for a message.
+func (p *Proxy) record(direction string, raw []byte) {
+	payload := string(raw)
+	if p.redactSecrets {
+		payload = scanner.RedactKnownSecrets(payload)
+	}
+	p.logMu.Lock()
+	defer p.logMu.Unlock()
+	fmt.Fprintf(p.log, "%s %s %s\n", p.now().UTC().Format(time.RFC3339Nano), direction, payload)
+}
@@ -0,0 +1,203 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ensigniasec/run-mcp/internal/allowlist"
+	"github.com/ensigniasec/run-mcp/internal/storage"
+)
+
+// mockServer is an in-process MCP server implementing the initialize/tools/list round-trip.
+func mockServer(r io.Reader, w io.WriteCloser) {
+	defer w.Close()
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.Unmarshal(sc.Bytes(), &req); err != nil || len(req.ID) == 0 {
+			continue
+		}
+		var result interface{}
+		switch req.Method {
+		case "initialize":
+			result = map[string]interface{}{"serverInfo": map[string]string{"name": "mock"}, "capabilities": map[string]interface{}{"tools": map[string]interface{}{}}}
+		case "tools/list":
+			result = map[string]interface{}{"tools": []map[string]string{{"name": "read_file"}, {"name": "delete_file"}}}
+		case "tools/call":
+			result = map[string]interface{}{"content": []map[string]string{{"type": "text", "text": "ok"}}}
+		}
+		b, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+		_, _ = w.Write(append(b, '\n'))
+	}
+}
+
+// syncBuffer is a goroutine-safe bytes.Buffer for capturing logs.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// runBridge wires a proxy between the given client input and the mock server and returns client output.
+func runBridge(t *testing.T, p *Proxy, clientInput string) string {
+	t.Helper()
+	serverInR, serverInW := io.Pipe()
+	serverOutR, serverOutW := io.Pipe()
+	go mockServer(serverInR, serverOutW)
+
+	var clientOut bytes.Buffer
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, p.Bridge(ctx, strings.NewReader(clientInput), &clientOut, serverInW, serverOutR))
+	return clientOut.String()
+}
+
+func TestProxy_ForwardsAndLogsRoundTrip(t *testing.T) {
+	var log syncBuffer
+	p := New(WithLog(&log))
+
+	out := runBridge(t, p,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`+"\n"+
+			`{"jsonrpc":"2.0","method":"notifications/initialized"}`+"\n"+
+			`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`+"\n")
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"name":"mock"`)
+	assert.Contains(t, lines[1], `"read_file"`)
+
+	logged := log.String()
+	assert.Equal(t, 3, strings.Count(logged, ClientToServer))
+	assert.Equal(t, 2, strings.Count(logged, ServerToClient))
+	// Each line starts with an RFC 3339 timestamp.
+	for _, line := range strings.Split(strings.TrimSpace(logged), "\n") {
+		ts := strings.SplitN(line, " ", 2)[0]
+		_, err := time.Parse(time.RFC3339Nano, ts)
+		require.NoError(t, err, line)
+	}
+}
+
+func TestProxy_RedactsSecretsInLogOnly(t *testing.T) {
+	var log syncBuffer
+	p := New(WithLog(&log), WithRedactSecrets(true))
+
+	out := runBridge(t, p, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"read_file","arguments":{"key":"AKIA1234567890ABCDEF"}}}`+"\n")
+
+	assert.Contains(t, out, `"ok"`)
+	assert.NotContains(t, log.String(), "AKIA1234567890ABCDEF")
+	assert.Contains(t, log.String(), "AKIA****")
+}
+
+func TestProxy_PolicyBlocksDeniedTool(t *testing.T) {
+	st := &storage.Storage{Data: storage.Data{
+		Allowlist: map[string][]string{},
+		Denylist:  map[string][]string{"tool": {"delete_file"}},
+	}}
+	policy, err := NewPolicy(st, "mock")
+	require.NoError(t, err)
+
+	var log syncBuffer
+	p := New(WithLog(&log), WithPolicy(policy))
+	out := runBridge(t, p,
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"delete_file"}}`+"\n"+
+			`{"jsonrpc":"2.0","id":8,"method":"tools/call","params":{"name":"read_file"}}`+"\n")
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"code":-32001`)
+	assert.Contains(t, lines[0], `"id":7`)
+	assert.Contains(t, lines[0], `blocked by run-mcp local policy`)
+	assert.Contains(t, lines[1], `"id":8`)
+	assert.Contains(t, lines[1], `"result"`)
+	assert.Contains(t, log.String(), ProxyToClient)
+}
+
+func TestProxy_RejectsMalformedToolCall(t *testing.T) {
+	var log syncBuffer
+	p := New(WithLog(&log))
+	out := runBridge(t, p,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":["delete_file"]}`+"\n"+
+			`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{}}`+"\n")
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	for i, id := range []string{`"id":3`, `"id":4`} {
+		assert.Contains(t, lines[i], id)
+		assert.Contains(t, lines[i], `"code":-32602`)
+		assert.NotContains(t, lines[i], `"result"`)
+	}
+}
+
+func TestProxy_PolicyFromDenylist(t *testing.T) {
+	v, err := allowlist.NewVerifier(filepath.Join(t.TempDir(), "storage.json"))
+	require.NoError(t, err)
+	require.NoError(t, v.AddToDenylist("tool", "delete_file", "sha256:0f1e2d"))
+	require.NoError(t, v.AddToDenylist("server", "evil", "sha256:a1b2c3"))
+
+	_, err = NewPolicy(v.Storage, "evil")
+	require.ErrorIs(t, err, ErrServerDenied)
+
+	policy, err := NewPolicy(v.Storage, "mock")
+	require.NoError(t, err)
+	out := runBridge(t, New(WithLog(io.Discard), WithPolicy(policy)),
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"delete_file"}}`+"\n")
+	assert.Contains(t, out, `blocked by run-mcp local policy`)
+}
+
+func TestNewPolicy(t *testing.T) {
+	st := &storage.Storage{Data: storage.Data{
+		Allowlist: map[string][]string{"tool": {"read_file"}},
+		Denylist:  map[string][]string{"server": {"evil"}},
+	}}
+	_, err := NewPolicy(st, "evil")
+	require.ErrorIs(t, err, ErrServerDenied)
+
+	policy, err := NewPolicy(st, "good")
+	require.NoError(t, err)
+	assert.True(t, policy.AllowsTool("read_file"))
+	assert.False(t, policy.AllowsTool("write_file"))
+
+	assert.True(t, Policy{}.AllowsTool("anything"))
+}
+
+func TestReader_PassesThroughNonJSON(t *testing.T) {
+	r := NewReader(strings.NewReader("not json\n\n" + `{"jsonrpc":"2.0","id":"a","method":"ping"}` + "\n"))
+	msg, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "not json", string(msg.Raw))
+	assert.False(t, msg.IsRequest())
+
+	msg, err = r.Read()
+	require.NoError(t, err)
+	assert.True(t, msg.IsRequest())
+	assert.Equal(t, "ping", msg.Method)
+
+	_, err = r.Read()
+	require.ErrorIs(t, err, io.EOF)
+}
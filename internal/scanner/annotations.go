@@ -0,0 +1,93 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Annotation is a remediation note from an annotations file (scan --annotations-file), such
+// as a ticket ID, owner and due date. Match is a server name or secret kind, or a
+// filepath.Match pattern of one.
+type Annotation struct {
+	Match string `yaml:"match" json:"match"`
+	Note  string `yaml:"note" json:"note"`
+	Owner string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Due   string `yaml:"due,omitempty" json:"due,omitempty"`
+}
+
+// Validate checks the required fields, the pattern syntax and the due date format.
+func (a Annotation) Validate() error {
+	if a.Match == "" || a.Note == "" {
+		return fmt.Errorf("annotation %+v: match and note must be non-empty", a)
+	}
+	if _, err := filepath.Match(a.Match, ""); err != nil {
+		return fmt.Errorf("annotation for %q: invalid pattern: %w", a.Match, err)
+	}
+	if a.Due != "" {
+		if _, err := time.Parse(suppressionDateLayout, a.Due); err != nil {
+			return fmt.Errorf("annotation for %q has invalid due %q: want YYYY-MM-DD", a.Match, a.Due)
+		}
+	}
+	return nil
+}
+
+// LoadAnnotations reads a YAML list of annotations. Unlike LoadSuppressions, a missing file
+// is an error, since the file is only read when named explicitly.
+func LoadAnnotations(path string) ([]Annotation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Annotation
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, e := range entries {
+		if err := e.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return entries, nil
+}
+
+// Annotate sets Annotation on every server matching an entry by name and on every secret
+// finding matching one by kind. An exact match wins over a pattern; otherwise the first
+// matching entry in file order is used.
+func Annotate(summary *ScanSummary, annotations []Annotation) {
+	if len(annotations) == 0 {
+		return
+	}
+	for _, servers := range [][]ServerReport{summary.Servers, summary.Suppressed} {
+		for i := range servers {
+			s := &servers[i]
+			s.Annotation = matchAnnotation(annotations, s.Name)
+			annotateSecrets(s.Secrets, annotations)
+		}
+	}
+	annotateSecrets(summary.Secrets, annotations)
+}
+
+func annotateSecrets(findings []SecretFinding, annotations []Annotation) {
+	for i := range findings {
+		findings[i].Annotation = matchAnnotation(annotations, findings[i].Kind)
+	}
+}
+
+// matchAnnotation returns a copy of the entry matching name, or nil.
+func matchAnnotation(annotations []Annotation, name string) *Annotation {
+	for _, a := range annotations {
+		if a.Match == name {
+			return &a
+		}
+	}
+	for _, a := range annotations {
+		if ok, _ := filepath.Match(a.Match, name); ok {
+			return &a
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, t.Name()+".yaml")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		entries, err := LoadAnnotations(write(t, `
+- match: noisy
+  note: "JIRA-123: replace with the vetted fork"
+  owner: team-alpha
+  due: "2025-12-31"
+- match: "OpenAI*"
+  note: rotate key
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []Annotation{
+			{Match: "noisy", Note: "JIRA-123: replace with the vetted fork", Owner: "team-alpha", Due: "2025-12-31"},
+			{Match: "OpenAI*", Note: "rotate key"},
+		}, entries)
+	})
+
+	for name, content := range map[string]string{
+		"missing note": "- match: noisy\n",
+		"bad pattern":  "- match: \"[\"\n  note: x\n",
+		"bad due":      "- match: noisy\n  note: x\n  due: soon\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadAnnotations(write(t, content))
+			require.Error(t, err)
+		})
+	}
+
+	_, err := LoadAnnotations(filepath.Join(dir, "missing.yaml"))
+	require.Error(t, err, "a named file must exist")
+}
+
+func TestAnnotate(t *testing.T) {
+	summary := GenerateSummary(suppressResult())
+	Annotate(&summary, []Annotation{
+		{Match: "*", Note: "catch-all"},
+		{Match: "noisy", Note: "JIRA-123"},
+		{Match: "OpenAI *", Note: "rotate key", Owner: "team-alpha"},
+	})
+
+	byName := map[string]*Annotation{}
+	for _, s := range summary.Servers {
+		byName[s.Name] = s.Annotation
+	}
+	require.NotNil(t, byName["noisy"])
+	assert.Equal(t, "JIRA-123", byName["noisy"].Note, "an exact match wins over an earlier pattern")
+	require.NotNil(t, byName["kept"])
+	assert.Equal(t, "catch-all", byName["kept"].Note)
+
+	require.Len(t, summary.Secrets, 1)
+	require.NotNil(t, summary.Secrets[0].Annotation)
+	assert.Equal(t, "catch-all", summary.Secrets[0].Annotation.Note, "patterns are tried in file order")
+
+	var buf bytes.Buffer
+	writeTextSummary(&buf, summary, TextLayout{})
+	assert.Contains(t, buf.String(), "    Note: JIRA-123\n")
+
+	Annotate(&summary, nil)
+	assert.NotNil(t, summary.Servers[0].Annotation, "no entries leave annotations unchanged")
+
+	Annotate(&summary, []Annotation{{Match: "OpenAI *", Note: "rotate key"}})
+	assert.Nil(t, summary.Servers[0].Annotation)
+	require.NotNil(t, summary.Secrets[0].Annotation)
+	assert.Equal(t, "rotate key", summary.Secrets[0].Annotation.Note, "secrets match by kind")
+}
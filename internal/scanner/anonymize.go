@@ -0,0 +1,196 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+)
+
+// anonymizedHashLen is the number of hex characters kept from the path hash.
+const anonymizedHashLen = 8
+
+// AnonymizePath returns a stable stand-in for path: the first 8 hex characters of
+// SHA-256(salt + path) followed by the original file extension.
+func AnonymizePath(path, salt string) string {
+	if path == "" {
+		return ""
+	}
+	return anonymizedHash(path, salt) + filepath.Ext(path)
+}
+
+// anonymizedHash returns the first 8 hex characters of SHA-256(salt + s).
+func anonymizedHash(s, salt string) string {
+	h := sha256.Sum256([]byte(salt + s))
+	return hex.EncodeToString(h[:])[:anonymizedHashLen]
+}
+
+// RedactServerName returns a stable stand-in for a server name, hashed like AnonymizePath:
+// the first 8 hex characters of SHA-256(salt + name).
+func RedactServerName(name, salt string) string {
+	if name == "" {
+		return ""
+	}
+	return anonymizedHash(name, salt)
+}
+
+// RedactServerNames replaces every server name in the summary (server and canonical names,
+// suppressed servers included, and the server names of secret and misconfiguration
+// findings) with RedactServerName.
+func RedactServerNames(summary *ScanSummary, salt string) {
+	if summary == nil {
+		return
+	}
+	redact := func(name string) string { return RedactServerName(name, salt) }
+	for _, servers := range [][]ServerReport{summary.Servers, summary.Suppressed} {
+		for i := range servers {
+			servers[i].Name = redact(servers[i].Name)
+			servers[i].CanonicalName = redact(servers[i].CanonicalName)
+			servers[i].Secrets = renameFindingServers(servers[i].Secrets, redact)
+		}
+	}
+	summary.Secrets = renameFindingServers(summary.Secrets, redact)
+	summary.MisconfigFindings = renameMisconfigServers(summary.MisconfigFindings, redact)
+}
+
+// RedactResultServerNames is RedactServerNames for a scan result, whose server names are
+// used by the SBOM formats.
+func RedactResultServerNames(result *ScanResult, salt string) {
+	if result == nil {
+		return
+	}
+	redact := func(name string) string { return RedactServerName(name, salt) }
+	for i := range result.Files {
+		RedactFileServerNames(&result.Files[i], salt)
+	}
+	result.Servers = renameServers(result.Servers, redact)
+	result.SecretFindings = renameFindingServers(result.SecretFindings, redact)
+}
+
+// RedactFileServerNames is RedactServerNames for one file result. Its slices are replaced,
+// not edited, so a shallow copy of a FileResult can be redacted without touching the original.
+func RedactFileServerNames(file *FileResult, salt string) {
+	redact := func(name string) string { return RedactServerName(name, salt) }
+	file.Servers = renameServers(file.Servers, redact)
+	file.SecretFindings = renameFindingServers(file.SecretFindings, redact)
+	file.MisconfigFindings = renameMisconfigServers(file.MisconfigFindings, redact)
+}
+
+// renameServers returns a copy of servers with rename applied to their names.
+func renameServers(servers []ServerConfig, rename func(string) string) []ServerConfig {
+	if servers == nil {
+		return nil
+	}
+	out := make([]ServerConfig, len(servers))
+	for i, s := range servers {
+		s.Name = rename(s.Name)
+		s.CanonicalName = rename(s.CanonicalName)
+		out[i] = s
+	}
+	return out
+}
+
+// renameFindingServers returns a copy of findings with rename applied to ServerName.
+func renameFindingServers(findings []SecretFinding, rename func(string) string) []SecretFinding {
+	if findings == nil {
+		return nil
+	}
+	out := make([]SecretFinding, len(findings))
+	for i, f := range findings {
+		f.ServerName = rename(f.ServerName)
+		out[i] = f
+	}
+	return out
+}
+
+func renameMisconfigServers(findings []MisconfigFinding, rename func(string) string) []MisconfigFinding {
+	if findings == nil {
+		return nil
+	}
+	out := make([]MisconfigFinding, len(findings))
+	for i, f := range findings {
+		f.ServerName = rename(f.ServerName)
+		out[i] = f
+	}
+	return out
+}
+
+// AnonymizePaths replaces every file path in the summary (server paths, suppressed
+// included, secret occurrence keys and misconfiguration paths) with AnonymizePath.
+func AnonymizePaths(summary *ScanSummary, salt string) {
+	if summary == nil {
+		return
+	}
+	rewriteSummaryPaths(summary, func(path string) string { return AnonymizePath(path, salt) })
+}
+
+// StdinPath stands in for the temporary file holding a config read with scan --stdin.
+const StdinPath = "<stdin>"
+
+// ReplaceResultPath renames file path from to to throughout a scan result, e.g. to hide the
+// temporary file a config read from stdin was scanned from.
+func ReplaceResultPath(result *ScanResult, from, to string) {
+	if result == nil {
+		return
+	}
+	rename := func(path string) string {
+		if path == from {
+			return to
+		}
+		return path
+	}
+	for i := range result.Targets {
+		result.Targets[i] = rename(result.Targets[i])
+	}
+	for i := range result.Files {
+		f := &result.Files[i]
+		f.Path = rename(f.Path)
+		f.SecretFindings = rewriteFindingPaths(f.SecretFindings, rename)
+		f.MisconfigFindings = rewriteMisconfigPaths(f.MisconfigFindings, rename)
+	}
+	result.SecretFindings = rewriteFindingPaths(result.SecretFindings, rename)
+}
+
+// rewriteSummaryPaths applies rewrite to every file path in the summary.
+func rewriteSummaryPaths(summary *ScanSummary, rewrite func(string) string) {
+	for _, servers := range [][]ServerReport{summary.Servers, summary.Suppressed} {
+		for i := range servers {
+			servers[i].Path = rewrite(servers[i].Path)
+			servers[i].Secrets = rewriteFindingPaths(servers[i].Secrets, rewrite)
+		}
+	}
+	summary.Secrets = rewriteFindingPaths(summary.Secrets, rewrite)
+	summary.MisconfigFindings = rewriteMisconfigPaths(summary.MisconfigFindings, rewrite)
+	for i := range summary.ProfileReport {
+		summary.ProfileReport[i].Path = rewrite(summary.ProfileReport[i].Path)
+	}
+}
+
+// rewriteFindingPaths rewrites occurrence keys. Occurrence maps are rebuilt rather than
+// edited in place because the same map is shared between findings lists.
+func rewriteFindingPaths(findings []SecretFinding, rewrite func(string) string) []SecretFinding {
+	if findings == nil {
+		return nil
+	}
+	out := make([]SecretFinding, len(findings))
+	for i, f := range findings {
+		occ := make(map[string][]int, len(f.Occurrences))
+		for path, lines := range f.Occurrences {
+			occ[rewrite(path)] = lines
+		}
+		f.Occurrences = occ
+		out[i] = f
+	}
+	return out
+}
+
+func rewriteMisconfigPaths(findings []MisconfigFinding, rewrite func(string) string) []MisconfigFinding {
+	if findings == nil {
+		return nil
+	}
+	out := make([]MisconfigFinding, len(findings))
+	for i, f := range findings {
+		f.Path = rewrite(f.Path)
+		out[i] = f
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymizePath(t *testing.T) {
+	a := AnonymizePath("/Users/alice/.cursor/mcp.json", "")
+	assert.Regexp(t, `^[0-9a-f]{8}\.json$`, a)
+	assert.Equal(t, a, AnonymizePath("/Users/alice/.cursor/mcp.json", ""), "hash must be stable")
+	assert.NotEqual(t, a, AnonymizePath("/Users/alice/.cursor/mcp.json", "pipeline-42"), "salt must change the hash")
+	assert.NotEqual(t, a, AnonymizePath("/Users/bob/.cursor/mcp.json", ""))
+	assert.Equal(t, "", AnonymizePath("", ""))
+}
+
+func TestAnonymizePaths_SharedOccurrences(t *testing.T) {
+	f := NewSecretFinding("srv", "OpenAI API Key", "env.KEY", "sk-proj-abcT3BlbkFJdef", "HIGH", "/home/alice/mcp.json", 3, nil) //nolint:gosec // test data
+	summary := ScanSummary{
+		Servers:           []ServerReport{{Name: "srv", Path: "/home/alice/mcp.json", Secrets: []SecretFinding{f}}},
+		Secrets:           []SecretFinding{f},
+		MisconfigFindings: []MisconfigFinding{{ServerName: "srv", Path: "/home/alice/mcp.json"}},
+	}
+	want := AnonymizePath("/home/alice/mcp.json", "s")
+
+	AnonymizePaths(&summary, "s")
+
+	assert.Equal(t, want, summary.Servers[0].Path)
+	assert.Equal(t, map[string][]int{want: {3}}, summary.Servers[0].Secrets[0].Occurrences)
+	assert.Equal(t, map[string][]int{want: {3}}, summary.Secrets[0].Occurrences, "shared maps must not be hashed twice")
+	assert.Equal(t, want, summary.MisconfigFindings[0].Path)
+}
+
+func TestReplaceResultPath(t *testing.T) {
+	const tmp = "/tmp/run-mcp-stdin-123.json"
+	f := NewSecretFinding("srv", "OpenAI API Key", "env.KEY", "sk-proj-abcT3BlbkFJdef", "HIGH", tmp, 3, nil) //nolint:gosec // test data
+	result := ScanResult{
+		Targets: []string{tmp},
+		Files: []FileResult{{
+			Path:              tmp,
+			SecretFindings:    []SecretFinding{f},
+			MisconfigFindings: []MisconfigFinding{{ServerName: "srv", Path: tmp}},
+		}},
+		SecretFindings: []SecretFinding{f},
+	}
+
+	ReplaceResultPath(&result, tmp, StdinPath)
+
+	assert.Equal(t, []string{StdinPath}, result.Targets)
+	assert.Equal(t, StdinPath, result.Files[0].Path)
+	assert.Equal(t, map[string][]int{StdinPath: {3}}, result.Files[0].SecretFindings[0].Occurrences)
+	assert.Equal(t, map[string][]int{StdinPath: {3}}, result.SecretFindings[0].Occurrences)
+	assert.Equal(t, StdinPath, result.Files[0].MisconfigFindings[0].Path)
+}
+
+func TestRedactServerNames(t *testing.T) {
+	name := RedactServerName("acme-billing", "")
+	assert.Regexp(t, `^[0-9a-f]{8}$`, name)
+	assert.Equal(t, name, RedactServerName("acme-billing", ""), "hash must be stable")
+	assert.NotEqual(t, name, RedactServerName("acme-billing", "pipeline-42"), "salt must change the hash")
+	assert.Equal(t, "", RedactServerName("", ""))
+
+	f := NewSecretFinding("acme-billing", "OpenAI API Key", "env.KEY", "sk-proj-abcT3BlbkFJdef", "HIGH", "/home/alice/mcp.json", 3, nil) //nolint:gosec // test data
+	m := MisconfigFinding{ServerName: "acme-billing", Path: "/home/alice/mcp.json"}
+	summary := ScanSummary{
+		Servers:           []ServerReport{{Name: "acme-billing", CanonicalName: "acme-billing", Secrets: []SecretFinding{f}}},
+		Suppressed:        []ServerReport{{Name: "acme-internal"}},
+		Secrets:           []SecretFinding{f},
+		MisconfigFindings: []MisconfigFinding{m},
+	}
+	result := ScanResult{
+		Files: []FileResult{{
+			Servers:           []ServerConfig{{Name: "acme-billing"}},
+			SecretFindings:    []SecretFinding{f},
+			MisconfigFindings: []MisconfigFinding{m},
+		}},
+		Servers:        []ServerConfig{{Name: "acme-billing"}},
+		SecretFindings: []SecretFinding{f},
+	}
+	want := RedactServerName("acme-billing", "s")
+
+	original := result.Files[0]
+	RedactServerNames(&summary, "s")
+	RedactResultServerNames(&result, "s")
+
+	assert.Equal(t, want, summary.Servers[0].Name)
+	assert.Equal(t, want, summary.Servers[0].CanonicalName)
+	assert.Equal(t, want, summary.Servers[0].Secrets[0].ServerName)
+	assert.Equal(t, RedactServerName("acme-internal", "s"), summary.Suppressed[0].Name)
+	assert.Equal(t, want, summary.Secrets[0].ServerName)
+	assert.Equal(t, want, summary.MisconfigFindings[0].ServerName)
+	assert.Equal(t, want, result.Files[0].Servers[0].Name)
+	assert.Equal(t, want, result.Files[0].SecretFindings[0].ServerName)
+	assert.Equal(t, want, result.Files[0].MisconfigFindings[0].ServerName)
+	assert.Equal(t, want, result.Servers[0].Name)
+	assert.Equal(t, want, result.SecretFindings[0].ServerName)
+	assert.Equal(t, "acme-billing", original.Servers[0].Name, "copies of a file result are not affected")
+}
@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+
+	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
+)
+
+// Server categories reported in ServerReport.Category.
+const (
+	CategoryNPM    = "npm"
+	CategoryPython = "python"
+	CategoryDocker = "docker"
+	CategoryBinary = "binary"
+)
+
+// ParseCategory normalizes a user-supplied server category (npm, python, docker, binary).
+func ParseCategory(s string) (string, error) {
+	c := strings.ToLower(strings.TrimSpace(s))
+	switch c {
+	case CategoryNPM, CategoryPython, CategoryDocker, CategoryBinary:
+		return c, nil
+	}
+	return "", fmt.Errorf("invalid category %q: must be one of npm, python, docker, binary", s)
+}
+
+// ServerCategory classifies a server config by the identifiers IdentifierExtractor derives
+// from it: npm and PyPI package URLs, OCI images, or, for a server launched by a command with
+// no package, image or URL identifier, binary. Remote servers and configs without a command
+// have no category. configFilePath is passed on to ExtractIdentifiers.
+func ServerCategory(configFilePath, name string, config interface{}) string {
+	hasURL := false
+	for _, id := range NewIdentifierExtractor().ExtractIdentifiers(configFilePath, name, config) {
+		switch id.Kind {
+		case apigen.Purl:
+			switch {
+			case strings.HasPrefix(id.Value, "pkg:npm/"):
+				return CategoryNPM
+			case strings.HasPrefix(id.Value, "pkg:pypi/"):
+				return CategoryPython
+			}
+		case apigen.Oci:
+			return CategoryDocker
+		case apigen.Url:
+			hasURL = true
+		}
+	}
+	if hasURL {
+		return ""
+	}
+	// Repository hints alone do not make a server a package; treat it as a local binary.
+	if cfg, ok := config.(map[string]interface{}); ok && len(ruleFieldValues("command", name, cfg)) > 0 {
+		return CategoryBinary
+	}
+	return ""
+}
+
+// FilterByCategory keeps only the reported servers of the given category. Totals and secret
+// findings are left untouched, as with --secrets-only.
+func FilterByCategory(summary *ScanSummary, category string) {
+	kept := summary.Servers[:0]
+	for _, sr := range summary.Servers {
+		if sr.Category == category {
+			kept = append(kept, sr)
+		}
+	}
+	summary.Servers = kept
+}
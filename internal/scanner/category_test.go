@@ -0,0 +1,50 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerCategory(t *testing.T) {
+	cases := []struct {
+		name   string
+		config Server
+		want   string
+	}{
+		{"npm", Server{"command": "npx", "args": []interface{}{"-y", "@modelcontextprotocol/server-git"}}, CategoryNPM},
+		{"python", Server{"command": "uvx", "args": []interface{}{"mcp-server-fetch"}}, CategoryPython},
+		{"docker", Server{"command": "docker", "args": []interface{}{"run", "-i", "--rm", "ghcr.io/github/github-mcp-server"}}, CategoryDocker},
+		{"binary", Server{"command": "/usr/local/bin/my-mcp", "args": []interface{}{"--stdio"}}, CategoryBinary},
+		{"remote", Server{"url": "https://mcp.example.com/sse"}, ""},
+		{"empty", Server{}, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ServerCategory("", tc.name, tc.config))
+		})
+	}
+}
+
+func TestFilterByCategory(t *testing.T) {
+	summary := ScanSummary{
+		TotalServers: 3,
+		Servers: []ServerReport{
+			{Name: "a", Category: CategoryNPM},
+			{Name: "b", Category: CategoryDocker},
+			{Name: "c"},
+		},
+	}
+	FilterByCategory(&summary, CategoryDocker)
+	require.Len(t, summary.Servers, 1)
+	assert.Equal(t, "b", summary.Servers[0].Name)
+	assert.Equal(t, 3, summary.TotalServers)
+
+	c, err := ParseCategory(" Docker ")
+	require.NoError(t, err)
+	assert.Equal(t, CategoryDocker, c)
+	_, err = ParseCategory("rust")
+	assert.Error(t, err)
+}
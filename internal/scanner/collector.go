@@ -1,15 +1,22 @@
 package scanner
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
 	api "github.com/ensigniasec/run-mcp/internal/api"
 	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
+	"github.com/ensigniasec/run-mcp/internal/metrics"
 	"github.com/ensigniasec/run-mcp/internal/storage"
+	"github.com/ensigniasec/run-mcp/internal/telemetry"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -23,7 +30,10 @@ const (
 	// Named durations to avoid magic numbers in timeouts/intervals.
 	scanPollTimeout     = 2 * time.Minute
 	scanPollInterval    = 500 * time.Millisecond
+	flushPollGrace      = 2 * time.Second
 	serverPolicyUnknown = "unknown"
+	// DefaultRatingsCacheTTL is how long a cached rating is reused before resubmitting its identifier.
+	DefaultRatingsCacheTTL = 24 * time.Hour
 )
 
 // RatingsCollector batches identifier submissions to the ratings API and maps them back to servers.
@@ -37,28 +47,84 @@ type RatingsCollector struct {
 	debounce    time.Duration
 	workerCount int
 	fetchCount  int
+	channelSize int
+	metrics     *metrics.Registry
+	// hashAlgorithm names the digest of the fingerprints passed to ApplyToSummary; empty
+	// means HashSHA256.
+	hashAlgorithm string
 
 	mu          sync.Mutex
 	seen        map[apigen.IdentifierKind]map[string]struct{}
 	curBatch    []apigen.TargetIdentifier
 	timer       *time.Timer
 	idToServers map[string][]string
+	// stopped is set by FlushAndStop once sendCh is about to close.
+	stopped bool
 
 	serverPolicy map[string]string
 	serverLinks  map[string]string
 	serverRating map[string]*SecurityRating
 
+	// cacheTTL enables reuse of ratings persisted in storage; zero disables the cache.
+	cacheTTL time.Duration
+	now      func() time.Time
+
 	sendCh chan []apigen.TargetIdentifier
 	wg     sync.WaitGroup
+	// polls tracks the pollAndApply goroutines started for accepted batches. FlushAndStop
+	// waits up to pollGrace for them before saving the cache.
+	polls     sync.WaitGroup
+	pollGrace time.Duration
+	// cacheSaved is set once FlushAndStop has saved the cache; later polls leave storage alone.
+	cacheSaved bool
 
 	// Optional notifications for UI stages.
 	notifySubmitted  func(serverName string)
 	notifyProcessing func(serverName string)
 	notifyReceived   func(serverName string)
+
+	stats collectorStats
+}
+
+// CollectorOption configures a RatingsCollector when it is created.
+type CollectorOption func(*RatingsCollector)
+
+// WithWorkerCount sets how many batches are delivered to the API concurrently. n < 1 is ignored.
+func WithWorkerCount(n int) CollectorOption { //nolint:ireturn
+	return func(rc *RatingsCollector) {
+		if n >= 1 {
+			rc.workerCount = n
+		}
+	}
+}
+
+// WithChannelSize sets how many batches may queue for a free worker. Batches that do not
+// fit stay buffered in the collector and are retried after the debounce interval. n < 0 is ignored.
+func WithChannelSize(n int) CollectorOption { //nolint:ireturn
+	return func(rc *RatingsCollector) {
+		if n >= 0 {
+			rc.channelSize = n
+		}
+	}
+}
+
+// WithMetrics counts each ratings API request in m by outcome.
+func WithMetrics(m *metrics.Registry) CollectorOption { //nolint:ireturn
+	return func(rc *RatingsCollector) {
+		rc.metrics = m
+	}
+}
+
+// WithHashAlgorithm records that server fingerprints use algorithm (see NewHashFunc), so
+// fingerprints stored under another algorithm are not reported as changes.
+func WithHashAlgorithm(algorithm string) CollectorOption { //nolint:ireturn
+	return func(rc *RatingsCollector) {
+		rc.hashAlgorithm = algorithm
+	}
 }
 
 // NewRatingsCollector creates a new collector. Pass a nil client to operate offline.
-func NewRatingsCollector(ctx context.Context, client api.RatingsClient, st *storage.Storage) *RatingsCollector { //nolint:ireturn
+func NewRatingsCollector(ctx context.Context, client api.RatingsClient, st *storage.Storage, opts ...CollectorOption) *RatingsCollector { //nolint:ireturn
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -70,22 +136,56 @@ func NewRatingsCollector(ctx context.Context, client api.RatingsClient, st *stor
 		debounce:     debounce,
 		workerCount:  workerCount,
 		fetchCount:   fetchCount,
+		channelSize:  channelSize,
 		seen:         make(map[apigen.IdentifierKind]map[string]struct{}),
 		idToServers:  make(map[string][]string),
 		serverPolicy: make(map[string]string),
 		serverLinks:  make(map[string]string),
 		serverRating: make(map[string]*SecurityRating),
-		sendCh:       make(chan []apigen.TargetIdentifier, channelSize),
+		pollGrace:    flushPollGrace,
+		now:          time.Now,
 	}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	rc.sendCh = make(chan []apigen.TargetIdentifier, rc.channelSize)
 	rc.startWorkers()
 	return rc
 }
 
+// LookupFailures reports how many batches are missing ratings because submitting or polling
+// them failed, or because their poll is still running after FlushAndStop.
+func (rc *RatingsCollector) LookupFailures() int {
+	if rc == nil {
+		return 0
+	}
+	return int(rc.stats.failed.Load() + rc.stats.pending.Load())
+}
+
 // IsOffline reports whether the collector is operating without a remote client.
 func (rc *RatingsCollector) IsOffline() bool {
 	return rc == nil || rc.client == nil
 }
 
+// Identifiers returns every identifier recorded by Submit, sorted by kind then value.
+func (rc *RatingsCollector) Identifiers() []apigen.TargetIdentifier {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	out := []apigen.TargetIdentifier{}
+	for kind, values := range rc.seen {
+		for v := range values {
+			out = append(out, apigen.TargetIdentifier{Kind: kind, Value: v})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].Value < out[j].Value
+	})
+	return out
+}
+
 // SetClient sets the underlying ratings client after the collector has been created.
 // If there is a pending batch, it will be flushed immediately.
 func (rc *RatingsCollector) SetClient(client api.RatingsClient) {
@@ -106,6 +206,15 @@ func (rc *RatingsCollector) WithStageNotifiers(submitted func(string), processin
 	return rc
 }
 
+// WithRatingsCache enables the persistent ratings cache with the given TTL. Identifiers with a
+// cache entry younger than ttl are not resubmitted. A zero ttl or nil storage disables the cache.
+func (rc *RatingsCollector) WithRatingsCache(ttl time.Duration) *RatingsCollector { //nolint:ireturn
+	rc.mu.Lock()
+	rc.cacheTTL = ttl
+	rc.mu.Unlock()
+	return rc
+}
+
 // startWorkers launches the batch delivery workers.
 func (rc *RatingsCollector) startWorkers() {
 	for range rc.workerCount {
@@ -122,17 +231,24 @@ func (rc *RatingsCollector) startWorkers() {
 // makeKey returns a stable map key for an identifier.
 func makeKey(t apigen.TargetIdentifier) string { return string(t.Kind) + "|" + t.Value }
 
-// Submit records identifiers for a server and schedules a batched flush.
-func (rc *RatingsCollector) Submit(serverName string, serverConfig interface{}) {
-	// Apply local allowlist immediately and skip remote lookup for those.
+// Submit records identifiers for a server read from configFilePath and schedules a batched flush.
+func (rc *RatingsCollector) Submit(configFilePath, serverName string, serverConfig interface{}) {
+	// Apply local allowlist and denylist immediately and skip remote lookup for those.
 	if localAllowlisted(rc.storage, serverName, serverName) {
 		rc.mu.Lock()
 		rc.serverPolicy[serverName] = "allowed"
 		rc.mu.Unlock()
 		return
 	}
+	if localDenylisted(rc.storage, serverName) {
+		rc.mu.Lock()
+		rc.serverPolicy[serverName] = "denied"
+		rc.mu.Unlock()
+		return
+	}
 
-	ids := NewIdentifierExtractor().ExtractIdentifiers(serverName, serverConfig)
+	ids := NewIdentifierExtractor().ExtractIdentifiers(configFilePath, serverName, serverConfig)
+	rc.stats.identifiers.Add(int64(len(ids)))
 	if len(ids) == 0 {
 		rc.mu.Lock()
 		if _, ok := rc.serverPolicy[serverName]; !ok {
@@ -165,20 +281,26 @@ func (rc *RatingsCollector) Submit(serverName string, serverConfig interface{})
 		go rc.notifySubmitted(serverName)
 	}
 
-	// Only schedule a flush when a client is available; otherwise, we'll flush when SetClient is called.
-	if rc.client != nil {
-		if rc.timer == nil {
-			rc.timer = time.AfterFunc(rc.debounce, func() { rc.flush() })
-		} else {
-			rc.timer.Reset(rc.debounce)
-		}
-	}
+	rc.scheduleFlushLocked()
 	if len(rc.curBatch) >= rc.batchSize {
 		rc.flushLocked()
 	}
 	rc.mu.Unlock()
 }
 
+// scheduleFlushLocked (re)arms the debounce timer. It is a no-op while offline, since
+// SetClient flushes any buffered identifiers. Caller must hold rc.mu.
+func (rc *RatingsCollector) scheduleFlushLocked() {
+	if rc.client == nil || rc.stopped {
+		return
+	}
+	if rc.timer == nil {
+		rc.timer = time.AfterFunc(rc.debounce, func() { rc.flush() })
+	} else {
+		rc.timer.Reset(rc.debounce)
+	}
+}
+
 // flush triggers a flush from the debounce callback.
 func (rc *RatingsCollector) flush() {
 	rc.mu.Lock()
@@ -189,35 +311,61 @@ func (rc *RatingsCollector) flush() {
 	rc.mu.Unlock()
 }
 
-// flushLocked moves the current batch to the send channel. Caller must hold rc.mu.
+// flushLocked moves the current batch to the send channel in chunks of at most batchSize.
+// Chunks that do not fit are kept buffered and retried after the debounce interval.
+// Caller must hold rc.mu.
 func (rc *RatingsCollector) flushLocked() {
-	if len(rc.curBatch) == 0 {
+	if len(rc.curBatch) == 0 || rc.stopped {
 		return
 	}
 	if rc.client == nil {
 		// Keep batch buffered until a client is set.
 		return
 	}
-	batch := make([]apigen.TargetIdentifier, len(rc.curBatch))
-	copy(batch, rc.curBatch)
-	rc.curBatch = rc.curBatch[:0]
-	select {
-	case rc.sendCh <- batch:
-	default:
-		logrus.Debug("ratings collector backpressure: dropping batch")
+	batches := rc.takeBatchesLocked()
+	for i, batch := range batches {
+		select {
+		case rc.sendCh <- batch:
+		default:
+			logrus.Debugf("ratings collector backpressure: deferring %d batches", len(batches)-i)
+			for _, rest := range batches[i:] {
+				rc.curBatch = append(rc.curBatch, rest...)
+			}
+			rc.scheduleFlushLocked()
+			return
+		}
 	}
 }
 
+// takeBatchesLocked empties the current batch and returns it split into chunks of at most
+// batchSize. Caller must hold rc.mu.
+func (rc *RatingsCollector) takeBatchesLocked() [][]apigen.TargetIdentifier {
+	var batches [][]apigen.TargetIdentifier
+	for chunk := range slices.Chunk(rc.curBatch, rc.batchSize) {
+		batches = append(batches, slices.Clone(chunk))
+	}
+	rc.curBatch = rc.curBatch[:0]
+	return batches
+}
+
 // deliverBatch sends a batch with retries honoring Retry-After and 5xx backoff.
 func (rc *RatingsCollector) deliverBatch(batch []apigen.TargetIdentifier) {
 	if rc.client == nil || len(batch) == 0 {
 		return
 	}
-	ctx := rc.ctx
+	if batch = rc.applyCached(batch); len(batch) == 0 {
+		return
+	}
+	ctx, span := telemetry.Tracer().Start(rc.ctx, telemetry.SpanDeliverBatch,
+		trace.WithAttributes(attribute.Int("batch.size", len(batch))))
+	defer span.End()
+	rc.stats.batches.Add(1)
 
 	backoff := backoffBase
-	for range maxAttempts {
+	for attempt := range maxAttempts {
+		span.SetAttributes(attribute.Int("attempt.count", attempt+1))
 		resp, accepted, err := rc.client.SubmitBatchRatings(ctx, apigen.BatchRatingRequest{Identifiers: batch})
+		rc.metrics.IncAPIRequest(apiRequestStatus(err))
 		if err == nil {
 			if accepted != nil {
 				rc.onAccepted(batch, accepted.ScanId.String())
@@ -232,21 +380,31 @@ func (rc *RatingsCollector) deliverBatch(batch []apigen.TargetIdentifier) {
 		}
 
 		logrus.Debugf("batch submit failed, dropping: %v", err)
+		rc.stats.failed.Add(1)
 		return
 	}
 	logrus.Debug("batch submit: max attempts reached, dropping")
+	rc.stats.failed.Add(1)
 }
 
 // onAccepted handles 202 Accepted: notify processing, mark pending, and poll async.
 func (rc *RatingsCollector) onAccepted(batch []apigen.TargetIdentifier, scanID string) {
 	rc.notifyProcessingForBatch(batch)
 	rc.markServersPending(batch)
-	go rc.pollAndApply(scanID)
+	rc.polls.Add(1)
+	rc.stats.pending.Add(1)
+	go func() {
+		defer rc.polls.Done()
+		defer rc.stats.pending.Add(-1)
+		rc.pollAndApply(scanID, batch)
+	}()
 }
 
 // onImmediateResponse handles synchronous rating response and notifies receivers.
 func (rc *RatingsCollector) onImmediateResponse(batch []apigen.TargetIdentifier, resp apigen.BatchRatingResponse) {
+	rc.stats.ratings.Add(int64(len(resp.Ratings)))
 	rc.applyRatings(resp)
+	rc.cacheLinks(resp)
 	rc.notifyReceivedForBatch(batch)
 }
 
@@ -288,6 +446,19 @@ func (rc *RatingsCollector) notifyReceivedForBatch(batch []apigen.TargetIdentifi
 	}
 }
 
+// apiRequestStatus maps a ratings API call result to its metrics outcome label.
+func apiRequestStatus(err error) string {
+	var rl api.RateLimitedError
+	switch {
+	case err == nil:
+		return metrics.StatusOK
+	case errors.As(err, &rl):
+		return metrics.StatusRateLimited
+	default:
+		return metrics.StatusError
+	}
+}
+
 // handleRetryableError returns true if the error was handled and the caller should retry.
 func (rc *RatingsCollector) handleRetryableError(err error, backoff *time.Duration) bool { //nolint:ireturn
 	var rl api.RateLimitedError
@@ -307,18 +478,20 @@ func (rc *RatingsCollector) handleRetryableError(err error, backoff *time.Durati
 	return false
 }
 
-func (rc *RatingsCollector) pollAndApply(scanID string) {
+func (rc *RatingsCollector) pollAndApply(scanID string, batch []apigen.TargetIdentifier) {
 	ctx, cancel := context.WithTimeout(rc.ctx, scanPollTimeout)
 	defer cancel()
 	ratings, err := rc.client.WaitForScanCompletion(ctx, scanID, scanPollInterval)
+	rc.metrics.IncAPIRequest(apiRequestStatus(err))
 	if err != nil {
 		logrus.Debugf("polling scan %s failed: %v", scanID, err)
+		rc.stats.failed.Add(1)
 		return
 	}
-	// Convert returned ratings to link mapping by querying IDs from serverLinks
-	// We don't have direct mapping here, but serverLinks are applied when fetching
-	// batch links. For now, nothing to link; future improvement could map by identifiers.
-	_ = ratings
+	rc.stats.ratings.Add(int64(len(ratings)))
+	// The cache is only updated in memory here; FlushAndStop saves it.
+	rc.applyPolledRatings(batch, ratings)
+	rc.cacheRatings(batch, ratings)
 	// Notify received for servers related to the identifiers.
 	if rc.notifyReceived != nil {
 		rc.mu.Lock()
@@ -331,17 +504,47 @@ func (rc *RatingsCollector) pollAndApply(scanID string) {
 	}
 }
 
-// applyRatings integrates received ratings into server link mappings.
+// applyPolledRatings records each polled rating for the servers of the batch identifiers it
+// describes.
+func (rc *RatingsCollector) applyPolledRatings(batch []apigen.TargetIdentifier, ratings []apigen.SecurityRating) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for i := range ratings {
+		for _, id := range batch {
+			if !ratingDescribes(&ratings[i], id) {
+				continue
+			}
+			for _, name := range rc.idToServers[makeKey(id)] {
+				rc.serverRating[name] = securityRatingFromAPI(&ratings[i])
+			}
+		}
+	}
+}
+
+// serverRater is implemented by clients that rate servers by name, such as MockRatingsClient.
+type serverRater interface {
+	ServerRating(name string) (SecurityRating, bool)
+}
+
+// applyRatings integrates received ratings into server link mappings, and records the
+// ratings of clients that rate servers by name.
 func (rc *RatingsCollector) applyRatings(resp apigen.BatchRatingResponse) {
 	if len(resp.Ratings) == 0 {
 		return
 	}
 	rc.mu.Lock()
+	rater, _ := rc.client.(serverRater)
 	for _, item := range resp.Ratings {
 		k := makeKey(item.Identifier)
 		if servers, ok := rc.idToServers[k]; ok {
 			for _, name := range servers {
 				rc.serverLinks[name] = item.RatingUrl
+				if rater == nil {
+					continue
+				}
+				if r, ok := rater.ServerRating(name); ok {
+					rc.serverRating[name] = &r
+				}
 			}
 		}
 	}
@@ -357,7 +560,8 @@ func asRemote(err error) (api.RemoteError, bool) { //nolint:ireturn
 	return api.RemoteError{}, false
 }
 
-// ApplyToSummary attaches local policies and any available ratings to the summary.
+// ApplyToSummary attaches local policies and any available ratings to the summary, and flags
+// servers whose fingerprint changed since the previous scan recorded in storage.
 func (rc *RatingsCollector) ApplyToSummary(summary *ScanSummary) {
 	if summary == nil {
 		return
@@ -366,26 +570,228 @@ func (rc *RatingsCollector) ApplyToSummary(summary *ScanSummary) {
 	defer rc.mu.Unlock()
 	for i := range summary.Servers {
 		s := &summary.Servers[i]
-		if p, ok := rc.serverPolicy[s.Name]; ok {
+		name := s.Name
+		if s.CanonicalName != "" {
+			name = s.CanonicalName
+		}
+		if p, ok := rc.serverPolicy[name]; ok {
 			s.LocalPolicy = p
 		}
-		if r, ok := rc.serverRating[s.Name]; ok {
+		if r, ok := rc.serverRating[name]; ok {
 			s.Rating = r
 		}
+		rc.trackFingerprintLocked(s)
+	}
+}
+
+// trackFingerprintLocked marks s as changed if its fingerprint differs from the one stored
+// by the previous scan, then records the current one. The caller saves the storage.
+func (rc *RatingsCollector) trackFingerprintLocked(s *ServerReport) {
+	if rc.storage == nil || s.Fingerprint == "" {
+		return
+	}
+	if rc.storage.Data.ScannedEntities == nil {
+		rc.storage.Data.ScannedEntities = make(map[string]map[string]string)
+	}
+	key := fingerprintEntityKey(s.Path, s.Name)
+	prevEntity := rc.storage.Data.ScannedEntities[key]
+	// SHA-256 entries, including those from before --hash-algorithm, carry no algorithm.
+	algorithm := cmp.Or(rc.hashAlgorithm, HashSHA256)
+	prevAlgorithm := cmp.Or(prevEntity["hash_algorithm"], HashSHA256)
+	if prev := prevEntity["fingerprint"]; prev != "" && prev != s.Fingerprint && prevAlgorithm == algorithm {
+		s.Changed = true
+		s.PreviousFingerprint = prev
 	}
+	entity := map[string]string{
+		"name":        s.Name,
+		"path":        s.Path,
+		"fingerprint": s.Fingerprint,
+	}
+	if algorithm != HashSHA256 {
+		entity["hash_algorithm"] = algorithm
+	}
+	rc.storage.Data.ScannedEntities[key] = entity
 }
 
-// FlushAndStop drains pending identifiers and stops workers.
+// FlushAndStop drains pending identifiers and stops workers. Later calls are no-ops.
 func (rc *RatingsCollector) FlushAndStop() {
 	rc.mu.Lock()
+	if rc.stopped {
+		rc.mu.Unlock()
+		return
+	}
 	if rc.timer != nil {
 		rc.timer.Stop()
 		rc.timer = nil
 	}
-	rc.flushLocked()
+	rc.stopped = true
+	var batches [][]apigen.TargetIdentifier
+	if rc.client != nil {
+		batches = rc.takeBatchesLocked()
+	}
 	rc.mu.Unlock()
+	// Block until the workers take every remaining batch; the lock is released so they can progress.
+	for _, batch := range batches {
+		rc.sendCh <- batch
+	}
 	close(rc.sendCh)
 	rc.wg.Wait()
+	// Polls can run for as long as scanPollTimeout, so only those finishing within pollGrace
+	// make it into the saved cache. Slower polls still apply their ratings in memory.
+	done := make(chan struct{})
+	go func() {
+		rc.polls.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(rc.pollGrace):
+		logrus.Debug("ratings cache: saving without polls still in progress")
+	}
+	rc.persistCache()
+}
+
+// cacheEnabledLocked reports whether the ratings cache is in use. Caller must hold rc.mu.
+func (rc *RatingsCollector) cacheEnabledLocked() bool {
+	return rc.cacheTTL > 0 && rc.storage != nil
+}
+
+// applyCached applies fresh cache entries for batch and returns the identifiers still to submit.
+func (rc *RatingsCollector) applyCached(batch []apigen.TargetIdentifier) []apigen.TargetIdentifier {
+	rc.mu.Lock()
+	if !rc.cacheEnabledLocked() {
+		rc.mu.Unlock()
+		return batch
+	}
+	now := rc.now()
+	remaining := make([]apigen.TargetIdentifier, 0, len(batch))
+	hits := []apigen.TargetIdentifier{}
+	for _, id := range batch {
+		entry, ok := rc.storage.Data.RatingCache[makeKey(id)]
+		if !ok || now.Sub(entry.FetchedAt) > rc.cacheTTL {
+			remaining = append(remaining, id)
+			continue
+		}
+		for _, name := range rc.idToServers[makeKey(id)] {
+			if entry.RatingURL != "" {
+				rc.serverLinks[name] = entry.RatingURL
+			}
+			if entry.Rating != nil {
+				rc.serverRating[name] = securityRatingFromAPI(entry.Rating)
+			}
+		}
+		hits = append(hits, id)
+	}
+	rc.mu.Unlock()
+
+	if len(hits) > 0 {
+		logrus.Debugf("ratings cache: reusing %d of %d identifiers", len(hits), len(batch))
+		rc.notifyReceivedForBatch(hits)
+	}
+	return remaining
+}
+
+// cacheLinks records rating links from a synchronous batch response.
+func (rc *RatingsCollector) cacheLinks(resp apigen.BatchRatingResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if !rc.cacheEnabledLocked() {
+		return
+	}
+	now := rc.now()
+	for _, item := range resp.Ratings {
+		k := makeKey(item.Identifier)
+		entry := rc.storage.Data.RatingCache[k]
+		entry.RatingURL = item.RatingUrl
+		entry.FetchedAt = now
+		rc.setCacheEntryLocked(k, entry)
+	}
+}
+
+// cacheRatings records polled ratings against the batch identifiers they describe.
+func (rc *RatingsCollector) cacheRatings(batch []apigen.TargetIdentifier, ratings []apigen.SecurityRating) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	// Once saved, the storage belongs to the caller again.
+	if !rc.cacheEnabledLocked() || rc.cacheSaved {
+		return
+	}
+	now := rc.now()
+	for i := range ratings {
+		for _, id := range batch {
+			if !ratingDescribes(&ratings[i], id) {
+				continue
+			}
+			k := makeKey(id)
+			entry := rc.storage.Data.RatingCache[k]
+			entry.Rating = &ratings[i]
+			entry.FetchedAt = now
+			rc.setCacheEntryLocked(k, entry)
+		}
+	}
+}
+
+func (rc *RatingsCollector) setCacheEntryLocked(key string, entry storage.CachedRating) {
+	if rc.storage.Data.RatingCache == nil {
+		rc.storage.Data.RatingCache = make(map[string]storage.CachedRating)
+	}
+	rc.storage.Data.RatingCache[key] = entry
+}
+
+// ratingDescribes reports whether the rating's consolidated identifiers include id.
+func ratingDescribes(r *apigen.SecurityRating, id apigen.TargetIdentifier) bool {
+	if r.Identifiers == nil {
+		return false
+	}
+	var v *string
+	switch id.Kind {
+	case apigen.Oci:
+		v = r.Identifiers.Oci
+	case apigen.Purl:
+		v = r.Identifiers.Purl
+	case apigen.Repo:
+		v = r.Identifiers.Repo
+	case apigen.Url:
+		v = r.Identifiers.ServerUrl
+	}
+	return v != nil && *v == id.Value
+}
+
+// securityRatingFromAPI converts an API rating to the summary's SecurityRating. The risk score
+// is derived from the overall percentage, where 100% means no risk.
+func securityRatingFromAPI(r *apigen.SecurityRating) *SecurityRating {
+	out := &SecurityRating{
+		Name:        r.Name,
+		Category:    string(r.Classification),
+		LastUpdated: r.LastUpdated,
+		Source:      string(r.Source),
+	}
+	if r.Version != nil {
+		out.Version = *r.Version
+	}
+	if p := r.Scores.OverallPercent; p != nil && *p >= 0 && *p <= 100 {
+		out.RiskScore = float64(100-*p) / 10
+	}
+	return out
+}
+
+// persistCache prunes expired entries and saves the cache to storage.
+func (rc *RatingsCollector) persistCache() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.cacheSaved = true
+	if !rc.cacheEnabledLocked() || len(rc.storage.Data.RatingCache) == 0 {
+		return
+	}
+	now := rc.now()
+	for k, entry := range rc.storage.Data.RatingCache {
+		if now.Sub(entry.FetchedAt) > rc.cacheTTL {
+			delete(rc.storage.Data.RatingCache, k)
+		}
+	}
+	if err := rc.storage.Save(); err != nil {
+		logrus.Debugf("ratings cache: save failed: %v", err)
+	}
 }
 
 // localAllowlisted checks local allowlist using provided storage.
@@ -401,3 +807,11 @@ func localAllowlisted(st *storage.Storage, serverName, hash string) bool {
 	}
 	return false
 }
+
+// localDenylisted checks the local denylist using provided storage.
+func localDenylisted(st *storage.Storage, serverName string) bool {
+	if st == nil {
+		return false
+	}
+	return slices.Contains(st.Data.Denylist["server"], serverName)
+}
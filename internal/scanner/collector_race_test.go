@@ -32,10 +32,8 @@ func (dummyClient) WaitForScanCompletion(ctx context.Context, ref string, pollEv
 
 // TestRatingsCollector_SendOnClosedChannel_Race exercises the race where a pending
 // batch is flushed via SetClient after the send channel has been closed by
-// FlushAndStop. It is skipped by default until the race is fixed.
+// FlushAndStop.
 func TestRatingsCollector_SendOnClosedChannel_Race(t *testing.T) {
-	t.Skip("Known race reproducer: send on closed channel. Enable when fixed.")
-
 	const iterations = 200
 	for i := range iterations {
 		rc := NewRatingsCollector(context.Background(), nil, nil)
@@ -0,0 +1,362 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/ensigniasec/run-mcp/internal/api"
+	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
+	"github.com/ensigniasec/run-mcp/internal/storage"
+)
+
+// recordingClient answers batches synchronously (or as accepted scans) and records what was submitted.
+type recordingClient struct {
+	dummyClient
+	mu        sync.Mutex
+	submitted []apigen.TargetIdentifier
+	maxBatch  int
+	async     bool
+	ratings   []apigen.SecurityRating
+	// pollDelay holds back WaitForScanCompletion, as a scan that takes time to complete would.
+	pollDelay time.Duration
+	// submitErr fails every submission.
+	submitErr error
+}
+
+func (c *recordingClient) SubmitBatchRatings(_ context.Context, req apigen.BatchRatingRequest) (apigen.BatchRatingResponse, *apigen.ScanStatus, error) {
+	c.mu.Lock()
+	c.submitted = append(c.submitted, req.Identifiers...)
+	c.maxBatch = max(c.maxBatch, len(req.Identifiers))
+	c.mu.Unlock()
+	if c.submitErr != nil {
+		return apigen.BatchRatingResponse{}, nil, c.submitErr
+	}
+	if c.async {
+		return apigen.BatchRatingResponse{}, &apigen.ScanStatus{ScanId: uuid.New()}, nil
+	}
+	var resp apigen.BatchRatingResponse
+	for _, id := range req.Identifiers {
+		resp.Ratings = append(resp.Ratings, struct {
+			Identifier apigen.TargetIdentifier `json:"identifier"`
+			RatingUrl  string                  `json:"rating_url"`
+		}{Identifier: id, RatingUrl: "/ratings/" + id.Value})
+	}
+	return resp, nil, nil
+}
+
+func (c *recordingClient) WaitForScanCompletion(context.Context, string, time.Duration) ([]apigen.SecurityRating, error) {
+	time.Sleep(c.pollDelay)
+	return c.ratings, nil
+}
+
+func (c *recordingClient) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.submitted)
+}
+
+var _ api.RatingsClient = (*recordingClient)(nil)
+
+func runCollector(t *testing.T, path string, client *recordingClient, ttl time.Duration, now time.Time) *RatingsCollector {
+	t.Helper()
+	st, err := storage.NewStorage(path)
+	require.NoError(t, err)
+	rc := NewRatingsCollector(context.Background(), client, st).WithRatingsCache(ttl)
+	rc.now = func() time.Time { return now }
+	rc.Submit("", "remote", Server{"url": "https://example.com/mcp"})
+	rc.FlushAndStop()
+	return rc
+}
+
+func TestRatingsCollector_CacheSkipsFreshIdentifiers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := &recordingClient{}
+	runCollector(t, path, first, time.Hour, start)
+	require.Equal(t, 1, first.count())
+
+	// The cache was persisted; a fresh process reuses it and links the server without submitting.
+	second := &recordingClient{}
+	rc := runCollector(t, path, second, time.Hour, start.Add(30*time.Minute))
+	assert.Equal(t, 0, second.count())
+	assert.Equal(t, "/ratings/https://example.com/mcp", rc.serverLinks["remote"])
+
+	// Past the TTL the identifier is submitted again.
+	third := &recordingClient{}
+	runCollector(t, path, third, time.Hour, start.Add(2*time.Hour))
+	assert.Equal(t, 1, third.count())
+}
+
+func TestRatingsCollector_CacheDisabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	now := time.Now()
+
+	runCollector(t, path, &recordingClient{}, 0, now)
+	client := &recordingClient{}
+	runCollector(t, path, client, 0, now)
+	assert.Equal(t, 1, client.count())
+
+	st, err := storage.NewStorage(path)
+	require.NoError(t, err)
+	assert.Empty(t, st.Data.RatingCache)
+}
+
+func TestRatingsCollector_CachesPolledRatings(t *testing.T) {
+	st, err := storage.NewStorage(filepath.Join(t.TempDir(), "results.json"))
+	require.NoError(t, err)
+	serverURL := "https://example.com/mcp"
+	rating := apigen.SecurityRating{Description: "remote server"}
+	rating.Identifiers = &struct {
+		Oci       *string `json:"oci,omitempty"`
+		Purl      *string `json:"purl,omitempty"`
+		Repo      *string `json:"repo,omitempty"`
+		ServerUrl *string `json:"server_url,omitempty"`
+	}{ServerUrl: &serverURL}
+
+	rc := NewRatingsCollector(context.Background(), &recordingClient{async: true}, st).WithRatingsCache(time.Hour)
+	id := apigen.TargetIdentifier{Kind: apigen.Url, Value: serverURL}
+	rc.client.(*recordingClient).ratings = []apigen.SecurityRating{rating}
+	rc.pollAndApply(uuid.NewString(), []apigen.TargetIdentifier{id})
+
+	entry, ok := st.Data.RatingCache[makeKey(id)]
+	require.True(t, ok)
+	require.NotNil(t, entry.Rating)
+	assert.Equal(t, "remote server", entry.Rating.Description)
+	rc.FlushAndStop()
+}
+
+// remoteRating is an API rating describing the server at serverURL.
+func remoteRating(serverURL string) apigen.SecurityRating {
+	percent := int32(80)
+	rating := apigen.SecurityRating{Name: "remote", Classification: apigen.Benign, Source: apigen.Heuristic}
+	rating.Scores.OverallPercent = &percent
+	rating.Identifiers = &struct {
+		Oci       *string `json:"oci,omitempty"`
+		Purl      *string `json:"purl,omitempty"`
+		Repo      *string `json:"repo,omitempty"`
+		ServerUrl *string `json:"server_url,omitempty"`
+	}{ServerUrl: &serverURL}
+	return rating
+}
+
+func TestRatingsCollector_CacheHitAppliesRating(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	serverURL := "https://example.com/mcp"
+	st, err := storage.NewStorage(path)
+	require.NoError(t, err)
+	rating := remoteRating(serverURL)
+	st.Data.RatingCache = map[string]storage.CachedRating{
+		makeKey(apigen.TargetIdentifier{Kind: apigen.Url, Value: serverURL}): {Rating: &rating, FetchedAt: now},
+	}
+	require.NoError(t, st.Save())
+
+	client := &recordingClient{}
+	rc := runCollector(t, path, client, time.Hour, now.Add(time.Minute))
+	assert.Equal(t, 0, client.count())
+
+	summary := ScanSummary{Servers: []ServerReport{{Name: "remote"}}}
+	rc.ApplyToSummary(&summary)
+	require.NotNil(t, summary.Servers[0].Rating)
+	assert.Equal(t, string(apigen.Benign), summary.Servers[0].Rating.Category)
+	assert.InDelta(t, 2.0, summary.Servers[0].Rating.RiskScore, 0.001)
+}
+
+func TestRatingsCollector_FlushWaitsForPolls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	serverURL := "https://example.com/mcp"
+	client := &recordingClient{
+		async:     true,
+		ratings:   []apigen.SecurityRating{remoteRating(serverURL)},
+		pollDelay: 50 * time.Millisecond,
+	}
+	rc := runCollector(t, path, client, time.Hour, time.Now())
+
+	// The poll finished within the grace period, so its rating was applied and saved.
+	summary := ScanSummary{Servers: []ServerReport{{Name: "remote"}}}
+	rc.ApplyToSummary(&summary)
+	require.NotNil(t, summary.Servers[0].Rating)
+
+	st, err := storage.NewStorage(path)
+	require.NoError(t, err)
+	entry, ok := st.Data.RatingCache[makeKey(apigen.TargetIdentifier{Kind: apigen.Url, Value: serverURL})]
+	require.True(t, ok)
+	assert.NotNil(t, entry.Rating)
+}
+
+func TestRatingsCollector_FlushDoesNotWaitForSlowPolls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	st, err := storage.NewStorage(path)
+	require.NoError(t, err)
+	client := &recordingClient{
+		async:     true,
+		ratings:   []apigen.SecurityRating{remoteRating("https://example.com/mcp")},
+		pollDelay: time.Second,
+	}
+	rc := NewRatingsCollector(context.Background(), client, st).WithRatingsCache(time.Hour)
+	rc.pollGrace = 10 * time.Millisecond
+	rc.Submit("", "remote", Server{"url": "https://example.com/mcp"})
+
+	start := time.Now()
+	rc.FlushAndStop()
+	assert.Less(t, time.Since(start), client.pollDelay, "flush returns before the poll completes")
+
+	// The late poll must not write to storage the caller now owns.
+	rc.polls.Wait()
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	assert.Nil(t, st.Data.RatingCache[makeKey(apigen.TargetIdentifier{Kind: apigen.Url, Value: "https://example.com/mcp"})].Rating)
+	assert.NotNil(t, rc.serverRating["remote"], "the rating is still applied in memory")
+}
+
+func TestRatingsCollector_LookupFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	rc := runCollector(t, path, &recordingClient{}, 0, time.Now())
+	assert.Equal(t, 0, rc.LookupFailures())
+
+	rc = runCollector(t, path, &recordingClient{submitErr: errors.New("bad request")}, 0, time.Now())
+	assert.Equal(t, 1, rc.LookupFailures())
+
+	slow := &recordingClient{async: true, pollDelay: time.Second}
+	st, err := storage.NewStorage(path)
+	require.NoError(t, err)
+	rc = NewRatingsCollector(context.Background(), slow, st)
+	rc.pollGrace = 10 * time.Millisecond
+	rc.Submit("", "remote", Server{"url": "https://example.com/mcp"})
+	rc.FlushAndStop()
+	assert.Equal(t, 1, rc.LookupFailures(), "a poll still running counts as a failure")
+}
+
+func TestRatingsCollector_LocalPolicy(t *testing.T) {
+	st, err := storage.NewStorage(filepath.Join(t.TempDir(), "results.json"))
+	require.NoError(t, err)
+	st.Data.Allowlist["server"] = []string{"good"}
+	st.Data.Denylist["server"] = []string{"bad"}
+
+	client := &recordingClient{}
+	rc := NewRatingsCollector(context.Background(), client, st)
+	rc.Submit("", "good", Server{"url": "https://good.example.com/mcp"})
+	rc.Submit("", "bad", Server{"url": "https://bad.example.com/mcp"})
+	rc.FlushAndStop()
+
+	summary := ScanSummary{Servers: []ServerReport{{Name: "good"}, {Name: "bad"}}}
+	rc.ApplyToSummary(&summary)
+	assert.Equal(t, "allowed", summary.Servers[0].LocalPolicy)
+	assert.Equal(t, "denied", summary.Servers[1].LocalPolicy)
+	assert.Equal(t, 0, client.count(), "listed servers are not looked up")
+}
+
+func TestRatingsCollector_SetClientLate(t *testing.T) {
+	rc := NewRatingsCollector(context.Background(), nil, nil)
+	rc.Submit("", "a", Server{"url": "https://a.example.com/mcp"})
+	rc.Submit("", "b", Server{"url": "https://b.example.com/mcp"})
+	rc.Submit("", "c", Server{"command": "uvx", "args": []interface{}{"consult7"}})
+
+	rc.mu.Lock()
+	pending := len(rc.curBatch)
+	rc.mu.Unlock()
+	require.Equal(t, 3, pending, "identifiers are held back while offline")
+
+	client := &recordingClient{}
+	rc.SetClient(client)
+	rc.FlushAndStop()
+
+	assert.Equal(t, 3, client.count())
+	assert.Equal(t, "/ratings/pkg:pypi/consult7", rc.serverLinks["c"])
+}
+
+func TestRatingsCollector_Identifiers(t *testing.T) {
+	rc := NewRatingsCollector(context.Background(), nil, nil)
+	rc.Submit("", "b", Server{"url": "https://b.example.com/mcp"})
+	rc.Submit("", "a", Server{"url": "https://a.example.com/mcp"})
+	rc.Submit("", "dup", Server{"url": "https://a.example.com/mcp"})
+	rc.Submit("", "c", Server{"command": "uvx", "args": []interface{}{"consult7"}})
+	rc.FlushAndStop()
+
+	assert.Equal(t, []apigen.TargetIdentifier{
+		{Kind: apigen.Purl, Value: "pkg:pypi/consult7"},
+		{Kind: apigen.Url, Value: "https://a.example.com/mcp"},
+		{Kind: apigen.Url, Value: "https://b.example.com/mcp"},
+	}, rc.Identifiers())
+}
+
+func TestRatingsCollector_HighVolume(t *testing.T) {
+	const total = 500
+	var (
+		mu       sync.Mutex
+		received = map[string]int{}
+		requests int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/health" {
+			_ = json.NewEncoder(w).Encode(apigen.HealthResponse{Status: apigen.Healthy})
+			return
+		}
+		var req apigen.BatchRatingRequest
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.LessOrEqual(t, len(req.Identifiers), batchSize)
+		mu.Lock()
+		requests++
+		for _, id := range req.Identifiers {
+			received[id.Value]++
+		}
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(apigen.BatchRatingResponse{})
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClient(api.WithBaseURL(srv.URL + "/api/v1"))
+	require.NoError(t, err)
+
+	// A single worker and no queue maximise backpressure: nothing may be dropped.
+	rc := NewRatingsCollector(context.Background(), client, nil, WithWorkerCount(1), WithChannelSize(0))
+	for i := range total {
+		rc.Submit("", fmt.Sprintf("server-%d", i), Server{"url": fmt.Sprintf("https://example.com/mcp/%d", i)})
+	}
+	want := rc.Identifiers()
+	require.GreaterOrEqual(t, len(want), total)
+	rc.FlushAndStop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	missing := 0
+	for _, id := range want {
+		if received[id.Value] != 1 {
+			missing++
+		}
+	}
+	assert.Zero(t, missing, "identifiers not delivered exactly once")
+	assert.Len(t, received, len(want))
+	assert.GreaterOrEqual(t, requests, len(want)/batchSize)
+}
+
+func TestRatingsCollector_SplitsLargeBatches(t *testing.T) {
+	client := &recordingClient{}
+	rc := NewRatingsCollector(context.Background(), nil, nil, WithChannelSize(100))
+	for i := range 2*batchSize + 1 {
+		rc.Submit("", fmt.Sprintf("server-%d", i), Server{"url": fmt.Sprintf("https://example.com/mcp/%d", i)})
+	}
+	want := len(rc.Identifiers())
+	// Buffered while offline, then flushed at once when the client arrives.
+	rc.SetClient(client)
+	rc.FlushAndStop()
+	assert.Equal(t, want, client.count())
+	assert.LessOrEqual(t, client.maxBatch, batchSize)
+}
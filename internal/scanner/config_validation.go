@@ -0,0 +1,140 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Diagnostic is a single config validation problem. Line is 1-based and 0 when unknown.
+type Diagnostic struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// ConfigValidation is the lint result for one config file.
+type ConfigValidation struct {
+	File        string       `json:"file"`
+	Kind        string       `json:"kind,omitempty"`
+	Valid       bool         `json:"valid"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// serverLaunchKeys are the fields, at least one of which a server needs to be started or reached.
+//
+//nolint:gochecknoglobals // Fixed lookup table.
+var serverLaunchKeys = []string{"command", "url", "endpoint", "baseUrl"}
+
+// yamlLineRe extracts the line number from yaml.v3 error messages ("yaml: line 3: ...").
+var yamlLineRe = regexp.MustCompile(`line (\d+)`) //nolint:gochecknoglobals // compiled once
+
+// ValidateConfigFile checks that path is a well-formed MCP config: readable, valid JSON or
+// YAML, a recognised config kind, free of case-insensitive key collisions, and with every
+// server declaring how it is launched. It never redacts secrets or contacts the network.
+func ValidateConfigFile(path string) (res ConfigValidation) {
+	res.File = path
+	fail := func(line int, format string, args ...any) {
+		res.Diagnostics = append(res.Diagnostics, Diagnostic{File: path, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+	defer func() { res.Valid = len(res.Diagnostics) == 0 }()
+
+	content, err := readFile(path, DefaultMaxFileSize)
+	if err != nil {
+		fail(0, "cannot read file: %v", err)
+		return res
+	}
+
+	var generic map[string]interface{}
+	if err := unmarshal(path, content, &generic); err != nil {
+		fail(syntaxErrorLine(content, err), "%v", err)
+		return res
+	}
+
+	chosen, found := detectConfig(generic)
+	if !found {
+		fail(0, "not a recognised MCP config (no known servers section)")
+		return res
+	}
+	res.Kind = chosen.kind.String()
+
+	cfg := chosen.new()
+	if err := unmarshal(path, content, cfg); err != nil {
+		fail(syntaxErrorLine(content, err), "%v", err)
+		return res
+	}
+
+	servers := rawServers(cfg)
+	if len(servers) == 0 {
+		fail(0, "no servers defined")
+	}
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		line := firstLine(locateLines(content, strconv.Quote(name)), locateLines(content, name))
+		if err := validateConfig(name, servers[name]); err != nil {
+			fail(line, "%v", err)
+		}
+		if !hasLaunchKey(servers[name]) {
+			fail(line, "server '%s' has no command or url", name)
+		}
+	}
+	return res
+}
+
+// hasLaunchKey reports whether server sets a non-empty launch field, directly or under stdio.
+func hasLaunchKey(server Server) bool {
+	for _, m := range []map[string]interface{}{server, getMap(server, "stdio")} {
+		for _, k := range serverLaunchKeys {
+			switch v := m[k].(type) {
+			case nil:
+			case string:
+				if v != "" {
+					return true
+				}
+			default:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// syntaxErrorLine maps a JSON or YAML decode error to a 1-based line, or 0 if unknown.
+func syntaxErrorLine(content []byte, err error) int {
+	var offset int64 = -1
+	var se *json.SyntaxError
+	var te *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &se):
+		offset = se.Offset
+	case errors.As(err, &te):
+		offset = te.Offset
+	}
+	if offset >= 0 {
+		return bytes.Count(content[:min(int(offset), len(content))], []byte("\n")) + 1
+	}
+	if m := yamlLineRe.FindStringSubmatch(err.Error()); m != nil {
+		if n, convErr := strconv.Atoi(m[1]); convErr == nil {
+			return n
+		}
+	}
+	return 0
+}
+
+// firstLine returns the first line from the first non-empty candidate list.
+func firstLine(candidates ...[]int) int {
+	for _, c := range candidates {
+		if len(c) > 0 {
+			return c[0]
+		}
+	}
+	return 0
+}
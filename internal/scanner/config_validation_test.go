@@ -0,0 +1,49 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	missingLaunch := filepath.Join(dir, "mcp.json")
+	require.NoError(t, os.WriteFile(missingLaunch, []byte("{\n  \"mcpServers\": {\n    \"ok\": {\"command\": \"npx\"},\n    \"broken\": {\"args\": [\"x\"]}\n  }\n}\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		path     string
+		wantKind string
+		wantLine int
+		wantMsg  string
+	}{
+		{"valid", filepath.Join("..", "..", "testdata", "claude_desktop_config.json"), "ClaudeConfigFile", 0, ""},
+		{"stdio list command", filepath.Join("..", "..", "testdata", "test_config.json"), "ClaudeConfigFile", 0, ""},
+		{"yaml syntax error", filepath.Join("..", "..", "testdata", "malformed.yaml"), "", 3, "did not find expected"},
+		{"case collision", filepath.Join("..", "..", "testdata", "test_parser_case_conflict.json"), "", 0, "case-insensitive key collision"},
+		{"unknown format", filepath.Join("..", "..", "testdata", "empty_config.json"), "", 0, "not a recognised MCP config"},
+		{"missing launch key", missingLaunch, "ClaudeConfigFile", 4, "server 'broken' has no command or url"},
+		{"missing file", filepath.Join(dir, "nope.json"), "", 0, "cannot read file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ValidateConfigFile(tt.path)
+			assert.Equal(t, tt.path, res.File)
+			assert.Equal(t, tt.wantKind, res.Kind)
+			if tt.wantMsg == "" {
+				assert.True(t, res.Valid)
+				assert.Empty(t, res.Diagnostics)
+				return
+			}
+			assert.False(t, res.Valid)
+			require.Len(t, res.Diagnostics, 1)
+			assert.Contains(t, res.Diagnostics[0].Message, tt.wantMsg)
+			assert.Equal(t, tt.wantLine, res.Diagnostics[0].Line)
+		})
+	}
+}
@@ -35,56 +35,97 @@ func (k ConfigKind) String() string {
 	}
 }
 
+// ParseMCPConfigFile parses and redacts the config at path, adding its secret findings to
+// s.ScanResult.
 func (s *MCPScanner) ParseMCPConfigFile(path string) (MCPConfig, error) {
-	content, err := readFile(path)
+	cfg, findings, err := s.parseMCPConfigFile(path)
+	if len(findings) > 0 {
+		s.mu.Lock()
+		s.ScanResult.SecretFindings = append(s.ScanResult.SecretFindings, findings...)
+		s.mu.Unlock()
+	}
+	return cfg, err
+}
+
+// parseMCPConfigFile parses and redacts the config at path and returns its secret findings
+// without touching shared scanner state, so workers can call it concurrently.
+func (s *MCPScanner) parseMCPConfigFile(path string) (MCPConfig, []SecretFinding, error) {
+	cfg, content, err := parseConfig(path, s.maxFileSize)
+	if err != nil || cfg == nil {
+		return nil, nil, err
+	}
+
+	// 4) Scan + redact via the wrapper (hides iteration/write-back)
+	if servers := cfg.GetServers(); len(servers) == 0 {
+		return nil, nil, nil
+	}
+	if s.skipSecrets {
+		return cfg, nil, nil
+	}
+	return cfg, s.findAndRedactSecrets(cfg, path, content), nil
+}
+
+// LoadServers parses the config file at path and returns its servers without redacting secrets.
+// Intended for commands that need to launch a server with its real environment, e.g. inspect.
+func LoadServers(path string) (map[string]Server, error) {
+	cfg, _, err := parseConfig(path, DefaultMaxFileSize)
+	if err != nil || cfg == nil {
+		return nil, err
+	}
+	return cfg.GetServers(), nil
+}
+
+// parseConfig reads the file at path, of at most maxSize bytes, detects its config kind and
+// unmarshals it. Returns a nil config (and nil error) when the file is not a recognised MCP config.
+func parseConfig(path string, maxSize int64) (MCPConfig, []byte, error) {
+	content, err := readFile(path, maxSize)
 	if err != nil {
 		logrus.Debugf("Failed to read file: %v", err)
-		return nil, err
+		return nil, nil, err
+	}
+
+	if isEnvFile(path) {
+		vars, err := parseEnvFile(content)
+		if err != nil {
+			logrus.Debugf("Invalid .env file %s: %v", path, err)
+			return nil, nil, nil
+		}
+		servers := envServers(vars)
+		if len(servers) == 0 {
+			return nil, nil, nil
+		}
+		return &EnvConfigFile{Servers: servers}, content, nil
 	}
 
 	// 1) Parse once generically so we can detect the config kind
 	var generic map[string]interface{}
 	if err := unmarshal(path, content, &generic); err != nil {
 		logrus.Debugf("Unknown or invalid config format for %s: %v", path, err)
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	// 2) Detect configKind without constructing all concrete types
-	var chosen configDetector
-	found := false
-	for _, d := range configDetectors {
-		if d.match(generic) {
-			chosen = d
-			found = true
-			break
-		}
-	}
+	chosen, found := detectConfig(generic)
 	if !found {
 		logrus.Debugf("Unknown config kind: %v", path)
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	// 3) Unmarshal into the chosen concrete configKind now that we know it
 	cfg := chosen.new()
 	if err := unmarshal(path, content, cfg); err != nil {
 		logrus.Warnf("Failed to unmarshal config: %v", err)
-		return nil, err
-	}
-
-	// 4) Scan + redact via the wrapper (hides iteration/write-back)
-	if servers := cfg.GetServers(); len(servers) == 0 {
-		return nil, nil
+		return nil, nil, err
 	}
-	_ = s.findAndRedactSecrets(cfg, path, content)
-	return cfg, nil
+	return cfg, content, nil
 }
 
-// findAndRedactSecrets scans all servers, redacts secrets in-place on cfg, and only returns an error.
-func (s *MCPScanner) findAndRedactSecrets(cfg MCPConfig, filePath string, fileContent []byte) error {
+// findAndRedactSecrets scans all servers, redacts secrets in-place on cfg, and returns the findings.
+func (s *MCPScanner) findAndRedactSecrets(cfg MCPConfig, filePath string, fileContent []byte) []SecretFinding {
 	if cfg == nil {
 		return nil
 	}
-	ctx := newSecretScanContext(filePath, fileContent)
+	ctx := newSecretScanContext(filePath, fileContent, s.entropyThreshold, s.hash)
 
 	servers := cfg.GetServers()
 	redactedServers := make(map[string]Server, len(servers))
@@ -97,11 +138,7 @@ func (s *MCPScanner) findAndRedactSecrets(cfg MCPConfig, filePath string, fileCo
 		}
 	}
 	setServers(cfg, redactedServers)
-	findings := ctx.Findings()
-	if len(findings) > 0 {
-		s.ScanResult.SecretFindings = append(s.ScanResult.SecretFindings, findings...)
-	}
-	return nil
+	return ctx.Findings()
 }
 
 // setServers writes the provided servers map back to the config.
@@ -122,5 +159,28 @@ func setServers(config MCPConfig, servers map[string]Server) {
 		c.MCPServers = servers
 	case *LibreChatConfigFile:
 		c.MCP.Servers = servers
+	case *EnvConfigFile:
+		c.Servers = servers
 	}
 }
+
+// rawServers returns the config's servers without filterConfig, so invalid entries are kept.
+func rawServers(config MCPConfig) map[string]Server {
+	switch c := config.(type) {
+	case *ClaudeConfigFile:
+		return c.MCPServers
+	case *VSCodeMCPConfig:
+		return c.Servers
+	case *VSCodeConfigFile:
+		if c.MCP != nil {
+			return c.MCP.Servers
+		}
+	case *ContinueConfigFile:
+		return c.MCP
+	case *GooseConfigFile:
+		return c.MCPServers
+	case *LibreChatConfigFile:
+		return c.MCP.Servers
+	}
+	return nil
+}
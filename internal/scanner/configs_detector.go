@@ -54,3 +54,13 @@ func hasNested(m map[string]interface{}, ks ...string) bool {
 	}
 	return true
 }
+
+// detectConfig returns the first detector matching a generically decoded config.
+func detectConfig(generic map[string]interface{}) (configDetector, bool) {
+	for _, d := range configDetectors {
+		if d.match(generic) {
+			return d, true
+		}
+	}
+	return configDetector{}, false
+}
@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	_ "embed"
+
+	"gopkg.in/yaml.v3"
+)
+
+// genericSecretKind is the kind of entropy-based secret findings, and the fallback for
+// secret kinds without guidance.
+const genericSecretKind = "Generic Secret"
+
+// explanationsYAML holds the guidance added by Explain; see explanations.yaml.
+//
+//go:embed explanations.yaml
+var explanationsYAML []byte
+
+// explanation is the guidance for one risk tier or secret kind.
+type explanation struct {
+	Explanation string `yaml:"explanation"`
+	Remediation string `yaml:"remediation"`
+}
+
+// explanations maps risk tiers and secret kinds to their guidance.
+//
+//nolint:gochecknoglobals // Parsed once from the embedded table.
+var explanations = mustLoadExplanations(explanationsYAML)
+
+func mustLoadExplanations(data []byte) map[string]explanation {
+	var m map[string]explanation
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		panic("scanner: invalid explanations.yaml: " + err.Error())
+	}
+	return m
+}
+
+// Explain sets Explanation and Remediation on every rated server, by risk tier, and on
+// every secret finding, by kind.
+func Explain(summary *ScanSummary) {
+	for i := range summary.Servers {
+		s := &summary.Servers[i]
+		if s.Rating != nil {
+			e := explanations[riskTierFromScore(s.Rating.RiskScore)]
+			s.Explanation, s.Remediation = e.Explanation, e.Remediation
+		}
+		explainSecrets(s.Secrets)
+	}
+	explainSecrets(summary.Secrets)
+}
+
+func explainSecrets(findings []SecretFinding) {
+	for i := range findings {
+		e, ok := explanations[findings[i].Kind]
+		if !ok {
+			e = explanations[genericSecretKind]
+		}
+		findings[i].Explanation, findings[i].Remediation = e.Explanation, e.Remediation
+	}
+}
@@ -0,0 +1,49 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplanations_Complete(t *testing.T) {
+	keys := []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", genericSecretKind}
+	for _, kind := range providerDisplayType {
+		keys = append(keys, kind)
+	}
+	for _, key := range keys {
+		e, ok := explanations[key]
+		if assert.True(t, ok, "no guidance for %q", key) {
+			assert.NotEmpty(t, e.Explanation, key)
+			assert.NotEmpty(t, e.Remediation, key)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	openai := SecretFinding{Kind: "OpenAI API Key", Key: "env.OPENAI_API_KEY", ServerName: "chat"}
+	summary := ScanSummary{
+		Servers: []ServerReport{
+			{Name: "evil", Rating: &SecurityRating{RiskScore: 9.5}},
+			{Name: "chat", Secrets: []SecretFinding{openai}},
+		},
+		Secrets: []SecretFinding{openai, {Kind: "Some Future Token", ServerName: "chat"}},
+	}
+	Explain(&summary)
+
+	assert.Equal(t, explanations["CRITICAL"].Remediation, summary.Servers[0].Remediation)
+	assert.Empty(t, summary.Servers[1].Explanation, "unrated servers have no tier guidance")
+	assert.Equal(t, "Rotate the key at platform.openai.com, move to an env manager like 1Password Secrets Automation",
+		summary.Servers[1].Secrets[0].Remediation)
+	assert.Equal(t, summary.Servers[1].Secrets[0], summary.Secrets[0])
+	assert.Equal(t, explanations[genericSecretKind].Remediation, summary.Secrets[1].Remediation, "unknown kinds fall back")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSummary(&buf, summary, FormatText))
+	out := buf.String()
+	assert.Contains(t, out, "    Why: "+explanations["CRITICAL"].Explanation+"\n")
+	assert.Contains(t, out, "      Fix: Rotate the key at platform.openai.com")
+}
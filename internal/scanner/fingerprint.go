@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// fingerprintFields are the launch settings covered by ServerFingerprint. url covers
+// remote servers, which have no command.
+//
+//nolint:gochecknoglobals // Fixed lookup table.
+var fingerprintFields = []string{"command", "args", "env", "url"}
+
+// ServerFingerprint returns the hex digest, under hash (SHA-256 when nil), of the server's
+// command, args, env and url, encoded as JSON with sorted keys. A change in any of them,
+// such as an npx package version bump, changes the fingerprint. Servers that are not
+// objects have none.
+func ServerFingerprint(server interface{}, hash HashFunc) string {
+	cfg, ok := server.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	launch := make(map[string]interface{}, len(fingerprintFields))
+	for _, k := range fingerprintFields {
+		if v, ok := cfg[k]; ok {
+			launch[k] = v
+		}
+	}
+	// encoding/json sorts map keys, so equal configs always encode identically.
+	b, err := json.Marshal(launch)
+	if err != nil {
+		return ""
+	}
+	return hashOrDefault(hash)(b)
+}
+
+// fingerprintEntityKey is the storage.Data.ScannedEntities key under which a server's
+// fingerprint is recorded.
+func fingerprintEntityKey(path, name string) string {
+	return "server|" + path + "|" + name
+}
+
+// CanonicalServerName folds the spellings configs use for the same server into one name:
+// it lower-cases name, turns underscores, dots and spaces into single dashes, and drops an
+// "mcp-" or "mcp-server-" prefix and a "-mcp" or "-mcp-server" suffix. Filesystem,
+// mcp_filesystem and filesystem-mcp all become filesystem.
+func CanonicalServerName(name string) string {
+	canonical := strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ' '
+	}), "-")
+	trimmed := canonical
+	for _, prefix := range []string{"mcp-server-", "mcp-"} {
+		trimmed = strings.TrimPrefix(trimmed, prefix)
+	}
+	for _, suffix := range []string{"-mcp-server", "-mcp"} {
+		trimmed = strings.TrimSuffix(trimmed, suffix)
+	}
+	if trimmed == "" {
+		return canonical
+	}
+	return trimmed
+}
@@ -0,0 +1,135 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ensigniasec/run-mcp/internal/storage"
+)
+
+func TestServerFingerprint(t *testing.T) {
+	base := map[string]interface{}{"command": "npx", "args": []interface{}{"-y", "pkg@1.0.0"}, "env": map[string]interface{}{"A": "1", "B": "2"}}
+	reordered := map[string]interface{}{"env": map[string]interface{}{"B": "2", "A": "1"}, "args": []interface{}{"-y", "pkg@1.0.0"}, "command": "npx", "disabled": true}
+	bumped := map[string]interface{}{"command": "npx", "args": []interface{}{"-y", "pkg@1.0.1"}, "env": map[string]interface{}{"A": "1", "B": "2"}}
+
+	assert.Len(t, ServerFingerprint(base, nil), 64)
+	assert.Equal(t, ServerFingerprint(base, nil), ServerFingerprint(reordered, nil), "key order and unrelated fields do not matter")
+	assert.NotEqual(t, ServerFingerprint(base, nil), ServerFingerprint(bumped, nil))
+	assert.Empty(t, ServerFingerprint("not an object", nil))
+}
+
+func TestFingerprintChangeDetection(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "mcp.json")
+	storagePath := filepath.Join(dir, "results.json")
+
+	scanWith := func(content, algorithm string) ServerReport {
+		t.Helper()
+		require.NoError(t, os.WriteFile(config, []byte(content), 0o600))
+		st, err := storage.NewOrExistingStorage(storagePath)
+		require.NoError(t, err)
+		hash, _, err := NewHashFunc(algorithm)
+		require.NoError(t, err)
+		fr, err := NewMCPScanner(nil, storagePath).WithHashFunc(hash).scanFile(config)
+		require.NoError(t, err)
+
+		summary := GenerateSummary(ScanResult{Files: []FileResult{*fr}})
+		rc := NewRatingsCollector(context.Background(), nil, st, WithHashAlgorithm(algorithm))
+		rc.ApplyToSummary(&summary)
+		rc.FlushAndStop()
+		require.NoError(t, st.Save())
+		require.Len(t, summary.Servers, 1)
+		return summary.Servers[0]
+	}
+	scan := func(content string) ServerReport {
+		t.Helper()
+		return scanWith(content, HashSHA256)
+	}
+
+	first := scan(`{"mcpServers": {"fs": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem@1.0.0", "/tmp"]}}}`)
+	assert.NotEmpty(t, first.Fingerprint)
+	assert.False(t, first.Changed, "nothing to compare against on the first scan")
+
+	same := scan(`{"mcpServers": {"fs": {"args": ["-y", "@modelcontextprotocol/server-filesystem@1.0.0", "/tmp"], "command": "npx"}}}`)
+	assert.False(t, same.Changed)
+
+	changed := scan(`{"mcpServers": {"fs": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem@1.0.1", "/tmp"]}}}`)
+	assert.True(t, changed.Changed)
+	assert.Equal(t, first.Fingerprint, changed.PreviousFingerprint)
+	assert.NotEqual(t, first.Fingerprint, changed.Fingerprint)
+
+	again := scan(`{"mcpServers": {"fs": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem@1.0.1", "/tmp"]}}}`)
+	assert.False(t, again.Changed, "the new fingerprint is recorded")
+
+	rehashed := scanWith(`{"mcpServers": {"fs": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem@1.0.1", "/tmp"]}}}`, HashBLAKE2b256)
+	assert.NotEqual(t, again.Fingerprint, rehashed.Fingerprint)
+	assert.False(t, rehashed.Changed, "switching --hash-algorithm is not a config change")
+
+	var buf bytes.Buffer
+	writeTextSummary(&buf, ScanSummary{Servers: []ServerReport{changed}}, TextLayout{})
+	assert.Contains(t, buf.String(), `Server: "fs" (`+config+`) ⚠️ CHANGED`)
+}
+
+func TestCanonicalServerName(t *testing.T) {
+	for name, want := range map[string]string{
+		"filesystem":               "filesystem",
+		"Filesystem":               "filesystem",
+		"mcp-filesystem":           "filesystem",
+		"MCP_Filesystem":           "filesystem",
+		"mcp-server-filesystem":    "filesystem",
+		"filesystem-mcp":           "filesystem",
+		"brave search":             "brave-search",
+		"github__copilot":          "github-copilot",
+		"mcp":                      "mcp",
+		"mcp-server":               "server",
+		"@scope/filesystem.server": "@scope/filesystem-server",
+	} {
+		assert.Equal(t, want, CanonicalServerName(name), name)
+	}
+}
+
+func TestGenerateSummary_NormalizedServerNames(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "a", "mcp.json")
+	second := filepath.Join(dir, "b", "mcp.json")
+	for path, name := range map[string]string{first: "filesystem", second: "Filesystem"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(`{"mcpServers": {"`+name+`": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem"]}}}`), 0o600))
+	}
+
+	result, err := NewMCPScanner([]string{first, second}, "").Scan()
+	require.NoError(t, err)
+	assert.Len(t, GenerateSummary(*result).Servers, 2, "names are kept apart by default")
+
+	rc := NewRatingsCollector(context.Background(), nil, nil)
+	result, err = NewMCPScanner([]string{first, second}, "").WithNormalizedServerNames().WithRatingsCollector(rc).Scan()
+	require.NoError(t, err)
+	rc.FlushAndStop()
+	summary := GenerateSummary(*result)
+	rc.ApplyToSummary(&summary)
+
+	require.Len(t, summary.Servers, 1)
+	assert.Equal(t, 1, summary.TotalServers)
+	sr := summary.Servers[0]
+	assert.Equal(t, "filesystem", sr.CanonicalName)
+	assert.Equal(t, "filesystem", sr.Name, "the first occurrence is kept")
+	assert.Equal(t, first, sr.Path)
+	require.NotEmpty(t, rc.idToServers)
+	for _, names := range rc.idToServers {
+		for _, name := range names {
+			assert.Equal(t, "filesystem", name, "the collector sees the canonical name")
+		}
+	}
+
+	var buf bytes.Buffer
+	summary.Servers[0].Name = "Filesystem"
+	writeServerHeading(&buf, 1, summary.Servers[0])
+	assert.Contains(t, buf.String(), `Server: "filesystem" (as "Filesystem")`)
+}
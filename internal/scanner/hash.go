@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"crypto/sha3"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Hash algorithms accepted by NewHashFunc for fingerprints and secret value hashes.
+const (
+	HashSHA256     = "sha256"
+	HashSHA3_256   = "sha3-256"
+	HashBLAKE2b256 = "blake2b-256"
+)
+
+// HashFunc returns the hex digest of data. A nil HashFunc means SHA-256.
+type HashFunc func(data []byte) string
+
+// NewHashFunc returns the HashFunc of a --hash-algorithm name and its canonical spelling.
+func NewHashFunc(algorithm string) (HashFunc, string, error) {
+	name := strings.ToLower(strings.TrimSpace(algorithm))
+	switch name {
+	case HashSHA256:
+		return sha256Hex, name, nil
+	case HashSHA3_256:
+		return func(data []byte) string {
+			h := sha3.Sum256(data)
+			return hex.EncodeToString(h[:])
+		}, name, nil
+	case HashBLAKE2b256:
+		return func(data []byte) string {
+			h := blake2b.Sum256(data)
+			return hex.EncodeToString(h[:])
+		}, name, nil
+	}
+	return nil, "", fmt.Errorf("invalid hash algorithm %q: must be one of %s, %s, %s",
+		algorithm, HashSHA256, HashSHA3_256, HashBLAKE2b256)
+}
+
+func sha256Hex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// hashOrDefault returns hash, or SHA-256 when it is nil.
+func hashOrDefault(hash HashFunc) HashFunc {
+	if hash == nil {
+		return sha256Hex
+	}
+	return hash
+}
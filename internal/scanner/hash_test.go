@@ -0,0 +1,31 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHashFunc(t *testing.T) {
+	// Digests of the empty string.
+	for algorithm, want := range map[string]string{
+		HashSHA256:     "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		HashSHA3_256:   "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a",
+		HashBLAKE2b256: "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8",
+	} {
+		hash, name, err := NewHashFunc(algorithm)
+		require.NoError(t, err)
+		assert.Equal(t, algorithm, name)
+		assert.Equal(t, want, hash(nil), algorithm)
+	}
+
+	_, name, err := NewHashFunc(" SHA3-256 ")
+	require.NoError(t, err)
+	assert.Equal(t, HashSHA3_256, name)
+	_, _, err = NewHashFunc("md5")
+	require.Error(t, err)
+
+	assert.Equal(t, sha256Hex([]byte("x")), hashOrDefault(nil)([]byte("x")))
+}
@@ -0,0 +1,144 @@
+package scanner
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ensigniasec/run-mcp/internal/storage"
+)
+
+// NewHistoryEntry captures the servers (and their ratings) reported in summary.
+func NewHistoryEntry(summary ScanSummary, at time.Time) storage.ScanHistoryEntry {
+	entry := storage.ScanHistoryEntry{ScannedAt: at.UTC(), Servers: make([]storage.HistoryServer, 0, len(summary.Servers))}
+	for _, s := range summary.Servers {
+		entry.Servers = append(entry.Servers, storage.HistoryServer{Name: s.Name, Path: s.Path, Rating: historyRating(s.Rating)})
+	}
+	return entry
+}
+
+// historyRating reduces a rating to a comparable string; unrated servers yield "".
+func historyRating(r *SecurityRating) string {
+	if r == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.1f %s", r.RiskScore, r.Category)
+}
+
+// FilterSeenBefore removes servers that a history entry at or before since already reported
+// with the same rating, along with their secret findings.
+func FilterSeenBefore(summary *ScanSummary, history []storage.ScanHistoryEntry, since time.Time) {
+	seen := make(map[storage.HistoryServer]struct{})
+	for _, e := range history {
+		if e.ScannedAt.After(since) {
+			continue
+		}
+		for _, s := range e.Servers {
+			seen[s] = struct{}{}
+		}
+	}
+	filterServers(summary, func(s ServerReport) bool {
+		_, old := seen[storage.HistoryServer{Name: s.Name, Path: s.Path, Rating: historyRating(s.Rating)}]
+		return !old
+	})
+}
+
+// FilterChangedFiles keeps only servers and secret findings from files in changed
+// (a set of absolute paths).
+func FilterChangedFiles(summary *ScanSummary, changed map[string]struct{}) {
+	inChanged := func(path string) bool {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return false
+		}
+		if _, ok := changed[abs]; ok {
+			return true
+		}
+		// git reports the resolved repository root; compare against the resolved path too.
+		if real, err := filepath.EvalSymlinks(abs); err == nil {
+			_, ok := changed[real]
+			return ok
+		}
+		return false
+	}
+	filterServers(summary, func(s ServerReport) bool { return inChanged(s.Path) })
+	kept := summary.Secrets[:0]
+	for _, f := range summary.Secrets {
+		for path := range f.Occurrences {
+			if inChanged(path) {
+				kept = append(kept, f)
+				break
+			}
+		}
+	}
+	summary.Secrets = kept
+	summary.TotalFindings = len(summary.Secrets)
+}
+
+// filterServers keeps servers for which keep returns true and drops secret findings
+// that belonged only to removed servers.
+func filterServers(summary *ScanSummary, keep func(ServerReport) bool) {
+	type serverKey struct{ name, path string }
+	removed := make(map[serverKey]struct{})
+	servers := summary.Servers[:0]
+	for _, s := range summary.Servers {
+		if keep(s) {
+			servers = append(servers, s)
+		} else {
+			removed[serverKey{s.Name, s.Path}] = struct{}{}
+		}
+	}
+	summary.Servers = servers
+	summary.TotalServers = len(servers)
+
+	secrets := summary.Secrets[:0]
+	for _, f := range summary.Secrets {
+		drop := len(f.Occurrences) > 0
+		for path := range f.Occurrences {
+			if _, ok := removed[serverKey{f.ServerName, path}]; !ok {
+				drop = false
+				break
+			}
+		}
+		if !drop {
+			secrets = append(secrets, f)
+		}
+	}
+	summary.Secrets = secrets
+	summary.TotalFindings = len(secrets)
+}
+
+// GitChangedFiles returns the absolute paths of files changed between ref and HEAD
+// in the git repository containing the working directory.
+func GitChangedFiles(ref string) (map[string]struct{}, error) {
+	top, err := runGit("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root := strings.TrimSpace(top)
+	out, err := runGit("diff", "--name-only", ref, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	changed := make(map[string]struct{})
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			changed[filepath.Join(root, filepath.FromSlash(line))] = struct{}{}
+		}
+	}
+	return changed, nil
+}
+
+func runGit(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
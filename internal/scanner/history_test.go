@@ -0,0 +1,96 @@
+package scanner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ensigniasec/run-mcp/internal/storage"
+)
+
+func historySummary() ScanSummary {
+	secret := NewSecretFinding("old", "OpenAI API Key", "env.KEY", "sk-proj-abcT3BlbkFJdef", "HIGH", "/a/mcp.json", 1, nil) //nolint:gosec // test data
+	return ScanSummary{
+		Servers: []ServerReport{
+			{Name: "old", Path: "/a/mcp.json"},
+			{Name: "rerated", Path: "/a/mcp.json", Rating: &SecurityRating{RiskScore: 8, Category: "HIGH"}},
+			{Name: "new", Path: "/b/mcp.json"},
+		},
+		Secrets:       []SecretFinding{secret},
+		TotalServers:  3,
+		TotalFindings: 1,
+	}
+}
+
+func TestFilterSeenBefore(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := []storage.ScanHistoryEntry{
+		{ScannedAt: t0, Servers: []storage.HistoryServer{
+			{Name: "old", Path: "/a/mcp.json"},
+			{Name: "rerated", Path: "/a/mcp.json", Rating: "2.0 LOW"},
+		}},
+		// Recorded after the cutoff, so it must not hide "new".
+		{ScannedAt: t0.Add(48 * time.Hour), Servers: []storage.HistoryServer{{Name: "new", Path: "/b/mcp.json"}}},
+	}
+
+	summary := historySummary()
+	FilterSeenBefore(&summary, history, t0.Add(time.Hour))
+
+	names := []string{}
+	for _, s := range summary.Servers {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"rerated", "new"}, names)
+	assert.Equal(t, 2, summary.TotalServers)
+	assert.Empty(t, summary.Secrets, "secrets of filtered servers are dropped")
+	assert.Equal(t, 0, summary.TotalFindings)
+}
+
+func TestNewHistoryEntry(t *testing.T) {
+	entry := NewHistoryEntry(historySummary(), time.Unix(0, 0))
+	require.Len(t, entry.Servers, 3)
+	assert.Equal(t, storage.HistoryServer{Name: "rerated", Path: "/a/mcp.json", Rating: "8.0 HIGH"}, entry.Servers[1])
+}
+
+func TestFilterChangedFiles(t *testing.T) {
+	summary := historySummary()
+	FilterChangedFiles(&summary, map[string]struct{}{"/b/mcp.json": {}})
+	require.Len(t, summary.Servers, 1)
+	assert.Equal(t, "new", summary.Servers[0].Name)
+	assert.Empty(t, summary.Secrets)
+}
+
+func TestGitChangedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "a.json"), []byte("{}"), 0o600))
+	git("add", ".")
+	git("commit", "-qm", "one")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "b.json"), []byte("{}"), 0o600))
+	git("add", ".")
+	git("commit", "-qm", "two")
+
+	t.Chdir(repo)
+	changed, err := GitChangedFiles("HEAD~1")
+	require.NoError(t, err)
+	real, err := filepath.EvalSymlinks(repo)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{filepath.Join(real, "b.json"): {}}, changed)
+
+	_, err = GitChangedFiles("no-such-ref")
+	require.Error(t, err)
+}
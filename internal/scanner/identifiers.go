@@ -1,12 +1,17 @@
 package scanner
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 
+	"github.com/sirupsen/logrus"
+
 	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
 )
 
@@ -19,15 +24,52 @@ func NewIdentifierExtractor() *IdentifierExtractor { //nolint:ireturn
 	return &IdentifierExtractor{}
 }
 
+// IdentifierConfidence tells how an identifier was inferred.
+type IdentifierConfidence string
+
+const (
+	// IdentifierConfirmed identifiers are read from the launch command, URL or image.
+	IdentifierConfirmed IdentifierConfidence = "confirmed"
+	// IdentifierTentative identifiers are guessed from naming conventions alone, such as a
+	// bare mcp-server-* binary assumed to be a Rust crate.
+	IdentifierTentative IdentifierConfidence = "tentative"
+)
+
+// ScoredIdentifier is a TargetIdentifier with the confidence of its inference. The API
+// schema of TargetIdentifier is closed, so the confidence is kept beside the generated type
+// rather than sent with it.
+type ScoredIdentifier struct {
+	apigen.TargetIdentifier
+	IdentifierConfidence IdentifierConfidence `json:"confidence,omitempty"`
+}
+
 // ExtractIdentifiers inspects a single server config and returns zero or more identifiers.
+// configFilePath is the config file the server was read from; local scripts are identified
+// by the package manifest next to it. Pass "" when there is no file.
 // The order is deterministic and stable across platforms.
-func (x *IdentifierExtractor) ExtractIdentifiers(serverName string, serverConfig interface{}) []apigen.TargetIdentifier {
+func (x *IdentifierExtractor) ExtractIdentifiers(configFilePath, serverName string, serverConfig interface{}) []apigen.TargetIdentifier {
+	scored := x.ExtractScoredIdentifiers(configFilePath, serverName, serverConfig)
+	if scored == nil {
+		return nil
+	}
+	out := make([]apigen.TargetIdentifier, len(scored))
+	for i, id := range scored {
+		out[i] = id.TargetIdentifier
+	}
+	return out
+}
+
+// ExtractScoredIdentifiers is ExtractIdentifiers with the confidence of each identifier.
+// When no package is found in the launch command, a bare binary named like a Rust MCP server
+// yields a tentative cargo purl.
+func (x *IdentifierExtractor) ExtractScoredIdentifiers(configFilePath, serverName string, serverConfig interface{}) []ScoredIdentifier {
 	cfg, ok := serverConfig.(map[string]interface{})
 	if !ok || cfg == nil {
 		return nil
 	}
 
 	var out []apigen.TargetIdentifier
+	tentative := make(map[apigen.TargetIdentifier]bool)
 
 	// 1) URL-based servers (http/sse): accept common keys: url, endpoint, baseUrl.
 	for _, key := range []string{"url", "endpoint", "baseUrl"} {
@@ -39,9 +81,18 @@ func (x *IdentifierExtractor) ExtractIdentifiers(serverName string, serverConfig
 		}
 	}
 
-	// 2) Stdio package runners: infer purl from command/args heuristics.
+	// 2) Stdio package runners: infer purl from command/args heuristics, then from the package
+	// manifest next to the config for local scripts, falling back to the naming convention of
+	// Rust MCP server binaries.
+	manifestPurl := ""
 	if p := extractPurlFromStdio(cfg); p != "" {
 		out = append(out, apigen.TargetIdentifier{Kind: apigen.Purl, Value: p})
+	} else if manifestPurl = extractPurlFromNeighbourLockfile(configFilePath, cfg); manifestPurl != "" {
+		out = append(out, apigen.TargetIdentifier{Kind: apigen.Purl, Value: manifestPurl})
+	} else if p := rustBinaryPurl(cfg); p != "" {
+		id := apigen.TargetIdentifier{Kind: apigen.Purl, Value: p}
+		tentative[id] = true
+		out = append(out, id)
 	}
 
 	// 3) OCI image references inside docker/podman invocations or explicit images.
@@ -53,17 +104,26 @@ func (x *IdentifierExtractor) ExtractIdentifiers(serverName string, serverConfig
 	if org, repo := extractRepoHint(cfg, serverName); org != "" && repo != "" {
 		out = append(out, apigen.TargetIdentifier{Kind: apigen.Repo, Value: org + "/" + repo})
 	}
-	// 4b) Official repo inference from built artifacts.
-	if r := extractRepoFromNodeDist(cfg); r != "" {
+	// 4b) Official repo inference from built artifacts, unless a manifest named the package.
+	if r := extractRepoFromNodeDist(cfg); r != "" && manifestPurl == "" {
 		out = append(out, apigen.TargetIdentifier{Kind: apigen.Repo, Value: r})
 	}
 
 	// Deduplicate while preserving order.
-	return dedupeIdentifiers(out)
+	var scored []ScoredIdentifier
+	for _, id := range dedupeIdentifiers(out) {
+		confidence := IdentifierConfirmed
+		if tentative[id] {
+			confidence = IdentifierTentative
+		}
+		scored = append(scored, ScoredIdentifier{TargetIdentifier: id, IdentifierConfidence: confidence})
+	}
+	return scored
 }
 
-// ExtractIdentifiersFromServers returns identifiers for all servers in a config map.
-func (x *IdentifierExtractor) ExtractIdentifiersFromServers(servers map[string]Server) []apigen.TargetIdentifier {
+// ExtractIdentifiersFromServers returns identifiers for all servers in a config map read
+// from configFilePath.
+func (x *IdentifierExtractor) ExtractIdentifiersFromServers(configFilePath string, servers map[string]Server) []apigen.TargetIdentifier {
 	if len(servers) == 0 {
 		return nil
 	}
@@ -74,7 +134,7 @@ func (x *IdentifierExtractor) ExtractIdentifiersFromServers(servers map[string]S
 	sort.Strings(names)
 	var all []apigen.TargetIdentifier
 	for _, name := range names {
-		all = append(all, x.ExtractIdentifiers(name, servers[name])...)
+		all = append(all, x.ExtractIdentifiers(configFilePath, name, servers[name])...)
 	}
 	return dedupeIdentifiers(all)
 }
@@ -160,16 +220,249 @@ func extractPurlFromStdio(cfg map[string]interface{}) string { //nolint:gocyclo,
 				return toPurlPyPI(mod)
 			}
 		}
-		if cur == "pipx" && i+2 < len(tokens) && tokens[i+1] == "run" {
-			if isPyPackageToken(tokens[i+2]) {
-				return toPurlPyPI(tokens[i+2])
+	}
+
+	if spec := pipxPackageSpec(tokens); spec != "" {
+		return toPurlPyPISpec(spec)
+	}
+
+	if spec := cargoBinstallSpec(tokens); spec != "" {
+		return toPurlCargoSpec(spec)
+	}
+
+	return ""
+}
+
+// cargoBinstallValueFlags are cargo binstall flags followed by a value.
+//
+//nolint:gochecknoglobals // Fixed lookup table.
+var cargoBinstallValueFlags = map[string]bool{
+	"--version": true, "--targets": true, "--install-path": true, "--root": true, "--index": true,
+	"--registry": true, "--git": true, "--manifest-path": true, "--pkg-url": true, "--bin-dir": true,
+	"--pkg-fmt": true, "--strategies": true, "--disable-strategies": true, "--log-level": true,
+}
+
+// cargoBinstallSpec returns the crate of `cargo binstall [flags] <crate>[@version]` or
+// `cargo-binstall ...`, with a --version flag appended as @version. Like pipxPackageSpec it
+// also looks inside shell strings.
+func cargoBinstallSpec(tokens []string) string {
+	var words []string
+	for _, tok := range tokens {
+		words = append(words, strings.Fields(tok)...)
+	}
+	for i, w := range words {
+		start := -1
+		switch {
+		case w == "cargo-binstall":
+			start = i + 1
+		case w == "cargo" && i+1 < len(words) && words[i+1] == "binstall":
+			start = i + 2
+		}
+		if start < 0 {
+			continue
+		}
+		var crate, version string
+		for k := start; k < len(words) && crate == ""; k++ {
+			switch flag, value, hasValue := strings.Cut(words[k], "="); {
+			case flag == "--version" && hasValue:
+				version = value
+			case flag == "--version" && k+1 < len(words):
+				version = words[k+1]
+				k++
+			case cargoBinstallValueFlags[flag] && !hasValue:
+				k++
+			case strings.HasPrefix(flag, "-"):
+			case words[k] == "&&" || words[k] == ";":
+				k = len(words)
+			default:
+				crate = words[k]
 			}
 		}
+		if crate == "" {
+			continue
+		}
+		if version != "" && !strings.Contains(crate, "@") {
+			crate += "@" + version
+		}
+		return crate
 	}
+	return ""
+}
+
+// toPurlCargoSpec converts a crate of the form name or name@version into a cargo purl.
+func toPurlCargoSpec(spec string) string {
+	name, version, _ := strings.Cut(spec, "@")
+	if !isAlphaNumPlus(name) {
+		return ""
+	}
+	purl := "pkg:cargo/" + name
+	if version != "" {
+		purl += "@" + version
+	}
+	return purl
+}
+
+// rustMCPBinaryRe matches the binary names Rust MCP servers conventionally install, such as
+// mcp-server-git.
+var rustMCPBinaryRe = regexp.MustCompile(`^mcp-server-[a-z0-9][a-z0-9_-]*$`)
+
+// rustBinaryPurl returns a cargo purl for a server launched directly by a binary named like
+// a Rust MCP server. The crate name is a guess from the binary name alone.
+func rustBinaryPurl(cfg map[string]interface{}) string {
+	tokens := launchTokens(cfg)
+	if len(tokens) == 0 {
+		return ""
+	}
+	name := strings.TrimSuffix(filepath.Base(strings.ReplaceAll(tokens[0], "\\", "/")), ".exe")
+	if !rustMCPBinaryRe.MatchString(name) {
+		return ""
+	}
+	return "pkg:cargo/" + name
+}
 
+// argsInsideDir reports whether every argument, and the value of every --flag=value
+// argument, resolves to a path inside dir when taken as a path relative to it. Arguments
+// that are not paths, such as "run" or "-y", trivially do.
+func argsInsideDir(dir string, args []string) bool {
+	for _, arg := range args {
+		if _, value, ok := strings.Cut(arg, "="); ok && strings.HasPrefix(arg, "-") {
+			arg = value
+		}
+		path := filepath.FromSlash(arg)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeRuntimes and pythonRuntimes run local scripts described by a package.json or a
+// pyproject.toml.
+//
+//nolint:gochecknoglobals // Fixed lookup tables.
+var (
+	nodeRuntimes   = map[string]bool{"node": true, "bun": true, "deno": true, "tsx": true, "ts-node": true}
+	pythonRuntimes = map[string]bool{"python": true, "python3": true, "uv": true, "poetry": true}
+)
+
+// extractPurlFromNeighbourLockfile identifies a server that runs a local script, such as
+// `node dist/index.js`, by the package manifest in the directory of configFilePath: the name
+// and version of package.json (or package-lock.json) for Node runtimes, and of the
+// [tool.poetry] or [project] table of pyproject.toml for Python ones. The manifest is only
+// used when every path argument stays inside that directory, so `node /opt/other/index.js`
+// is not taken for the package next to the config.
+func extractPurlFromNeighbourLockfile(configFilePath string, cfg map[string]interface{}) string {
+	tokens := launchTokens(cfg)
+	if configFilePath == "" || len(tokens) == 0 {
+		return ""
+	}
+	dir := filepath.Dir(configFilePath)
+	if !argsInsideDir(dir, tokens[1:]) {
+		return ""
+	}
+	runtime := strings.TrimSuffix(filepath.Base(strings.ReplaceAll(tokens[0], "\\", "/")), ".exe")
+	switch {
+	case nodeRuntimes[runtime]:
+		for _, manifest := range []string{"package.json", "package-lock.json"} {
+			var pkg struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			}
+			data, err := os.ReadFile(filepath.Join(dir, manifest))
+			if err != nil || json.Unmarshal(data, &pkg) != nil || !isNpmPackageToken(pkg.Name) {
+				continue
+			}
+			if pkg.Version != "" {
+				return toPurlNPM(pkg.Name + "@" + pkg.Version)
+			}
+			return toPurlNPM(pkg.Name)
+		}
+	case pythonRuntimes[runtime]:
+		data, err := os.ReadFile(filepath.Join(dir, "pyproject.toml"))
+		if err != nil {
+			return ""
+		}
+		doc, err := decodeTOML(data)
+		if err != nil {
+			logrus.Debugf("Ignoring unparsable %s: %v", filepath.Join(dir, "pyproject.toml"), err)
+			return ""
+		}
+		for _, table := range []map[string]interface{}{getMap(getMap(doc, "tool"), "poetry"), getMap(doc, "project")} {
+			name := getString(table, "name")
+			if name == "" {
+				continue
+			}
+			spec := name
+			if version := getString(table, "version"); version != "" {
+				spec += "==" + version
+			}
+			return toPurlPyPISpec(spec)
+		}
+	}
 	return ""
 }
 
+// pipxValueFlags are pipx run/install flags followed by a value.
+//
+//nolint:gochecknoglobals // Fixed lookup table.
+var pipxValueFlags = map[string]bool{"--python": true, "--pip-args": true, "--index-url": true}
+
+// pipxPackageSpec returns the package of `pipx run [flags] <package>`, or of
+// `pipx install [flags] <package> && <binary>`. Tokens are split on whitespace first, so
+// both forms are also found inside a shell string such as `sh -c "pipx install x && x"`.
+func pipxPackageSpec(tokens []string) string {
+	var words []string
+	for _, tok := range tokens {
+		words = append(words, strings.Fields(tok)...)
+	}
+	for i := 0; i+1 < len(words); i++ {
+		if words[i] != "pipx" || (words[i+1] != "run" && words[i+1] != "install") {
+			continue
+		}
+		install := words[i+1] == "install"
+		for k := i + 2; k < len(words); k++ {
+			w := words[k]
+			if w == "--spec" && k+1 < len(words) {
+				return words[k+1]
+			}
+			if pipxValueFlags[w] {
+				k++
+				continue
+			}
+			if strings.HasPrefix(w, "-") {
+				continue
+			}
+			// An install alone names no server; it must be followed by the binary it provides.
+			if install && (k+2 >= len(words) || words[k+1] != "&&") {
+				break
+			}
+			return w
+		}
+	}
+	return ""
+}
+
+// toPurlPyPISpec converts a pip requirement of the form name, name==version or
+// name@version into a PyPI purl.
+func toPurlPyPISpec(spec string) string {
+	name, version, ok := strings.Cut(spec, "==")
+	if !ok {
+		name, version, _ = strings.Cut(spec, "@")
+	}
+	if !isPyPackageToken(name) {
+		return ""
+	}
+	purl := toPurlPyPI(name)
+	if version != "" {
+		purl += "@" + version
+	}
+	return purl
+}
+
 func isNpmPackageToken(tok string) bool {
 	if tok == "" {
 		return false
@@ -203,7 +496,9 @@ func isAlphaNumPlus(s string) bool {
 	return true
 }
 
-func extractOCIFromDocker(cfg map[string]interface{}) string { //nolint:gocyclo,gocognit
+// launchTokens returns the command and args of a server, from the top level and any nested
+// "stdio" block, as one token list.
+func launchTokens(cfg map[string]interface{}) []string {
 	var tokens []string
 	for _, m := range []map[string]interface{}{cfg, getMap(cfg, "stdio")} {
 		if m == nil {
@@ -225,6 +520,11 @@ func extractOCIFromDocker(cfg map[string]interface{}) string { //nolint:gocyclo,
 			}
 		}
 	}
+	return tokens
+}
+
+func extractOCIFromDocker(cfg map[string]interface{}) string { //nolint:gocyclo,gocognit
+	tokens := launchTokens(cfg)
 	if len(tokens) == 0 {
 		return ""
 	}
@@ -330,7 +630,8 @@ func getMap(m map[string]interface{}, key string) map[string]interface{} {
 
 func takesValue(flag string) bool {
 	switch flag {
-	case "-e", "--env", "-v", "--volume", "-p", "--publish", "--name", "--network":
+	case "-e", "--env", "-v", "--volume", "-p", "--publish", "--name", "--network", "--net",
+		"--cap-add", "--cap-drop", "--mount":
 		return true
 	default:
 		return false
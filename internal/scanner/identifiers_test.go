@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -43,6 +44,62 @@ func TestIdentifierExtractor_SingleServer_Cases(t *testing.T) {
 			},
 			want: []apigen.TargetIdentifier{{Kind: apigen.Purl, Value: "pkg:pypi/consult7"}},
 		},
+		{
+			name: "pipx run pinned pypi",
+			server: Server{
+				"command": "pipx",
+				"args":    []interface{}{"run", "--no-cache", "mcp-server-fetch@2025.4.7"},
+			},
+			want: []apigen.TargetIdentifier{{Kind: apigen.Purl, Value: "pkg:pypi/mcp-server-fetch@2025.4.7"}},
+		},
+		{
+			name: "pipx run pip requirement",
+			server: Server{
+				"command": "pipx",
+				"args":    []interface{}{"run", "--python", "3.12", "mcp_server_time==0.6.2"},
+			},
+			want: []apigen.TargetIdentifier{{Kind: apigen.Purl, Value: "pkg:pypi/mcp-server-time@0.6.2"}},
+		},
+		{
+			name: "pipx installed binary",
+			server: Server{
+				"command": "pipx",
+				"args":    []interface{}{"install", "mcp-server-git", "&&", "mcp-server-git", "--repository", "."},
+			},
+			want: []apigen.TargetIdentifier{{Kind: apigen.Purl, Value: "pkg:pypi/mcp-server-git"}},
+		},
+		{
+			name: "pipx installed binary in shell",
+			server: Server{
+				"command": "sh",
+				"args":    []interface{}{"-c", "pipx install --force mcp-server-sqlite && mcp-server-sqlite --db-path test.db"},
+			},
+			want: []apigen.TargetIdentifier{{Kind: apigen.Purl, Value: "pkg:pypi/mcp-server-sqlite"}},
+		},
+		{
+			name: "cargo binstall pinned crate",
+			server: Server{
+				"command": "cargo",
+				"args":    []interface{}{"binstall", "-y", "--root", "/opt/mcp", "mcp-server-rs@0.3.1"},
+			},
+			want: []apigen.TargetIdentifier{{Kind: apigen.Purl, Value: "pkg:cargo/mcp-server-rs@0.3.1"}},
+		},
+		{
+			name: "cargo binstall binary with version flag in shell",
+			server: Server{
+				"command": "sh",
+				"args":    []interface{}{"-c", "cargo-binstall --no-confirm --version=1.0.2 rust-mcp-fs && rust-mcp-fs ~/src"},
+			},
+			want: []apigen.TargetIdentifier{{Kind: apigen.Purl, Value: "pkg:cargo/rust-mcp-fs@1.0.2"}},
+		},
+		{
+			name: "bare rust mcp binary",
+			server: Server{
+				"command": "/home/dev/.cargo/bin/mcp-server-git",
+				"args":    []interface{}{"--repository", "."},
+			},
+			want: []apigen.TargetIdentifier{{Kind: apigen.Purl, Value: "pkg:cargo/mcp-server-git"}},
+		},
 		{
 			name: "docker run image",
 			server: Server{
@@ -63,7 +120,7 @@ func TestIdentifierExtractor_SingleServer_Cases(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := x.ExtractIdentifiers(tt.name, tt.server)
+			got := x.ExtractIdentifiers("", tt.name, tt.server)
 			if len(got) != len(tt.want) {
 				t.Fatalf("len(got)=%d len(want)=%d got=%v", len(got), len(tt.want), got)
 			}
@@ -76,6 +133,38 @@ func TestIdentifierExtractor_SingleServer_Cases(t *testing.T) {
 	}
 }
 
+func TestIdentifierExtractor_Confidence(t *testing.T) {
+	t.Parallel()
+
+	x := NewIdentifierExtractor()
+	tests := []struct {
+		name   string
+		server Server
+		want   IdentifierConfidence
+	}{
+		{"cargo binstall", Server{"command": "cargo", "args": []interface{}{"binstall", "mcp-server-rs"}}, IdentifierConfirmed},
+		{"bare binary", Server{"command": "mcp-server-git"}, IdentifierTentative},
+		{"windows binary", Server{"command": `C:\tools\mcp-server-git.exe`}, IdentifierTentative},
+		{"npx wins over the binary name", Server{"command": "npx", "args": []interface{}{"mcp-server-git"}}, IdentifierConfirmed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := x.ExtractScoredIdentifiers("", tt.name, tt.server)
+			if len(got) != 1 || got[0].Kind != apigen.Purl {
+				t.Fatalf("want one purl, got=%v", got)
+			}
+			if got[0].IdentifierConfidence != tt.want {
+				t.Fatalf("confidence of %s: got=%s want=%s", got[0].Value, got[0].IdentifierConfidence, tt.want)
+			}
+		})
+	}
+
+	if got := x.ExtractScoredIdentifiers("", "other", Server{"command": "my-server"}); len(got) != 0 {
+		t.Fatalf("binaries not named like MCP servers get no identifier, got=%v", got)
+	}
+}
+
 func TestIdentifierExtractor_FromConfigFiles(t *testing.T) {
 	t.Parallel()
 
@@ -89,7 +178,7 @@ func TestIdentifierExtractor_FromConfigFiles(t *testing.T) {
 		t.Fatalf("failed to parse %s: %v", p, err)
 	}
 	servers := cfg.GetServers()
-	ids := x.ExtractIdentifiersFromServers(servers)
+	ids := x.ExtractIdentifiersFromServers("", servers)
 	// Expect URL for github and PURL for context7.
 	assertHas(t, ids, apigen.Url, "https://api.githubcopilot.com/mcp")
 	assertHasPrefix(t, ids, apigen.Purl, "pkg:npm/@upstash/context7-mcp")
@@ -100,10 +189,89 @@ func TestIdentifierExtractor_FromConfigFiles(t *testing.T) {
 	if err != nil || cfg == nil {
 		t.Fatalf("failed to parse %s: %v", p, err)
 	}
-	ids = x.ExtractIdentifiersFromServers(cfg.GetServers())
+	ids = x.ExtractIdentifiersFromServers("", cfg.GetServers())
 	assertHas(t, ids, apigen.Oci, "ghcr.io/github/github-mcp-server")
 }
 
+func TestIdentifierExtractor_NeighbourLockfile(t *testing.T) {
+	t.Parallel()
+
+	x := NewIdentifierExtractor()
+	nodeDist := Server{"command": "node", "args": []interface{}{"dist/filesystem/index.js"}}
+	tests := []struct {
+		name     string
+		files    map[string]string
+		server   Server
+		wantPurl string
+	}{
+		{
+			name:     "package.json",
+			files:    map[string]string{"package.json": `{"name": "@acme/fs-mcp", "version": "1.4.0"}`},
+			server:   nodeDist,
+			wantPurl: "pkg:npm/@acme/fs-mcp@1.4.0",
+		},
+		{
+			name:     "package-lock.json",
+			files:    map[string]string{"package-lock.json": `{"name": "fs-mcp", "version": "0.2.1", "lockfileVersion": 3}`},
+			server:   nodeDist,
+			wantPurl: "pkg:npm/fs-mcp@0.2.1",
+		},
+		{
+			name:     "poetry",
+			files:    map[string]string{"pyproject.toml": "[tool.poetry]\nname = \"acme-mcp\"\nversion = \"2.0.0\"\n"},
+			server:   Server{"command": "python", "args": []interface{}{"src/server.py"}},
+			wantPurl: "pkg:pypi/acme-mcp@2.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ids := x.ExtractIdentifiers(filepath.Join(dir, "mcp.json"), "local", tt.server)
+			assertHas(t, ids, apigen.Purl, tt.wantPurl)
+			for _, id := range ids {
+				if id.Kind == apigen.Repo {
+					t.Fatalf("manifest should replace the node dist heuristic, got=%v", ids)
+				}
+			}
+		})
+	}
+
+	// A script outside the config's directory is not the neighbouring package.
+	outside := filepath.Join(string(filepath.Separator), "opt", "other-server")
+	for _, server := range []Server{
+		{"command": "node", "args": []interface{}{filepath.Join(outside, "index.js")}},
+		{"command": "node", "args": []interface{}{"../other-server/index.js"}},
+		{"command": "uv", "args": []interface{}{"--directory=" + outside, "run", "server.py"}},
+	} {
+		dir := t.TempDir()
+		manifests := map[string]string{
+			"package.json":   `{"name": "repo-root", "version": "1.0.0"}`,
+			"pyproject.toml": "[project]\nname = \"repo-root\"\n",
+		}
+		for name, content := range manifests {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		ids := x.ExtractIdentifiers(filepath.Join(dir, ".mcp.json"), "local", server)
+		for _, id := range ids {
+			if strings.Contains(id.Value, "repo-root") {
+				t.Fatalf("%v: manifest of the config directory used, got=%v", server, ids)
+			}
+		}
+	}
+
+	// Without a manifest the node dist heuristic still applies.
+	ids := x.ExtractIdentifiers(filepath.Join(t.TempDir(), "mcp.json"), "fs", nodeDist)
+	assertHas(t, ids, apigen.Repo, "modelcontextprotocol/servers")
+}
+
 func assertHas(t *testing.T, ids []apigen.TargetIdentifier, k apigen.IdentifierKind, v string) {
 	t.Helper()
 	for _, id := range ids {
@@ -0,0 +1,224 @@
+package scanner
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Misconfiguration rules reported in MisconfigFinding.Rule.
+const (
+	MisconfigPrivileged    = "docker-privileged"
+	MisconfigCapAdd        = "docker-cap-add"
+	MisconfigHostNetwork   = "docker-host-network"
+	MisconfigHostRootMount = "docker-host-root-mount"
+	MisconfigShellCommand  = "shell-command"
+	MisconfigBroadFSAccess = "broad-filesystem-access"
+)
+
+// filesystemServerPurl is the reference MCP filesystem server, whose args are the directories
+// it may access.
+const filesystemServerPurl = "pkg:npm/@modelcontextprotocol/server-filesystem"
+
+// driveRootRe matches a Windows drive root such as C:\ or D:/.
+var driveRootRe = regexp.MustCompile(`^[A-Za-z]:[\\/]?$`)
+
+// shellVarRe matches $VAR, ${VAR} and %VAR% references in a shell command line.
+var shellVarRe = regexp.MustCompile(`\$[A-Za-z_]\w*|\$\{[A-Za-z_]\w*\}|%[A-Za-z_]\w*%`)
+
+// MisconfigFinding is a dangerous launch setting in a server config, such as a container
+// started with --privileged.
+type MisconfigFinding struct {
+	ServerName  string `json:"server_name"`
+	Path        string `json:"path"`
+	Rule        string `json:"rule"`
+	Severity    string `json:"severity"` // MEDIUM, HIGH or CRITICAL
+	Flag        string `json:"flag"`     // The offending option as written, e.g. "--cap-add SYS_ADMIN"
+	Description string `json:"description"`
+	// Hint suggests how to fix or accept the finding.
+	Hint string `json:"hint,omitempty"`
+}
+
+// misconfigMessage describes a misconfiguration finding on one line, for the report formats.
+func misconfigMessage(m MisconfigFinding) string {
+	msg := fmt.Sprintf("MCP server %q: %s (%s)", m.ServerName, m.Description, m.Flag)
+	if m.Hint != "" {
+		msg += ". " + m.Hint
+	}
+	return msg
+}
+
+// DetectMisconfigs inspects the docker/podman run options of a server for privileged mode,
+// added capabilities, host networking and a bind mount of the host root filesystem. Only
+// options before the image are checked; later tokens belong to the container command.
+func DetectMisconfigs(serverName, filePath string, server interface{}) []MisconfigFinding {
+	cfg, ok := server.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	tokens := launchTokens(cfg)
+	var out []MisconfigFinding
+	add := func(rule, severity, flag, description string) {
+		out = append(out, MisconfigFinding{
+			ServerName:  serverName,
+			Path:        filePath,
+			Rule:        rule,
+			Severity:    severity,
+			Flag:        flag,
+			Description: description,
+		})
+	}
+	for i, tok := range tokens {
+		if (tok != "docker" && tok != "podman") || i+1 >= len(tokens) || tokens[i+1] != "run" {
+			continue
+		}
+		for j := i + 2; j < len(tokens); j++ {
+			flag, value, inline := strings.Cut(tokens[j], "=")
+			if !strings.HasPrefix(flag, "-") {
+				break // the image
+			}
+			written := tokens[j]
+			if !inline && takesValue(flag) && j+1 < len(tokens) {
+				j++
+				value = tokens[j]
+				written = flag + " " + value
+			}
+			switch flag {
+			case "--privileged":
+				if !inline || value == "true" {
+					add(MisconfigPrivileged, "CRITICAL", written, "Container runs privileged with full access to host devices and kernel capabilities")
+				}
+			case "--cap-add":
+				capability := strings.TrimPrefix(strings.ToUpper(value), "CAP_")
+				severity := "HIGH"
+				if capability == "SYS_ADMIN" || capability == "ALL" {
+					severity = "CRITICAL"
+				}
+				add(MisconfigCapAdd, severity, written, fmt.Sprintf("Container is granted the %s capability", capability))
+			case "--network", "--net":
+				if value == "host" {
+					add(MisconfigHostNetwork, "HIGH", written, "Container shares the host network namespace")
+				}
+			case "-v", "--volume":
+				if source, _, _ := strings.Cut(value, ":"); source == "/" {
+					add(MisconfigHostRootMount, "CRITICAL", written, "Host root filesystem is mounted into the container")
+				}
+			case "--mount":
+				if mountsHostRoot(value) {
+					add(MisconfigHostRootMount, "CRITICAL", written, "Host root filesystem is mounted into the container")
+				}
+			}
+		}
+	}
+	return out
+}
+
+// mountsHostRoot reports whether a --mount spec bind mounts the host's "/".
+func mountsHostRoot(spec string) bool {
+	bind, root := false, false
+	for _, field := range strings.Split(spec, ",") {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "type":
+			bind = value == "bind"
+		case "source", "src":
+			root = value == "/"
+		}
+	}
+	return bind && root
+}
+
+// detectShellInjection flags servers launched through a shell command line (sh -c, bash -c,
+// cmd /c and similar). Static command lines are MEDIUM; ones that interpolate environment
+// variables ($VAR, ${VAR} or %VAR%) are HIGH, since whoever controls the variable controls
+// the command. The returned findings have no Path; the caller sets it.
+func detectShellInjection(serverName string, server Server) []MisconfigFinding {
+	tokens := launchTokens(server)
+	var out []MisconfigFinding
+	for i := 0; i+2 < len(tokens); i++ {
+		// Windows paths use backslashes whatever the scanning OS.
+		shell := strings.TrimSuffix(strings.ToLower(path.Base(strings.ReplaceAll(tokens[i], `\`, "/"))), ".exe")
+		flag := tokens[i+1]
+		var script string
+		switch shell {
+		case "sh", "bash", "zsh", "dash", "ksh", "ash":
+			// Combined short options such as -lc or -ec also take a command string.
+			if !strings.HasPrefix(flag, "-") || strings.HasPrefix(flag, "--") || !strings.Contains(flag, "c") {
+				continue
+			}
+			script = tokens[i+2]
+		case "cmd":
+			if !strings.EqualFold(flag, "/c") && !strings.EqualFold(flag, "/k") {
+				continue
+			}
+			script = strings.Join(tokens[i+2:], " ")
+		default:
+			continue
+		}
+		f := MisconfigFinding{
+			ServerName:  serverName,
+			Rule:        MisconfigShellCommand,
+			Severity:    "MEDIUM",
+			Flag:        tokens[i] + " " + flag,
+			Description: fmt.Sprintf("Server is launched through a shell command line (%s); review it for injectable input", script),
+		}
+		if vars := shellVarRe.FindAllString(script, -1); len(vars) > 0 {
+			f.Severity = "HIGH"
+			f.Description = fmt.Sprintf("Shell command line interpolates %s (%s); a controlled value can inject commands",
+				strings.Join(vars, ", "), script)
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// detectBroadFilesystemAccess flags args that hand a server the whole filesystem (/, a drive
+// root) as HIGH, or every home directory (~, $HOME, /home, C:\Users and similar) as MEDIUM.
+// Option values written as --flag=value are checked too. Narrowed paths such as /tmp or a
+// project directory are not reported. The returned findings have no Path; the caller sets it.
+func detectBroadFilesystemAccess(serverName string, server Server) []MisconfigFinding {
+	var out []MisconfigFinding
+	for _, arg := range ruleFieldValues("args", serverName, server) {
+		value := arg
+		if _, v, ok := strings.Cut(arg, "="); ok && strings.HasPrefix(arg, "-") {
+			value = v
+		}
+		severity, scope := broadPathScope(strings.Trim(value, `"'`))
+		if severity == "" {
+			continue
+		}
+		out = append(out, MisconfigFinding{
+			ServerName:  serverName,
+			Rule:        MisconfigBroadFSAccess,
+			Severity:    severity,
+			Flag:        arg,
+			Description: fmt.Sprintf("Server is given access to %s (%s)", scope, value),
+			Hint:        broadFSHint(serverName, server, value),
+		})
+	}
+	return out
+}
+
+// broadPathScope classifies a path argument; severity is empty for paths that are narrow enough.
+func broadPathScope(p string) (string, string) {
+	if p == "/" || driveRootRe.MatchString(p) {
+		return "HIGH", "the whole filesystem"
+	}
+	p = strings.TrimRight(p, `/\`)
+	switch strings.ToLower(p) {
+	case "~", "$home", "${home}", "$userprofile", "${userprofile}", "%userprofile%", "%homepath%",
+		"/home", "/users", "/root", `c:\users`, "c:/users":
+		return "MEDIUM", "home directories"
+	}
+	return "", ""
+}
+
+// broadFSHint suggests narrowing the path or, if the access is intended, a suppression entry.
+func broadFSHint(serverName string, server Server, value string) string {
+	fix := fmt.Sprintf("check that the server needs %s", value)
+	if strings.HasPrefix(extractPurlFromStdio(server), filesystemServerPurl) {
+		fix = fmt.Sprintf("replace %s with the project directories the filesystem server should expose", value)
+	}
+	return fmt.Sprintf("%s, or accept the risk with \"- server: %s\" in %s", fix, serverName, DefaultSuppressFile)
+}
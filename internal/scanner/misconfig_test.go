@@ -0,0 +1,162 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectMisconfigs(t *testing.T) {
+	docker := func(args ...interface{}) Server {
+		return Server{"command": "docker", "args": append([]interface{}{"run", "-i", "--rm"}, args...)}
+	}
+	cases := []struct {
+		name   string
+		server Server
+		want   []MisconfigFinding
+	}{
+		{"privileged", docker("--privileged", "ghcr.io/a/b"), []MisconfigFinding{
+			{Rule: MisconfigPrivileged, Severity: "CRITICAL", Flag: "--privileged"},
+		}},
+		{"privileged false", docker("--privileged=false", "ghcr.io/a/b"), nil},
+		{"cap-add", docker("--cap-add", "SYS_ADMIN", "--cap-add=net_admin", "ghcr.io/a/b"), []MisconfigFinding{
+			{Rule: MisconfigCapAdd, Severity: "CRITICAL", Flag: "--cap-add SYS_ADMIN"},
+			{Rule: MisconfigCapAdd, Severity: "HIGH", Flag: "--cap-add=net_admin"},
+		}},
+		{"host network", docker("--network", "host", "--net=bridge", "ghcr.io/a/b"), []MisconfigFinding{
+			{Rule: MisconfigHostNetwork, Severity: "HIGH", Flag: "--network host"},
+		}},
+		{"root mount", docker("-v", "/:/host:ro", "--mount", "type=bind,source=/,target=/mnt", "-v", "/data:/data", "ghcr.io/a/b"), []MisconfigFinding{
+			{Rule: MisconfigHostRootMount, Severity: "CRITICAL", Flag: "-v /:/host:ro"},
+			{Rule: MisconfigHostRootMount, Severity: "CRITICAL", Flag: "--mount type=bind,source=/,target=/mnt"},
+		}},
+		{"container args ignored", docker("ghcr.io/a/b", "--privileged"), nil},
+		{"nested stdio", Server{"stdio": map[string]interface{}{
+			"command": "podman", "args": []interface{}{"run", "--privileged", "quay.io/a/b"},
+		}}, []MisconfigFinding{{Rule: MisconfigPrivileged, Severity: "CRITICAL", Flag: "--privileged"}}},
+		{"not docker", Server{"command": "npx", "args": []interface{}{"--privileged"}}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DetectMisconfigs("srv", "/mcp.json", tc.server)
+			require.Len(t, got, len(tc.want))
+			for i, want := range tc.want {
+				assert.Equal(t, "srv", got[i].ServerName)
+				assert.Equal(t, "/mcp.json", got[i].Path)
+				assert.Equal(t, want.Rule, got[i].Rule)
+				assert.Equal(t, want.Severity, got[i].Severity)
+				assert.Equal(t, want.Flag, got[i].Flag)
+				assert.NotEmpty(t, got[i].Description)
+			}
+		})
+	}
+}
+
+func TestScan_MisconfigFindings(t *testing.T) {
+	path := filepath.Join("..", "..", "testdata", "docker_privileged_config.json")
+	result, err := NewMCPScanner([]string{path}, "").Scan()
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+
+	rules := map[string][]string{}
+	for _, m := range result.Files[0].MisconfigFindings {
+		rules[m.ServerName] = append(rules[m.ServerName], m.Rule)
+	}
+	assert.Equal(t, map[string][]string{
+		"capabilities": {MisconfigCapAdd, MisconfigCapAdd},
+		"host-access":  {MisconfigHostNetwork, MisconfigHostRootMount},
+		"privileged":   {MisconfigPrivileged},
+	}, rules)
+
+	summary := GenerateSummary(*result, Suppression{Server: "privileged"})
+	assert.Len(t, summary.MisconfigFindings, 4, "findings of suppressed servers are dropped")
+}
+
+func TestDetectShellInjection(t *testing.T) {
+	cases := []struct {
+		name     string
+		server   Server
+		severity string
+		flag     string
+	}{
+		{"sh with env var", Server{"command": "sh", "args": []interface{}{"-c", "curl $MCP_URL"}}, "HIGH", "sh -c"},
+		{"braced var", Server{"command": "bash", "args": []interface{}{"-c", "run ${TARGET}"}}, "HIGH", "bash -c"},
+		{"static", Server{"command": "/bin/bash", "args": []interface{}{"-lc", "exec /opt/server"}}, "MEDIUM", "/bin/bash -lc"},
+		{"cmd percent var", Server{"command": `C:\Windows\System32\cmd.exe`, "args": []interface{}{"/C", "tool", "%TOKEN%"}}, "HIGH", `C:\Windows\System32\cmd.exe /C`},
+		{"command array", Server{"command": []interface{}{"sh", "-c", "echo hi"}}, "MEDIUM", "sh -c"},
+		{"no -c", Server{"command": "bash", "args": []interface{}{"script.sh"}}, "", ""},
+		{"long option", Server{"command": "bash", "args": []interface{}{"--rcfile", "x"}}, "", ""},
+		{"not a shell", Server{"command": "npx", "args": []interface{}{"-c", "$X"}}, "", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := detectShellInjection("srv", tc.server)
+			if tc.severity == "" {
+				assert.Empty(t, got)
+				return
+			}
+			require.Len(t, got, 1)
+			assert.Equal(t, MisconfigShellCommand, got[0].Rule)
+			assert.Equal(t, tc.severity, got[0].Severity)
+			assert.Equal(t, tc.flag, got[0].Flag)
+		})
+	}
+
+	path := filepath.Join("..", "..", "testdata", "shell_injection_config.json")
+	result, err := NewMCPScanner([]string{path}, "").Scan()
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	severities := map[string]string{}
+	for _, m := range result.Files[0].MisconfigFindings {
+		assert.Equal(t, path, m.Path)
+		severities[m.ServerName] = m.Severity
+	}
+	assert.Equal(t, map[string]string{"fetcher": "HIGH", "static": "MEDIUM", "windows": "HIGH"}, severities)
+}
+
+func TestDetectBroadFilesystemAccess(t *testing.T) {
+	fs := func(dirs ...interface{}) Server {
+		return Server{"command": "npx", "args": append([]interface{}{"-y", "@modelcontextprotocol/server-filesystem"}, dirs...)}
+	}
+	cases := []struct {
+		name     string
+		server   Server
+		severity string
+	}{
+		{"root", fs("/"), "HIGH"},
+		{"drive root", fs(`C:\`), "HIGH"},
+		{"tilde", fs("~"), "MEDIUM"},
+		{"home var", fs("$HOME"), "MEDIUM"},
+		{"windows users", fs(`C:\Users\`), "MEDIUM"},
+		{"option value", Server{"command": "my-server", "args": []interface{}{"--root=/"}}, "HIGH"},
+		{"narrow", fs("/tmp", "~/projects/app"), ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := detectBroadFilesystemAccess("srv", tc.server)
+			if tc.severity == "" {
+				assert.Empty(t, got)
+				return
+			}
+			require.Len(t, got, 1)
+			assert.Equal(t, MisconfigBroadFSAccess, got[0].Rule)
+			assert.Equal(t, tc.severity, got[0].Severity)
+			assert.Contains(t, got[0].Hint, `- server: srv`)
+		})
+	}
+	assert.Contains(t, detectBroadFilesystemAccess("srv", fs("/"))[0].Hint, "project directories",
+		"the filesystem server gets a specific hint")
+
+	path := filepath.Join("..", "..", "testdata", "filesystem_access_config.json")
+	result, err := NewMCPScanner([]string{path}, "").Scan()
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	severities := map[string]string{}
+	for _, m := range result.Files[0].MisconfigFindings {
+		severities[m.ServerName] = m.Severity
+	}
+	assert.Equal(t, map[string]string{"fs-root": "HIGH", "fs-home": "MEDIUM"}, severities)
+}
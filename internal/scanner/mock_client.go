@@ -0,0 +1,96 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ensigniasec/run-mcp/internal/api"
+	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
+	"github.com/google/uuid"
+)
+
+// mockRatingURLPrefix starts the rating link returned for every identifier by MockRatingsClient.
+const mockRatingURLPrefix = "mock://ratings/"
+
+// MockRatingsClient is an in-memory api.RatingsClient that rates servers by name from a
+// pre-loaded table. Every batch is answered synchronously, as a 200 OK response, so no scan is
+// ever polled. It backs "scan --mock-api" for demos and tests that need deterministic ratings.
+type MockRatingsClient struct {
+	ratings map[string]SecurityRating
+}
+
+var _ api.RatingsClient = (*MockRatingsClient)(nil)
+
+// NewMockRatingsClient returns a client serving ratings, keyed by server name.
+func NewMockRatingsClient(ratings map[string]SecurityRating) *MockRatingsClient {
+	if ratings == nil {
+		ratings = make(map[string]SecurityRating)
+	}
+	return &MockRatingsClient{ratings: ratings}
+}
+
+// LoadMockRatings reads a JSON object mapping server names to ratings, e.g.
+// {"filesystem": {"risk_score": 9.5, "category": "UNTRUSTED"}}.
+func LoadMockRatings(path string) (*MockRatingsClient, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var ratings map[string]SecurityRating
+	if err := json.Unmarshal(data, &ratings); err != nil {
+		return nil, fmt.Errorf("failed to parse mock ratings %s: %w", path, err)
+	}
+	for name, r := range ratings {
+		if r.Name == "" {
+			r.Name = name
+		}
+		if r.Source == "" {
+			r.Source = "mock"
+		}
+		ratings[name] = r
+	}
+	return NewMockRatingsClient(ratings), nil
+}
+
+// ServerRating returns the pre-loaded rating for the named server.
+func (m *MockRatingsClient) ServerRating(name string) (SecurityRating, bool) {
+	r, ok := m.ratings[name]
+	return r, ok
+}
+
+// GetRating reports every single-target lookup as not found, since ratings are keyed by server name.
+func (m *MockRatingsClient) GetRating(_ context.Context, _ api.RatingTarget) (api.RatingResult, error) {
+	return api.RatingResult{}, api.ErrNotFound
+}
+
+// SubmitBatchRatings answers immediately with a rating link for each identifier.
+func (m *MockRatingsClient) SubmitBatchRatings(_ context.Context, req apigen.BatchRatingRequest) (apigen.BatchRatingResponse, *apigen.ScanStatus, error) {
+	var resp apigen.BatchRatingResponse
+	for _, id := range req.Identifiers {
+		resp.Ratings = append(resp.Ratings, struct {
+			Identifier apigen.TargetIdentifier `json:"identifier"`
+			RatingUrl  string                  `json:"rating_url"`
+		}{Identifier: id, RatingUrl: mockRatingURLPrefix + string(id.Kind) + "/" + id.Value})
+	}
+	return resp, nil, nil
+}
+
+// GetScanStatus reports any scan as completed; the mock never starts one.
+func (m *MockRatingsClient) GetScanStatus(_ context.Context, scanID uuid.UUID) (apigen.ScanStatus, error) {
+	now := time.Now()
+	return apigen.ScanStatus{
+		ScanId:      scanID,
+		Status:      apigen.ScanStatusStatusCompleted,
+		StartedAt:   now,
+		CompletedAt: &now,
+		Targets:     []apigen.ScanTarget{},
+	}, nil
+}
+
+// WaitForScanCompletion returns no ratings immediately; the mock never starts a scan.
+func (m *MockRatingsClient) WaitForScanCompletion(_ context.Context, _ string, _ time.Duration) ([]apigen.SecurityRating, error) {
+	return nil, nil
+}
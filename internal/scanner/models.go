@@ -11,6 +11,10 @@ type ScanError struct {
 type ServerConfig struct {
 	Name   string      `json:"name,omitempty"`
 	Server interface{} `json:"server"`
+	// Fingerprint is the ServerFingerprint of Server as parsed, before any output redaction.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// CanonicalName is the CanonicalServerName of Name, set by WithNormalizedServerNames.
+	CanonicalName string `json:"canonical_name,omitempty"`
 }
 
 // FileResult represents the scan output for a single config file.
@@ -19,16 +23,40 @@ type FileResult struct {
 	Servers        []ServerConfig  `json:"servers,omitempty"`
 	Error          *ScanError      `json:"error,omitempty"`
 	SecretFindings []SecretFinding `json:"secret_findings,omitempty"`
+	// MisconfigFindings lists dangerous launch settings, e.g. privileged docker containers.
+	MisconfigFindings []MisconfigFinding `json:"misconfig_findings,omitempty"`
+	// Duration is the time taken to scan the file, recorded by WithProfiling.
+	Duration time.Duration `json:"duration,omitempty"`
+	// SkippedServers lists the servers left out by WithSkippedServers.
+	SkippedServers []string `json:"skipped_servers,omitempty"`
 }
 
 // ServerReport represents a server with attached rating and findings.
 type ServerReport struct {
 	// TODO: add an id field to match IDs.md
-	Name        string          `json:"name"`
-	Path        string          `json:"path" validate:"omitempty,filepath"`
-	Rating      *SecurityRating `json:"rating,omitempty"`
-	Secrets     []SecretFinding `json:"secrets,omitempty"`
-	LocalPolicy string          `json:"local_policy,omitempty"` // allowed|denied|unknown
+	Name string `json:"name"`
+	// CanonicalName is set with scan --normalize-server-names; servers sharing it are merged.
+	CanonicalName string          `json:"canonical_name,omitempty"`
+	Path          string          `json:"path" validate:"omitempty,filepath"`
+	Rating        *SecurityRating `json:"rating,omitempty"`
+	Secrets       []SecretFinding `json:"secrets,omitempty"`
+	LocalPolicy   string          `json:"local_policy,omitempty"` // allowed|denied|unknown
+	// SuppressionReason is set on servers moved to ScanSummary.Suppressed.
+	SuppressionReason string `json:"suppression_reason,omitempty"`
+	// CustomFindings lists the scan --rules-file rules that matched this server.
+	CustomFindings []CustomFinding `json:"custom_findings,omitempty"`
+	// Category is the server type derived from its identifiers: npm, python, docker or binary.
+	Category string `json:"category,omitempty"`
+	// Fingerprint identifies the server's launch config; see ServerFingerprint.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Changed is set when the fingerprint differs from the one recorded by the previous scan.
+	Changed             bool   `json:"changed,omitempty"`
+	PreviousFingerprint string `json:"previous_fingerprint,omitempty"`
+	// Explanation and Remediation describe the risk tier; set by Explain (scan --explain).
+	Explanation string `json:"explanation,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+	// Annotation is the matching scan --annotations-file entry; set by Annotate.
+	Annotation *Annotation `json:"annotation,omitempty"`
 }
 
 // SecurityRating represents a server's security assessment.
@@ -111,3 +139,12 @@ type LibreChatConfigFile struct {
 func (c *LibreChatConfigFile) GetServers() map[string]Server {
 	return filterConfig(c.MCP.Servers)
 }
+
+// EnvConfigFile holds the servers described by MCP_SERVER_<NAME>_<FIELD> variables of a .env file.
+type EnvConfigFile struct {
+	Servers map[string]Server `json:"servers"`
+}
+
+func (c *EnvConfigFile) GetServers() map[string]Server {
+	return filterConfig(c.Servers)
+}
@@ -5,19 +5,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
 
-const (
-	maxConfigSize = 10 * 1024 * 1024 // 10MB limit to prevent memory exhaustion
-)
+// DefaultMaxFileSize is the largest config file read unless WithMaxFileSize is used.
+const DefaultMaxFileSize = 10 * 1024 * 1024 // 10MB limit to prevent memory exhaustion
 
-// readFile reads a file with sane limits to prevent attacks.
-func readFile(path string) ([]byte, error) {
+// readFile reads a file of at most maxSize bytes with sane limits to prevent attacks.
+func readFile(path string, maxSize int64) ([]byte, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -30,16 +33,37 @@ func readFile(path string) ([]byte, error) {
 		return nil, err
 	}
 
-	if info.Size() > maxConfigSize {
-		return nil, fmt.Errorf("config file too large: %d bytes (max %d)", info.Size(), maxConfigSize)
+	if info.Size() > maxSize {
+		return nil, fmt.Errorf("config file too large: %d bytes (max %d)", info.Size(), maxSize)
 	}
 
 	// Use limited reader to enforce size limit
-	limitedReader := io.LimitReader(file, maxConfigSize)
+	limitedReader := io.LimitReader(file, maxSize)
 	return io.ReadAll(limitedReader)
 }
 
-// unmarshal decodes data using path to choose JSON or YAML.
+// ParseFileSize parses a size in bytes, optionally with a KB or MB suffix (powers of 1024,
+// case-insensitive, B optional), such as 5MB, 512kb or 1048576. It must be positive.
+func ParseFileSize(s string) (int64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	unit := int64(1)
+	for _, suffix := range []struct {
+		name string
+		size int64
+	}{{"KB", 1024}, {"MB", 1024 * 1024}, {"K", 1024}, {"M", 1024 * 1024}, {"B", 1}} {
+		if strings.HasSuffix(v, suffix.name) {
+			v, unit = strings.TrimSpace(strings.TrimSuffix(v, suffix.name)), suffix.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64/unit {
+		return 0, fmt.Errorf("invalid size %q: want a positive number of bytes, KB or MB", s)
+	}
+	return n * unit, nil
+}
+
+// unmarshal decodes data using path to choose JSON, YAML or TOML.
 // For JSON, runs a case-insensitive key collision check before decoding.
 func unmarshal(path string, data []byte, v interface{}) error {
 	if isJSONFile(path) {
@@ -51,6 +75,9 @@ func unmarshal(path string, data []byte, v interface{}) error {
 	if isYAMLFile(path) {
 		return yaml.Unmarshal(data, v)
 	}
+	if isTOMLFile(path) {
+		return unmarshalTOML(data, v)
+	}
 	return fmt.Errorf("unknown config file extension: %s", path)
 }
 
@@ -155,3 +182,109 @@ func filterConfig(servers map[string]Server) map[string]Server {
 	}
 	return validServers
 }
+
+// envServerPrefix starts the variables of a .env file that describe MCP servers.
+const envServerPrefix = "MCP_SERVER_"
+
+// envServerVarRe splits MCP_SERVER_<NAME>_<FIELD>; the name is as short as possible, so
+// MCP_SERVER_GITHUB_ENV_GITHUB_TOKEN is the ENV_GITHUB_TOKEN field of server GITHUB.
+var envServerVarRe = regexp.MustCompile(`^MCP_SERVER_(.+?)_(COMMAND|ARGS|URL|TYPE|CWD|ENV_.+|HEADER_.+)$`)
+
+// envKeyRe matches valid variable names in a .env file.
+var envKeyRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// isEnvFile reports whether path is a dotenv file: .env, .env.<suffix> such as .env.local
+// or .env.production, or <name>.env.
+func isEnvFile(path string) bool {
+	name := strings.ToLower(filepath.Base(path))
+	return name == ".env" || strings.HasPrefix(name, ".env.") || filepath.Ext(name) == ".env"
+}
+
+// parseEnvFile parses dotenv syntax: KEY=value lines with optional "export " prefixes,
+// blank lines and # comments. Values may be single-quoted (literal), double-quoted (with
+// \n, \t, \" and \\ escapes) or unquoted, where a " #" starts a trailing comment.
+func parseEnvFile(data []byte) (map[string]string, error) {
+	vars := make(map[string]string)
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !envKeyRe.MatchString(key) {
+			return nil, fmt.Errorf("line %d: expected KEY=value", i+1)
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			value = strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+		case strings.HasPrefix(value, "'") || strings.HasPrefix(value, `"`):
+			return nil, fmt.Errorf("line %d: unterminated quoted value for %s", i+1, key)
+		default:
+			if j := strings.Index(value, " #"); j >= 0 {
+				value = strings.TrimSpace(value[:j])
+			}
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// envServers groups MCP_SERVER_<NAME>_<FIELD> variables into server configs keyed by the
+// lower-cased name. COMMAND, URL, TYPE and CWD become string fields; ARGS is a JSON array
+// or a whitespace-separated list; ENV_<VAR> and HEADER_<NAME> fill the env and headers maps.
+func envServers(vars map[string]string) map[string]Server {
+	servers := make(map[string]Server)
+	for key, value := range vars {
+		m := envServerVarRe.FindStringSubmatch(key)
+		if m == nil {
+			if strings.HasPrefix(key, envServerPrefix) {
+				logrus.Debugf("Ignoring unrecognised MCP server variable %s", key)
+			}
+			continue
+		}
+		name, field := strings.ToLower(m[1]), m[2]
+		server, ok := servers[name]
+		if !ok {
+			server = Server{}
+			servers[name] = server
+		}
+		switch {
+		case field == "ARGS":
+			server["args"] = envArgs(value)
+		case strings.HasPrefix(field, "ENV_"):
+			envMapField(server, "env")[strings.TrimPrefix(field, "ENV_")] = value
+		case strings.HasPrefix(field, "HEADER_"):
+			envMapField(server, "headers")[strings.TrimPrefix(field, "HEADER_")] = value
+		default:
+			server[strings.ToLower(field)] = value
+		}
+	}
+	return servers
+}
+
+// envArgs decodes an ARGS value given as a JSON array, or else splits it on whitespace.
+func envArgs(value string) []interface{} {
+	var args []interface{}
+	if strings.HasPrefix(strings.TrimSpace(value), "[") && json.Unmarshal([]byte(value), &args) == nil {
+		return args
+	}
+	for _, f := range strings.Fields(value) {
+		args = append(args, f)
+	}
+	return args
+}
+
+// envMapField returns the string map stored under key in server, creating it if needed.
+func envMapField(server Server, key string) map[string]interface{} {
+	m, ok := server[key].(map[string]interface{})
+	if !ok {
+		m = make(map[string]interface{})
+		server[key] = m
+	}
+	return m
+}
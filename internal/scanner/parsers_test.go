@@ -3,6 +3,7 @@ package scanner
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -32,7 +33,7 @@ func TestReadFile(t *testing.T) {
 		},
 		{
 			name:        "file too large",
-			fileSize:    maxConfigSize + 1,
+			fileSize:    DefaultMaxFileSize + 1,
 			expectError: true,
 			errorMsg:    "config file too large",
 		},
@@ -58,7 +59,7 @@ func TestReadFile(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			data, err := readFile(filePath)
+			data, err := readFile(filePath, DefaultMaxFileSize)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -73,6 +74,49 @@ func TestReadFile(t *testing.T) {
 	}
 }
 
+func TestParseFileSize(t *testing.T) {
+	for in, want := range map[string]int64{
+		"1048576": 1048576,
+		"512B":    512,
+		"5MB":     5 * 1024 * 1024,
+		"5mb":     5 * 1024 * 1024,
+		"64 KB":   64 * 1024,
+		"2k":      2048,
+		"1M":      1024 * 1024,
+	} {
+		got, err := ParseFileSize(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, in := range []string{"", "0", "-1MB", "1.5MB", "10GB", "MB", "99999999999999999MB"} {
+		_, err := ParseFileSize(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestMCPScanner_WithMaxFileSize(t *testing.T) {
+	const limit = 1024
+	config := `{"mcpServers": {"fs": {"command": "npx", "args": ["%s"]}}}`
+	padding := limit - len(fmt.Sprintf(config, ""))
+	dir := t.TempDir()
+	below := filepath.Join(dir, "below.json")
+	above := filepath.Join(dir, "above.json")
+	require.NoError(t, os.WriteFile(below, []byte(fmt.Sprintf(config, strings.Repeat("a", padding))), 0o600))
+	require.NoError(t, os.WriteFile(above, []byte(fmt.Sprintf(config, strings.Repeat("a", padding+1))), 0o600))
+
+	s := NewMCPScanner(nil, "").WithMaxFileSize(limit)
+	cfg, err := s.ParseMCPConfigFile(below)
+	require.NoError(t, err, "a file of exactly the limit is accepted")
+	assert.Len(t, cfg.GetServers(), 1)
+
+	_, err = s.ParseMCPConfigFile(above)
+	require.ErrorContains(t, err, "config file too large: 1025 bytes (max 1024)")
+
+	cfg, err = s.WithMaxFileSize(0).ParseMCPConfigFile(above)
+	require.NoError(t, err, "zero restores the default limit")
+	assert.Len(t, cfg.GetServers(), 1)
+}
+
 func TestUnmarshalJSON(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -170,6 +214,75 @@ test: value
 	}
 }
 
+func TestUnmarshalTOML(t *testing.T) {
+	tests := []struct {
+		name     string
+		tomlData string
+		expected map[string]interface{}
+		errorMsg string
+	}{
+		{
+			name: "values",
+			tomlData: `
+title = "a \"quoted\" \u00e9" # comment
+path = 'C:\Users\me'
+count = 1_000
+ratio = 0.5
+enabled = true
+when = 2025-06-01T12:00:00Z
+args = [
+  "-y",  # comment
+  "pkg",
+]
+inline = { a.b = 1, c = [] }
+script = """
+line one \
+  continued"""
+`,
+			expected: map[string]interface{}{
+				"title": `a "quoted" é`, "path": `C:\Users\me`, "count": float64(1000), "ratio": 0.5,
+				"enabled": true, "when": "2025-06-01T12:00:00Z", "args": []interface{}{"-y", "pkg"},
+				"inline": map[string]interface{}{"a": map[string]interface{}{"b": float64(1)}, "c": []interface{}{}},
+				"script": "line one continued",
+			},
+		},
+		{
+			name: "array of tables keyed by name",
+			tomlData: `
+[[mcpServers]]
+name = "a"
+command = "npx"
+
+[[mcpServers]]
+name = "b"
+url = "https://example.com"
+`,
+			expected: map[string]interface{}{"mcpServers": map[string]interface{}{
+				"a": map[string]interface{}{"command": "npx"},
+				"b": map[string]interface{}{"url": "https://example.com"},
+			}},
+		},
+		{name: "duplicate key", tomlData: "a = 1\na = 2\n", errorMsg: "line 2"},
+		{name: "table over value", tomlData: "a = 1\n[a]\n", errorMsg: "line 2"},
+		{name: "unterminated string", tomlData: `a = "x`, errorMsg: "unexpected EOF"},
+		{name: "missing equals", tomlData: "a 1\n", errorMsg: "expected '.' or '='"},
+		{name: "server without name", tomlData: "[[mcpServers]]\ncommand = \"x\"\n", errorMsg: "server 1 has no name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result map[string]interface{}
+			err := unmarshal("test.toml", []byte(tt.tomlData), &result)
+			if tt.errorMsg != "" {
+				require.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestDetectCaseInsensitiveKeyCollisions(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -381,7 +494,7 @@ func BenchmarkReadFile(b *testing.B) {
 
 	b.ResetTimer()
 	for range b.N {
-		_, err := readFile(filePath)
+		_, err := readFile(filePath, DefaultMaxFileSize)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -413,6 +526,108 @@ func BenchmarkDetectCaseInsensitiveKeyCollisions(b *testing.B) {
 	}
 }
 
+func TestIsEnvFile(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/project/.env":            true,
+		"/project/.env.local":      true,
+		"/project/.env.production": true,
+		"/project/test_mcp.env":    true,
+		"/project/.ENV":            true,
+		"/project/mcp.json":        false,
+		"/project/.envrc":          false,
+		"/project/env":             false,
+	} {
+		assert.Equal(t, want, isEnvFile(path), path)
+	}
+}
+
+func TestParseEnvFile(t *testing.T) {
+	vars, err := parseEnvFile([]byte(strings.Join([]string{
+		"# comment",
+		"",
+		"PLAIN=value # trailing comment",
+		"export EXPORTED=yes",
+		`DOUBLE="a \"quoted\" value\nwith # hash"`,
+		"SINGLE='literal \\n'",
+		"EMPTY=",
+		"WINDOWS=crlf\r",
+	}, "\n")))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"PLAIN":    "value",
+		"EXPORTED": "yes",
+		"DOUBLE":   "a \"quoted\" value\nwith # hash",
+		"SINGLE":   `literal \n`,
+		"EMPTY":    "",
+		"WINDOWS":  "crlf",
+	}, vars)
+
+	_, err = parseEnvFile([]byte("OK=1\nnot a variable\n"))
+	require.ErrorContains(t, err, "line 2")
+	_, err = parseEnvFile([]byte(`TOKEN="never closed`))
+	require.ErrorContains(t, err, "unterminated")
+}
+
+func TestEnvServers(t *testing.T) {
+	servers := envServers(map[string]string{
+		"MCP_SERVER_FS_COMMAND":                  "npx",
+		"MCP_SERVER_FS_ARGS":                     "-y @modelcontextprotocol/server-filesystem /tmp",
+		"MCP_SERVER_GIT_HUB_COMMAND":             "docker",
+		"MCP_SERVER_GIT_HUB_ARGS":                `["run", "-i", "ghcr.io/github/github-mcp-server"]`,
+		"MCP_SERVER_GIT_HUB_ENV_GITHUB_TOKEN":    "token",
+		"MCP_SERVER_REMOTE_URL":                  "https://mcp.example.com/sse",
+		"MCP_SERVER_REMOTE_HEADER_AUTHORIZATION": "Bearer token",
+		"MCP_SERVER_BROKEN":                      "ignored",
+		"DATABASE_URL":                           "ignored",
+	})
+
+	require.Len(t, servers, 3)
+	assert.Equal(t, Server{
+		"command": "npx",
+		"args":    []interface{}{"-y", "@modelcontextprotocol/server-filesystem", "/tmp"},
+	}, servers["fs"])
+	assert.Equal(t, Server{
+		"command": "docker",
+		"args":    []interface{}{"run", "-i", "ghcr.io/github/github-mcp-server"},
+		"env":     map[string]interface{}{"GITHUB_TOKEN": "token"},
+	}, servers["git_hub"])
+	assert.Equal(t, Server{
+		"url":     "https://mcp.example.com/sse",
+		"headers": map[string]interface{}{"AUTHORIZATION": "Bearer token"},
+	}, servers["remote"])
+}
+
+func TestParseMCPConfigFile_EnvFile(t *testing.T) {
+	path := filepath.Join("..", "..", "testdata", "test_env_mcp.env")
+	s := NewMCPScanner(nil, filepath.Join(t.TempDir(), "storage.json"))
+	config, err := s.ParseMCPConfigFile(path)
+	require.NoError(t, err)
+	require.IsType(t, &EnvConfigFile{}, config)
+
+	servers := config.GetServers()
+	require.NotEmpty(t, servers)
+	assert.Equal(t, "npx", servers["filesystem"]["command"])
+	assert.Equal(t, "docker", servers["github"]["command"])
+	assert.Equal(t, "https://mcp.example.com/sse", servers["remote_search"]["url"])
+
+	// The token in the env map is detected and redacted like in any other config.
+	var kinds []string
+	for _, f := range s.ScanResult.SecretFindings {
+		kinds = append(kinds, f.Kind)
+	}
+	assert.Contains(t, kinds, "GitHub Personal Access Token")
+	env, ok := servers["github"]["env"].(map[string]interface{})
+	require.True(t, ok)
+	assert.NotContains(t, env["GITHUB_PERSONAL_ACCESS_TOKEN"], "github_pat_")
+
+	// Files without MCP_SERVER_ variables are not configs.
+	plain := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(plain, []byte("DATABASE_URL=postgres://localhost/app\n"), 0o600))
+	config, err = s.ParseMCPConfigFile(plain)
+	require.NoError(t, err)
+	assert.Nil(t, config)
+}
+
 // Fuzz test for parser robustness.
 func FuzzUnmarshalJSON(f *testing.F) {
 	// Seed with valid JSON examples
@@ -31,6 +31,17 @@ var (
 		// Common
 		"mcp_config.json",
 		"mcp_settings.json",
+
+		// Neovim (mcphub.nvim)
+		"mcpservers.json",
+
+		// TOML-based clients
+		".mcp.toml",
+		"mcp_config.toml",
+
+		// MCP_SERVER_<NAME>_<FIELD> variables in dotenv files
+		".env",
+		".env.local",
 	}
 
 	wellKnownMCPPathsMacOS = []string{
@@ -63,6 +74,16 @@ var (
 		"~/Library/Application Support/Enconvo/mcp.json",
 		// Warp
 		"~/Library/Application Support/dev.warp.Warp-Stable/config/settings.yaml",
+		// Cline (VS Code extension storage)
+		"~/Library/Application Support/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json",
+		// Amazon Q CLI
+		"~/.aws/amazonq/mcp.json",
+		"~/.aws/amazonq/mcp_config.json",
+		// GitHub Copilot CLI
+		"~/Library/Application Support/gh/copilot/mcp.json",
+		// JetBrains AI Assistant
+		"~/.config/JetBrains/AIAssistant/mcp.json",
+		"~/Library/Application Support/JetBrains/AIAssistant/mcp.json",
 	}
 
 	wellKnownMCPPathsWindows = []string{
@@ -112,6 +133,10 @@ var (
 		"$APPDATA\\Witsy\\mcp.json",
 		// Enconvo
 		"$APPDATA\\Enconvo\\mcp.json",
+		// GitHub Copilot CLI
+		"$APPDATA\\GitHub CLI\\copilot\\mcp.json",
+		// Gemini CLI
+		"$USERPROFILE\\.gemini\\mcp_config.json",
 	}
 
 	wellKnownMCPPathsLinux = []string{
@@ -141,6 +166,10 @@ var (
 		"~/.config/enconvo/mcp.json",
 		// Warp
 		"~/.local/state/warp-terminal/config/settings.yaml",
+		// GitHub Copilot CLI
+		"~/.config/gh/copilot/mcp.json",
+		// JetBrains AI Assistant
+		"~/.config/JetBrains/AIAssistant/mcp.json",
 	}
 
 	wellKnownMCPPathsUnix = []string{
@@ -178,6 +207,11 @@ var (
 		"~/.witsy/mcp.json",
 		// Enconvo
 		"~/.enconvo/mcp.json",
+		// Gemini CLI
+		"~/.gemini/mcp_config.json",
+		"~/.config/gemini-cli/mcp.json",
+		// Neovim (mcphub.nvim)
+		"~/.config/nvim/mcpservers.json",
 	}
 
 	// wellKnownMCPPathsProject contains project-level paths (work on all platforms).
@@ -205,6 +239,11 @@ var (
 		".enconvo/mcp.json",
 		// Generic assistants (project-level overrides)
 		".gemini/mcp.json",
+		".gemini/mcp_config.json",
+		// Neovim
+		".nvim/mcp.json",
+		// JetBrains IDEs
+		".idea/mcp.json",
 		".grok/mcp.json",
 		".chatgpt/mcp.json",
 		".openai/mcp.json",
@@ -223,6 +262,14 @@ var (
 		"mcp.json",
 		"mcp_config.json",
 		"mcp_settings.json",
+
+		// TOML-based clients
+		".mcp.toml",
+		"mcp_config.toml",
+
+		// MCP_SERVER_<NAME>_<FIELD> variables in dotenv files
+		".env",
+		".env.local",
 	}
 
 	// skipDirs are directories we don't want to scan.
@@ -240,26 +287,31 @@ var (
 	}
 )
 
-// GetWellKnownMCPPaths returns the appropriate MCP config paths for the current operating system.
-// Paths are expanded (~ and environment variables resolved) for immediate use.
-func GetWellKnownMCPPaths() []string {
+// WellKnownPathsConfig selects which classes of well-known config paths GetWellKnownMCPPaths
+// returns.
+type WellKnownPathsConfig struct {
+	// IncludeSystem adds the OS- and user-level paths for the current operating system.
+	IncludeSystem bool
+	// IncludeProject adds the project-level paths, resolved against the working directory and
+	// its git repository root.
+	IncludeProject bool
+}
+
+// AllWellKnownPaths selects both system and project paths.
+func AllWellKnownPaths() WellKnownPathsConfig {
+	return WellKnownPathsConfig{IncludeSystem: true, IncludeProject: true}
+}
+
+// GetWellKnownMCPPaths returns the MCP config paths selected by cfg for the current operating
+// system. Paths are expanded (~ and environment variables resolved) for immediate use.
+func GetWellKnownMCPPaths(cfg WellKnownPathsConfig) []string {
+	system, project := wellKnownPathsBySource()
 	var rawPaths []string
-	// Add OS-specific paths
-	switch runtime.GOOS {
-	case "darwin": // macOS
-		rawPaths = append(rawPaths, wellKnownMCPPathsMacOS...)
-		rawPaths = append(rawPaths, wellKnownMCPPathsUnix...)
-	case "linux":
-		rawPaths = append(rawPaths, wellKnownMCPPathsLinux...)
-		rawPaths = append(rawPaths, wellKnownMCPPathsUnix...)
-	case "windows":
-		rawPaths = append(rawPaths, wellKnownMCPPathsWindows...)
+	if cfg.IncludeSystem {
+		rawPaths = append(rawPaths, system...)
 	}
-	// Always add project-level paths (work on all platforms), resolved against plausible roots
-	for _, root := range getProjectRoots() {
-		for _, rel := range wellKnownMCPPathsProject {
-			rawPaths = append(rawPaths, filepath.Join(root, rel))
-		}
+	if cfg.IncludeProject {
+		rawPaths = append(rawPaths, project...)
 	}
 	// Expand all paths (resolve ~ and environment variables)
 	var expandedPaths []string
@@ -274,6 +326,29 @@ func GetWellKnownMCPPaths() []string {
 	return expandedPaths
 }
 
+// wellKnownPathsBySource splits the unexpanded well-known paths into the OS-level lists for
+// the current operating system and the project-level paths joined to each project root.
+func wellKnownPathsBySource() ([]string, []string) {
+	var system, project []string
+	switch runtime.GOOS {
+	case "darwin": // macOS
+		system = append(system, wellKnownMCPPathsMacOS...)
+		system = append(system, wellKnownMCPPathsUnix...)
+	case "linux":
+		system = append(system, wellKnownMCPPathsLinux...)
+		system = append(system, wellKnownMCPPathsUnix...)
+	case "windows":
+		system = append(system, wellKnownMCPPathsWindows...)
+	}
+	// Project-level paths work on all platforms, resolved against plausible roots.
+	for _, root := range getProjectRoots() {
+		for _, rel := range wellKnownMCPPathsProject {
+			project = append(project, filepath.Join(root, rel))
+		}
+	}
+	return system, project
+}
+
 // getProjectRoots returns plausible roots for resolving project-level relative paths.
 // It includes the current working directory and the enclosing git repository root, if any.
 func getProjectRoots() []string {
@@ -346,6 +421,10 @@ func isJSONFile(path string) bool {
 	return ext == ".json"
 }
 
+func isTOMLFile(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == ".toml"
+}
+
 func isJSONOrYAMLFile(path string) bool {
 	ext := strings.ToLower(filepath.Ext(path))
 	return ext == ".json" || ext == ".yaml" || ext == ".yml"
@@ -373,8 +452,16 @@ func isSkippedDir(name string) bool {
 // over a channel. The channel is closed when walking completes or the context is canceled.
 const streamBufferSize = 64
 
-//nolint:gocognit // file walking logic is intentionally explicit for clarity; refactor deferred.
 func streamConfigFiles(ctx context.Context, root string) <-chan string {
+	return streamConfigFilesWithDepth(ctx, root, 0)
+}
+
+// streamConfigFilesWithDepth is streamConfigFiles without entering directories nested more
+// than maxDepth levels below root, so 1 covers root and its immediate subdirectories.
+// maxDepth 0 means no limit.
+//
+//nolint:gocognit // file walking logic is intentionally explicit for clarity; refactor deferred.
+func streamConfigFilesWithDepth(ctx context.Context, root string, maxDepth int) <-chan string {
 	out := make(chan string, streamBufferSize)
 	go func() {
 		defer close(out)
@@ -393,6 +480,9 @@ func streamConfigFiles(ctx context.Context, root string) <-chan string {
 				if isSkippedDir(name) {
 					return fs.SkipDir
 				}
+				if maxDepth > 0 && walkDepth(root, path) > maxDepth {
+					return fs.SkipDir
+				}
 				return nil
 			}
 			if isWellKnownMCPFilename(name) || isJSONOrYAMLFile(path) {
@@ -407,3 +497,13 @@ func streamConfigFiles(ctx context.Context, root string) <-chan string {
 	}()
 	return out
 }
+
+// walkDepth returns how many levels path is below root: 0 for root itself and 1 for its
+// entries.
+func walkDepth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
@@ -2,9 +2,12 @@
 package scanner
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 
@@ -152,7 +155,7 @@ func TestExpandPath(t *testing.T) {
 }
 
 func TestGetWellKnownMCPPaths(t *testing.T) {
-	paths := GetWellKnownMCPPaths()
+	paths := GetWellKnownMCPPaths(AllWellKnownPaths())
 
 	// Should return some paths
 	assert.NotEmpty(t, paths)
@@ -198,6 +201,21 @@ func TestGetWellKnownMCPPaths(t *testing.T) {
 	}
 	assert.True(t, foundAnyNew, "Should include at least one newly added project-level client path")
 
+	// Cline and Amazon Q CLI keep their macOS configs in these locations; Roo Code, the
+	// Cline fork, uses a project-level file.
+	for _, want := range []string{
+		"~/Library/Application Support/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json",
+		"~/.aws/amazonq/mcp.json",
+		"~/.aws/amazonq/mcp_config.json",
+	} {
+		assert.Contains(t, wellKnownMCPPathsMacOS, want)
+	}
+	assert.Contains(t, wellKnownMCPPathsProject, ".roo/mcp.json")
+	assert.Contains(t, wellKnownMCPPathsProject, ".gemini/mcp_config.json")
+	assert.Contains(t, wellKnownMCPPathsProject, ".nvim/mcp.json")
+	assert.Contains(t, wellKnownMCPPathsProject, ".idea/mcp.json")
+	assert.Contains(t, WellKnownMCPFilenames, "mcpservers.json")
+
 	// OS-specific path checks
 	switch runtime.GOOS {
 	case "darwin":
@@ -210,6 +228,18 @@ func TestGetWellKnownMCPPaths(t *testing.T) {
 			}
 		}
 		assert.True(t, foundMacPath, "Should include macOS-specific paths")
+		for _, p := range []string{
+			"~/.aws/amazonq/mcp.json",
+			"~/Library/Application Support/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json",
+			"~/Library/Application Support/gh/copilot/mcp.json",
+			"~/.gemini/mcp_config.json",
+			"~/.config/nvim/mcpservers.json",
+			"~/.config/JetBrains/AIAssistant/mcp.json",
+		} {
+			expanded, err := expandPath(p)
+			require.NoError(t, err)
+			assert.Contains(t, paths, expanded)
+		}
 
 	case "linux":
 		// Should include Linux-specific paths
@@ -221,6 +251,17 @@ func TestGetWellKnownMCPPaths(t *testing.T) {
 			}
 		}
 		assert.True(t, foundLinuxPath, "Should include Linux-specific paths")
+		for _, p := range []string{
+			"~/.config/gh/copilot/mcp.json",
+			"~/.gemini/mcp_config.json",
+			"~/.config/gemini-cli/mcp.json",
+			"~/.config/nvim/mcpservers.json",
+			"~/.config/JetBrains/AIAssistant/mcp.json",
+		} {
+			expanded, err := expandPath(p)
+			require.NoError(t, err)
+			assert.Contains(t, paths, expanded)
+		}
 
 	case "windows":
 		// Should include Windows-specific paths (now expanded)
@@ -232,6 +273,9 @@ func TestGetWellKnownMCPPaths(t *testing.T) {
 			}
 		}
 		assert.True(t, foundWindowsPath, "Should include Windows-specific paths")
+		assert.True(t, slices.ContainsFunc(paths, func(p string) bool {
+			return strings.HasSuffix(p, `\GitHub CLI\copilot\mcp.json`) || strings.HasSuffix(p, `\.gemini\mcp_config.json`)
+		}), "Should include the GitHub Copilot CLI or Gemini CLI config")
 	}
 }
 
@@ -314,9 +358,33 @@ func TestPathExpansionIntegration(t *testing.T) {
 }
 
 // Benchmark path operations.
+func TestGetWellKnownMCPPaths_Scope(t *testing.T) {
+	all := GetWellKnownMCPPaths(AllWellKnownPaths())
+	system := GetWellKnownMCPPaths(WellKnownPathsConfig{IncludeSystem: true})
+	project := GetWellKnownMCPPaths(WellKnownPathsConfig{IncludeProject: true})
+	assert.ElementsMatch(t, all, append(slices.Clone(system), project...), "all is system plus project")
+	assert.Empty(t, GetWellKnownMCPPaths(WellKnownPathsConfig{}))
+
+	roots := getProjectRoots()
+	require.NotEmpty(t, roots)
+	underRoot := func(p string) bool {
+		return slices.ContainsFunc(roots, func(root string) bool { return strings.HasPrefix(p, root+string(filepath.Separator)) })
+	}
+	assert.Len(t, project, len(roots)*len(wellKnownMCPPathsProject))
+	for _, p := range project {
+		assert.True(t, underRoot(p), "project path %s should be under a project root", p)
+	}
+	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		assert.NotEmpty(t, system)
+	}
+	for _, p := range system {
+		assert.False(t, underRoot(p), "system path %s should not be a project path", p)
+	}
+}
+
 func BenchmarkGetWellKnownMCPPaths(b *testing.B) {
 	for range b.N {
-		paths := GetWellKnownMCPPaths()
+		paths := GetWellKnownMCPPaths(AllWellKnownPaths())
 		if len(paths) == 0 {
 			b.Fatal("Expected non-empty paths")
 		}
@@ -374,7 +442,7 @@ func TestPathExpansionProperties(t *testing.T) {
 
 	// Property: all well-known paths should be expandable without error
 	t.Run("all well-known paths expandable", func(t *testing.T) {
-		paths := GetWellKnownMCPPaths()
+		paths := GetWellKnownMCPPaths(AllWellKnownPaths())
 		for _, path := range paths {
 			// Since paths are already expanded, expanding again should not error
 			_, err := expandPath(path)
@@ -382,3 +450,30 @@ func TestPathExpansionProperties(t *testing.T) {
 		}
 	})
 }
+
+func TestStreamConfigFilesWithDepth(t *testing.T) {
+	// root/mcp.json, root/l1/mcp.json, ..., root/l1/l2/l3/l4/l5/mcp.json
+	root := t.TempDir()
+	dir := root
+	all := []string{filepath.Join(root, "mcp.json")}
+	for i := 1; i <= 5; i++ {
+		dir = filepath.Join(dir, fmt.Sprintf("l%d", i))
+		all = append(all, filepath.Join(dir, "mcp.json"))
+	}
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	for _, p := range all {
+		require.NoError(t, os.WriteFile(p, []byte(`{"mcpServers": {}}`), 0o600))
+	}
+
+	collect := func(maxDepth int) []string {
+		var got []string
+		for p := range streamConfigFilesWithDepth(context.Background(), root, maxDepth) {
+			got = append(got, p)
+		}
+		return got
+	}
+	assert.ElementsMatch(t, all, collect(0), "0 means no limit")
+	assert.ElementsMatch(t, all[:3], collect(2), "files in l2 are found, l3 is not entered")
+	assert.ElementsMatch(t, all[:2], collect(1))
+	assert.ElementsMatch(t, all, collect(5))
+}
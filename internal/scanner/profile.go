@@ -0,0 +1,44 @@
+package scanner
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// profileTopFiles is the number of slowest files listed in the text report.
+const profileTopFiles = 10
+
+// FileProfile is the time taken to scan one config file, recorded by WithProfiling.
+type FileProfile struct {
+	Path        string        `json:"Path"`
+	Duration    time.Duration `json:"Duration"`
+	ServerCount int           `json:"ServerCount"`
+}
+
+// NewProfileReport lists the scan time of every file in result, slowest first. Files are
+// only timed when the scan was run WithProfiling.
+func NewProfileReport(result ScanResult) []FileProfile {
+	report := make([]FileProfile, 0, len(result.Files))
+	for _, f := range result.Files {
+		report = append(report, FileProfile{Path: f.Path, Duration: f.Duration, ServerCount: len(f.Servers)})
+	}
+	sort.SliceStable(report, func(i, j int) bool { return report[i].Duration > report[j].Duration })
+	return report
+}
+
+// writeProfile writes the slowest files of the profile report as a table.
+func writeProfile(w io.Writer, report []FileProfile) {
+	fmt.Fprintf(w, "\n⏱️ SLOWEST FILES\n")
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	fmt.Fprintf(w, "   %10s  %7s  %s\n", "DURATION", "SERVERS", "FILE")
+	for i, p := range report {
+		if i == profileTopFiles {
+			fmt.Fprintf(w, "   ... and %d more files\n", len(report)-profileTopFiles)
+			break
+		}
+		fmt.Fprintf(w, "   %10s  %7d  %s\n", HumanDuration(p.Duration), p.ServerCount, p.Path)
+	}
+}
@@ -0,0 +1,64 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProfileReport(t *testing.T) {
+	report := NewProfileReport(ScanResult{Files: []FileResult{
+		{Path: "fast.json", Duration: time.Millisecond},
+		{Path: "slow.json", Duration: time.Second, Servers: []ServerConfig{{Name: "a"}, {Name: "b"}}},
+		{Path: "medium.json", Duration: 20 * time.Millisecond},
+	}})
+	assert.Equal(t, []FileProfile{
+		{Path: "slow.json", Duration: time.Second, ServerCount: 2},
+		{Path: "medium.json", Duration: 20 * time.Millisecond},
+		{Path: "fast.json", Duration: time.Millisecond},
+	}, report)
+}
+
+func TestWriteProfile(t *testing.T) {
+	var report []FileProfile
+	for i := 12; i > 0; i-- {
+		report = append(report, FileProfile{Path: fmt.Sprintf("file-%02d.json", i), Duration: time.Duration(i) * time.Millisecond, ServerCount: 1})
+	}
+	var buf bytes.Buffer
+	writeProfile(&buf, report)
+	out := buf.String()
+
+	assert.Contains(t, out, "SLOWEST FILES")
+	assert.Contains(t, out, "12ms        1  file-12.json")
+	assert.Contains(t, out, "file-03.json")
+	assert.NotContains(t, out, "file-02.json", "only the 10 slowest files are listed")
+	assert.Contains(t, out, "... and 2 more files")
+	assert.Less(t, strings.Index(out, "file-12.json"), strings.Index(out, "file-11.json"))
+}
+
+func TestMCPScanner_WithProfiling(t *testing.T) {
+	dir := t.TempDir()
+	writeScanFixtures(t, dir, 3)
+
+	result, err := NewMCPScanner([]string{dir}, filepath.Join(t.TempDir(), "s.json")).Scan()
+	require.NoError(t, err)
+	for _, f := range result.Files {
+		assert.Zero(t, f.Duration, "files are only timed when profiling")
+	}
+
+	result, err = NewMCPScanner([]string{dir}, filepath.Join(t.TempDir(), "s.json")).WithProfiling().Scan()
+	require.NoError(t, err)
+	report := NewProfileReport(*result)
+	require.Len(t, report, 3)
+	for _, p := range report {
+		assert.Positive(t, p.Duration, p.Path)
+		assert.Equal(t, 2, p.ServerCount, p.Path)
+	}
+}
@@ -0,0 +1,107 @@
+package scanner
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// FormatAzDO selects the Azure DevOps Pipelines logging commands built by NewAzDOReport.
+const FormatAzDO = "azdo"
+
+// Azure Pipelines issue types.
+const (
+	azdoError   = "error"
+	azdoWarning = "warning"
+)
+
+// AzDOIssue is one ##vso[task.logissue] logging command. LineNumber is omitted when the
+// location is not known.
+type AzDOIssue struct {
+	Type       string
+	SourcePath string
+	LineNumber int
+	Message    string
+}
+
+// NewAzDOReport builds Azure Pipelines issues from a scan summary: an error per line of each
+// secret finding, a warning per server rated high or critical, unless allowed by local
+// policy, and an issue per launch misconfiguration (an error when CRITICAL, else a warning).
+// Issues are sorted by path, line and message.
+func NewAzDOReport(summary ScanSummary) []AzDOIssue {
+	var issues []AzDOIssue
+	for _, s := range summary.Secrets {
+		message := fmt.Sprintf("%s secret in %s of server %q (%s confidence)", s.Kind, s.Key, s.ServerName, s.Confidence)
+		for path, lines := range s.Occurrences {
+			if len(lines) == 0 {
+				issues = append(issues, AzDOIssue{Type: azdoError, SourcePath: path, Message: message})
+			}
+			for _, line := range lines {
+				issues = append(issues, AzDOIssue{Type: azdoError, SourcePath: path, LineNumber: line, Message: message})
+			}
+		}
+	}
+
+	for _, sr := range summary.Servers {
+		if sr.Rating == nil || sr.LocalPolicy == "allowed" {
+			continue
+		}
+		tier := riskTierFromScore(sr.Rating.RiskScore)
+		if severityRank[tier] < severityRank["HIGH"] {
+			continue
+		}
+		message := fmt.Sprintf("MCP server %q has %s risk (score %.1f)", sr.Name, strings.ToLower(tier), sr.Rating.RiskScore)
+		if len(sr.Rating.Vulnerabilities) > 0 {
+			message += ": " + strings.Join(sr.Rating.Vulnerabilities, ", ")
+		}
+		issues = append(issues, AzDOIssue{Type: azdoWarning, SourcePath: sr.Path, Message: message})
+	}
+
+	for _, m := range summary.MisconfigFindings {
+		issueType := azdoWarning
+		if m.Severity == "CRITICAL" {
+			issueType = azdoError
+		}
+		issues = append(issues, AzDOIssue{Type: issueType, SourcePath: m.Path, Message: misconfigMessage(m)})
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.SourcePath != b.SourcePath {
+			return a.SourcePath < b.SourcePath
+		}
+		if a.LineNumber != b.LineNumber {
+			return a.LineNumber < b.LineNumber
+		}
+		return a.Message < b.Message
+	})
+	return issues
+}
+
+// WriteAzDO writes one logging command per issue. Property values and messages are escaped
+// as Azure Pipelines expects, so they cannot end the command early.
+func WriteAzDO(w io.Writer, issues []AzDOIssue) error {
+	for _, issue := range issues {
+		props := "type=" + azdoEscapeProperty(issue.Type)
+		if issue.SourcePath != "" {
+			props += ";sourcepath=" + azdoEscapeProperty(issue.SourcePath)
+		}
+		if issue.LineNumber > 0 {
+			props += fmt.Sprintf(";linenumber=%d", issue.LineNumber)
+		}
+		if _, err := fmt.Fprintf(w, "##vso[task.logissue %s]%s\n", props, azdoEscapeData(issue.Message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//nolint:gochecknoglobals // Immutable escape tables.
+var (
+	azdoDataEscaper     = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A")
+	azdoPropertyEscaper = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", ";", "%3B", "]", "%5D")
+)
+
+func azdoEscapeData(s string) string     { return azdoDataEscaper.Replace(s) }
+func azdoEscapeProperty(s string) string { return azdoPropertyEscaper.Replace(s) }
@@ -0,0 +1,56 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAzDOReport(t *testing.T) {
+	summary := vexSummary()
+	summary.Secrets = summary.Servers[0].Secrets
+	summary.Servers = append(summary.Servers,
+		ServerReport{Name: "crit", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 9.5}},
+		ServerReport{Name: "ok", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 9.5}, LocalPolicy: "allowed"},
+		ServerReport{Name: "low", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 2}},
+	)
+
+	assert.Equal(t, []AzDOIssue{
+		{Type: "warning", SourcePath: "/a/mcp.json", Message: `MCP server "fs" has high risk (score 7.5): CVE-2025-0001`},
+		{Type: "error", SourcePath: "/a/mcp.json", LineNumber: 7, Message: `GitHub Token secret in GITHUB_TOKEN of server "fs" (HIGH confidence)`},
+		{Type: "warning", SourcePath: "/b/mcp.json", Message: `MCP server "crit" has critical risk (score 9.5)`},
+	}, NewAzDOReport(summary), "low-risk and allowed servers are not reported")
+}
+
+func TestNewAzDOReport_Misconfigs(t *testing.T) {
+	summary := ScanSummary{MisconfigFindings: []MisconfigFinding{
+		{ServerName: "docker", Path: "/a/mcp.json", Rule: MisconfigPrivileged, Severity: "CRITICAL", Flag: "--privileged", Description: "Container runs privileged"},
+		{ServerName: "sh", Path: "/a/mcp.json", Rule: MisconfigShellCommand, Severity: "MEDIUM", Flag: "sh -c", Description: "Server is started through a shell"},
+	}}
+
+	assert.Equal(t, []AzDOIssue{
+		{Type: "error", SourcePath: "/a/mcp.json", Message: `MCP server "docker": Container runs privileged (--privileged)`},
+		{Type: "warning", SourcePath: "/a/mcp.json", Message: `MCP server "sh": Server is started through a shell (sh -c)`},
+	}, NewAzDOReport(summary))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteAzDO(&buf, NewAzDOReport(summary)))
+	assert.Contains(t, buf.String(), "##vso[task.logissue type=error;sourcepath=/a/mcp.json]MCP server \"docker\": Container runs privileged (--privileged)\n")
+}
+
+func TestWriteAzDO(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteAzDO(&buf, []AzDOIssue{
+		{Type: "error", SourcePath: "/a/mcp.json", LineNumber: 7, Message: "secret in env"},
+		{Type: "warning", SourcePath: "/b/we;ird]dir/mcp.json", Message: "100% risky\nreally"},
+	}))
+	assert.Equal(t, "##vso[task.logissue type=error;sourcepath=/a/mcp.json;linenumber=7]secret in env\n"+
+		"##vso[task.logissue type=warning;sourcepath=/b/we%3Bird%5Ddir/mcp.json]100%AZP25 risky%0Areally\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteAzDO(&buf, nil))
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,134 @@
+package scanner
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// FormatCheckstyle selects the Checkstyle XML report built by NewCheckstyleReport.
+const FormatCheckstyle = "checkstyle"
+
+// checkstyleVersion is the Checkstyle report format version written to the root element.
+const checkstyleVersion = "8.0"
+
+// Checkstyle severities.
+const (
+	checkstyleError   = "error"
+	checkstyleWarning = "warning"
+	checkstyleInfo    = "info"
+)
+
+// CheckstyleReport is a Checkstyle XML document, as consumed by IDE and CI annotation plugins.
+type CheckstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []CheckstyleFile `xml:"file"`
+}
+
+// CheckstyleFile groups the findings in one config file.
+type CheckstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []CheckstyleError `xml:"error"`
+}
+
+// CheckstyleError is a single finding. Line is omitted when the location is not known.
+type CheckstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// NewCheckstyleReport builds a Checkstyle report from a scan summary: one entry per line of
+// each secret finding (error for HIGH confidence, warning for LOW), one per server rated
+// high or critical (error for critical, warning for high, info when allowed by local policy),
+// and one per launch misconfiguration (error for CRITICAL, warning for HIGH, info for MEDIUM).
+// Files and their entries are sorted by name and line.
+func NewCheckstyleReport(summary ScanSummary) CheckstyleReport {
+	byFile := make(map[string][]CheckstyleError)
+	add := func(path string, line int, severity, message string) {
+		byFile[path] = append(byFile[path], CheckstyleError{Line: line, Severity: severity, Message: message, Source: "run-mcp"})
+	}
+
+	for _, s := range summary.Secrets {
+		severity := checkstyleWarning
+		if s.Confidence == ConfidenceHigh {
+			severity = checkstyleError
+		}
+		message := fmt.Sprintf("%s secret in %s of server %q (%s confidence)", s.Kind, s.Key, s.ServerName, s.Confidence)
+		for path, lines := range s.Occurrences {
+			if len(lines) == 0 {
+				add(path, 0, severity, message)
+			}
+			for _, line := range lines {
+				add(path, line, severity, message)
+			}
+		}
+	}
+
+	for _, sr := range summary.Servers {
+		if sr.Rating == nil {
+			continue
+		}
+		tier := riskTierFromScore(sr.Rating.RiskScore)
+		if severityRank[tier] < severityRank["HIGH"] {
+			continue
+		}
+		severity := checkstyleWarning
+		switch {
+		case sr.LocalPolicy == "allowed":
+			severity = checkstyleInfo
+		case tier == "CRITICAL":
+			severity = checkstyleError
+		}
+		message := fmt.Sprintf("MCP server %q has %s risk (score %.1f)", sr.Name, strings.ToLower(tier), sr.Rating.RiskScore)
+		if sr.Rating.Category != "" {
+			message += ", rated " + sr.Rating.Category
+		}
+		if len(sr.Rating.Vulnerabilities) > 0 {
+			message += ": " + strings.Join(sr.Rating.Vulnerabilities, ", ")
+		}
+		add(sr.Path, 0, severity, message)
+	}
+
+	for _, m := range summary.MisconfigFindings {
+		severity := checkstyleInfo
+		switch m.Severity {
+		case "CRITICAL":
+			severity = checkstyleError
+		case "HIGH":
+			severity = checkstyleWarning
+		}
+		add(m.Path, 0, severity, misconfigMessage(m))
+	}
+
+	report := CheckstyleReport{Version: checkstyleVersion, Files: make([]CheckstyleFile, 0, len(byFile))}
+	for path, errs := range byFile {
+		sort.SliceStable(errs, func(i, j int) bool {
+			if errs[i].Line != errs[j].Line {
+				return errs[i].Line < errs[j].Line
+			}
+			return errs[i].Message < errs[j].Message
+		})
+		report.Files = append(report.Files, CheckstyleFile{Name: path, Errors: errs})
+	}
+	sort.Slice(report.Files, func(i, j int) bool { return report.Files[i].Name < report.Files[j].Name })
+	return report
+}
+
+// WriteCheckstyle writes report as an indented XML document.
+func WriteCheckstyle(w io.Writer, report CheckstyleReport) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,92 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCheckstyleReport(t *testing.T) {
+	summary := vexSummary()
+	summary.Secrets = append(summary.Servers[0].Secrets, SecretFinding{
+		Kind: "High Entropy String", Key: "API_KEY", Confidence: ConfidenceLow, ServerName: "local",
+		Occurrences: map[string][]int{"/a/mcp.json": {3}, "/b/mcp.json": {9}},
+	})
+	summary.Servers = append(summary.Servers,
+		ServerReport{Name: "crit", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 9.5, Category: "MALICIOUS"}},
+		ServerReport{Name: "ok", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 7.5}, LocalPolicy: "allowed"},
+		ServerReport{Name: "low", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 2}},
+	)
+
+	report := NewCheckstyleReport(summary)
+	require.Len(t, report.Files, 2)
+	assert.Equal(t, "/a/mcp.json", report.Files[0].Name)
+	assert.Equal(t, []CheckstyleError{
+		{Line: 0, Severity: "warning", Message: `MCP server "fs" has high risk (score 7.5), rated SUSPICIOUS: CVE-2025-0001`, Source: "run-mcp"},
+		{Line: 3, Severity: "warning", Message: `High Entropy String secret in API_KEY of server "local" (LOW confidence)`, Source: "run-mcp"},
+		{Line: 7, Severity: "error", Message: `GitHub Token secret in GITHUB_TOKEN of server "fs" (HIGH confidence)`, Source: "run-mcp"},
+	}, report.Files[0].Errors)
+
+	b := report.Files[1]
+	assert.Equal(t, "/b/mcp.json", b.Name)
+	require.Len(t, b.Errors, 3, "low-risk servers are not reported")
+	assert.Equal(t, "error", b.Errors[0].Severity, "critical servers are errors")
+	assert.Equal(t, "info", b.Errors[1].Severity, "allowed servers are informational")
+	assert.Equal(t, 9, b.Errors[2].Line)
+}
+
+func TestNewCheckstyleReport_Misconfigs(t *testing.T) {
+	summary := ScanSummary{MisconfigFindings: []MisconfigFinding{
+		{ServerName: "docker", Path: "/a/mcp.json", Rule: MisconfigPrivileged, Severity: "CRITICAL", Flag: "--privileged", Description: "Container runs privileged"},
+		{ServerName: "docker", Path: "/a/mcp.json", Rule: MisconfigHostNetwork, Severity: "HIGH", Flag: "--network=host", Description: "Container shares the host network namespace"},
+		{ServerName: "sh", Path: "/a/mcp.json", Rule: MisconfigShellCommand, Severity: "MEDIUM", Flag: "sh -c", Description: "Server is started through a shell", Hint: "Run the command directly"},
+	}}
+
+	report := NewCheckstyleReport(summary)
+	require.Len(t, report.Files, 1)
+	assert.Equal(t, []CheckstyleError{
+		{Severity: "error", Message: `MCP server "docker": Container runs privileged (--privileged)`, Source: "run-mcp"},
+		{Severity: "warning", Message: `MCP server "docker": Container shares the host network namespace (--network=host)`, Source: "run-mcp"},
+		{Severity: "info", Message: `MCP server "sh": Server is started through a shell (sh -c). Run the command directly`, Source: "run-mcp"},
+	}, report.Files[0].Errors)
+}
+
+func TestWriteCheckstyle_WellFormed(t *testing.T) {
+	summary := vexSummary()
+	summary.Secrets = []SecretFinding{{
+		Kind: "Token", Key: `a"<b>&`, Confidence: ConfidenceHigh, ServerName: "s",
+		Occurrences: map[string][]int{"/a/mcp.json": {1}},
+	}}
+	for name, s := range map[string]ScanSummary{"findings": summary, "empty": {}} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteCheckstyle(&buf, NewCheckstyleReport(s)))
+
+			// Every token must decode, and the document must round-trip.
+			dec := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+			for {
+				_, err := dec.Token()
+				if err != nil {
+					require.ErrorIs(t, err, io.EOF)
+					break
+				}
+			}
+			var parsed CheckstyleReport
+			require.NoError(t, xml.Unmarshal(buf.Bytes(), &parsed), buf.String())
+			assert.Equal(t, "checkstyle", parsed.XMLName.Local)
+			assert.Equal(t, NewCheckstyleReport(s).Files, nonNilFiles(parsed.Files))
+		})
+	}
+}
+
+func nonNilFiles(files []CheckstyleFile) []CheckstyleFile {
+	if files == nil {
+		return []CheckstyleFile{}
+	}
+	return files
+}
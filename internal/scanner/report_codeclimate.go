@@ -0,0 +1,144 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// FormatCodeClimate selects the Code Climate issue list built by NewCodeClimateReport, as
+// consumed by GitLab Code Quality.
+const FormatCodeClimate = "codeclimate"
+
+// Code Climate severities used by the report.
+const (
+	codeClimateCritical = "critical"
+	codeClimateMajor    = "major"
+	codeClimateMinor    = "minor"
+	codeClimateInfo     = "info"
+)
+
+// Code Climate check names of the kinds of issue.
+const (
+	codeClimateSecretCheck    = "run-mcp/exposed-secret"
+	codeClimateServerCheck    = "run-mcp/server-risk"
+	codeClimateMisconfigCheck = "run-mcp/launch-misconfiguration"
+)
+
+// CodeClimateIssue is a single issue of a Code Climate report.
+type CodeClimateIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Categories  []string            `json:"categories"`
+	Severity    string              `json:"severity"`
+	Fingerprint string              `json:"fingerprint"`
+	Location    CodeClimateLocation `json:"location"`
+}
+
+// CodeClimateLocation is the file and line range of an issue.
+type CodeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines CodeClimateLines `json:"lines"`
+}
+
+// CodeClimateLines is an inclusive line range.
+type CodeClimateLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+// NewCodeClimateReport builds Code Climate issues from a scan summary: a critical issue per
+// line of each secret finding, one per server rated high or critical (critical or major,
+// info when allowed by local policy), and one per launch misconfiguration (critical, major or
+// minor for CRITICAL, HIGH and MEDIUM). Server and misconfiguration issues are placed on
+// line 1, since GitLab requires a line. Issues are sorted by path, line and description.
+func NewCodeClimateReport(summary ScanSummary) []CodeClimateIssue {
+	issues := []CodeClimateIssue{}
+	add := func(check, path string, line int, severity, description, identity string) {
+		if line < 1 {
+			line = 1
+		}
+		sum := sha256.Sum256([]byte(strings.Join([]string{check, path, fmt.Sprint(line), identity}, "\x00")))
+		issues = append(issues, CodeClimateIssue{
+			Type:        "issue",
+			CheckName:   check,
+			Description: description,
+			Categories:  []string{"Security"},
+			Severity:    severity,
+			Fingerprint: hex.EncodeToString(sum[:]),
+			Location:    CodeClimateLocation{Path: path, Lines: CodeClimateLines{Begin: line}},
+		})
+	}
+
+	for _, s := range summary.Secrets {
+		description := fmt.Sprintf("%s secret in %s of server %q (%s confidence)", s.Kind, s.Key, s.ServerName, s.Confidence)
+		for path, lines := range s.Occurrences {
+			if len(lines) == 0 {
+				add(codeClimateSecretCheck, path, 0, codeClimateCritical, description, s.ValueHash)
+			}
+			for _, line := range lines {
+				add(codeClimateSecretCheck, path, line, codeClimateCritical, description, s.ValueHash)
+			}
+		}
+	}
+
+	for _, sr := range summary.Servers {
+		if sr.Rating == nil {
+			continue
+		}
+		tier := riskTierFromScore(sr.Rating.RiskScore)
+		if severityRank[tier] < severityRank["HIGH"] {
+			continue
+		}
+		severity := codeClimateMajor
+		switch {
+		case sr.LocalPolicy == "allowed":
+			severity = codeClimateInfo
+		case tier == "CRITICAL":
+			severity = codeClimateCritical
+		}
+		description := fmt.Sprintf("MCP server %q has %s risk (score %.1f)", sr.Name, strings.ToLower(tier), sr.Rating.RiskScore)
+		if len(sr.Rating.Vulnerabilities) > 0 {
+			description += ": " + strings.Join(sr.Rating.Vulnerabilities, ", ")
+		}
+		add(codeClimateServerCheck, sr.Path, 0, severity, description, sr.Name)
+	}
+
+	for _, m := range summary.MisconfigFindings {
+		severity := codeClimateMinor
+		switch m.Severity {
+		case "CRITICAL":
+			severity = codeClimateCritical
+		case "HIGH":
+			severity = codeClimateMajor
+		}
+		add(codeClimateMisconfigCheck, m.Path, 0, severity, misconfigMessage(m), m.ServerName+"\x00"+m.Rule+"\x00"+m.Flag)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].Location, issues[j].Location
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Lines.Begin != b.Lines.Begin {
+			return a.Lines.Begin < b.Lines.Begin
+		}
+		return issues[i].Description < issues[j].Description
+	})
+	return issues
+}
+
+// WriteCodeClimate writes issues as an indented JSON array.
+func WriteCodeClimate(w io.Writer, issues []CodeClimateIssue) error {
+	if issues == nil {
+		issues = []CodeClimateIssue{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
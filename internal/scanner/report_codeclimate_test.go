@@ -0,0 +1,164 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validateCodeClimateIssue checks an issue decoded from JSON against the required fields and
+// enumerations of the Code Climate issue spec, plus the fields GitLab Code Quality requires.
+func validateCodeClimateIssue(issue map[string]interface{}) error {
+	known := []string{"type", "check_name", "description", "content", "categories", "location",
+		"other_locations", "trace", "remediation_points", "severity", "fingerprint", "engine_name"}
+	for k := range issue {
+		if !slices.Contains(known, k) {
+			return fmt.Errorf("unknown field %q", k)
+		}
+	}
+	if issue["type"] != "issue" {
+		return fmt.Errorf("type is %v, want issue", issue["type"])
+	}
+	for _, k := range []string{"check_name", "description", "fingerprint"} {
+		if s, ok := issue[k].(string); !ok || s == "" {
+			return fmt.Errorf("%s must be a non-empty string", k)
+		}
+	}
+	severities := []string{"info", "minor", "major", "critical", "blocker"}
+	if s, ok := issue["severity"].(string); !ok || !slices.Contains(severities, s) {
+		return fmt.Errorf("invalid severity %v", issue["severity"])
+	}
+	categories, ok := issue["categories"].([]interface{})
+	if !ok || len(categories) == 0 {
+		return fmt.Errorf("categories must be a non-empty array")
+	}
+	allowed := []string{"Bug Risk", "Clarity", "Compatibility", "Complexity", "Duplication", "Performance", "Security", "Style"}
+	for _, c := range categories {
+		if s, ok := c.(string); !ok || !slices.Contains(allowed, s) {
+			return fmt.Errorf("invalid category %v", c)
+		}
+	}
+	location, ok := issue["location"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("location must be an object")
+	}
+	if s, ok := location["path"].(string); !ok || s == "" {
+		return fmt.Errorf("location.path must be a non-empty string")
+	}
+	lines, ok := location["lines"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("location.lines must be an object")
+	}
+	begin, ok := lines["begin"].(float64)
+	if !ok || begin < 1 || begin != float64(int(begin)) {
+		return fmt.Errorf("location.lines.begin must be a positive integer, got %v", lines["begin"])
+	}
+	if end, ok := lines["end"]; ok {
+		if e, ok := end.(float64); !ok || e < begin {
+			return fmt.Errorf("location.lines.end must not precede begin, got %v", end)
+		}
+	}
+	return nil
+}
+
+func TestNewCodeClimateReport(t *testing.T) {
+	summary := vexSummary()
+	summary.Secrets = append(summary.Servers[0].Secrets, SecretFinding{
+		Kind: "Generic Secret", Key: "API_KEY", Confidence: ConfidenceLow, ServerName: "local", ValueHash: "h2",
+		Occurrences: map[string][]int{"/a/mcp.json": {3}, "/b/mcp.json": {9}},
+	})
+	summary.Servers = append(summary.Servers,
+		ServerReport{Name: "crit", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 9.5, Category: "MALICIOUS"}},
+		ServerReport{Name: "ok", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 7.5}, LocalPolicy: "allowed"},
+		ServerReport{Name: "low", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 2}},
+	)
+
+	issues := NewCodeClimateReport(summary)
+	type row struct {
+		path     string
+		line     int
+		check    string
+		severity string
+	}
+	var got []row
+	for _, i := range issues {
+		got = append(got, row{i.Location.Path, i.Location.Lines.Begin, i.CheckName, i.Severity})
+		assert.Equal(t, []string{"Security"}, i.Categories)
+	}
+	assert.Equal(t, []row{
+		{"/a/mcp.json", 1, codeClimateServerCheck, "major"},
+		{"/a/mcp.json", 3, codeClimateSecretCheck, "critical"},
+		{"/a/mcp.json", 7, codeClimateSecretCheck, "critical"},
+		{"/b/mcp.json", 1, codeClimateServerCheck, "critical"},
+		{"/b/mcp.json", 1, codeClimateServerCheck, "info"},
+		{"/b/mcp.json", 9, codeClimateSecretCheck, "critical"},
+	}, got, "low-risk servers are not reported")
+	assert.Equal(t, `MCP server "fs" has high risk (score 7.5): CVE-2025-0001`, issues[0].Description)
+
+	fingerprints := map[string]bool{}
+	for _, i := range issues {
+		assert.False(t, fingerprints[i.Fingerprint], "fingerprint %s must be unique", i.Fingerprint)
+		fingerprints[i.Fingerprint] = true
+	}
+	assert.Equal(t, issues, NewCodeClimateReport(summary), "fingerprints are stable across runs")
+}
+
+func TestNewCodeClimateReport_Misconfigs(t *testing.T) {
+	summary := ScanSummary{MisconfigFindings: []MisconfigFinding{
+		{ServerName: "docker", Path: "/a/mcp.json", Rule: MisconfigPrivileged, Severity: "CRITICAL", Flag: "--privileged", Description: "Container runs privileged"},
+		{ServerName: "docker", Path: "/a/mcp.json", Rule: MisconfigCapAdd, Severity: "HIGH", Flag: "--cap-add NET_ADMIN", Description: "Container is granted extra capabilities"},
+		{ServerName: "sh", Path: "/a/mcp.json", Rule: MisconfigShellCommand, Severity: "MEDIUM", Flag: "sh -c", Description: "Server is started through a shell"},
+	}}
+
+	issues := NewCodeClimateReport(summary)
+	require.Len(t, issues, 3)
+	severities := map[string]string{}
+	for _, i := range issues {
+		assert.Equal(t, codeClimateMisconfigCheck, i.CheckName)
+		assert.Equal(t, 1, i.Location.Lines.Begin)
+		severities[i.Description] = i.Severity
+	}
+	assert.Equal(t, map[string]string{
+		`MCP server "docker": Container runs privileged (--privileged)`:                      "critical",
+		`MCP server "docker": Container is granted extra capabilities (--cap-add NET_ADMIN)`: "major",
+		`MCP server "sh": Server is started through a shell (sh -c)`:                         "minor",
+	}, severities)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCodeClimate(&buf, issues))
+	var decoded []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	for _, issue := range decoded {
+		require.NoError(t, validateCodeClimateIssue(issue))
+	}
+}
+
+func TestWriteCodeClimate(t *testing.T) {
+	summary := vexSummary()
+	summary.Secrets = summary.Servers[0].Secrets
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCodeClimate(&buf, NewCodeClimateReport(summary)))
+	var issues []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &issues))
+	require.Len(t, issues, 2)
+	for _, issue := range issues {
+		require.NoError(t, validateCodeClimateIssue(issue))
+	}
+
+	// Round trip through the typed form loses nothing.
+	var typed []CodeClimateIssue
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &typed))
+	assert.Equal(t, NewCodeClimateReport(summary), typed)
+
+	buf.Reset()
+	require.NoError(t, WriteCodeClimate(&buf, nil))
+	assert.Equal(t, "[]\n", buf.String(), "an empty report is an empty array")
+}
@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormatCSV selects the RFC 4180 CSV written by WriteCSV, for import into spreadsheets.
+const FormatCSV = "csv"
+
+// Column headers of the two CSV sections.
+var (
+	csvServerHeader = []string{"ServerName", "Path", "RiskTier", "RiskScore", "Category", "Vulnerabilities", "LocalPolicy", "HasSecrets"}
+	csvSecretHeader = []string{"ServerName", "Kind", "Key", "RedactedValue", "Files", "Confidence"}
+)
+
+// WriteCSV writes the summary as CSV: a row per server, then, after a blank line, a row per
+// secret finding. Unrated servers have empty RiskTier and RiskScore cells; lists such as
+// Vulnerabilities and Files are joined with ";".
+func WriteCSV(w io.Writer, summary ScanSummary) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvServerHeader); err != nil {
+		return err
+	}
+	for _, sr := range summary.Servers {
+		var tier, score string
+		var vulnerabilities []string
+		if sr.Rating != nil {
+			tier = riskTierFromScore(sr.Rating.RiskScore)
+			score = strconv.FormatFloat(sr.Rating.RiskScore, 'f', 1, 64)
+			vulnerabilities = sr.Rating.Vulnerabilities
+		}
+		if err := cw.Write([]string{
+			sr.Name, sr.Path, tier, score, sr.Category, strings.Join(vulnerabilities, ";"),
+			sr.LocalPolicy, strconv.FormatBool(len(sr.Secrets) > 0),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+
+	// A record with one empty field would be written as `""`, so the separator goes to w directly.
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return err
+	}
+	if err := cw.Write(csvSecretHeader); err != nil {
+		return err
+	}
+	for _, s := range summary.Secrets {
+		files := make([]string, 0, len(s.Occurrences))
+		for path := range s.Occurrences {
+			files = append(files, path)
+		}
+		sort.Strings(files)
+		if err := cw.Write([]string{s.ServerName, s.Kind, s.Key, s.Value, strings.Join(files, ";"), s.Confidence}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
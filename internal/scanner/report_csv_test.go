@@ -0,0 +1,45 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCSV(t *testing.T) {
+	summary := vexSummary()
+	summary.Secrets = summary.Servers[0].Secrets
+	summary.Secrets[0].Value = "ghp_****wxyz"
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, summary))
+
+	sections := strings.Split(buf.String(), "\n\n")
+	require.Len(t, sections, 2)
+
+	servers, err := csv.NewReader(strings.NewReader(sections[0])).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, servers, len(summary.Servers)+1)
+	assert.Equal(t, []string{"ServerName", "Path", "RiskTier", "RiskScore", "Category", "Vulnerabilities", "LocalPolicy", "HasSecrets"}, servers[0])
+	assert.Equal(t, []string{"fs", "/a/mcp.json", "HIGH", "7.5", CategoryNPM, "CVE-2025-0001", "", "true"}, servers[1])
+	assert.Equal(t, []string{"local", "/a/mcp.json", "", "", CategoryBinary, "", "", "false"}, servers[2], "unrated servers have no tier or score")
+
+	secrets, err := csv.NewReader(strings.NewReader(sections[1])).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, secrets, len(summary.Secrets)+1)
+	assert.Equal(t, []string{"ServerName", "Kind", "Key", "RedactedValue", "Files", "Confidence"}, secrets[0])
+	assert.Equal(t, []string{"fs", "GitHub Token", "GITHUB_TOKEN", "ghp_****wxyz", "/a/mcp.json", ConfidenceHigh}, secrets[1])
+}
+
+func TestWriteCSV_Quoting(t *testing.T) {
+	summary := ScanSummary{Servers: []ServerReport{{Name: `say "hi", world`, Path: "/a/mcp.json"}}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, summary))
+	assert.Contains(t, buf.String(), `"say ""hi"", world",/a/mcp.json`)
+}
@@ -0,0 +1,170 @@
+package scanner
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FormatCycloneDX selects the CycloneDX VEX report built by NewCycloneDXVEX.
+const FormatCycloneDX = "cyclonedx"
+
+// CycloneDXVulnerability is a security advisory about one or more components.
+type CycloneDXVulnerability struct {
+	BOMRef      string                            `json:"bom-ref,omitempty"`
+	ID          string                            `json:"id"`
+	Source      *CycloneDXSource                  `json:"source,omitempty"`
+	References  []CycloneDXVulnerabilityReference `json:"references,omitempty"`
+	Ratings     []CycloneDXRating                 `json:"ratings,omitempty"`
+	Description string                            `json:"description,omitempty"`
+	Affects     []CycloneDXAffect                 `json:"affects"`
+	Properties  []CycloneDXProperty               `json:"properties,omitempty"`
+}
+
+// CycloneDXSource names the origin of an advisory or rating.
+type CycloneDXSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// CycloneDXVulnerabilityReference links an advisory to a related one, such as a CVE.
+type CycloneDXVulnerabilityReference struct {
+	ID     string          `json:"id"`
+	Source CycloneDXSource `json:"source"`
+}
+
+// CycloneDXRating scores an advisory.
+type CycloneDXRating struct {
+	Source   *CycloneDXSource `json:"source,omitempty"`
+	Score    float64          `json:"score"`
+	Severity string           `json:"severity"`
+	Method   string           `json:"method"`
+}
+
+// CycloneDXAffect references a component affected by an advisory.
+type CycloneDXAffect struct {
+	Ref string `json:"ref"`
+}
+
+// NewCycloneDXVEX builds a CycloneDX VEX report from a scan summary. Every reported server
+// becomes an application component, with its secret findings attached as "evidence" external
+// references pointing at the file and line. Every rated server gets a vulnerability carrying
+// its risk score and known CVEs. Scan totals are recorded as metadata properties.
+func NewCycloneDXVEX(summary ScanSummary, host SBOMHost, toolVersion string, now time.Time) CycloneDXBOM {
+	timestamp := now
+	if !summary.StartedAt.IsZero() {
+		timestamp = summary.StartedAt
+	}
+	metadata := cycloneDXMetadata(host, toolVersion, timestamp)
+	metadata.Properties = []CycloneDXProperty{
+		{Name: "run-mcp:total_servers", Value: strconv.Itoa(summary.TotalServers)},
+		{Name: "run-mcp:total_findings", Value: strconv.Itoa(summary.TotalFindings)},
+		{Name: "run-mcp:scanned_files", Value: strconv.Itoa(summary.ScannedFiles)},
+		{Name: "run-mcp:duration", Value: summary.Duration.String()},
+	}
+	if summary.Environment != "" {
+		metadata.Properties = append(metadata.Properties, CycloneDXProperty{Name: "run-mcp:environment", Value: summary.Environment})
+	}
+
+	components := make([]CycloneDXComponent, 0, len(summary.Servers))
+	vulnerabilities := []CycloneDXVulnerability{}
+	for _, sr := range summary.Servers {
+		c := CycloneDXComponent{
+			Type:               "application",
+			BOMRef:             "mcp-server:" + sr.Path + "#" + sr.Name,
+			Name:               sr.Name,
+			ExternalReferences: secretEvidence(sr.Secrets),
+			Properties:         []CycloneDXProperty{{Name: "run-mcp:path", Value: sr.Path}},
+		}
+		if sr.Category != "" {
+			c.Properties = append(c.Properties, CycloneDXProperty{Name: "run-mcp:category", Value: sr.Category})
+		}
+		if sr.LocalPolicy != "" {
+			c.Properties = append(c.Properties, CycloneDXProperty{Name: "run-mcp:local_policy", Value: sr.LocalPolicy})
+		}
+		components = append(components, c)
+		if sr.Rating != nil {
+			vulnerabilities = append(vulnerabilities, vulnerabilityFromRating(sr, c.BOMRef))
+		}
+	}
+
+	return CycloneDXBOM{
+		BOMFormat:       "CycloneDX",
+		SpecVersion:     CycloneDXSpecVersion,
+		SerialNumber:    "urn:uuid:" + uuid.NewString(),
+		Version:         1,
+		Metadata:        metadata,
+		Components:      components,
+		Vulnerabilities: vulnerabilities,
+	}
+}
+
+// vulnerabilityFromRating describes a rated server as an advisory affecting ref.
+func vulnerabilityFromRating(sr ServerReport, ref string) CycloneDXVulnerability {
+	r := sr.Rating
+	id := r.Hash
+	if id == "" {
+		id = sr.Name
+	}
+	source := &CycloneDXSource{Name: "run-mcp"}
+	v := CycloneDXVulnerability{
+		BOMRef: "vuln:" + ref,
+		ID:     id,
+		Source: source,
+		Ratings: []CycloneDXRating{{
+			Source:   source,
+			Score:    r.RiskScore,
+			Severity: strings.ToLower(riskTierFromScore(r.RiskScore)),
+			Method:   "other",
+		}},
+		Affects: []CycloneDXAffect{{Ref: ref}},
+	}
+	if r.Category != "" {
+		v.Description = fmt.Sprintf("MCP server %q is rated %s", sr.Name, r.Category)
+		v.Properties = append(v.Properties, CycloneDXProperty{Name: "run-mcp:rating_category", Value: r.Category})
+	}
+	if r.Source != "" {
+		v.Properties = append(v.Properties, CycloneDXProperty{Name: "run-mcp:rating_source", Value: r.Source})
+	}
+	for _, cve := range r.Vulnerabilities {
+		v.References = append(v.References, CycloneDXVulnerabilityReference{
+			ID:     cve,
+			Source: CycloneDXSource{Name: "NVD", URL: "https://nvd.nist.gov/vuln/detail/" + url.PathEscape(cve)},
+		})
+	}
+	return v
+}
+
+// secretEvidence returns one "evidence" reference per location of each secret finding.
+// Only the kind, key and confidence are recorded; values never leave the report.
+func secretEvidence(secrets []SecretFinding) []CycloneDXExternalReference {
+	var refs []CycloneDXExternalReference
+	for _, s := range secrets {
+		comment := fmt.Sprintf("%s secret in %s (%s confidence)", s.Kind, s.Key, s.Confidence)
+		paths := make([]string, 0, len(s.Occurrences))
+		for path := range s.Occurrences {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			lines := s.Occurrences[path]
+			if len(lines) == 0 {
+				lines = []int{0}
+			}
+			for _, line := range lines {
+				u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+				if line > 0 {
+					u.Fragment = "L" + strconv.Itoa(line)
+				}
+				refs = append(refs, CycloneDXExternalReference{Type: "evidence", URL: u.String(), Comment: comment})
+			}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,182 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/cyclonedx-1.5.schema.json
+var cycloneDXSchema []byte
+
+// validateSchema checks doc against the JSON schema keywords used by the embedded CycloneDX
+// schema: type, enum, pattern, required, properties, additionalProperties, items and local $ref.
+func validateSchema(t *testing.T, schemaDoc, doc []byte) []string {
+	t.Helper()
+	var schema, value map[string]interface{}
+	require.NoError(t, json.Unmarshal(schemaDoc, &schema))
+	require.NoError(t, json.Unmarshal(doc, &value))
+	var errs []string
+	var check func(s map[string]interface{}, v interface{}, at string)
+	check = func(s map[string]interface{}, v interface{}, at string) {
+		if ref, ok := s["$ref"].(string); ok {
+			def := schema["definitions"].(map[string]interface{})[strings.TrimPrefix(ref, "#/definitions/")]
+			require.NotNil(t, def, "unresolved $ref %s", ref)
+			check(def.(map[string]interface{}), v, at)
+			return
+		}
+		if typ, ok := s["type"].(string); ok && !schemaTypeMatches(typ, v) {
+			errs = append(errs, fmt.Sprintf("%s: want %s, got %T", at, typ, v))
+			return
+		}
+		if enum, ok := s["enum"].([]interface{}); ok && !slices.Contains(enum, v) {
+			errs = append(errs, fmt.Sprintf("%s: %v is not one of %v", at, v, enum))
+		}
+		if pattern, ok := s["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(v.(string)) {
+			errs = append(errs, fmt.Sprintf("%s: %q does not match %s", at, v, pattern))
+		}
+		switch v := v.(type) {
+		case map[string]interface{}:
+			props, _ := s["properties"].(map[string]interface{})
+			for _, name := range asSlice(s["required"]) {
+				if _, ok := v[name.(string)]; !ok {
+					errs = append(errs, fmt.Sprintf("%s: missing required %q", at, name))
+				}
+			}
+			for name, child := range v {
+				sub, ok := props[name].(map[string]interface{})
+				if !ok {
+					if s["additionalProperties"] == false {
+						errs = append(errs, fmt.Sprintf("%s: unexpected property %q", at, name))
+					}
+					continue
+				}
+				check(sub, child, at+"."+name)
+			}
+		case []interface{}:
+			if items, ok := s["items"].(map[string]interface{}); ok {
+				for i, child := range v {
+					check(items, child, fmt.Sprintf("%s[%d]", at, i))
+				}
+			}
+		}
+	}
+	check(schema, value, "$")
+	return errs
+}
+
+func asSlice(v interface{}) []interface{} {
+	s, _ := v.([]interface{})
+	return s
+}
+
+func schemaTypeMatches(typ string, v interface{}) bool {
+	switch typ {
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == float64(int64(f))
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	}
+	return false
+}
+
+func vexSummary() ScanSummary {
+	return ScanSummary{
+		Servers: []ServerReport{
+			{
+				Name:     "fs",
+				Path:     "/a/mcp.json",
+				Category: CategoryNPM,
+				Rating: &SecurityRating{
+					Hash: "abc123", Category: "SUSPICIOUS", RiskScore: 7.5, Source: "api",
+					Vulnerabilities: []string{"CVE-2025-0001"},
+				},
+				Secrets: []SecretFinding{{
+					Kind: "GitHub Token", Key: "GITHUB_TOKEN", Confidence: ConfidenceHigh, ServerName: "fs",
+					Occurrences: map[string][]int{"/a/mcp.json": {7}},
+				}},
+			},
+			{Name: "local", Path: "/a/mcp.json", Category: CategoryBinary},
+		},
+		TotalServers:  2,
+		TotalFindings: 1,
+		ScannedFiles:  1,
+		StartedAt:     time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Duration:      1500 * time.Millisecond,
+		Environment:   "staging",
+	}
+}
+
+func TestNewCycloneDXVEX(t *testing.T) {
+	vex := NewCycloneDXVEX(vexSummary(), SBOMHost{Name: "host", UUID: "6f1c1b52-7c2e-4c4a-9d55-0d1c6a1f2b3c"}, "1.0.0", time.Now())
+
+	assert.Equal(t, "2025-06-01T12:00:00Z", vex.Metadata.Timestamp, "scan start time is the report time")
+	assert.Contains(t, vex.Metadata.Properties, CycloneDXProperty{Name: "run-mcp:total_servers", Value: "2"})
+	assert.Contains(t, vex.Metadata.Properties, CycloneDXProperty{Name: "run-mcp:environment", Value: "staging"})
+
+	require.Len(t, vex.Components, 2, "every reported server is a component")
+	fs := vex.Components[0]
+	assert.Equal(t, "mcp-server:/a/mcp.json#fs", fs.BOMRef)
+	require.Len(t, fs.ExternalReferences, 1)
+	assert.Equal(t, CycloneDXExternalReference{
+		Type:    "evidence",
+		URL:     "file:///a/mcp.json#L7",
+		Comment: "GitHub Token secret in GITHUB_TOKEN (HIGH confidence)",
+	}, fs.ExternalReferences[0])
+
+	require.Len(t, vex.Vulnerabilities, 1, "only rated servers are advisories")
+	v := vex.Vulnerabilities[0]
+	assert.Equal(t, "abc123", v.ID)
+	assert.Equal(t, []CycloneDXAffect{{Ref: fs.BOMRef}}, v.Affects)
+	require.Len(t, v.Ratings, 1)
+	assert.Equal(t, "high", v.Ratings[0].Severity)
+	assert.InDelta(t, 7.5, v.Ratings[0].Score, 0)
+	require.Len(t, v.References, 1)
+	assert.Equal(t, "CVE-2025-0001", v.References[0].ID)
+}
+
+func TestNewCycloneDXVEX_Schema(t *testing.T) {
+	for name, summary := range map[string]ScanSummary{
+		"findings": vexSummary(),
+		"empty":    {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteCycloneDX(&buf, NewCycloneDXVEX(summary, SBOMHost{Name: "host"}, "dev", time.Now())))
+			assert.Empty(t, validateSchema(t, cycloneDXSchema, buf.Bytes()))
+		})
+	}
+
+	// The --sbom document shares the schema.
+	var sbom bytes.Buffer
+	require.NoError(t, WriteCycloneDX(&sbom, NewCycloneDXBOM(sbomResult(), SBOMHost{Name: "host"}, "dev", time.Now())))
+	assert.Empty(t, validateSchema(t, cycloneDXSchema, sbom.Bytes()))
+
+	// The validator itself must reject documents that break the schema.
+	bad := []byte(`{"bomFormat": "SPDX", "specVersion": "1.5", "components": [{"type": "app", "name": "x", "extra": 1}]}`)
+	assert.Len(t, validateSchema(t, cycloneDXSchema, bad), 3)
+}
@@ -0,0 +1,193 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FormatGitLabSAST selects the GitLab SAST report built by NewGitLabSASTReport, as consumed
+// by artifacts:reports:sast.
+const FormatGitLabSAST = "gitlab-sast"
+
+// gitLabSASTSchemaVersion is the version of the GitLab security report schema the report follows.
+const gitLabSASTSchemaVersion = "15.0.7"
+
+// gitLabSASTTimeLayout is the timestamp format of the scan block; GitLab expects no zone.
+const gitLabSASTTimeLayout = "2006-01-02T15:04:05"
+
+// GitLab severities used by the report.
+const (
+	gitLabSASTCritical = "Critical"
+	gitLabSASTHigh     = "High"
+	gitLabSASTMedium   = "Medium"
+)
+
+// GitLabSASTReport is a GitLab SAST security report.
+type GitLabSASTReport struct {
+	Version         string                    `json:"version"`
+	Vulnerabilities []GitLabSASTVulnerability `json:"vulnerabilities"`
+	Scan            GitLabSASTScan            `json:"scan"`
+}
+
+// GitLabSASTVulnerability is a single finding of a GitLab SAST report.
+type GitLabSASTVulnerability struct {
+	ID          string                 `json:"id"`
+	Category    string                 `json:"category"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Severity    string                 `json:"severity"`
+	Solution    string                 `json:"solution,omitempty"`
+	Identifiers []GitLabSASTIdentifier `json:"identifiers"`
+	Location    GitLabSASTLocation     `json:"location"`
+}
+
+// GitLabSASTIdentifier names the rule that produced a vulnerability.
+type GitLabSASTIdentifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// GitLabSASTLocation is the file and line of a vulnerability.
+type GitLabSASTLocation struct {
+	File      string `json:"file"`
+	StartLine int    `json:"start_line"`
+}
+
+// GitLabSASTScan describes the scan that produced the report.
+type GitLabSASTScan struct {
+	Analyzer  GitLabSASTTool `json:"analyzer"`
+	Scanner   GitLabSASTTool `json:"scanner"`
+	Type      string         `json:"type"`
+	StartTime string         `json:"start_time"`
+	EndTime   string         `json:"end_time"`
+	Status    string         `json:"status"`
+}
+
+// GitLabSASTTool identifies the analyzer or scanner of a report.
+type GitLabSASTTool struct {
+	ID      string           `json:"id"`
+	Name    string           `json:"name"`
+	Version string           `json:"version"`
+	Vendor  GitLabSASTVendor `json:"vendor"`
+}
+
+// GitLabSASTVendor is the vendor of a tool.
+type GitLabSASTVendor struct {
+	Name string `json:"name"`
+}
+
+// NewGitLabSASTReport builds a GitLab SAST report from a scan summary: a critical
+// vulnerability per line of each secret finding, one per server rated high or critical and
+// one per launch misconfiguration at its own severity (both placed on line 1, since GitLab
+// requires a line). The scan times come from the summary,
+// falling back to now. Vulnerabilities are sorted by file, line and name, and their IDs are
+// stable across runs.
+func NewGitLabSASTReport(summary ScanSummary, version string, now time.Time) GitLabSASTReport {
+	vulns := []GitLabSASTVulnerability{}
+	add := func(rule, name, path string, line int, severity, description, solution, identity string) {
+		if line < 1 {
+			line = 1
+		}
+		sum := sha256.Sum256([]byte(strings.Join([]string{rule, path, fmt.Sprint(line), identity}, "\x00")))
+		vulns = append(vulns, GitLabSASTVulnerability{
+			ID:          hex.EncodeToString(sum[:]),
+			Category:    "sast",
+			Name:        name,
+			Description: description,
+			Severity:    severity,
+			Solution:    solution,
+			Identifiers: []GitLabSASTIdentifier{{Type: "run-mcp", Name: rule, Value: rule}},
+			Location:    GitLabSASTLocation{File: path, StartLine: line},
+		})
+	}
+
+	for _, s := range summary.Secrets {
+		name := s.Kind + " exposed in MCP config"
+		description := fmt.Sprintf("%s secret in %s of server %q (%s confidence)", s.Kind, s.Key, s.ServerName, s.Confidence)
+		for path, lines := range s.Occurrences {
+			if len(lines) == 0 {
+				add(codeClimateSecretCheck, name, path, 0, gitLabSASTCritical, description, s.Remediation, s.ValueHash)
+			}
+			for _, line := range lines {
+				add(codeClimateSecretCheck, name, path, line, gitLabSASTCritical, description, s.Remediation, s.ValueHash)
+			}
+		}
+	}
+
+	for _, sr := range summary.Servers {
+		if sr.Rating == nil {
+			continue
+		}
+		tier := riskTierFromScore(sr.Rating.RiskScore)
+		if severityRank[tier] < severityRank["HIGH"] {
+			continue
+		}
+		severity := gitLabSASTHigh
+		if tier == "CRITICAL" {
+			severity = gitLabSASTCritical
+		}
+		description := fmt.Sprintf("MCP server %q has %s risk (score %.1f)", sr.Name, strings.ToLower(tier), sr.Rating.RiskScore)
+		if len(sr.Rating.Vulnerabilities) > 0 {
+			description += ": " + strings.Join(sr.Rating.Vulnerabilities, ", ")
+		}
+		add(codeClimateServerCheck, fmt.Sprintf("Risky MCP server %q", sr.Name), sr.Path, 0, severity, description, sr.Remediation, sr.Name)
+	}
+
+	for _, m := range summary.MisconfigFindings {
+		severity := gitLabSASTMedium
+		switch m.Severity {
+		case "CRITICAL":
+			severity = gitLabSASTCritical
+		case "HIGH":
+			severity = gitLabSASTHigh
+		}
+		name := fmt.Sprintf("Launch misconfiguration of MCP server %q", m.ServerName)
+		add(codeClimateMisconfigCheck, name, m.Path, 0, severity, misconfigMessage(m), m.Hint, m.ServerName+"\x00"+m.Rule+"\x00"+m.Flag)
+	}
+
+	sort.SliceStable(vulns, func(i, j int) bool {
+		a, b := vulns[i].Location, vulns[j].Location
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.StartLine != b.StartLine {
+			return a.StartLine < b.StartLine
+		}
+		return vulns[i].Name < vulns[j].Name
+	})
+
+	start := summary.StartedAt
+	if start.IsZero() {
+		start = now
+	}
+	tool := GitLabSASTTool{ID: "run-mcp", Name: "run-mcp", Version: version, Vendor: GitLabSASTVendor{Name: "Ensignia"}}
+	return GitLabSASTReport{
+		Version:         gitLabSASTSchemaVersion,
+		Vulnerabilities: vulns,
+		Scan: GitLabSASTScan{
+			Analyzer:  tool,
+			Scanner:   tool,
+			Type:      "sast",
+			StartTime: start.UTC().Format(gitLabSASTTimeLayout),
+			EndTime:   start.Add(summary.Duration).UTC().Format(gitLabSASTTimeLayout),
+			Status:    "success",
+		},
+	}
+}
+
+// WriteGitLabSAST writes the report as indented JSON.
+func WriteGitLabSAST(w io.Writer, report GitLabSASTReport) error {
+	if report.Vulnerabilities == nil {
+		report.Vulnerabilities = []GitLabSASTVulnerability{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
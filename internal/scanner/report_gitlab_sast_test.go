@@ -0,0 +1,172 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validateGitLabSASTReport checks a report decoded from JSON against the required fields and
+// enumerations of the GitLab security report schema for SAST.
+func validateGitLabSASTReport(report map[string]interface{}) error {
+	if s, ok := report["version"].(string); !ok || !regexp.MustCompile(`^\d+\.\d+\.\d+$`).MatchString(s) {
+		return fmt.Errorf("version must be a semantic version, got %v", report["version"])
+	}
+	scan, ok := report["scan"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("scan must be an object")
+	}
+	for _, k := range []string{"analyzer", "scanner"} {
+		tool, ok := scan[k].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("scan.%s must be an object", k)
+		}
+		for _, f := range []string{"id", "name", "version"} {
+			if s, ok := tool[f].(string); !ok || s == "" {
+				return fmt.Errorf("scan.%s.%s must be a non-empty string", k, f)
+			}
+		}
+		vendor, ok := tool["vendor"].(map[string]interface{})
+		if !ok || vendor["name"] == "" {
+			return fmt.Errorf("scan.%s.vendor.name is required", k)
+		}
+	}
+	if scan["type"] != "sast" {
+		return fmt.Errorf("scan.type is %v, want sast", scan["type"])
+	}
+	if !slices.Contains([]interface{}{"success", "failure"}, scan["status"]) {
+		return fmt.Errorf("invalid scan.status %v", scan["status"])
+	}
+	timestamp := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}$`)
+	for _, k := range []string{"start_time", "end_time"} {
+		if s, ok := scan[k].(string); !ok || !timestamp.MatchString(s) {
+			return fmt.Errorf("scan.%s must be a timestamp without zone, got %v", k, scan[k])
+		}
+	}
+
+	vulns, ok := report["vulnerabilities"].([]interface{})
+	if !ok {
+		return fmt.Errorf("vulnerabilities must be an array")
+	}
+	severities := []string{"Info", "Unknown", "Low", "Medium", "High", "Critical"}
+	for i, v := range vulns {
+		vuln, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("vulnerabilities[%d] must be an object", i)
+		}
+		if s, ok := vuln["id"].(string); !ok || s == "" {
+			return fmt.Errorf("vulnerabilities[%d].id must be a non-empty string", i)
+		}
+		if s, ok := vuln["severity"].(string); !ok || !slices.Contains(severities, s) {
+			return fmt.Errorf("vulnerabilities[%d]: invalid severity %v", i, vuln["severity"])
+		}
+		identifiers, ok := vuln["identifiers"].([]interface{})
+		if !ok || len(identifiers) == 0 {
+			return fmt.Errorf("vulnerabilities[%d].identifiers must be a non-empty array", i)
+		}
+		for _, id := range identifiers {
+			m, ok := id.(map[string]interface{})
+			if !ok || m["type"] == "" || m["name"] == "" || m["value"] == "" {
+				return fmt.Errorf("vulnerabilities[%d]: identifiers need type, name and value", i)
+			}
+		}
+		location, ok := vuln["location"].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("vulnerabilities[%d].location must be an object", i)
+		}
+		if s, ok := location["file"].(string); !ok || s == "" {
+			return fmt.Errorf("vulnerabilities[%d].location.file must be a non-empty string", i)
+		}
+		if line, ok := location["start_line"].(float64); !ok || line < 1 || line != float64(int(line)) {
+			return fmt.Errorf("vulnerabilities[%d].location.start_line must be a positive integer, got %v", i, location["start_line"])
+		}
+	}
+	return nil
+}
+
+func TestNewGitLabSASTReport(t *testing.T) {
+	summary := vexSummary()
+	summary.Secrets = summary.Servers[0].Secrets
+	summary.Servers = append(summary.Servers,
+		ServerReport{Name: "crit", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 9.5, Category: "MALICIOUS"}},
+		ServerReport{Name: "low", Path: "/b/mcp.json", Rating: &SecurityRating{RiskScore: 2}},
+	)
+
+	report := NewGitLabSASTReport(summary, "1.0.0", time.Now())
+	type row struct {
+		file     string
+		line     int
+		severity string
+	}
+	var got []row
+	for _, v := range report.Vulnerabilities {
+		got = append(got, row{v.Location.File, v.Location.StartLine, v.Severity})
+		assert.Equal(t, "sast", v.Category)
+	}
+	assert.Equal(t, []row{
+		{"/a/mcp.json", 1, "High"},
+		{"/a/mcp.json", 7, "Critical"},
+		{"/b/mcp.json", 1, "Critical"},
+	}, got, "low-risk servers are not reported")
+	assert.Equal(t, `MCP server "fs" has high risk (score 7.5): CVE-2025-0001`, report.Vulnerabilities[0].Description)
+
+	assert.Equal(t, "run-mcp", report.Scan.Scanner.ID)
+	assert.Equal(t, "1.0.0", report.Scan.Scanner.Version)
+	assert.Equal(t, "2025-06-01T12:00:00", report.Scan.StartTime, "scan start time is the report start time")
+	assert.Equal(t, "2025-06-01T12:00:01", report.Scan.EndTime)
+	assert.Equal(t, report, NewGitLabSASTReport(summary, "1.0.0", time.Now()), "IDs are stable across runs")
+}
+
+func TestNewGitLabSASTReport_Misconfigs(t *testing.T) {
+	summary := ScanSummary{MisconfigFindings: []MisconfigFinding{
+		{ServerName: "docker", Path: "/a/mcp.json", Rule: MisconfigPrivileged, Severity: "CRITICAL", Flag: "--privileged", Description: "Container runs privileged"},
+		{ServerName: "host", Path: "/a/mcp.json", Rule: MisconfigHostNetwork, Severity: "HIGH", Flag: "--network=host", Description: "Container shares the host network namespace"},
+		{ServerName: "sh", Path: "/a/mcp.json", Rule: MisconfigShellCommand, Severity: "MEDIUM", Flag: "sh -c", Description: "Server is started through a shell", Hint: "Run the command directly"},
+	}}
+
+	report := NewGitLabSASTReport(summary, "1.0.0", time.Now())
+	require.Len(t, report.Vulnerabilities, 3)
+	severities := map[string]string{}
+	for _, v := range report.Vulnerabilities {
+		assert.Equal(t, 1, v.Location.StartLine)
+		severities[v.Name] = v.Severity
+	}
+	assert.Equal(t, map[string]string{
+		`Launch misconfiguration of MCP server "docker"`: "Critical",
+		`Launch misconfiguration of MCP server "host"`:   "High",
+		`Launch misconfiguration of MCP server "sh"`:     "Medium",
+	}, severities)
+	assert.Equal(t, "Run the command directly", report.Vulnerabilities[2].Solution)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteGitLabSAST(&buf, report))
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.NoError(t, validateGitLabSASTReport(decoded))
+}
+
+func TestWriteGitLabSAST(t *testing.T) {
+	summary := vexSummary()
+	summary.Secrets = summary.Servers[0].Secrets
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteGitLabSAST(&buf, NewGitLabSASTReport(summary, "1.0.0", time.Now())))
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.NoError(t, validateGitLabSASTReport(report))
+	assert.Len(t, report["vulnerabilities"], 2)
+
+	buf.Reset()
+	require.NoError(t, WriteGitLabSAST(&buf, NewGitLabSASTReport(ScanSummary{}, "dev", time.Now())))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.NoError(t, validateGitLabSASTReport(report))
+	assert.Equal(t, []interface{}{}, report["vulnerabilities"], "an empty report has an empty array")
+}
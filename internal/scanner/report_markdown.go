@@ -0,0 +1,102 @@
+package scanner
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// markdownTiers lists the risk tiers reported in the Markdown summary, most severe first.
+//
+//nolint:gochecknoglobals // Fixed lookup table.
+var markdownTiers = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}
+
+// WriteMarkdown renders a GitHub-flavoured Markdown summary: a table of servers per risk tier,
+// the rated servers most severe first, and the secret findings with their locations.
+func WriteMarkdown(w io.Writer, summary ScanSummary) error {
+	var b strings.Builder
+	b.WriteString("## run-mcp scan\n\n")
+	fmt.Fprintf(&b, "Scanned %d files, %d servers detected", summary.ScannedFiles, summary.TotalServers)
+	if summary.Environment != "" {
+		fmt.Fprintf(&b, " (environment: `%s`)", summary.Environment)
+	}
+	b.WriteString(".\n\n")
+
+	counts := make(map[string]int)
+	var rated []ServerReport
+	for _, s := range summary.Servers {
+		tier := serverTier(s)
+		if tier == "NONE" {
+			tier = "LOW"
+		}
+		counts[tier]++
+		if s.Rating != nil {
+			rated = append(rated, s)
+		}
+	}
+	b.WriteString("| Risk | Servers |\n| --- | ---: |\n")
+	for _, tier := range markdownTiers {
+		fmt.Fprintf(&b, "| %s | %d |\n", tier, counts[tier])
+	}
+	fmt.Fprintf(&b, "| UNRATED | %d |\n", counts["UNRATED"])
+	fmt.Fprintf(&b, "| Secrets | %d |\n", len(summary.Secrets))
+
+	if len(rated) > 0 {
+		sort.SliceStable(rated, func(i, j int) bool {
+			return rated[i].Rating.RiskScore > rated[j].Rating.RiskScore
+		})
+		b.WriteString("\n### Rated servers\n\n")
+		for _, s := range rated {
+			fmt.Fprintf(&b, "- **%s** `%s` in `%s`: risk %.1f/10", riskTierFromScore(s.Rating.RiskScore), markdownCode(s.Name), markdownCode(s.Path), s.Rating.RiskScore)
+			if s.Rating.Category != "" {
+				b.WriteString(", " + s.Rating.Category)
+			}
+			if len(s.Rating.Vulnerabilities) > 0 {
+				b.WriteString(" (" + strings.Join(s.Rating.Vulnerabilities, ", ") + ")")
+			}
+			if s.LocalPolicy == "allowed" {
+				b.WriteString(", allowed by local policy")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(summary.Secrets) > 0 {
+		b.WriteString("\n### Secrets\n\n")
+		for _, f := range summary.Secrets {
+			fmt.Fprintf(&b, "- %s secret in `%s` of server `%s` (%s confidence)", f.Kind, markdownCode(f.Key), markdownCode(f.ServerName), f.Confidence)
+			if locs := markdownLocations(f.Occurrences); locs != "" {
+				b.WriteString(": " + locs)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownLocations lists each occurrence as `path:line`, sorted by path.
+func markdownLocations(occurrences map[string][]int) string {
+	paths := make([]string, 0, len(occurrences))
+	for p := range occurrences {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	var locs []string
+	for _, p := range paths {
+		if len(occurrences[p]) == 0 {
+			locs = append(locs, "`"+markdownCode(p)+"`")
+		}
+		for _, line := range occurrences[p] {
+			locs = append(locs, fmt.Sprintf("`%s:%d`", markdownCode(p), line))
+		}
+	}
+	return strings.Join(locs, ", ")
+}
+
+// markdownCode makes s safe inside a single-backtick code span.
+func markdownCode(s string) string {
+	return strings.ReplaceAll(s, "`", "'")
+}
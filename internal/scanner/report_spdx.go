@@ -0,0 +1,235 @@
+package scanner
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
+)
+
+// FormatSPDX selects the SPDX tag-value SBOM built by NewSPDXDocument.
+const FormatSPDX = "spdx"
+
+// SPDXVersion is the SPDX specification version written by WriteSPDX.
+const SPDXVersion = "SPDX-2.3"
+
+// spdxNoAssertion marks a field run-mcp makes no claim about.
+const spdxNoAssertion = "NOASSERTION"
+
+// spdxDocumentID is the SPDX identifier of the document itself.
+const spdxDocumentID = "SPDXRef-DOCUMENT"
+
+// spdxIDInvalid matches the characters not allowed in an SPDX identifier.
+var spdxIDInvalid = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// SPDXDocument is the subset of an SPDX 2.3 document that run-mcp produces.
+type SPDXDocument struct {
+	Name          string
+	Namespace     string
+	Creators      []string
+	Created       time.Time
+	Packages      []SPDXPackage
+	Relationships []SPDXRelationship
+	Annotations   []SPDXAnnotation
+}
+
+// SPDXPackage is a package used by one or more MCP servers.
+type SPDXPackage struct {
+	SPDXID           string
+	Name             string
+	Version          string
+	DownloadLocation string
+	// PURL is written as a PACKAGE-MANAGER external reference when set.
+	PURL    string
+	Comment string
+}
+
+// SPDXRelationship relates two SPDX elements, e.g. the document DESCRIBES a package.
+type SPDXRelationship struct {
+	Element string
+	Type    string
+	Related string
+}
+
+// SPDXAnnotation is a review comment attached to an SPDX element.
+type SPDXAnnotation struct {
+	Annotator string
+	Date      time.Time
+	Type      string
+	SPDXRef   string
+	Comment   string
+}
+
+// NewSPDXDocument builds an SPDX 2.3 SBOM from the identifiers of every discovered server,
+// like NewCycloneDXBOM: package URLs, OCI references and remote server URLs each become a
+// package described by the document. Packages used by a rated server in summary are
+// annotated with the highest risk score among those servers. Packages are sorted by name.
+func NewSPDXDocument(result ScanResult, summary ScanSummary, host SBOMHost, toolVersion string, now time.Time) SPDXDocument {
+	ratings := make(map[string]*SecurityRating)
+	for _, sr := range summary.Servers {
+		if sr.Rating != nil {
+			ratings[sr.Name] = sr.Rating
+		}
+	}
+
+	extractor := NewIdentifierExtractor()
+	byKey := make(map[string]*SPDXPackage)
+	servers := make(map[string][]string)
+	for _, file := range result.Files {
+		for _, sc := range file.Servers {
+			for _, id := range extractor.ExtractIdentifiers(file.Path, sc.Name, sc.Server) {
+				key := string(id.Kind) + ":" + id.Value
+				if _, ok := byKey[key]; !ok {
+					pkg, keep := spdxPackageFromIdentifier(id)
+					if !keep {
+						continue
+					}
+					byKey[key] = &pkg
+				}
+				if !slices.Contains(servers[key], sc.Name) {
+					servers[key] = append(servers[key], sc.Name)
+				}
+			}
+		}
+	}
+	keys := make([]string, 0, len(byKey))
+	for k := range byKey {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := byKey[keys[i]], byKey[keys[j]]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return keys[i] < keys[j]
+	})
+
+	tool := "Tool: run-mcp-" + toolVersion
+	doc := SPDXDocument{
+		Name:      "run-mcp-" + host.Name,
+		Namespace: "https://spdx.org/spdxdocs/run-mcp-" + uuid.NewString(),
+		Creators:  []string{tool},
+		Created:   now.UTC(),
+	}
+	usedIDs := make(map[string]bool)
+	for _, k := range keys {
+		pkg := *byKey[k]
+		pkg.SPDXID = uniqueSPDXID("SPDXRef-Package-"+spdxIDInvalid.ReplaceAllString(pkg.Name, "-"), usedIDs)
+		pkg.Comment = "Used by MCP servers: " + strings.Join(servers[k], ", ")
+		doc.Packages = append(doc.Packages, pkg)
+		doc.Relationships = append(doc.Relationships, SPDXRelationship{Element: spdxDocumentID, Type: "DESCRIBES", Related: pkg.SPDXID})
+
+		var worst *SecurityRating
+		for _, name := range servers[k] {
+			if r := ratings[name]; r != nil && (worst == nil || r.RiskScore > worst.RiskScore) {
+				worst = r
+			}
+		}
+		if worst != nil {
+			doc.Annotations = append(doc.Annotations, SPDXAnnotation{
+				Annotator: tool,
+				Date:      doc.Created,
+				Type:      "REVIEW",
+				SPDXRef:   pkg.SPDXID,
+				Comment:   fmt.Sprintf("Rating: %.1f/10", worst.RiskScore),
+			})
+		}
+	}
+	return doc
+}
+
+// spdxPackageFromIdentifier maps an identifier to a package; ok is false for kinds that are
+// not included in the SBOM.
+func spdxPackageFromIdentifier(id apigen.TargetIdentifier) (SPDXPackage, bool) {
+	pkg := SPDXPackage{DownloadLocation: spdxNoAssertion}
+	switch id.Kind {
+	case apigen.Purl:
+		pkg.Name, pkg.Version = splitPurl(id.Value)
+		pkg.PURL = id.Value
+	case apigen.Oci:
+		pkg.Name, pkg.Version = splitImageRef(id.Value)
+	case apigen.Url:
+		pkg.Name = id.Value
+		pkg.DownloadLocation = id.Value
+	default:
+		return SPDXPackage{}, false
+	}
+	return pkg, true
+}
+
+// uniqueSPDXID returns id, suffixed with a counter if it is already used, and records it.
+func uniqueSPDXID(id string, used map[string]bool) string {
+	candidate := id
+	for n := 2; used[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d", id, n)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// WriteSPDX writes doc in the SPDX tag-value format.
+func WriteSPDX(w io.Writer, doc SPDXDocument) error {
+	var b strings.Builder
+	tag := func(name, value string) {
+		if strings.Contains(value, "\n") {
+			value = "<text>" + value + "</text>"
+		}
+		fmt.Fprintf(&b, "%s: %s\n", name, value)
+	}
+
+	tag("SPDXVersion", SPDXVersion)
+	tag("DataLicense", "CC0-1.0")
+	tag("SPDXID", spdxDocumentID)
+	tag("DocumentName", doc.Name)
+	tag("DocumentNamespace", doc.Namespace)
+	for _, c := range doc.Creators {
+		tag("Creator", c)
+	}
+	tag("Created", doc.Created.Format(time.RFC3339))
+
+	for _, p := range doc.Packages {
+		b.WriteString("\n")
+		tag("PackageName", p.Name)
+		tag("SPDXID", p.SPDXID)
+		if p.Version != "" {
+			tag("PackageVersion", p.Version)
+		}
+		tag("PackageDownloadLocation", p.DownloadLocation)
+		tag("FilesAnalyzed", "false")
+		tag("PackageLicenseConcluded", spdxNoAssertion)
+		tag("PackageLicenseDeclared", spdxNoAssertion)
+		tag("PackageCopyrightText", spdxNoAssertion)
+		if p.PURL != "" {
+			tag("ExternalRef", "PACKAGE-MANAGER purl "+p.PURL)
+		}
+		if p.Comment != "" {
+			tag("PackageComment", p.Comment)
+		}
+	}
+
+	if len(doc.Relationships) > 0 {
+		b.WriteString("\n")
+	}
+	for _, r := range doc.Relationships {
+		tag("Relationship", r.Element+" "+r.Type+" "+r.Related)
+	}
+
+	for _, a := range doc.Annotations {
+		b.WriteString("\n")
+		tag("Annotator", a.Annotator)
+		tag("AnnotationDate", a.Date.Format(time.RFC3339))
+		tag("AnnotationType", a.Type)
+		tag("SPDXREF", a.SPDXRef)
+		tag("AnnotationComment", a.Comment)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,156 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spdxTag is one "Tag: Value" pair of an SPDX tag-value document.
+type spdxTag struct {
+	Name  string
+	Value string
+}
+
+// parseSPDXTagValue is a minimal SPDX 2.3 tag-value parser: blank lines and comments are
+// skipped, every other line must be "Tag: Value", and a value opened with <text> runs until
+// the matching </text>, possibly over several lines.
+func parseSPDXTagValue(data string) ([]spdxTag, error) {
+	var tags []spdxTag
+	sc := bufio.NewScanner(strings.NewReader(data))
+	line := 0
+	for sc.Scan() {
+		line++
+		text := sc.Text()
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(text, ": ")
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("line %d: not a tag-value pair: %q", line, text)
+		}
+		if strings.HasPrefix(value, "<text>") {
+			value = strings.TrimPrefix(value, "<text>")
+			for !strings.HasSuffix(value, "</text>") {
+				if !sc.Scan() {
+					return nil, fmt.Errorf("line %d: unterminated <text> for %s", line, name)
+				}
+				line++
+				value += "\n" + sc.Text()
+			}
+			value = strings.TrimSuffix(value, "</text>")
+		}
+		tags = append(tags, spdxTag{Name: name, Value: value})
+	}
+	return tags, sc.Err()
+}
+
+// spdxSections splits parsed tags into the document creation info and one map per package
+// and annotation, keyed by tag name. Relationships are returned separately.
+func spdxSections(tags []spdxTag) (doc map[string][]string, packages, annotations []map[string][]string, relationships []string) {
+	doc = map[string][]string{}
+	current := doc
+	for _, tag := range tags {
+		switch tag.Name {
+		case "PackageName":
+			current = map[string][]string{}
+			packages = append(packages, current)
+		case "Annotator":
+			current = map[string][]string{}
+			annotations = append(annotations, current)
+		case "Relationship":
+			relationships = append(relationships, tag.Value)
+			continue
+		}
+		current[tag.Name] = append(current[tag.Name], tag.Value)
+	}
+	return doc, packages, annotations, relationships
+}
+
+func TestWriteSPDX(t *testing.T) {
+	summary := ScanSummary{Servers: []ServerReport{
+		{Name: "fs", Rating: &SecurityRating{RiskScore: 3}},
+		{Name: "fs-copy", Rating: &SecurityRating{RiskScore: 9.4}},
+	}}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	doc := NewSPDXDocument(sbomResult(), summary, SBOMHost{Name: "laptop"}, "1.0.0", now)
+	var buf bytes.Buffer
+	require.NoError(t, WriteSPDX(&buf, doc))
+
+	tags, err := parseSPDXTagValue(buf.String())
+	require.NoError(t, err)
+	info, packages, annotations, relationships := spdxSections(tags)
+
+	for _, required := range []string{"SPDXVersion", "DataLicense", "SPDXID", "DocumentName", "DocumentNamespace", "Creator", "Created"} {
+		assert.NotEmpty(t, info[required], "document is missing %s", required)
+	}
+	assert.Equal(t, []string{"SPDX-2.3"}, info["SPDXVersion"])
+	assert.Equal(t, []string{"CC0-1.0"}, info["DataLicense"])
+	assert.Equal(t, []string{"SPDXRef-DOCUMENT"}, info["SPDXID"])
+	assert.Equal(t, []string{"Tool: run-mcp-1.0.0"}, info["Creator"])
+	assert.Equal(t, []string{"2025-06-01T12:00:00Z"}, info["Created"])
+	assert.Regexp(t, `^https://spdx\.org/spdxdocs/run-mcp-[0-9a-f-]{36}$`, info["DocumentNamespace"][0])
+
+	spdxID := regexp.MustCompile(`^SPDXRef-[A-Za-z0-9.-]+$`)
+	ids := map[string]map[string][]string{}
+	require.Len(t, packages, 3)
+	for _, p := range packages {
+		for _, required := range []string{"PackageName", "SPDXID", "PackageDownloadLocation", "PackageLicenseConcluded", "PackageCopyrightText"} {
+			require.Len(t, p[required], 1, "package %v must have exactly one %s", p["PackageName"], required)
+		}
+		id := p["SPDXID"][0]
+		assert.Regexp(t, spdxID, id)
+		assert.NotContains(t, ids, id, "SPDXID %s must be unique", id)
+		ids[id] = p
+		assert.Equal(t, []string{"NOASSERTION"}, p["PackageLicenseConcluded"])
+	}
+
+	fs := ids["SPDXRef-Package--modelcontextprotocol-server-filesystem"]
+	require.NotNil(t, fs)
+	assert.Equal(t, []string{"1.2.0"}, fs["PackageVersion"])
+	assert.Equal(t, []string{"PACKAGE-MANAGER purl pkg:npm/@modelcontextprotocol/server-filesystem@1.2.0"}, fs["ExternalRef"])
+	assert.Equal(t, []string{"Used by MCP servers: fs, fs-copy"}, fs["PackageComment"])
+
+	described := map[string]bool{}
+	for _, r := range relationships {
+		fields := strings.Fields(r)
+		require.Len(t, fields, 3, "relationship %q", r)
+		assert.Equal(t, "SPDXRef-DOCUMENT", fields[0])
+		assert.Equal(t, "DESCRIBES", fields[1])
+		assert.Contains(t, ids, fields[2], "relationship target must be a package")
+		described[fields[2]] = true
+	}
+	assert.Len(t, described, len(ids), "every package is described by the document")
+
+	require.Len(t, annotations, 1, "only packages used by a rated server are annotated")
+	a := annotations[0]
+	assert.Equal(t, []string{"SPDXRef-Package--modelcontextprotocol-server-filesystem"}, a["SPDXREF"])
+	assert.Equal(t, []string{"REVIEW"}, a["AnnotationType"])
+	assert.Equal(t, []string{"Rating: 9.4/10"}, a["AnnotationComment"], "the highest score of the sharing servers is used")
+}
+
+func TestParseSPDXTagValue(t *testing.T) {
+	tags, err := parseSPDXTagValue("# comment\nPackageComment: <text>line one\nline two</text>\n\nSPDXID: SPDXRef-A\n")
+	require.NoError(t, err)
+	assert.Equal(t, []spdxTag{{"PackageComment", "line one\nline two"}, {"SPDXID", "SPDXRef-A"}}, tags)
+
+	_, err = parseSPDXTagValue("PackageComment: <text>never closed\n")
+	require.ErrorContains(t, err, "unterminated")
+	_, err = parseSPDXTagValue("not a tag\n")
+	require.ErrorContains(t, err, "not a tag-value pair")
+}
+
+func TestUniqueSPDXID(t *testing.T) {
+	used := map[string]bool{}
+	assert.Equal(t, "SPDXRef-Package-a", uniqueSPDXID("SPDXRef-Package-a", used))
+	assert.Equal(t, "SPDXRef-Package-a-2", uniqueSPDXID("SPDXRef-Package-a", used))
+	assert.Equal(t, "SPDXRef-Package-a-3", uniqueSPDXID("SPDXRef-Package-a", used))
+}
@@ -0,0 +1,76 @@
+package scanner
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// FormatTable selects the aligned server table written by WriteTable.
+const FormatTable = "table"
+
+// tablePadding is the number of spaces between table columns.
+const tablePadding = 2
+
+// WriteTable writes the servers of the summary as an aligned table with the columns #, NAME,
+// PATH, RISK, SCORE and POLICY, in scan order. Unlike the text summary it does not group
+// servers by risk tier. When width is positive, paths are shortened from the left so that
+// rows fit in width columns where possible.
+func WriteTable(w io.Writer, summary ScanSummary, width int) error {
+	rows := [][]string{{"#", "NAME", "PATH", "RISK", "SCORE", "POLICY"}}
+	for i, sr := range summary.Servers {
+		risk, score := "-", "-"
+		if sr.Rating != nil {
+			risk = riskTierFromScore(sr.Rating.RiskScore)
+			score = strconv.FormatFloat(sr.Rating.RiskScore, 'f', 1, 64)
+		}
+		policy := sr.LocalPolicy
+		if policy == "" {
+			policy = "-"
+		}
+		// Tabs in names or paths would split cells.
+		name, path := strings.ReplaceAll(sr.Name, "\t", " "), strings.ReplaceAll(sr.Path, "\t", " ")
+		rows = append(rows, []string{strconv.Itoa(i + 1), name, path, risk, score, policy})
+	}
+
+	const pathColumn = 2
+	if width > 0 {
+		widths := make([]int, len(rows[0]))
+		for _, row := range rows {
+			for c, cell := range row {
+				widths[c] = max(widths[c], utf8.RuneCountInString(cell))
+			}
+		}
+		total := tablePadding * (len(widths) - 1)
+		for _, cw := range widths {
+			total += cw
+		}
+		if total > width {
+			limit := max(len(rows[0][pathColumn]), widths[pathColumn]-(total-width))
+			for _, row := range rows[1:] {
+				row[pathColumn] = truncateLeft(row[pathColumn], limit)
+			}
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, tablePadding, ' ', 0)
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// truncateLeft shortens s to at most n runes by replacing its start with an ellipsis, keeping
+// the end of a path, which is usually the more telling part.
+func truncateLeft(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n || n < 1 {
+		return s
+	}
+	return "…" + string(runes[len(runes)-n+1:])
+}
@@ -0,0 +1,49 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTable(t *testing.T) {
+	summary := vexSummary()
+	summary.Servers[0].LocalPolicy = "allowed"
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTable(&buf, summary, 0))
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, len(summary.Servers)+1)
+	assert.Equal(t, []string{"#", "NAME", "PATH", "RISK", "SCORE", "POLICY"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"1", "fs", "/a/mcp.json", "HIGH", "7.5", "allowed"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"2", "local", "/a/mcp.json", "-", "-", "-"}, strings.Fields(lines[2]))
+
+	// Columns are aligned: each header starts where its cells start.
+	for _, header := range []string{"NAME", "PATH", "RISK", "SCORE", "POLICY"} {
+		col := strings.Index(lines[0], header)
+		for _, line := range lines[1:] {
+			assert.NotEqual(t, ' ', line[col], "%s column of %q", header, line)
+			assert.Equal(t, ' ', rune(line[col-1]), "%s column of %q", header, line)
+		}
+	}
+	assert.NotContains(t, buf.String(), "\t")
+}
+
+func TestWriteTable_Width(t *testing.T) {
+	summary := ScanSummary{Servers: []ServerReport{
+		{Name: "fs", Path: "/home/user/Library/Application Support/Claude/claude_desktop_config.json"},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTable(&buf, summary, 60))
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		assert.LessOrEqual(t, utf8.RuneCountInString(line), 60, line)
+	}
+	assert.Contains(t, buf.String(), "…", "long paths are shortened")
+	assert.Contains(t, buf.String(), "claude_desktop_config.json", "the end of the path is kept")
+}
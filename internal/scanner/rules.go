@@ -0,0 +1,254 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is a user-defined check applied to every scanned server config.
+//
+// Match is one or more conditions joined with "&&". Each condition has the form
+// field.op("value"), optionally negated with a leading "!":
+//
+//	command.startsWith("sudo")
+//	args.contains("--privileged")
+//	env.API_URL.matches("^http://")
+//	command.equals("docker") && !image.startsWith("registry.example.com/")
+//
+// Fields are name, command, args, url, image (the docker/podman image) and env.<KEY>.
+// Ops are startsWith, endsWith, contains, equals and matches (a Go regexp). List fields
+// such as args match when any element does. A condition on a field the server does not
+// have never matches, even when negated.
+type Rule struct {
+	Name        string `yaml:"name" json:"name"`
+	Match       string `yaml:"match" json:"match"`
+	Severity    string `yaml:"severity" json:"severity"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	conditions []ruleCondition
+}
+
+// CustomFinding records a Rule that matched a server.
+type CustomFinding struct {
+	Rule        string `json:"rule"`
+	Severity    string `json:"severity"`
+	Description string `json:"description,omitempty"`
+}
+
+type ruleCondition struct {
+	negate bool
+	field  string
+	op     string
+	value  string
+	re     *regexp.Regexp
+}
+
+var ruleConditionRe = regexp.MustCompile(
+	`^(!?)\s*([A-Za-z_][\w.-]*)\.(startsWith|endsWith|contains|equals|matches)\(\s*("(?:[^"\\]|\\.)*")\s*\)$`,
+)
+
+// Compile validates the rule and parses its match expression.
+func (r *Rule) Compile() error {
+	if r.Name == "" {
+		return errors.New("rule is missing a name")
+	}
+	severity, err := ParseSeverity(r.Severity)
+	if err != nil {
+		return fmt.Errorf("rule %q: %w", r.Name, err)
+	}
+	r.Severity = severity
+	if strings.TrimSpace(r.Match) == "" {
+		return fmt.Errorf("rule %q is missing a match expression", r.Name)
+	}
+
+	r.conditions = nil
+	for _, part := range strings.Split(r.Match, "&&") {
+		c, err := parseRuleCondition(strings.TrimSpace(part))
+		if err != nil {
+			return fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+		r.conditions = append(r.conditions, c)
+	}
+	return nil
+}
+
+func parseRuleCondition(expr string) (ruleCondition, error) {
+	m := ruleConditionRe.FindStringSubmatch(expr)
+	if m == nil {
+		return ruleCondition{}, fmt.Errorf("invalid condition %q: want field.op(\"value\")", expr)
+	}
+	c := ruleCondition{negate: m[1] == "!", field: m[2], op: m[3]}
+	switch {
+	case c.field == "name", c.field == "command", c.field == "args", c.field == "url", c.field == "image":
+	case strings.HasPrefix(c.field, "env.") && len(c.field) > len("env."):
+	default:
+		return ruleCondition{}, fmt.Errorf("unknown field %q in %q", c.field, expr)
+	}
+	value, err := strconv.Unquote(m[4])
+	if err != nil {
+		return ruleCondition{}, fmt.Errorf("invalid value in %q: %w", expr, err)
+	}
+	c.value = value
+	if c.op == "matches" {
+		if c.re, err = regexp.Compile(value); err != nil {
+			return ruleCondition{}, fmt.Errorf("invalid regexp in %q: %w", expr, err)
+		}
+	}
+	return c, nil
+}
+
+// Matches reports whether every condition of the compiled rule holds for the server.
+func (r Rule) Matches(name string, server Server) bool {
+	if len(r.conditions) == 0 {
+		return false
+	}
+	for _, c := range r.conditions {
+		if !c.matches(ruleFieldValues(c.field, name, server)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c ruleCondition) matches(values []string) bool {
+	if len(values) == 0 {
+		return false
+	}
+	found := false
+	for _, v := range values {
+		if c.test(v) {
+			found = true
+			break
+		}
+	}
+	return found != c.negate
+}
+
+func (c ruleCondition) test(v string) bool {
+	switch c.op {
+	case "startsWith":
+		return strings.HasPrefix(v, c.value)
+	case "endsWith":
+		return strings.HasSuffix(v, c.value)
+	case "contains":
+		return strings.Contains(v, c.value)
+	case "equals":
+		return v == c.value
+	case "matches":
+		return c.re.MatchString(v)
+	default:
+		return false
+	}
+}
+
+// ruleFieldValues returns the values of field for a server. Launch settings are read from the
+// top level or from a nested "stdio" block.
+func ruleFieldValues(field, name string, server Server) []string {
+	launch := server
+	if stdio := getMap(server, "stdio"); stdio != nil {
+		launch = stdio
+	}
+	switch field {
+	case "name":
+		return []string{name}
+	case "command":
+		switch v := launch["command"].(type) {
+		case string:
+			if v != "" {
+				return []string{v}
+			}
+		case []interface{}:
+			parts := make([]string, 0, len(v))
+			for _, it := range v {
+				parts = append(parts, toString(it))
+			}
+			return []string{strings.Join(parts, " ")}
+		}
+		return nil
+	case "args":
+		args, _ := launch["args"].([]interface{})
+		values := make([]string, 0, len(args))
+		for _, it := range args {
+			values = append(values, toString(it))
+		}
+		return values
+	case "url":
+		for _, key := range []string{"url", "endpoint", "baseUrl"} {
+			if u := getString(server, key); u != "" {
+				return []string{u}
+			}
+		}
+		return nil
+	case "image":
+		if image := extractOCIFromDocker(server); image != "" {
+			return []string{image}
+		}
+		return nil
+	}
+	// env.<KEY>
+	env := getMap(launch, "env")
+	if env == nil {
+		return nil
+	}
+	v, ok := env[strings.TrimPrefix(field, "env.")]
+	if !ok {
+		return nil
+	}
+	return []string{toString(v)}
+}
+
+// LoadRules reads and compiles a YAML list of rules.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for i := range rules {
+		if err := rules[i].Compile(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return rules, nil
+}
+
+// ApplyRules evaluates compiled rules against the parsed config of every reported server and
+// records matches in ServerReport.CustomFindings. Suppressed servers are not checked.
+func ApplyRules(summary *ScanSummary, result ScanResult, rules []Rule) {
+	if len(rules) == 0 {
+		return
+	}
+	configs := make(map[[2]string]Server)
+	for _, file := range result.Files {
+		for _, sc := range file.Servers {
+			if server, ok := sc.Server.(map[string]interface{}); ok {
+				configs[[2]string{file.Path, sc.Name}] = server
+			}
+		}
+	}
+	for i := range summary.Servers {
+		sr := &summary.Servers[i]
+		server, ok := configs[[2]string{sr.Path, sr.Name}]
+		if !ok {
+			continue
+		}
+		for _, r := range rules {
+			if r.Matches(sr.Name, server) {
+				sr.CustomFindings = append(sr.CustomFindings, CustomFinding{
+					Rule:        r.Name,
+					Severity:    r.Severity,
+					Description: r.Description,
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compileRule(t *testing.T, match string) Rule {
+	t.Helper()
+	r := Rule{Name: "test", Match: match, Severity: "high"}
+	require.NoError(t, r.Compile())
+	return r
+}
+
+func TestRule_Matches(t *testing.T) {
+	sudo := Server{"command": "sudo", "args": []interface{}{"node", "server.js"}, "env": map[string]interface{}{"API_URL": "http://internal"}}
+	docker := Server{"stdio": map[string]interface{}{
+		"command": "docker",
+		"args":    []interface{}{"run", "-i", "ghcr.io/acme/mcp:1.0"},
+	}}
+	remote := Server{"url": "https://mcp.example.com/sse"}
+
+	tests := []struct {
+		match  string
+		server Server
+		want   bool
+	}{
+		{`command.startsWith("sudo")`, sudo, true},
+		{`command.startsWith("sudo")`, docker, false},
+		{`args.contains("server")`, sudo, true},
+		{`!args.contains("server")`, sudo, false},
+		{`env.API_URL.matches("^http://")`, sudo, true},
+		{`env.MISSING.equals("")`, sudo, false},
+		{`command.equals("docker") && !image.startsWith("registry.example.com/")`, docker, true},
+		{`command.equals("docker") && image.endsWith(":1.0")`, docker, true},
+		{`!image.startsWith("registry.example.com/")`, remote, false},
+		{`url.startsWith("https://")`, remote, true},
+		{`name.equals("srv")`, remote, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.match, func(t *testing.T) {
+			assert.Equal(t, tt.want, compileRule(t, tt.match).Matches("srv", tt.server))
+		})
+	}
+}
+
+func TestRule_CompileErrors(t *testing.T) {
+	for name, r := range map[string]Rule{
+		"missing name":   {Match: `command.equals("x")`, Severity: "low"},
+		"bad severity":   {Name: "r", Match: `command.equals("x")`, Severity: "urgent"},
+		"missing match":  {Name: "r", Severity: "low"},
+		"unknown field":  {Name: "r", Match: `cwd.equals("x")`, Severity: "low"},
+		"unknown op":     {Name: "r", Match: `command.is("x")`, Severity: "low"},
+		"unquoted value": {Name: "r", Match: `command.equals(x)`, Severity: "low"},
+		"invalid regexp": {Name: "r", Match: `command.matches("(")`, Severity: "low"},
+		"empty conjunct": {Name: "r", Match: `command.equals("x") &&`, Severity: "low"},
+		"bare env field": {Name: "r", Match: `env.equals("x")`, Severity: "low"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, r.Compile())
+		})
+	}
+}
+
+func TestLoadRulesAndApply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+- name: no-sudo
+  match: command.startsWith("sudo")
+  severity: critical
+  description: Servers must not run as root
+- name: npx-servers
+  match: command.equals("npx")
+  severity: low
+`), 0o600))
+	rules, err := LoadRules(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "CRITICAL", rules[0].Severity)
+
+	result := ScanResult{Files: []FileResult{{
+		Path: "/a/mcp.json",
+		Servers: []ServerConfig{
+			{Name: "root", Server: map[string]interface{}{"command": "sudo"}},
+			{Name: "fs", Server: map[string]interface{}{"command": "npx"}},
+		},
+	}}}
+	summary := GenerateSummary(result)
+	ApplyRules(&summary, result, rules)
+
+	byName := map[string][]CustomFinding{}
+	for _, s := range summary.Servers {
+		byName[s.Name] = s.CustomFindings
+	}
+	assert.Equal(t, []CustomFinding{{Rule: "no-sudo", Severity: "CRITICAL", Description: "Servers must not run as root"}}, byName["root"])
+	assert.Equal(t, []CustomFinding{{Rule: "npx-servers", Severity: "LOW"}}, byName["fs"])
+
+	_, err = LoadRules(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
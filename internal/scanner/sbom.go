@@ -0,0 +1,186 @@
+package scanner
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
+)
+
+// CycloneDXSpecVersion is the CycloneDX specification version written by NewCycloneDXBOM.
+const CycloneDXSpecVersion = "1.5"
+
+// serverProperty names the property listing the MCP servers that use a component.
+const serverProperty = "run-mcp:server"
+
+// CycloneDXBOM is the subset of a CycloneDX JSON BOM that run-mcp produces.
+type CycloneDXBOM struct {
+	BOMFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber"`
+	Version      int                  `json:"version"`
+	Metadata     CycloneDXMetadata    `json:"metadata"`
+	Components   []CycloneDXComponent `json:"components"`
+	// Vulnerabilities is only set on the VEX report built by NewCycloneDXVEX.
+	Vulnerabilities []CycloneDXVulnerability `json:"vulnerabilities,omitempty"`
+}
+
+// CycloneDXMetadata describes when, by what and for which host the BOM was produced.
+type CycloneDXMetadata struct {
+	Timestamp  string              `json:"timestamp"`
+	Tools      CycloneDXTools      `json:"tools"`
+	Component  *CycloneDXComponent `json:"component,omitempty"`
+	Properties []CycloneDXProperty `json:"properties,omitempty"`
+}
+
+// CycloneDXTools lists the tools that produced the BOM.
+type CycloneDXTools struct {
+	Components []CycloneDXComponent `json:"components"`
+}
+
+// CycloneDXComponent is a BOM component.
+type CycloneDXComponent struct {
+	Type               string                       `json:"type"`
+	BOMRef             string                       `json:"bom-ref,omitempty"`
+	Name               string                       `json:"name"`
+	Version            string                       `json:"version,omitempty"`
+	PURL               string                       `json:"purl,omitempty"`
+	ExternalReferences []CycloneDXExternalReference `json:"externalReferences,omitempty"`
+	Properties         []CycloneDXProperty          `json:"properties,omitempty"`
+}
+
+// CycloneDXExternalReference points at a resource related to a component.
+type CycloneDXExternalReference struct {
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// CycloneDXProperty is a name/value annotation.
+type CycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SBOMHost identifies the scanned machine in the BOM metadata.
+type SBOMHost struct {
+	Name string
+	UUID string
+}
+
+// NewCycloneDXBOM builds a CycloneDX BOM from the identifiers of every discovered server.
+// Package URLs become libraries, OCI references containers and remote servers libraries
+// with an external reference to their URL. Repository hints are too speculative for a
+// BOM and are left out. Components are sorted by bom-ref and list the servers using them.
+func NewCycloneDXBOM(result ScanResult, host SBOMHost, toolVersion string, now time.Time) CycloneDXBOM {
+	extractor := NewIdentifierExtractor()
+	byRef := make(map[string]*CycloneDXComponent)
+	for _, file := range result.Files {
+		for _, sc := range file.Servers {
+			for _, id := range extractor.ExtractIdentifiers(file.Path, sc.Name, sc.Server) {
+				c, ok := byRef[string(id.Kind)+":"+id.Value]
+				if !ok {
+					built, keep := componentFromIdentifier(id)
+					if !keep {
+						continue
+					}
+					c = &built
+					byRef[c.BOMRef] = c
+				}
+				prop := CycloneDXProperty{Name: serverProperty, Value: sc.Name}
+				if !slices.Contains(c.Properties, prop) {
+					c.Properties = append(c.Properties, prop)
+				}
+			}
+		}
+	}
+
+	components := make([]CycloneDXComponent, 0, len(byRef))
+	for _, c := range byRef {
+		components = append(components, *c)
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i].BOMRef < components[j].BOMRef })
+
+	return CycloneDXBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  CycloneDXSpecVersion,
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Metadata:     cycloneDXMetadata(host, toolVersion, now),
+		Components:   components,
+	}
+}
+
+// cycloneDXMetadata names run-mcp as the tool and the scanned host as the subject.
+func cycloneDXMetadata(host SBOMHost, toolVersion string, now time.Time) CycloneDXMetadata {
+	hostComponent := &CycloneDXComponent{Type: "device", Name: host.Name}
+	if host.UUID != "" {
+		hostComponent.BOMRef = "urn:uuid:" + host.UUID
+	}
+	return CycloneDXMetadata{
+		Timestamp: now.UTC().Format(time.RFC3339),
+		Tools: CycloneDXTools{Components: []CycloneDXComponent{
+			{Type: "application", Name: "run-mcp", Version: toolVersion},
+		}},
+		Component: hostComponent,
+	}
+}
+
+// componentFromIdentifier maps an identifier to a component; ok is false for kinds that are
+// not included in the BOM.
+func componentFromIdentifier(id apigen.TargetIdentifier) (CycloneDXComponent, bool) {
+	c := CycloneDXComponent{BOMRef: string(id.Kind) + ":" + id.Value}
+	switch id.Kind {
+	case apigen.Purl:
+		c.Type = "library"
+		c.PURL = id.Value
+		c.Name, c.Version = splitPurl(id.Value)
+	case apigen.Oci:
+		c.Type = "container"
+		c.Name, c.Version = splitImageRef(id.Value)
+	case apigen.Url:
+		c.Type = "library"
+		c.Name = id.Value
+		c.ExternalReferences = []CycloneDXExternalReference{{Type: "other", URL: id.Value}}
+	default:
+		return CycloneDXComponent{}, false
+	}
+	return c, true
+}
+
+// splitPurl returns the package name and version of a purl such as pkg:npm/@scope/name@1.2.
+func splitPurl(purl string) (string, string) {
+	rest := purl
+	if i := strings.Index(rest, "/"); strings.HasPrefix(rest, "pkg:") && i >= 0 {
+		rest = rest[i+1:]
+	}
+	// A leading @ is an npm scope, not a version separator.
+	if i := strings.LastIndex(rest, "@"); i > 0 {
+		return rest[:i], rest[i+1:]
+	}
+	return rest, ""
+}
+
+// splitImageRef returns the repository and the tag or digest of an image reference.
+func splitImageRef(ref string) (string, string) {
+	if i := strings.Index(ref, "@"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// WriteCycloneDX writes bom as indented JSON.
+func WriteCycloneDX(w io.Writer, bom CycloneDXBOM) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(bom)
+}
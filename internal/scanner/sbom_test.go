@@ -0,0 +1,121 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cycloneDXComponentTypes are the component types allowed by CycloneDX 1.5.
+//
+//nolint:gochecknoglobals // Fixed lookup table.
+var cycloneDXComponentTypes = map[string]bool{
+	"application": true, "framework": true, "library": true, "container": true, "platform": true,
+	"operating-system": true, "device": true, "device-driver": true, "firmware": true, "file": true,
+	"machine-learning-model": true, "data": true,
+}
+
+func sbomResult() ScanResult {
+	return ScanResult{Files: []FileResult{{
+		Path: "/a/mcp.json",
+		Servers: []ServerConfig{
+			{Name: "fs", Server: map[string]interface{}{
+				"command": "npx", "args": []interface{}{"-y", "@modelcontextprotocol/server-filesystem@1.2.0"},
+			}},
+			{Name: "fs-copy", Server: map[string]interface{}{
+				"command": "npx", "args": []interface{}{"-y", "@modelcontextprotocol/server-filesystem@1.2.0"},
+			}},
+			{Name: "gh", Server: map[string]interface{}{
+				"command": "docker", "args": []interface{}{"run", "-i", "--rm", "ghcr.io/github/github-mcp-server:v1"},
+			}},
+			{Name: "remote", Server: map[string]interface{}{"url": "https://mcp.example.com/sse"}},
+		},
+	}}}
+}
+
+func TestNewCycloneDXBOM(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	bom := NewCycloneDXBOM(sbomResult(), SBOMHost{Name: "laptop", UUID: "8f0a4c8e-2c1b-4b53-9d2e-6c5f0b6c1a11"}, "1.0.0", now)
+
+	byType := map[string][]CycloneDXComponent{}
+	for _, c := range bom.Components {
+		byType[c.Type] = append(byType[c.Type], c)
+	}
+	require.Len(t, byType["container"], 1)
+	assert.Equal(t, "ghcr.io/github/github-mcp-server", byType["container"][0].Name)
+	assert.Equal(t, "v1", byType["container"][0].Version)
+
+	require.Len(t, byType["library"], 2)
+	var pkg, remote CycloneDXComponent
+	for _, c := range byType["library"] {
+		if c.PURL != "" {
+			pkg = c
+		} else {
+			remote = c
+		}
+	}
+	assert.Equal(t, "pkg:npm/@modelcontextprotocol/server-filesystem@1.2.0", pkg.PURL)
+	assert.Equal(t, "@modelcontextprotocol/server-filesystem", pkg.Name)
+	assert.Equal(t, "1.2.0", pkg.Version)
+	assert.Equal(t, []CycloneDXProperty{{serverProperty, "fs"}, {serverProperty, "fs-copy"}}, pkg.Properties,
+		"servers sharing a package share one component")
+	assert.Equal(t, []CycloneDXExternalReference{{Type: "other", URL: "https://mcp.example.com/sse"}}, remote.ExternalReferences)
+
+	assert.Equal(t, "2025-06-01T12:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, "laptop", bom.Metadata.Component.Name)
+	assert.Equal(t, "urn:uuid:8f0a4c8e-2c1b-4b53-9d2e-6c5f0b6c1a11", bom.Metadata.Component.BOMRef)
+	assert.Equal(t, "1.0.0", bom.Metadata.Tools.Components[0].Version)
+}
+
+// TestWriteCycloneDX checks the JSON against the required fields and formats of the
+// CycloneDX 1.5 JSON schema.
+func TestWriteCycloneDX(t *testing.T) {
+	bom := NewCycloneDXBOM(sbomResult(), SBOMHost{Name: "laptop"}, "dev", time.Now())
+	var buf bytes.Buffer
+	require.NoError(t, WriteCycloneDX(&buf, bom))
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "CycloneDX", doc["bomFormat"])
+	assert.Equal(t, "1.5", doc["specVersion"])
+	assert.Regexp(t, regexp.MustCompile(`^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`), doc["serialNumber"])
+	assert.InDelta(t, 1, doc["version"], 0)
+
+	metadata, ok := doc["metadata"].(map[string]interface{})
+	require.True(t, ok)
+	_, err := time.Parse(time.RFC3339, metadata["timestamp"].(string))
+	require.NoError(t, err)
+	_, ok = metadata["tools"].(map[string]interface{})["components"].([]interface{})
+	assert.True(t, ok, "1.5 tools use the object form")
+
+	refs := map[string]bool{}
+	for _, raw := range doc["components"].([]interface{}) {
+		c := raw.(map[string]interface{})
+		assert.True(t, cycloneDXComponentTypes[c["type"].(string)], "type %v", c["type"])
+		assert.NotEmpty(t, c["name"])
+		ref := c["bom-ref"].(string)
+		assert.False(t, refs[ref], "bom-ref %s must be unique", ref)
+		refs[ref] = true
+	}
+	assert.Len(t, refs, 3)
+}
+
+func TestSplitPurlAndImageRef(t *testing.T) {
+	name, version := splitPurl("pkg:pypi/mcp-server-git")
+	assert.Equal(t, "mcp-server-git", name)
+	assert.Empty(t, version)
+
+	name, version = splitImageRef("registry.example.com:5000/team/img@sha256:abc")
+	assert.Equal(t, "registry.example.com:5000/team/img", name)
+	assert.Equal(t, "sha256:abc", version)
+
+	name, version = splitImageRef("registry.example.com:5000/team/img")
+	assert.Equal(t, "registry.example.com:5000/team/img", name)
+	assert.Empty(t, version)
+}
@@ -3,11 +3,22 @@ package scanner
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime/pprof"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/ensigniasec/run-mcp/internal/metrics"
+	"github.com/ensigniasec/run-mcp/internal/telemetry"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // ScanResult represents the results for an entire Scan across all targets.
@@ -21,6 +32,10 @@ type ScanResult struct {
 	Duration    time.Duration `json:"duration,omitempty"`
 	CompletedAt time.Time     `json:"completed_at,omitempty"`
 
+	// SuppressedErrors counts the missing or unreadable files skipped silently; see
+	// WithSuppressedFileErrors.
+	SuppressedErrors int `json:"suppressed_errors,omitempty"`
+
 	Summary *ScanSummary `json:"summary,omitempty"`
 }
 
@@ -37,23 +52,46 @@ func NewFileResult(path string) *FileResult {
 	return fr
 }
 
+// DefaultWorkers is the number of files scanned concurrently unless WithWorkers is used.
+const DefaultWorkers = 4
+
 type MCPScanner struct {
-	seenFiles         map[string]struct{}
+	// mu guards seenFiles and ScanResult, which workers and the result collector share.
+	mu sync.Mutex
+	// callbackMu serialises streamingCallback calls from concurrent workers.
+	callbackMu sync.Mutex
+	// seenFiles maps each file claimed in the current scan to its discovery order.
+	seenFiles         map[string]int
 	targets           []string
 	storageFile       string
 	ScanResult        *ScanResult
 	collector         *RatingsCollector
 	streamingCallback func(filePath string, fileResult *FileResult, err error)
+	skipSecrets       bool
+	entropyThreshold  float64
+	hash              HashFunc
+	metrics           *metrics.Registry
+	ctx               context.Context
+	workers           int
+	include           []string
+	exclude           []string
+	normalizeNames    bool
+	maxFileSize       int64
+	profile           bool
+	suppressErrors    bool
+	skipServers       map[string]struct{}
+	maxDepth          int
+	stats             scanStats
 }
 
 func NewMCPScanner(targets []string, storageFile string) *MCPScanner {
 	return &MCPScanner{
-		targets: targets,
-		seenFiles: make(
-			map[string]struct{},
-		), // using a struct{} instead of bool here - as it is a zero-byte value & we only care about the key,
+		targets:     targets,
+		seenFiles:   make(map[string]int),
 		storageFile: storageFile,
 		ScanResult:  NewScanResult(targets),
+		workers:     DefaultWorkers,
+		maxFileSize: DefaultMaxFileSize,
 	}
 }
 
@@ -69,53 +107,240 @@ func (s *MCPScanner) WithStreamingCallback(callback func(filePath string, fileRe
 	return s
 }
 
+// WithoutSecretScanning disables secret detection and redaction; parsed configs keep their raw values.
+func (s *MCPScanner) WithoutSecretScanning() *MCPScanner { //nolint:ireturn
+	s.skipSecrets = true
+	return s
+}
+
+// WithHashFunc sets the digest used for server fingerprints and secret value hashes.
+// Nil restores SHA-256.
+func (s *MCPScanner) WithHashFunc(hash HashFunc) *MCPScanner { //nolint:ireturn
+	s.hash = hash
+	return s
+}
+
+// WithEntropyThreshold sets the bits-per-character entropy above which unrecognised values
+// are reported as generic secrets. Zero restores DefaultEntropyThreshold.
+func (s *MCPScanner) WithEntropyThreshold(bits float64) *MCPScanner { //nolint:ireturn
+	s.entropyThreshold = bits
+	return s
+}
+
+// WithMetrics updates m with files, servers and secrets as each file is scanned.
+func (s *MCPScanner) WithMetrics(m *metrics.Registry) *MCPScanner { //nolint:ireturn
+	s.metrics = m
+	return s
+}
+
+// WithWorkers sets how many files are scanned concurrently. Values below one restore
+// DefaultWorkers.
+func (s *MCPScanner) WithWorkers(n int) *MCPScanner { //nolint:ireturn
+	if n < 1 {
+		n = DefaultWorkers
+	}
+	s.workers = n
+	return s
+}
+
+// WithMaxFileSize sets the size in bytes above which config files are rejected unread.
+// Values below one restore DefaultMaxFileSize.
+func (s *MCPScanner) WithMaxFileSize(n int64) *MCPScanner { //nolint:ireturn
+	if n < 1 {
+		n = DefaultMaxFileSize
+	}
+	s.maxFileSize = n
+	return s
+}
+
+// WithProfiling records how long each file takes to scan in FileResult.Duration; see
+// NewProfileReport.
+func (s *MCPScanner) WithProfiling() *MCPScanner { //nolint:ireturn
+	s.profile = true
+	return s
+}
+
+// WithSuppressedFileErrors skips files that vanish or cannot be read without logging, counting
+// them in ScanResult.SuppressedErrors instead. Targets that do not exist at all are not
+// counted, since most well-known paths are absent on any given host.
+func (s *MCPScanner) WithSuppressedFileErrors() *MCPScanner { //nolint:ireturn
+	s.suppressErrors = true
+	return s
+}
+
+// WithFileFilter limits the scan to files matching at least one include pattern, if any are
+// given, and never matching an exclude pattern. Patterns use filepath.Match syntax and are
+// matched against both the absolute path and the base name of each file.
+func (s *MCPScanner) WithFileFilter(include, exclude []string) *MCPScanner { //nolint:ireturn
+	s.include = include
+	s.exclude = exclude
+	return s
+}
+
+// WithNormalizedServerNames records the CanonicalServerName of every server and submits it,
+// instead of the configured name, to the ratings collector, so that servers spelled
+// differently across configs are rated and reported once.
+func (s *MCPScanner) WithNormalizedServerNames() *MCPScanner { //nolint:ireturn
+	s.normalizeNames = true
+	return s
+}
+
+// WithSkippedServers leaves out servers with any of the given names, compared
+// case-insensitively: they are not reported, submitted for ratings or checked for secrets and
+// misconfigurations, and are listed in FileResult.SkippedServers instead.
+func (s *MCPScanner) WithSkippedServers(names []string) *MCPScanner { //nolint:ireturn
+	s.skipServers = make(map[string]struct{}, len(names))
+	for _, name := range names {
+		s.skipServers[strings.ToLower(name)] = struct{}{}
+	}
+	return s
+}
+
+// WithMaxDepth stops directory targets from being walked more than n levels deep; 1 covers
+// the target and its immediate subdirectories. n < 1 means no limit.
+func (s *MCPScanner) WithMaxDepth(n int) *MCPScanner { //nolint:ireturn
+	s.maxDepth = max(n, 0)
+	return s
+}
+
+// WithContext bounds the scan by ctx: once it is done, no further files are scanned.
+func (s *MCPScanner) WithContext(ctx context.Context) *MCPScanner { //nolint:ireturn
+	s.ctx = ctx
+	return s
+}
+
+// Scan scans every target. Discovered files are deduplicated and handed to a pool of
+// workers; results are kept in the order the files were discovered, as in a sequential scan.
+// If the scan context ends first, the files scanned so far are returned together with the
+// context's error.
+//
 //nolint:gocognit // Scanning logic is explicit for clarity; future refactor may split by phases.
 func (s *MCPScanner) Scan() (*ScanResult, error) {
 	logrus.Debug("Starting scan of ", len(s.targets), " targets")
+	parent := s.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, span := telemetry.Tracer().Start(parent, telemetry.SpanScan)
+	defer span.End()
 	// Defensive reset of per-scan aggregations while preserving targets and start time
+	s.mu.Lock()
 	s.ScanResult.Files = nil
 	s.ScanResult.Servers = nil
 	s.ScanResult.SecretFindings = nil
+	s.ScanResult.SuppressedErrors = 0
+	s.seenFiles = make(map[string]int)
+	s.stats = scanStats{}
+	s.mu.Unlock()
+
+	workers := s.workers
+	if workers < 1 {
+		workers = DefaultWorkers
+	}
+	paths := make(chan string, workers*2)
+	results := make(chan *FileResult, workers)
+
+	// Workers scan files concurrently; each is labelled so CPU profiles can tell them apart.
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pprof.Do(ctx, pprof.Labels("scan.worker", strconv.Itoa(i)), func(ctx context.Context) {
+				for filePath := range paths {
+					if fileResult := s.processFile(ctx, filePath); fileResult != nil {
+						results <- fileResult
+					}
+				}
+			})
+		}()
+	}
 
-	// Stream discovered files and process immediately.
-	processFile := func(filePath string) {
-		if _, ok := s.seenFiles[filePath]; ok {
-			return
+	// A single collector appends results, so ScanResult.Files is only written here.
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for fileResult := range results {
+			s.metrics.IncFilesScanned()
+			s.metrics.AddServers(metrics.TierDiscovered, len(fileResult.Servers))
+			s.metrics.AddSecrets(len(fileResult.SecretFindings))
+			s.metrics.SetScanDuration(time.Since(s.ScanResult.StartedAt))
+			s.mu.Lock()
+			s.ScanResult.Files = append(s.ScanResult.Files, *fileResult)
+			s.mu.Unlock()
 		}
-		s.seenFiles[filePath] = struct{}{}
+	}()
 
-		// Emit a 'started' streaming event prior to scanning for real-time UIs.
-		if s.streamingCallback != nil {
-			s.streamingCallback(filePath, nil, nil)
+	enqueue := func(filePath string) {
+		if !s.claimFile(filePath) {
+			return
+		}
+		select {
+		case paths <- filePath:
+		case <-ctx.Done():
 		}
+	}
+	discoverStart := time.Now()
+	s.discover(ctx, enqueue)
+	s.stats.discover = time.Since(discoverStart)
+	close(paths)
+	wg.Wait()
+	close(results)
+	<-collected
+	s.orderResults()
 
-		fileResult, err := s.scanFile(filePath)
+	// Finalize timing
+	s.ScanResult.CompletedAt = time.Now()
+	s.ScanResult.Duration = s.ScanResult.CompletedAt.Sub(s.ScanResult.StartedAt)
+	s.metrics.SetScanDuration(s.ScanResult.Duration)
 
-		// Call streaming callback if provided (before error handling)
-		if s.streamingCallback != nil {
-			s.streamingCallback(filePath, fileResult, err)
-		}
+	span.SetAttributes(attribute.Int("file.count", len(s.ScanResult.Files)))
+	if err := ctx.Err(); err != nil {
+		span.RecordError(err)
+		logrus.Debugf("Scan stopped early: %v", err)
+		return s.ScanResult, err
+	}
+	logrus.Debug("Scan completed successfully")
+	return s.ScanResult, nil
+}
 
-		if err != nil {
-			if os.IsNotExist(err) {
-				logrus.Debugf("File not found: %s", filePath)
-			} else {
-				logrus.Errorf("Error scanning file %s: %v", filePath, err)
-			}
+// ListFiles returns the files Scan would scan, sorted by path, without reading them:
+// existing file targets and the candidate config files under directory targets, deduplicated
+// and filtered by WithFileFilter.
+func (s *MCPScanner) ListFiles() []string {
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	seen := make(map[string]bool)
+	files := []string{}
+	s.discover(ctx, func(filePath string) {
+		if seen[filePath] || !s.fileAllowed(filePath) {
 			return
 		}
-		// Append individualfile result
-		s.ScanResult.Files = append(s.ScanResult.Files, *fileResult)
-		// Aggregate servers at top-level (findings appended during parse/redact)
-		if len(fileResult.Servers) > 0 {
-			s.ScanResult.Servers = append(s.ScanResult.Servers, fileResult.Servers...)
-		}
-	}
+		seen[filePath] = true
+		files = append(files, filePath)
+	})
+	sort.Strings(files)
+	return files
+}
 
-	ctx := context.Background()
+// discover calls yield for each existing file target and each candidate config file found
+// under directory targets, skipping directories excluded by the skip rules.
+func (s *MCPScanner) discover(ctx context.Context, yield func(filePath string)) {
 	for _, target := range s.targets {
+		if ctx.Err() != nil {
+			return
+		}
 		st, err := os.Stat(target)
 		if err != nil {
+			// Most well-known targets do not exist; only inaccessible ones are worth counting.
+			if s.suppressErrors && errors.Is(err, fs.ErrPermission) {
+				s.mu.Lock()
+				s.ScanResult.SuppressedErrors++
+				s.mu.Unlock()
+				continue
+			}
 			logrus.Debugf("Skipping target %s due to error: %v", target, err)
 			continue
 		}
@@ -126,21 +351,132 @@ func (s *MCPScanner) Scan() (*ScanResult, error) {
 		}
 
 		if !st.IsDir() {
-			processFile(target)
+			yield(target)
 			continue
 		}
 
-		for p := range streamConfigFiles(ctx, target) {
-			processFile(p)
+		for p := range streamConfigFilesWithDepth(ctx, target, s.maxDepth) {
+			yield(p)
 		}
 	}
+}
 
-	// Finalize timing
-	s.ScanResult.CompletedAt = time.Now()
-	s.ScanResult.Duration = s.ScanResult.CompletedAt.Sub(s.ScanResult.StartedAt)
+// claimFile reports whether filePath is new to the current scan and records its discovery order.
+func (s *MCPScanner) claimFile(filePath string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.seenFiles[filePath]; ok {
+		return false
+	}
+	s.seenFiles[filePath] = len(s.seenFiles)
+	return true
+}
 
-	logrus.Debug("Scan completed successfully")
-	return s.ScanResult, nil
+// processFile scans one file for a worker, emitting streaming events around it. It returns
+// nil if the file could not be scanned or the scan context has ended.
+func (s *MCPScanner) processFile(ctx context.Context, filePath string) *FileResult {
+	s.stats.attempted.Add(1)
+	if ctx.Err() != nil {
+		s.stats.skipped.Add(1)
+		return nil
+	}
+	if !s.fileAllowed(filePath) {
+		logrus.Debugf("Skipping %s due to --include/--exclude patterns", filePath)
+		s.stats.skipped.Add(1)
+		return nil
+	}
+	// Emit a 'started' streaming event prior to scanning for real-time UIs.
+	s.notify(filePath, nil, nil)
+
+	_, fileSpan := telemetry.Tracer().Start(ctx, telemetry.SpanScanFile)
+	var start time.Time
+	if s.profile {
+		start = time.Now()
+	}
+	fileResult, err := s.scanFile(filePath)
+	if s.profile {
+		fileResult.Duration = time.Since(start)
+	}
+	fileSpan.SetAttributes(
+		attribute.String("file.path", filePath),
+		attribute.Int("server.count", len(fileResult.Servers)),
+	)
+	if err != nil {
+		fileSpan.RecordError(err)
+	}
+	fileSpan.End()
+
+	// Call streaming callback if provided (before error handling)
+	s.notify(filePath, fileResult, err)
+
+	if err != nil {
+		s.stats.errored.Add(1)
+		switch {
+		case s.suppressErrors && (errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission)):
+			s.mu.Lock()
+			s.ScanResult.SuppressedErrors++
+			s.mu.Unlock()
+		case os.IsNotExist(err):
+			logrus.Debugf("File not found: %s", filePath)
+		default:
+			logrus.Errorf("Error scanning file %s: %v", filePath, err)
+		}
+		return nil
+	}
+	return fileResult
+}
+
+// fileAllowed reports whether filePath passes the include and exclude patterns; excludes
+// take priority.
+func (s *MCPScanner) fileAllowed(filePath string) bool {
+	if len(s.include) == 0 && len(s.exclude) == 0 {
+		return true
+	}
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		abs = filePath
+	}
+	base := filepath.Base(filePath)
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := filepath.Match(pattern, abs); ok {
+				return true
+			}
+			if ok, _ := filepath.Match(pattern, base); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if matches(s.exclude) {
+		return false
+	}
+	return len(s.include) == 0 || matches(s.include)
+}
+
+// notify calls the streaming callback, if any, one event at a time.
+func (s *MCPScanner) notify(filePath string, fileResult *FileResult, err error) {
+	if s.streamingCallback == nil {
+		return
+	}
+	s.callbackMu.Lock()
+	defer s.callbackMu.Unlock()
+	s.streamingCallback(filePath, fileResult, err)
+}
+
+// orderResults sorts the collected files into discovery order and rebuilds the top-level
+// server and secret aggregations from them.
+func (s *MCPScanner) orderResults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := s.ScanResult.Files
+	sort.SliceStable(files, func(i, j int) bool {
+		return s.seenFiles[files[i].Path] < s.seenFiles[files[j].Path]
+	})
+	for _, f := range files {
+		s.ScanResult.Servers = append(s.ScanResult.Servers, f.Servers...)
+		s.ScanResult.SecretFindings = append(s.ScanResult.SecretFindings, f.SecretFindings...)
+	}
 }
 
 func (s *MCPScanner) scanFile(path string) (*FileResult, error) {
@@ -149,19 +485,34 @@ func (s *MCPScanner) scanFile(path string) (*FileResult, error) {
 	fileResult := new(FileResult)
 	fileResult.Path = path
 
-	// Capture current secret findings count to attribute new findings to this file
-	prevFindingsCount := len(s.ScanResult.SecretFindings)
-
-	config, err := s.ParseMCPConfigFile(path)
+	config, findings, err := s.parseMCPConfigFile(path)
 	if err != nil || config == nil {
 		logrus.Debugf("Could not parse file, or no MCP configuration found: %v", err)
 		return fileResult, err
 	}
 
 	servers := config.GetServers()
+	for name := range servers {
+		if _, ok := s.skipServers[strings.ToLower(name)]; ok {
+			logrus.Debugf("Skipping server: %s", name)
+			delete(servers, name)
+			fileResult.SkippedServers = append(fileResult.SkippedServers, name)
+		}
+	}
+	if len(fileResult.SkippedServers) > 0 {
+		sort.Strings(fileResult.SkippedServers)
+		findings = slices.DeleteFunc(findings, func(f SecretFinding) bool {
+			return slices.Contains(fileResult.SkippedServers, f.ServerName)
+		})
+	}
 
 	for name, serverData := range servers {
-		serverScanResult := &ServerConfig{Name: name, Server: serverData}
+		serverScanResult := &ServerConfig{Name: name, Server: serverData, Fingerprint: ServerFingerprint(serverData, s.hash)}
+		ratingName := name
+		if s.normalizeNames {
+			serverScanResult.CanonicalName = CanonicalServerName(name)
+			ratingName = serverScanResult.CanonicalName
+		}
 		fileResult.Servers = append(fileResult.Servers, *serverScanResult)
 
 		// Print the server configuration.
@@ -170,19 +521,25 @@ func (s *MCPScanner) scanFile(path string) (*FileResult, error) {
 			logrus.Debugf("Configuration:\n%s", string(serverJSON))
 		}
 
+		fileResult.MisconfigFindings = append(fileResult.MisconfigFindings, DetectMisconfigs(name, path, serverData)...)
+		launchFindings := append(detectShellInjection(name, serverData), detectBroadFilesystemAccess(name, serverData)...)
+		for _, f := range launchFindings {
+			f.Path = path
+			fileResult.MisconfigFindings = append(fileResult.MisconfigFindings, f)
+		}
+
 		// Submit identifiers for live batched ratings.
 		if s.collector != nil {
-			s.collector.Submit(name, serverData)
+			s.collector.Submit(path, ratingName, serverData)
 		}
 	}
 
-	// Attribute newly discovered secrets from this parse to this file result
-	if newCount := len(s.ScanResult.SecretFindings) - prevFindingsCount; newCount > 0 {
-		fileResult.SecretFindings = append(
-			fileResult.SecretFindings,
-			s.ScanResult.SecretFindings[prevFindingsCount:]...,
-		)
-	}
+	// Servers come from a map; keep misconfiguration output stable.
+	sort.SliceStable(fileResult.MisconfigFindings, func(i, j int) bool {
+		return fileResult.MisconfigFindings[i].ServerName < fileResult.MisconfigFindings[j].ServerName
+	})
+
+	fileResult.SecretFindings = findings
 
 	return fileResult, nil
 }
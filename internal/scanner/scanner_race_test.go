@@ -0,0 +1,105 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeScanFixtures writes n MCP configs under dir, each with two servers and one secret.
+func writeScanFixtures(tb testing.TB, dir string, n int) {
+	tb.Helper()
+	for i := range n {
+		sub := filepath.Join(dir, fmt.Sprintf("project-%02d", i))
+		require.NoError(tb, os.MkdirAll(sub, 0o755))
+		content := fmt.Sprintf(`{
+	"mcpServers": {
+		"fs-%[1]d": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem@1.%[1]d.0"]},
+		"supabase-%[1]d": {"command": "npx", "args": ["-y", "@supabase/mcp-server-supabase"],
+			"env": {"SUPABASE_ACCESS_TOKEN": "sbp_%[2]s"}}
+	}
+}`, i, fmt.Sprintf("%x", sha256.Sum256([]byte(sub)))[:40])
+		require.NoError(tb, os.WriteFile(filepath.Join(sub, "mcp.json"), []byte(content), 0o600))
+	}
+}
+
+// TestMCPScanner_Scan_ParallelWorkers runs a scan with several workers, sharing a ratings
+// collector and a streaming callback. Run it with -race to check the pipeline for data races;
+// the results must match a single-worker scan.
+func TestMCPScanner_Scan_ParallelWorkers(t *testing.T) {
+	dir := t.TempDir()
+	const files = 40
+	writeScanFixtures(t, dir, files)
+
+	// The target is listed twice: every file must still be scanned only once.
+	targets := []string{dir, dir}
+	sequential, err := NewMCPScanner(targets, filepath.Join(t.TempDir(), "s.json")).WithWorkers(1).Scan()
+	require.NoError(t, err)
+	require.Len(t, sequential.Files, files)
+
+	var mu sync.Mutex
+	started, done := map[string]int{}, map[string]int{}
+	rc := NewRatingsCollector(t.Context(), nil, nil)
+	s := NewMCPScanner(targets, filepath.Join(t.TempDir(), "s.json")).
+		WithWorkers(8).
+		WithRatingsCollector(rc).
+		WithStreamingCallback(func(filePath string, fileResult *FileResult, _ error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if fileResult == nil {
+				started[filePath]++
+			} else {
+				done[filePath]++
+			}
+		})
+	parallel, err := s.Scan()
+	require.NoError(t, err)
+	rc.FlushAndStop()
+
+	// Directories are walked concurrently and servers within a file come from a map, so
+	// files are matched by path and servers compared as sets.
+	require.Len(t, parallel.Files, files)
+	byPath := map[string]FileResult{}
+	for _, f := range sequential.Files {
+		byPath[f.Path] = f
+	}
+	for _, f := range parallel.Files {
+		assert.ElementsMatch(t, byPath[f.Path].Servers, f.Servers, f.Path)
+	}
+	assert.Len(t, parallel.Servers, 2*files)
+	assert.Len(t, parallel.SecretFindings, files)
+	for _, f := range parallel.Files {
+		require.Len(t, f.SecretFindings, 1, f.Path)
+		assert.Contains(t, f.SecretFindings[0].Occurrences, f.Path, "secrets are attributed to their own file")
+	}
+
+	assert.Len(t, started, files)
+	assert.Len(t, done, files)
+	for path, n := range done {
+		assert.Equal(t, 1, n, path)
+		assert.Equal(t, 1, started[path], path)
+	}
+
+	// A second scan with the same scanner sees the files again.
+	again, err := s.Scan()
+	require.NoError(t, err)
+	assert.Len(t, again.Files, files)
+
+	// Files given as targets keep their order whatever the number of workers.
+	var listed []string
+	for i := files - 1; i >= 0; i-- {
+		listed = append(listed, filepath.Join(dir, fmt.Sprintf("project-%02d", i), "mcp.json"))
+	}
+	ordered, err := NewMCPScanner(listed, filepath.Join(t.TempDir(), "s.json")).WithWorkers(8).Scan()
+	require.NoError(t, err)
+	require.Len(t, ordered.Files, files)
+	for i, f := range ordered.Files {
+		assert.Equal(t, listed[i], f.Path)
+	}
+}
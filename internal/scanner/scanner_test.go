@@ -2,7 +2,9 @@
 package scanner
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -263,6 +265,48 @@ func TestMCPScanner_scanFile(t *testing.T) {
 	}
 }
 
+func TestMCPScanner_scanFile_TOML(t *testing.T) {
+	_, thisFile, _, _ := runtime.Caller(0)
+	testdataDir := filepath.Join(filepath.Dir(thisFile), "..", "..", "testdata")
+
+	arrayStyle := filepath.Join(t.TempDir(), "mcp_config.toml")
+	require.NoError(t, os.WriteFile(arrayStyle, []byte(`
+[[mcpServers]]
+name = "my-server"
+command = "npx"
+args = ["-y", "@modelcontextprotocol/server-filesystem", "/tmp"]
+
+[[mcpServers]]
+name = "remote docs"
+url = "https://docs.example.com/mcp"
+`), 0o600))
+
+	for name, path := range map[string]string{
+		"tables":          filepath.Join(testdataDir, "test_toml_config.toml"),
+		"array of tables": arrayStyle,
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := NewMCPScanner(nil, "/tmp/storage").scanFile(path)
+			require.NoError(t, err)
+			require.Nil(t, result.Error)
+			require.Len(t, result.Servers, 2)
+
+			servers := map[string]Server{}
+			for _, s := range result.Servers {
+				servers[s.Name], _ = s.Server.(map[string]interface{})
+			}
+			require.Contains(t, servers, "my-server")
+			require.Contains(t, servers, "remote docs")
+			assert.Equal(t, "npx", servers["my-server"]["command"])
+			assert.Equal(t, "https://docs.example.com/mcp", servers["remote docs"]["url"])
+			assert.Equal(t, CategoryNPM, ServerCategory("", "my-server", servers["my-server"]))
+		})
+	}
+
+	assert.True(t, isWellKnownMCPFilename(".mcp.toml"))
+	assert.True(t, isWellKnownMCPFilename("mcp_config.toml"))
+}
+
 func TestMCPScanner_Integration(t *testing.T) {
 	_, thisFile, _, _ := runtime.Caller(0)
 	baseDir := filepath.Dir(thisFile)
@@ -510,6 +554,50 @@ func BenchmarkMCPScanner_Scan(b *testing.B) {
 	}
 }
 
+// BenchmarkMCPScanner_Scan_Parallel scans a directory of configs with different worker
+// counts; compare with -cpuprofile to see the per-worker pprof labels.
+func BenchmarkMCPScanner_Scan_Parallel(b *testing.B) {
+	dir := b.TempDir()
+	const files = 200
+	writeScanFixtures(b, dir, files)
+
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			scanner := NewMCPScanner([]string{dir}, "/tmp/storage").WithWorkers(workers)
+			for range b.N {
+				result, err := scanner.Scan()
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(result.Files) != files {
+					b.Fatalf("Expected %d results, got %d", files, len(result.Files))
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkMCPScanner_Scan_Profiling measures the overhead of timing each file.
+func BenchmarkMCPScanner_Scan_Profiling(b *testing.B) {
+	dir := b.TempDir()
+	writeScanFixtures(b, dir, 20)
+
+	for _, profile := range []bool{false, true} {
+		b.Run(fmt.Sprintf("profile=%t", profile), func(b *testing.B) {
+			s := NewMCPScanner([]string{dir}, filepath.Join(b.TempDir(), "s.json"))
+			if profile {
+				s.WithProfiling()
+			}
+			b.ResetTimer()
+			for range b.N {
+				if _, err := s.Scan(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkMCPScanner_scanFile(b *testing.B) {
 	tempDir := b.TempDir()
 
@@ -604,3 +692,148 @@ func TestMCPScanner_WithTestdata(t *testing.T) {
 	t.Logf("Summary: %d files with servers, %d files with errors, %d total servers",
 		filesWithServers, filesWithErrors, totalServers)
 }
+
+func TestScanner_WithoutSecretScanning(t *testing.T) {
+	path := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	withSecrets, err := NewMCPScanner([]string{path}, "").Scan()
+	require.NoError(t, err)
+	require.NotEmpty(t, withSecrets.SecretFindings)
+
+	result, err := NewMCPScanner([]string{path}, "").WithoutSecretScanning().Scan()
+	require.NoError(t, err)
+	assert.Empty(t, result.SecretFindings)
+	require.Len(t, result.Files, 1)
+	assert.Empty(t, result.Files[0].SecretFindings)
+	assert.Len(t, result.Servers, len(withSecrets.Servers), "servers are still discovered")
+}
+
+func TestScanner_WithContext(t *testing.T) {
+	first := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	second := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewMCPScanner([]string{first, second}, "").WithContext(ctx)
+	s.WithStreamingCallback(func(_ string, fileResult *FileResult, _ error) {
+		if fileResult != nil {
+			cancel() // stop after the first file completes
+		}
+	})
+
+	result, err := s.Scan()
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, result.Files, 1, "files scanned before cancellation are kept")
+	assert.Equal(t, first, result.Files[0].Path)
+	assert.NotEmpty(t, result.Servers)
+}
+
+func TestScanner_WithFileFilter(t *testing.T) {
+	claude := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	secrets := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+	abs, err := filepath.Abs(secrets)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		include, exclude []string
+		want             []string
+	}{
+		{"no patterns", nil, nil, []string{claude, secrets}},
+		{"include by base name", []string{"claude_*.json"}, nil, []string{claude}},
+		{"include by absolute path", []string{abs}, nil, []string{secrets}},
+		{"exclude", nil, []string{"test_*"}, []string{claude}},
+		{"exclude wins over include", []string{"*.json"}, []string{"claude_*"}, []string{secrets}},
+		{"nothing included", []string{"*.yaml"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewMCPScanner([]string{claude, secrets}, "").WithFileFilter(tt.include, tt.exclude).Scan()
+			require.NoError(t, err)
+			var got []string
+			for _, f := range result.Files {
+				got = append(got, f.Path)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScanner_WithSkippedServers(t *testing.T) {
+	path := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+	all, err := NewMCPScanner([]string{path}, "").Scan()
+	require.NoError(t, err)
+
+	rc := NewRatingsCollector(context.Background(), nil, nil)
+	result, err := NewMCPScanner([]string{path}, "").
+		WithRatingsCollector(rc).
+		WithSkippedServers([]string{"SUPABASE", "context7"}).
+		Scan()
+	require.NoError(t, err)
+	rc.FlushAndStop()
+
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, []string{"Context7", "supabase"}, result.Files[0].SkippedServers, "names match case-insensitively")
+	assert.Len(t, result.Servers, len(all.Servers)-2)
+	for _, server := range result.Servers {
+		assert.NotEqual(t, "supabase", server.Name)
+		assert.NotEqual(t, "Context7", server.Name)
+	}
+	for _, f := range result.SecretFindings {
+		assert.NotEqual(t, "supabase", f.ServerName, "secrets of skipped servers are not reported")
+	}
+	for _, id := range rc.Identifiers() {
+		assert.NotContains(t, id.Value, "context7", "skipped servers are not submitted for ratings")
+	}
+
+	summary := GenerateSummary(*result)
+	assert.Equal(t, []string{"Context7", "supabase"}, summary.SkippedServers)
+	assert.Equal(t, len(all.Servers)-2, summary.TotalServers)
+}
+
+func TestScanner_WithSuppressedFileErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks and permission bits are not reliable on Windows")
+	}
+	dir := t.TempDir()
+	writeScanFixtures(t, dir, 1)
+	// A dangling symlink is found by the walk but vanishes when read.
+	require.NoError(t, os.Symlink(filepath.Join(dir, "gone.json"), filepath.Join(dir, "mcp.json")))
+	want := 1
+	if os.Geteuid() != 0 {
+		restricted := filepath.Join(dir, "restricted", "mcp.json")
+		require.NoError(t, os.MkdirAll(filepath.Dir(restricted), 0o755))
+		require.NoError(t, os.WriteFile(restricted, []byte(`{"mcpServers": {}}`), 0o000))
+		want++
+	}
+	missing := filepath.Join(dir, "missing.json")
+
+	result, err := NewMCPScanner([]string{dir, missing}, "").WithSuppressedFileErrors().Scan()
+	require.NoError(t, err)
+	assert.Len(t, result.Files, 1)
+	assert.Equal(t, want, result.SuppressedErrors, "missing targets are not counted")
+	assert.Equal(t, want, GenerateSummary(*result).SuppressedErrors)
+
+	result, err = NewMCPScanner([]string{dir}, "").Scan()
+	require.NoError(t, err)
+	assert.Zero(t, result.SuppressedErrors, "errors are only counted when suppressed")
+}
+
+func TestScanner_ListFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeScanFixtures(t, dir, 3)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "node_modules", "pkg"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "node_modules", "pkg", "mcp.json"), []byte(`{}`), 0o600))
+	explicit := filepath.Join(dir, "project-01", "mcp.json")
+
+	s := NewMCPScanner([]string{dir, explicit, filepath.Join(dir, "missing.json")}, "")
+	assert.Equal(t, []string{
+		filepath.Join(dir, "project-00", "mcp.json"),
+		filepath.Join(dir, "project-01", "mcp.json"),
+		filepath.Join(dir, "project-02", "mcp.json"),
+	}, s.ListFiles(), "skipped directories, missing targets and duplicates are left out")
+
+	s.WithFileFilter([]string{filepath.Join(dir, "project-0[12]", "*")}, []string{filepath.Join(dir, "project-02", "*")})
+	assert.Equal(t, []string{explicit}, s.ListFiles())
+	assert.Empty(t, s.ScanResult.Files, "files are listed without being scanned")
+}
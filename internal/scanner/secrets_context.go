@@ -0,0 +1,133 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// contextSeparator separates snippets of distinct occurrences, as in grep -C.
+const contextSeparator = "--"
+
+// quotedStringRe matches double- or single-quoted strings on a single line.
+var quotedStringRe = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'[^']*'`) //nolint:gochecknoglobals // compiled once
+
+// AttachSecretContext re-reads every file with secret findings and sets each finding's
+// Context to the n lines before and after each occurrence. Secrets are only known by
+// hash at this point, so any token on a context line whose hash matches a finding in
+// the same file is masked, as is anything matching a known provider token pattern.
+// Unreadable files are skipped. n <= 0 is a no-op.
+func AttachSecretContext(result *ScanResult, n int) {
+	if result == nil || n <= 0 {
+		return
+	}
+	files := map[string][]string{}
+	hashes := map[string]map[string]struct{}{}
+	for _, f := range result.Files {
+		for _, sf := range f.SecretFindings {
+			for path := range sf.Occurrences {
+				if _, ok := hashes[path]; !ok {
+					hashes[path] = map[string]struct{}{}
+				}
+				hashes[path][sf.ValueHash] = struct{}{}
+			}
+		}
+	}
+	for path := range hashes {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logrus.Debugf("Cannot read %s for secret context: %v", path, err)
+			continue
+		}
+		files[path] = strings.Split(string(data), "\n")
+	}
+
+	attach := func(findings []SecretFinding) {
+		for i := range findings {
+			findings[i].Context = secretContext(findings[i], files, hashes, n)
+		}
+	}
+	for i := range result.Files {
+		attach(result.Files[i].SecretFindings)
+	}
+	attach(result.SecretFindings)
+}
+
+// secretContext renders the masked snippets for every occurrence of a finding.
+func secretContext(sf SecretFinding, files map[string][]string, hashes map[string]map[string]struct{}, n int) string {
+	paths := make([]string, 0, len(sf.Occurrences))
+	for path := range sf.Occurrences {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var snippets []string
+	for _, path := range paths {
+		lines, ok := files[path]
+		if !ok {
+			continue
+		}
+		for _, line := range sf.Occurrences[path] {
+			start, end, ok := contextRange(len(lines), line, n)
+			if !ok {
+				continue
+			}
+			var b strings.Builder
+			for ln := start; ln <= end; ln++ {
+				marker := " "
+				if ln == line {
+					marker = ">"
+				}
+				fmt.Fprintf(&b, "%s%4d | %s\n", marker, ln, maskSecretsInLine(lines[ln-1], hashes[path]))
+			}
+			snippets = append(snippets, strings.TrimSuffix(b.String(), "\n"))
+		}
+	}
+	return strings.Join(snippets, "\n"+contextSeparator+"\n")
+}
+
+// contextRange returns the 1-based inclusive line range of n lines around line, clamped
+// to the file. ok is false when line is outside the file.
+func contextRange(lineCount, line, n int) (int, int, bool) {
+	if line < 1 || line > lineCount {
+		return 0, 0, false
+	}
+	return max(1, line-n), min(lineCount, line+n), true
+}
+
+// maskSecretsInLine redacts tokens whose SHA-256 is in hashes, then known provider tokens.
+func maskSecretsInLine(line string, hashes map[string]struct{}) string {
+	for _, tok := range lineTokens(line) {
+		h := sha256.Sum256([]byte(tok))
+		if _, ok := hashes[hex.EncodeToString(h[:])]; ok {
+			line = strings.ReplaceAll(line, tok, redactSecret(tok))
+		}
+	}
+	return RedactKnownSecrets(line)
+}
+
+// lineTokens returns candidate raw values on a config line: quoted strings, the
+// unquoted remainder after a YAML key or list marker, and whitespace/delimiter fields.
+func lineTokens(line string) []string {
+	var toks []string
+	for _, q := range quotedStringRe.FindAllString(line, -1) {
+		toks = append(toks, q[1:len(q)-1])
+	}
+	trimmed := strings.TrimSpace(line)
+	if i := strings.Index(trimmed, ": "); i >= 0 {
+		toks = append(toks, strings.TrimSpace(trimmed[i+2:]))
+	}
+	if rest, ok := strings.CutPrefix(trimmed, "- "); ok {
+		toks = append(toks, strings.TrimSpace(rest))
+	}
+	toks = append(toks, strings.FieldsFunc(line, func(r rune) bool {
+		return strings.ContainsRune(" \t\"',:=[]{}", r)
+	})...)
+	return toks
+}
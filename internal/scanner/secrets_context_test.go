@@ -0,0 +1,70 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextRange(t *testing.T) {
+	start, end, ok := contextRange(10, 5, 2)
+	require.True(t, ok)
+	assert.Equal(t, [2]int{3, 7}, [2]int{start, end})
+
+	start, end, ok = contextRange(3, 1, 5)
+	require.True(t, ok)
+	assert.Equal(t, [2]int{1, 3}, [2]int{start, end}, "clamped to the file")
+
+	_, _, ok = contextRange(3, 4, 1)
+	assert.False(t, ok)
+}
+
+func TestAttachSecretContext(t *testing.T) {
+	const token = "sbp_ab794c03758f962f0ad993b0cd6578b13b4ec407" //nolint:gosec // test data
+	const opaque = "opaque-custom-secret-value-123"              //nolint:gosec // test data
+	path := filepath.Join(t.TempDir(), "mcp.json")
+	content := strings.Join([]string{
+		`{`,
+		`  "mcpServers": {`,
+		`    "supabase": {`,
+		`      "env": {`,
+		`        "SUPABASE_ACCESS_TOKEN": "` + token + `",`,
+		`        "OTHER": "` + opaque + `"`,
+		`      }`,
+		`    }`,
+		`  }`,
+		`}`,
+	}, "\n")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	f1 := NewSecretFinding("supabase", "Supabase Token", "env.SUPABASE_ACCESS_TOKEN", token, "HIGH", path, 5, nil)
+	f2 := NewSecretFinding("supabase", "Generic Secret", "env.OTHER", opaque, "LOW", path, 6, nil)
+	result := &ScanResult{
+		Files:          []FileResult{{Path: path, SecretFindings: []SecretFinding{f1, f2}}},
+		SecretFindings: []SecretFinding{f1, f2},
+	}
+
+	AttachSecretContext(result, 1)
+
+	got := result.Files[0].SecretFindings[0].Context
+	assert.Equal(t, result.SecretFindings[0].Context, got)
+	lines := strings.Split(got, "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "    4 | "))
+	assert.True(t, strings.HasPrefix(lines[1], ">   5 | "))
+	assert.Contains(t, lines[1], redactSecret(token))
+	// Neighbouring secrets from the same file are masked too.
+	assert.Contains(t, lines[2], redactSecret(opaque))
+	assert.NotContains(t, got, token)
+	assert.NotContains(t, got, opaque)
+
+	t.Run("disabled", func(t *testing.T) {
+		r := &ScanResult{Files: []FileResult{{Path: path, SecretFindings: []SecretFinding{f1}}}}
+		AttachSecretContext(r, 0)
+		assert.Empty(t, r.Files[0].SecretFindings[0].Context)
+	})
+}
@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	_ "embed"
 	"math"
 	"regexp"
 	"strings"
@@ -11,11 +12,14 @@ import (
 //nolint:gochecknoglobals,golines // Static registries used for token detection across package.
 var (
 	providerTokenRegex = map[string]*regexp.Regexp{
-		"openai":        regexp.MustCompile(`\b(?:sk-[A-Za-z0-9]{48}|sk-[A-Za-z0-9_-]+T3BlbkFJ[A-Za-z0-9_-]+)\b`),
-		"anthropic":     regexp.MustCompile(`\bsk-ant-api\d{0,2}-[A-Za-z0-9\-]{80,120}\b`),
-		"google":        regexp.MustCompile(`\b(?:AIza[0-9A-Za-z\-_]{35}|AIzaSy[A-Za-z0-9\-_]{33}|AI[a-zA-Z0-9_\-]{30,})\b`),
-		"openrouter":    regexp.MustCompile(`\bsk-or-v1-[a-z0-9]{64}\b`),
-		"groq":          regexp.MustCompile(`\bgsk_[A-Za-z0-9]{20,}\b`),
+		"openai":     regexp.MustCompile(`\b(?:sk-[A-Za-z0-9]{48}|sk-[A-Za-z0-9_-]+T3BlbkFJ[A-Za-z0-9_-]+)\b`),
+		"anthropic":  regexp.MustCompile(`\bsk-ant-api\d{0,2}-[A-Za-z0-9\-]{80,120}\b`),
+		"firebase":   regexp.MustCompile(`\bAIzaSy[0-9A-Za-z\-_]{33}\b`),
+		"google":     regexp.MustCompile(`\b(?:AIza[0-9A-Za-z\-_]{35}|AIzaSy[A-Za-z0-9\-_]{33}|AI[a-zA-Z0-9_\-]{30,})\b`),
+		"openrouter": regexp.MustCompile(`\bsk-or-v1-[a-z0-9]{64}\b`),
+		"groq":       regexp.MustCompile(`\bgsk_[A-Za-z0-9]{20,}\b`),
+		// User access tokens are hf_ prefixed; older organization API tokens api_org_.
+		"huggingface":   regexp.MustCompile(`\b(?:hf|api_org)_[A-Za-z0-9]{32,}\b`),
 		"mistral":       regexp.MustCompile(`\b[A-Za-z0-9]{32}\b`),
 		"elevenlabs":    regexp.MustCompile(`\b(?:[a-z0-9]{32}|sk_[a-z0-9]{48})\b`),
 		"supabase":      regexp.MustCompile(`\bsbp_[a-f0-9]{40}\b`),
@@ -29,13 +33,24 @@ var (
 		"atlassian_url": regexp.MustCompile(`(?i)\bhttps?://[^:@\s]+:[A-Za-z0-9]{16,64}@[A-Za-z0-9.-]+\.atlassian\.net\S*\b`),
 		"github_pat":    regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9]{22}_[A-Za-z0-9]{59}\b`),
 		"vantage":       regexp.MustCompile(`\bvntg_tkn_[a-f0-9]{40}\b`),
+		// Global API keys are 37 hex characters, API tokens 40 URL-safe characters.
+		"cloudflare": regexp.MustCompile(`^(?:[0-9a-f]{37}|[A-Za-z0-9_-]{40})$`),
+		"vercel":     regexp.MustCompile(`^[A-Za-z0-9]{24}$`),
+	}
+	// providerFieldRegex restricts providers whose token format is too generic to match on
+	// its own to values held in a field with a matching name.
+	providerFieldRegex = map[string]*regexp.Regexp{
+		"cloudflare": regexp.MustCompile(`(?i)^(?:CF|CLOUDFLARE)_API_(?:KEY|TOKEN)$`),
+		"vercel":     regexp.MustCompile(`(?i)^VERCEL_(?:API_)?TOKEN$`),
 	}
 	providerDisplayType = map[string]string{
 		"openai":        "OpenAI API Key",
 		"anthropic":     "Anthropic API Key",
+		"firebase":      "Firebase API Key",
 		"google":        "Google Token",
 		"openrouter":    "OpenRouter API Key",
 		"groq":          "Groq API Key",
+		"huggingface":   "HuggingFace Access Token",
 		"mistral":       "Mistral API Key",
 		"elevenlabs":    "ElevenLabs API Key",
 		"supabase":      "Supabase Access Token",
@@ -49,11 +64,14 @@ var (
 		"atlassian_url": "Atlassian URL with Credentials",
 		"github_pat":    "GitHub Personal Access Token",
 		"vantage":       "Vantage API Token",
+		"cloudflare":    "Cloudflare API Key",
+		"vercel":        "Vercel Token",
 	}
+	// firebase must precede google, whose pattern also matches Firebase keys.
 	providerOrder = []string{
-		"openai", "anthropic", "google", "openrouter", "groq",
-		"mistral", "elevenlabs", "supabase", "deepseek", "xai",
-		"aws", "database_url", "github_pat", "vantage", "slack",
+		"openai", "anthropic", "firebase", "google", "openrouter", "groq",
+		"huggingface", "mistral", "elevenlabs", "supabase", "deepseek", "xai",
+		"aws", "database_url", "github_pat", "vantage", "cloudflare", "vercel", "slack",
 		"slack_webhook", "atlassian", "atlassian_url",
 	}
 )
@@ -63,28 +81,60 @@ type Detector interface {
 	Classify(value string) (kind, confidence string, secretFound bool)
 }
 
-type defaultDetector struct{}
+// fieldDetector is implemented by detectors that also use the name of the field holding a
+// value, e.g. "CF_API_KEY", to classify it.
+type fieldDetector interface {
+	ClassifyField(field, value string) (kind, confidence string, secretFound bool)
+}
+
+// DefaultEntropyThreshold is the Shannon entropy, in bits per character, above which an
+// unrecognised value is reported as a LOW confidence generic secret.
+const DefaultEntropyThreshold = 3.8
+
+// Confidence levels assigned to secret findings.
+const (
+	ConfidenceHigh = "HIGH"
+	ConfidenceLow  = "LOW"
+)
+
+// defaultDetector matches provider token patterns, then falls back to entropy.
+// A zero entropyThreshold means DefaultEntropyThreshold.
+type defaultDetector struct {
+	entropyThreshold float64
+}
 
 func (d defaultDetector) Classify(value string) (string, string, bool) {
-	return classifySecretValue(value)
+	return d.ClassifyField("", value)
+}
+
+// ClassifyField is Classify for a value held in the named field; providers listed in
+// providerFieldRegex only match when the field name does.
+func (d defaultDetector) ClassifyField(field, value string) (string, string, bool) {
+	threshold := d.entropyThreshold
+	if threshold == 0 {
+		threshold = DefaultEntropyThreshold
+	}
+	return classifySecretValue(field, value, threshold)
 }
 
-func classifySecretValue(s string) (string, string, bool) {
+func classifySecretValue(field, s string, entropyThreshold float64) (string, string, bool) {
 	for _, provider := range providerOrder {
+		if fre := providerFieldRegex[provider]; fre != nil && !fre.MatchString(field) {
+			continue
+		}
 		re := providerTokenRegex[provider]
 		if re != nil && re.MatchString(s) {
-			return providerDisplayType[provider], "HIGH", true
+			return providerDisplayType[provider], ConfidenceHigh, true
 		}
 	}
-	if isHighEntropy(s) {
-		return "Generic Secret", "LOW", true
+	if isHighEntropy(s, entropyThreshold) {
+		return genericSecretKind, ConfidenceLow, true
 	}
 	return "", "", false
 }
 
-func isHighEntropy(s string) bool {
+func isHighEntropy(s string, minEntropyBitsPerChar float64) bool {
 	const minLen = 24
-	const minEntropyBitsPerChar = 3.8
 	if len(s) < minLen {
 		return false
 	}
@@ -100,8 +150,83 @@ func isHighEntropy(s string) bool {
 	if strings.ContainsAny(s, " \t\n\r") {
 		return false
 	}
-	h := shannonEntropy(s)
-	return h >= minEntropyBitsPerChar
+	for _, re := range knownFalsePositivePatterns {
+		if re.MatchString(s) {
+			return false
+		}
+	}
+	if shannonEntropy(s) < minEntropyBitsPerChar {
+		return false
+	}
+	return wordCoverage(s) <= maxWordCoverage
+}
+
+// knownFalsePositivePatterns match high-entropy values that are identifiers rather than
+// secrets and are never reported by the entropy check.
+//
+//nolint:gochecknoglobals // Static registry used for token detection.
+var knownFalsePositivePatterns = []*regexp.Regexp{
+	// UUIDs, e.g. test fixtures and resource IDs.
+	regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+	// Git commit SHAs, SHA-1 or SHA-256.
+	regexp.MustCompile(`^(?:[0-9a-f]{40}|[0-9a-f]{64})$`),
+	// Lists of hex colour codes, e.g. a theme palette.
+	regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}){1,2}(?:[,;]#(?:[0-9a-fA-F]{3}){1,2})+$`),
+}
+
+// maxWordCoverage is the fraction of a value's characters that may be spelled by common
+// words before the entropy check treats it as text, such as Lorem Ipsum or a long
+// camel-case identifier, rather than a secret.
+const maxWordCoverage = 0.5
+
+// commonWordsTxt holds the words counted by wordCoverage; see common_words.txt.
+//
+//go:embed common_words.txt
+var commonWordsTxt string
+
+// commonWords is the set of words in common_words.txt and maxCommonWordLen the longest one.
+//
+//nolint:gochecknoglobals // Parsed once from the embedded list.
+var commonWords, maxCommonWordLen = loadCommonWords(commonWordsTxt)
+
+func loadCommonWords(txt string) (map[string]struct{}, int) {
+	words := make(map[string]struct{})
+	longest := 0
+	for _, line := range strings.Split(txt, "\n") {
+		w := strings.TrimSpace(line)
+		if w == "" || strings.HasPrefix(w, "#") {
+			continue
+		}
+		words[strings.ToLower(w)] = struct{}{}
+		longest = max(longest, len(w))
+	}
+	return words, longest
+}
+
+// wordCoverage returns the fraction of s, case-insensitively, covered by common words,
+// matching the longest word at each position from left to right.
+func wordCoverage(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	lower := strings.ToLower(s)
+	covered := 0
+	for i := 0; i < len(lower); {
+		matched := 0
+		for n := min(maxCommonWordLen, len(lower)-i); n > 0; n-- {
+			if _, ok := commonWords[lower[i:i+n]]; ok {
+				matched = n
+				break
+			}
+		}
+		if matched == 0 {
+			i++
+			continue
+		}
+		covered += matched
+		i += matched
+	}
+	return float64(covered) / float64(len(s))
 }
 
 func shannonEntropy(s string) float64 {
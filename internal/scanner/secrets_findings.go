@@ -1,8 +1,7 @@
 package scanner
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
+	"fmt"
 	"sort"
 	"strings"
 )
@@ -11,26 +10,30 @@ import (
 type SecretFinding struct {
 	Kind        string           `json:"kind"`
 	Key         string           `json:"key"`
-	Value       string           `json:"value"` // Redacted value
+	Value       string           `json:"value,omitempty"` // Redacted value
 	Occurrences map[string][]int `json:"occurrences"`
-	ValueHash   string           `json:"value_hash,omitempty"`
+	ValueHash   string           `json:"value_hash"`
 	ServerName  string           `json:"server_name"`
 	Confidence  string           `json:"confidence"`
+	Context     string           `json:"context,omitempty"`     // Masked surrounding lines, set by AttachSecretContext
+	Explanation string           `json:"explanation,omitempty"` // Set by Explain
+	Remediation string           `json:"remediation,omitempty"` // Set by Explain
+	Annotation  *Annotation      `json:"annotation,omitempty"`  // Set by Annotate
 }
 
 // NewSecretFinding constructs a SecretFinding with automatic value redaction.
 // filePath is recorded for occurrences; pass line > 0 to record a single line.
+// ValueHash is the digest of rawValue under hash, SHA-256 when nil.
 func NewSecretFinding(
 	serverName, secretKind, key, rawValue, confidence string,
-	filePath string, line int,
+	filePath string, line int, hash HashFunc,
 ) SecretFinding {
 	var sf SecretFinding
 	sf.Kind = secretKind
 	sf.Key = key
 	// Redact the secret right away, but keep a hash of the raw value for grouping.
 	sf.Value = redactSecret(rawValue)
-	h := sha256.Sum256([]byte(rawValue))
-	sf.ValueHash = hex.EncodeToString(h[:])
+	sf.ValueHash = hashOrDefault(hash)([]byte(rawValue))
 	sf.ServerName = serverName
 	sf.Confidence = confidence
 	sf.Occurrences = make(map[string][]int, 1)
@@ -75,6 +78,25 @@ func (s *FindingSet) ListSorted() []SecretFinding {
 	return out
 }
 
+// AggregateSecretsByValue merges the summary's secrets that share a ValueHash, so a secret
+// copied into several configs is reported once with the occurrences of every copy, and
+// recounts TotalFindings. Server reports keep their own findings.
+func AggregateSecretsByValue(summary *ScanSummary) {
+	findings := NewFindingSet()
+	var unhashed []SecretFinding
+	for _, f := range summary.Secrets {
+		if f.ValueHash == "" {
+			unhashed = append(unhashed, f)
+			continue
+		}
+		// Add merges into the first finding's map; copy it so server reports are untouched.
+		f.Occurrences = mergeOccurrences(f.Occurrences, nil)
+		findings.Add(f)
+	}
+	summary.Secrets = append(findings.ListSorted(), unhashed...)
+	summary.TotalFindings = len(summary.Secrets)
+}
+
 // compareFindings defines deterministic ordering for findings to stabilize
 // output and tests. Ordering precedence: ServerName, Kind, Key, ValueHash.
 func compareFindings(a, b SecretFinding) bool {
@@ -133,3 +155,33 @@ func locateLines(content []byte, token string) []int {
 func (c *secretScanContext) Findings() []SecretFinding {
 	return c.findings.ListSorted()
 }
+
+// ParseConfidence normalizes a user-supplied minimum confidence (high or low).
+func ParseConfidence(s string) (string, error) {
+	c := strings.ToUpper(strings.TrimSpace(s))
+	if c != ConfidenceHigh && c != ConfidenceLow {
+		return "", fmt.Errorf("invalid confidence %q: must be high or low", s)
+	}
+	return c, nil
+}
+
+// FilterSecretsByConfidence drops secret findings below minConfidence from result, so
+// GenerateSummary neither reports nor counts them. ConfidenceLow keeps everything.
+func FilterSecretsByConfidence(result *ScanResult, minConfidence string) {
+	if result == nil || minConfidence != ConfidenceHigh {
+		return
+	}
+	keep := func(findings []SecretFinding) []SecretFinding {
+		var out []SecretFinding
+		for _, f := range findings {
+			if f.Confidence == ConfidenceHigh {
+				out = append(out, f)
+			}
+		}
+		return out
+	}
+	for i := range result.Files {
+		result.Files[i].SecretFindings = keep(result.Files[i].SecretFindings)
+	}
+	result.SecretFindings = keep(result.SecretFindings)
+}
@@ -1,17 +1,20 @@
 package scanner
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFindingSet_AddMergeAndList(t *testing.T) {
 	set := NewFindingSet()
 
-	f1 := NewSecretFinding("serverA", "OpenAI API Key", "env.OPENAI_API_KEY", "sk-proj-abcT3BlbkFJdef", "HIGH", "/tmp/a.json", 10) //nolint:gosec,golines // test data
-	f2 := NewSecretFinding("serverA", "OpenAI API Key", "env.OPENAI_API_KEY", "sk-proj-abcT3BlbkFJdef", "HIGH", "/tmp/a.json", 12)
-	f3 := NewSecretFinding("serverB", "OpenAI API Key", "env.OPENAI_API_KEY", "sk-proj-xyzT3BlbkFJuvw", "HIGH", "/tmp/b.json", 5)
+	f1 := NewSecretFinding("serverA", "OpenAI API Key", "env.OPENAI_API_KEY", "sk-proj-abcT3BlbkFJdef", "HIGH", "/tmp/a.json", 10, nil) //nolint:gosec,golines // test data
+	f2 := NewSecretFinding("serverA", "OpenAI API Key", "env.OPENAI_API_KEY", "sk-proj-abcT3BlbkFJdef", "HIGH", "/tmp/a.json", 12, nil)
+	f3 := NewSecretFinding("serverB", "OpenAI API Key", "env.OPENAI_API_KEY", "sk-proj-xyzT3BlbkFJuvw", "HIGH", "/tmp/b.json", 5, nil)
 
 	set.Add(f1)
 	set.Add(f2) // same value -> should merge lines
@@ -37,3 +40,28 @@ func TestLocateLines(t *testing.T) {
 	lines := locateLines(content, "secret_here")
 	assert.Equal(t, []int{2, 4}, lines)
 }
+
+func TestAggregateSecretsByValue(t *testing.T) {
+	dir := t.TempDir()
+	config := `{"mcpServers": {"supabase": {"command": "npx", "args": ["-y", "@supabase/mcp-server-supabase"],
+	"env": {"SUPABASE_ACCESS_TOKEN": "sbp_0123456789abcdef0123456789abcdef01234567"}}}}`
+	first, second := filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")
+	require.NoError(t, os.WriteFile(first, []byte(config), 0o600))
+	require.NoError(t, os.WriteFile(second, []byte(config), 0o600))
+
+	result, err := NewMCPScanner([]string{first, second}, "").Scan()
+	require.NoError(t, err)
+	summary := GenerateSummary(*result)
+	require.Len(t, summary.Secrets, 2, "each file reports its own copy")
+
+	AggregateSecretsByValue(&summary)
+	require.Len(t, summary.Secrets, 1)
+	assert.Equal(t, 1, summary.TotalFindings)
+	assert.Equal(t, map[string][]int{first: {2}, second: {2}}, summary.Secrets[0].Occurrences)
+	assert.NotEmpty(t, summary.Secrets[0].ValueHash)
+
+	for _, sr := range summary.Servers {
+		require.Len(t, sr.Secrets, 1)
+		assert.Equal(t, map[string][]int{sr.Path: {2}}, sr.Secrets[0].Occurrences, "server reports keep their own findings")
+	}
+}
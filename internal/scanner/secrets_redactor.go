@@ -27,11 +27,17 @@ func (c *secretScanContext) handleString(dotPath, s string) interface{} {
 	if s == "" {
 		return s
 	}
-	secretKind, confidence, secretFound := c.detector.Classify(s)
+	var secretKind, confidence string
+	var secretFound bool
+	if fd, ok := c.detector.(fieldDetector); ok {
+		secretKind, confidence, secretFound = fd.ClassifyField(fieldName(dotPath), s)
+	} else {
+		secretKind, confidence, secretFound = c.detector.Classify(s)
+	}
 	if secretFound {
 		redacted := c.redactor(s)
 		lines := locateLines(c.originalFileContent, s)
-		finding := NewSecretFinding(c.currentServer, secretKind, dotPath, s, confidence, c.filePath, 0)
+		finding := NewSecretFinding(c.currentServer, secretKind, dotPath, s, confidence, c.filePath, 0, c.hash)
 		if len(lines) > 0 {
 			finding.Occurrences[c.filePath] = lines
 		}
@@ -41,3 +47,75 @@ func (c *secretScanContext) handleString(dotPath, s string) interface{} {
 	}
 	return s
 }
+
+// fieldName returns the last key of a dot path, e.g. "CF_API_KEY" for "env.CF_API_KEY".
+func fieldName(dotPath string) string {
+	return dotPath[strings.LastIndex(dotPath, ".")+1:]
+}
+
+// RedactKnownSecrets masks every substring of text that matches a known provider token pattern.
+// Unlike the config traversal, this works on free-form text such as logged JSON-RPC payloads,
+// so providers that need a field name are skipped.
+func RedactKnownSecrets(text string) string {
+	for _, provider := range providerOrder {
+		re := providerTokenRegex[provider]
+		if re == nil || providerFieldRegex[provider] != nil {
+			continue
+		}
+		text = re.ReplaceAllStringFunc(text, redactSecret)
+	}
+	return text
+}
+
+// redactedEnvValue replaces each value masked by RedactEnv.
+const redactedEnvValue = "***"
+
+// RedactEnv replaces every string inside an "env" block of the parsed server configs in
+// result, at any depth, with "***". It is applied after Scan so identifier extraction has
+// already seen the original values. Configs are copied rather than edited in place.
+func RedactEnv(result *ScanResult) {
+	if result == nil {
+		return
+	}
+	redact := func(servers []ServerConfig) []ServerConfig {
+		if servers == nil {
+			return nil
+		}
+		out := make([]ServerConfig, len(servers))
+		for i, sc := range servers {
+			sc.Server = redactEnvBlocks(sc.Server, false)
+			out[i] = sc
+		}
+		return out
+	}
+	for i := range result.Files {
+		result.Files[i].Servers = redact(result.Files[i].Servers)
+	}
+	result.Servers = redact(result.Servers)
+}
+
+// redactEnvBlocks returns a copy of v with the strings below any "env" key masked;
+// inEnv is set once inside such a block.
+func redactEnvBlocks(v interface{}, inEnv bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, child := range t {
+			out[k] = redactEnvBlocks(child, inEnv || k == "env")
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, child := range t {
+			out[i] = redactEnvBlocks(child, inEnv)
+		}
+		return out
+	case string:
+		if inEnv {
+			return redactedEnvValue
+		}
+		return t
+	default:
+		return v
+	}
+}
@@ -31,3 +31,40 @@ func TestRedactSecret_EdgeCases(t *testing.T) {
 	mid = strings.TrimSuffix(mid, "...")
 	assert.Equal(t, strings.Repeat("*", 12), mid)
 }
+
+func TestRedactKnownSecrets(t *testing.T) {
+	in := `{"params":{"token":"AKIA1234567890ABCDEF","note":"hello"}}`
+	out := RedactKnownSecrets(in)
+	assert.NotContains(t, out, "AKIA1234567890ABCDEF")
+	assert.Contains(t, out, `"AKIA************..."`)
+	assert.Contains(t, out, `"note":"hello"`)
+}
+
+func TestRedactEnv(t *testing.T) {
+	original := map[string]interface{}{
+		"command": "npx",
+		"args":    []interface{}{"-y", "server"},
+		"env":     map[string]interface{}{"API_BASE": "https://internal.example.com", "RETRIES": 3.0},
+		"stdio": map[string]interface{}{
+			"env": map[string]interface{}{"FLAGS": []interface{}{"beta", "canary"}},
+		},
+	}
+	result := &ScanResult{
+		Files:   []FileResult{{Path: "/a/mcp.json", Servers: []ServerConfig{{Name: "s", Server: original}}}},
+		Servers: []ServerConfig{{Name: "s", Server: original}},
+	}
+
+	RedactEnv(result)
+
+	want := map[string]interface{}{
+		"command": "npx",
+		"args":    []interface{}{"-y", "server"},
+		"env":     map[string]interface{}{"API_BASE": "***", "RETRIES": 3.0},
+		"stdio": map[string]interface{}{
+			"env": map[string]interface{}{"FLAGS": []interface{}{"***", "***"}},
+		},
+	}
+	assert.Equal(t, want, result.Files[0].Servers[0].Server)
+	assert.Equal(t, want, result.Servers[0].Server)
+	assert.Equal(t, "https://internal.example.com", original["env"].(map[string]interface{})["API_BASE"], "original config untouched")
+}
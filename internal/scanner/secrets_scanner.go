@@ -13,17 +13,19 @@ type secretScanContext struct {
 	redactor            Redactor
 	currentServer       string
 	originalFileContent []byte
+	hash                HashFunc
 }
 
-func newSecretScanContext(filePath string, fileContent []byte) *secretScanContext {
+func newSecretScanContext(filePath string, fileContent []byte, entropyThreshold float64, hash HashFunc) *secretScanContext {
 	return &secretScanContext{
 		filePath:            filePath,
 		fileContent:         fileContent,
 		findings:            NewFindingSet(),
-		detector:            defaultDetector{},
+		detector:            defaultDetector{entropyThreshold: entropyThreshold},
 		redactor:            redactSecret,
 		currentServer:       "",
 		originalFileContent: append([]byte(nil), fileContent...),
+		hash:                hash,
 	}
 }
 
@@ -46,6 +46,44 @@ func TestScanSecuritySecretsConfig_Findings(t *testing.T) {
 	assertFinding("hyperbrowser", "Generic Secret", 48)
 }
 
+// TestScanSecretsFirebase_Findings verifies Firebase and Cloudflare keys are reported with
+// HIGH confidence, and that a hex commit SHA is not taken for a Cloudflare key.
+func TestScanSecretsFirebase_Findings(t *testing.T) {
+	testPath := filepath.Join("..", "..", "testdata", "test_secrets_firebase.json")
+
+	s := NewMCPScanner(nil, "")
+	if _, err := s.ParseMCPConfigFile(testPath); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	byKey := map[string]SecretFinding{}
+	for _, f := range s.ScanResult.SecretFindings {
+		byKey[f.Key] = f
+	}
+	for key, want := range map[string]struct {
+		server, kind string
+		line         int
+	}{
+		"env.FIREBASE_API_KEY":     {"firebase", "Firebase API Key", 7},
+		"env.CF_API_KEY":           {"cloudflare", "Cloudflare API Key", 14},
+		"env.CLOUDFLARE_API_TOKEN": {"cloudflare", "Cloudflare API Key", 15},
+	} {
+		f, ok := byKey[key]
+		if !ok {
+			t.Fatalf("missing finding for %s in %#v", key, s.ScanResult.SecretFindings)
+		}
+		if f.ServerName != want.server || f.Kind != want.kind || f.Confidence != ConfidenceHigh {
+			t.Errorf("%s: got %s/%s (%s), want %s/%s (HIGH)", key, f.ServerName, f.Kind, f.Confidence, want.server, want.kind)
+		}
+		if !hasOccurrenceForLine(f.Occurrences, want.line) {
+			t.Errorf("%s: expected line %d, got %v", key, want.line, f.Occurrences)
+		}
+	}
+	if f, ok := byKey["env.BUILD_SHA"]; ok && f.Kind == "Cloudflare API Key" {
+		t.Errorf("commit SHA classified as a Cloudflare key")
+	}
+}
+
 func hasOccurrenceForLine(occ map[string][]int, line int) bool {
 	if line <= 0 {
 		return false
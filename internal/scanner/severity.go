@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ensigniasec/run-mcp/internal/metrics"
+)
+
+// Severity tiers in ascending order, matching riskTierFromScore.
+//
+//nolint:gochecknoglobals // Fixed lookup table.
+var severityRank = map[string]int{
+	"NONE":     0,
+	"LOW":      1,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
+// ParseSeverity normalizes a user-supplied severity (low, medium, high, critical).
+func ParseSeverity(s string) (string, error) {
+	tier := strings.ToUpper(strings.TrimSpace(s))
+	if rank, ok := severityRank[tier]; !ok || rank == 0 {
+		return "", fmt.Errorf("invalid severity %q: must be one of low, medium, high, critical", s)
+	}
+	return tier, nil
+}
+
+// RiskTier returns the tier of a 0-10 risk score: CRITICAL, HIGH, MEDIUM, LOW or NONE.
+func RiskTier(score float64) string {
+	return riskTierFromScore(score)
+}
+
+// HasFindingsAtOrAbove reports whether any rated server's risk tier, or any misconfiguration
+// finding's severity, is at least severity. Servers explicitly allowed by local policy are
+// ignored.
+func HasFindingsAtOrAbove(summary ScanSummary, severity string) bool {
+	threshold, ok := severityRank[severity]
+	if !ok {
+		return false
+	}
+	for _, s := range summary.Servers {
+		if s.Rating == nil || s.LocalPolicy == "allowed" {
+			continue
+		}
+		tier := riskTierFromScore(s.Rating.RiskScore)
+		if severityRank[tier] > 0 && severityRank[tier] >= threshold {
+			return true
+		}
+	}
+	for _, m := range summary.MisconfigFindings {
+		if rank := severityRank[m.Severity]; rank > 0 && rank >= threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// ServerTierCounts counts reported servers by lower-case risk tier, as used by the
+// run_mcp_servers_total metric. Unrated servers are counted as discovered and rated servers
+// without any risk as low.
+func ServerTierCounts(summary ScanSummary) map[string]int {
+	counts := make(map[string]int)
+	for _, s := range summary.Servers {
+		if s.Rating == nil {
+			counts[metrics.TierDiscovered]++
+			continue
+		}
+		tier := riskTierFromScore(s.Rating.RiskScore)
+		if tier == "NONE" {
+			tier = "LOW"
+		}
+		counts[strings.ToLower(tier)]++
+	}
+	return counts
+}
+
+// SeverityCounts is the number of rated servers and misconfiguration findings per risk tier
+// and of secret findings, as printed by scan --severity-counts-only. Servers allowed by local
+// policy are left out, as in HasFindingsAtOrAbove, and rated servers without any risk count
+// as low.
+type SeverityCounts struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Secrets  int `json:"secrets"`
+}
+
+// NewSeverityCounts counts the findings of summary.
+func NewSeverityCounts(summary ScanSummary) SeverityCounts {
+	counts := SeverityCounts{Secrets: len(summary.Secrets)}
+	for _, s := range summary.Servers {
+		if s.Rating == nil || s.LocalPolicy == "allowed" {
+			continue
+		}
+		counts.add(riskTierFromScore(s.Rating.RiskScore))
+	}
+	for _, m := range summary.MisconfigFindings {
+		counts.add(m.Severity)
+	}
+	return counts
+}
+
+// add counts one finding of the given tier; anything below MEDIUM counts as low.
+func (c *SeverityCounts) add(tier string) {
+	switch tier {
+	case "CRITICAL":
+		c.Critical++
+	case "HIGH":
+		c.High++
+	case "MEDIUM":
+		c.Medium++
+	default:
+		c.Low++
+	}
+}
+
+// String returns the counts as a single line, e.g. "CRITICAL=2 HIGH=1 MEDIUM=0 LOW=3 SECRETS=1".
+func (c SeverityCounts) String() string {
+	return fmt.Sprintf("CRITICAL=%d HIGH=%d MEDIUM=%d LOW=%d SECRETS=%d", c.Critical, c.High, c.Medium, c.Low, c.Secrets)
+}
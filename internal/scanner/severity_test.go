@@ -0,0 +1,70 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverity(t *testing.T) {
+	tier, err := ParseSeverity(" High ")
+	require.NoError(t, err)
+	assert.Equal(t, "HIGH", tier)
+
+	_, err = ParseSeverity("none")
+	require.Error(t, err)
+	_, err = ParseSeverity("urgent")
+	require.Error(t, err)
+}
+
+func TestHasFindingsAtOrAbove(t *testing.T) {
+	summary := ScanSummary{Servers: []ServerReport{
+		{Name: "unrated"},
+		{Name: "medium", Rating: &SecurityRating{RiskScore: 5}},
+		{Name: "critical-but-allowed", LocalPolicy: "allowed", Rating: &SecurityRating{RiskScore: 9.5}},
+	}}
+
+	assert.True(t, HasFindingsAtOrAbove(summary, "LOW"))
+	assert.True(t, HasFindingsAtOrAbove(summary, "MEDIUM"))
+	assert.False(t, HasFindingsAtOrAbove(summary, "HIGH"))
+	assert.False(t, HasFindingsAtOrAbove(ScanSummary{}, "LOW"))
+
+	misconfigs := ScanSummary{MisconfigFindings: []MisconfigFinding{{ServerName: "docker", Rule: MisconfigHostNetwork, Severity: "HIGH"}}}
+	assert.True(t, HasFindingsAtOrAbove(misconfigs, "HIGH"))
+	assert.False(t, HasFindingsAtOrAbove(misconfigs, "CRITICAL"))
+}
+
+func TestServerTierCounts(t *testing.T) {
+	summary := ScanSummary{Servers: []ServerReport{
+		{Name: "a", Rating: &SecurityRating{RiskScore: 9.5}},
+		{Name: "b", Rating: &SecurityRating{RiskScore: 7.1}},
+		{Name: "c", Rating: &SecurityRating{RiskScore: 0}},
+		{Name: "d"},
+		{Name: "e"},
+	}}
+	assert.Equal(t, map[string]int{"critical": 1, "high": 1, "low": 1, "discovered": 2}, ServerTierCounts(summary))
+}
+
+func TestNewSeverityCounts(t *testing.T) {
+	summary := ScanSummary{
+		Servers: []ServerReport{
+			{Name: "a", Rating: &SecurityRating{RiskScore: 9.5}},
+			{Name: "b", Rating: &SecurityRating{RiskScore: 9.1}},
+			{Name: "c", Rating: &SecurityRating{RiskScore: 7.1}},
+			{Name: "d", Rating: &SecurityRating{RiskScore: 0}},
+			{Name: "unrated"},
+			{Name: "allowed", LocalPolicy: "allowed", Rating: &SecurityRating{RiskScore: 9.5}},
+		},
+		Secrets: []SecretFinding{{Kind: "GitHub Token"}},
+	}
+	counts := NewSeverityCounts(summary)
+	assert.Equal(t, SeverityCounts{Critical: 2, High: 1, Low: 1, Secrets: 1}, counts)
+	assert.Equal(t, "CRITICAL=2 HIGH=1 MEDIUM=0 LOW=1 SECRETS=1", counts.String())
+
+	summary.MisconfigFindings = []MisconfigFinding{
+		{ServerName: "docker", Rule: MisconfigPrivileged, Severity: "CRITICAL"},
+		{ServerName: "shell", Rule: MisconfigShellCommand, Severity: "MEDIUM"},
+	}
+	assert.Equal(t, SeverityCounts{Critical: 3, High: 1, Medium: 1, Low: 1, Secrets: 1}, NewSeverityCounts(summary))
+}
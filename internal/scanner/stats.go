@@ -0,0 +1,135 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// Phases timed in StatsReport.PhaseTimings. Discovery overlaps the scan, since files are
+// scanned as soon as they are found.
+const (
+	PhaseDiscover = "discover"
+	PhaseScan     = "scan"
+	PhaseRatings  = "ratings"
+	PhaseReport   = "report"
+)
+
+// StatsReport breaks a scan down for scan --stats: what happened to each discovered file,
+// what was sent to and received from the ratings API, and how long each phase took.
+type StatsReport struct {
+	FilesAttempted       int                      `json:"files_attempted"`
+	FilesScanned         int                      `json:"files_scanned"`
+	FilesSkipped         int                      `json:"files_skipped"`
+	FilesError           int                      `json:"files_error"`
+	ServersFound         int                      `json:"servers_found"`
+	IdentifiersExtracted int                      `json:"identifiers_extracted"`
+	BatchesSubmitted     int                      `json:"batches_submitted"`
+	RatingsReceived      int                      `json:"ratings_received"`
+	SecretKinds          map[string]int           `json:"secret_kinds"`
+	PhaseTimings         map[string]time.Duration `json:"phase_timings"`
+}
+
+// scanStats holds the file counters of one MCPScanner.Scan. Workers update them atomically.
+type scanStats struct {
+	attempted atomic.Int64
+	skipped   atomic.Int64
+	errored   atomic.Int64
+	discover  time.Duration
+}
+
+// collectorStats holds the counters of a RatingsCollector.
+type collectorStats struct {
+	identifiers atomic.Int64
+	batches     atomic.Int64
+	ratings     atomic.Int64
+	// failed counts batches whose submission or poll failed; pending counts running polls.
+	failed  atomic.Int64
+	pending atomic.Int64
+}
+
+// Stats reports the file, server and secret counts and the phase timings of the last Scan.
+func (s *MCPScanner) Stats() StatsReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := StatsReport{
+		FilesAttempted: int(s.stats.attempted.Load()),
+		FilesScanned:   len(s.ScanResult.Files),
+		FilesSkipped:   int(s.stats.skipped.Load()),
+		FilesError:     int(s.stats.errored.Load()),
+		ServersFound:   len(s.ScanResult.Servers),
+		SecretKinds:    make(map[string]int),
+		PhaseTimings: map[string]time.Duration{
+			PhaseDiscover: s.stats.discover,
+			PhaseScan:     s.ScanResult.Duration,
+		},
+	}
+	for _, f := range s.ScanResult.SecretFindings {
+		report.SecretKinds[f.Kind]++
+	}
+	return report
+}
+
+// ApplyStats adds the identifiers, batches and ratings counted by the collector to report.
+func (rc *RatingsCollector) ApplyStats(report *StatsReport) {
+	if rc == nil || report == nil {
+		return
+	}
+	report.IdentifiersExtracted += int(rc.stats.identifiers.Load())
+	report.BatchesSubmitted += int(rc.stats.batches.Load())
+	report.RatingsReceived += int(rc.stats.ratings.Load())
+}
+
+// WriteStats writes report as indented JSON, or as text with secret kinds and phases sorted
+// by name.
+func WriteStats(w io.Writer, report StatsReport, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	rows := []struct {
+		label string
+		value int
+	}{
+		{"Files attempted", report.FilesAttempted},
+		{"Files scanned", report.FilesScanned},
+		{"Files skipped", report.FilesSkipped},
+		{"Files with errors", report.FilesError},
+		{"Servers found", report.ServersFound},
+		{"Identifiers extracted", report.IdentifiersExtracted},
+		{"Batches submitted", report.BatchesSubmitted},
+		{"Ratings received", report.RatingsReceived},
+	}
+	if _, err := fmt.Fprintln(w, "Scan statistics:"); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if _, err := fmt.Fprintf(w, "  %-22s %d\n", r.label+":", r.value); err != nil {
+			return err
+		}
+	}
+	if len(report.SecretKinds) > 0 {
+		if _, err := fmt.Fprintln(w, "  Secrets by kind:"); err != nil {
+			return err
+		}
+	}
+	for _, kind := range slices.Sorted(maps.Keys(report.SecretKinds)) {
+		if _, err := fmt.Fprintf(w, "    %s: %d\n", kind, report.SecretKinds[kind]); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w, "  Phase timings:"); err != nil {
+		return err
+	}
+	for _, phase := range slices.Sorted(maps.Keys(report.PhaseTimings)) {
+		if _, err := fmt.Fprintf(w, "    %s: %s\n", phase, HumanDuration(report.PhaseTimings[phase])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner_Stats(t *testing.T) {
+	claude := filepath.Join("..", "..", "testdata", "claude_desktop_config.json")
+	secrets := filepath.Join("..", "..", "testdata", "test_secrets_config.json")
+	missing := filepath.Join(t.TempDir(), "missing.json")
+
+	client := &recordingClient{}
+	rc := NewRatingsCollector(context.Background(), client, nil)
+	s := NewMCPScanner([]string{claude, secrets}, "").
+		WithRatingsCollector(rc).
+		WithFileFilter(nil, []string{"claude_*"})
+	result, err := s.Scan()
+	require.NoError(t, err)
+	rc.FlushAndStop()
+	// processFile counts a file that disappears after discovery as an error.
+	require.Nil(t, s.processFile(context.Background(), missing))
+
+	stats := s.Stats()
+	rc.ApplyStats(&stats)
+	assert.Equal(t, 3, stats.FilesAttempted)
+	assert.Equal(t, 1, stats.FilesScanned)
+	assert.Equal(t, 1, stats.FilesSkipped)
+	assert.Equal(t, 1, stats.FilesError)
+	assert.Equal(t, len(result.Servers), stats.ServersFound)
+	assert.GreaterOrEqual(t, stats.IdentifiersExtracted, len(client.submitted), "identifiers shared by servers are submitted once")
+	assert.Positive(t, stats.BatchesSubmitted)
+	assert.Equal(t, len(client.submitted), stats.RatingsReceived, "every identifier is answered")
+	assert.Equal(t, 2, stats.SecretKinds["OpenAI API Key"])
+	assert.Contains(t, stats.PhaseTimings, PhaseScan)
+	assert.Contains(t, stats.PhaseTimings, PhaseDiscover)
+
+	// A new scan starts from zero.
+	_, err = s.Scan()
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.Stats().FilesAttempted)
+}
+
+func TestWriteStats(t *testing.T) {
+	report := StatsReport{
+		FilesAttempted: 2,
+		FilesScanned:   1,
+		SecretKinds:    map[string]int{"OpenAI API Key": 2, "GitHub Token": 1},
+		PhaseTimings:   map[string]time.Duration{PhaseScan: 1500 * time.Millisecond, PhaseDiscover: 3 * time.Millisecond},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteStats(&buf, report, false))
+	assert.Contains(t, buf.String(), "  Files attempted:       2\n")
+	assert.Contains(t, buf.String(), "  Secrets by kind:\n    GitHub Token: 1\n    OpenAI API Key: 2\n")
+	assert.Contains(t, buf.String(), "  Phase timings:\n    discover: 3ms\n    scan: 1.50s\n")
+
+	buf.Reset()
+	require.NoError(t, WriteStats(&buf, report, true))
+	var decoded StatsReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report, decoded)
+}
@@ -1,17 +1,23 @@
 package scanner
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 // ScanSummary provides a high-level summary of scan results.
 type ScanSummary struct {
 	Servers          []ServerReport  `json:"Servers"`
 	Secrets          []SecretFinding `json:"Secrets"`
+	Suppressed       []ServerReport  `json:"Suppressed,omitempty"`
 	TotalServers     int             `json:"TotalServers"`
 	TotalFindings    int             `json:"TotalFindings"`
 	CriticalFindings int             `json:"CriticalFindings"`
@@ -21,6 +27,22 @@ type ScanSummary struct {
 	StartedAt        time.Time       `json:"StartedAt"`
 	Duration         time.Duration   `json:"Duration"`
 	ScannedFiles     int             `json:"ScannedFiles"`
+	// Environment is an informational tag (e.g. "staging") set with scan --environment.
+	Environment string `json:"Environment,omitempty"`
+	// Labels are arbitrary key=value metadata set with scan --label.
+	Labels map[string]string `json:"Labels,omitempty"`
+	// HashAlgorithm is the digest of the server fingerprints and secret value hashes, set
+	// with scan --hash-algorithm.
+	HashAlgorithm string `json:"HashAlgorithm,omitempty"`
+	// MisconfigFindings lists dangerous launch settings of the reported servers.
+	MisconfigFindings []MisconfigFinding `json:"MisconfigFindings,omitempty"`
+	// ProfileReport lists the scan time of each file, slowest first; set with scan --profile.
+	ProfileReport []FileProfile `json:"ProfileReport,omitempty"`
+	// SuppressedErrors counts the missing or unreadable files skipped with
+	// scan --suppress-file-errors.
+	SuppressedErrors int `json:"SuppressedErrors,omitempty"`
+	// SkippedServers lists the servers left out with scan --skip-server, once per name.
+	SkippedServers []string `json:"SkippedServers,omitempty"`
 }
 
 func NewScanSummary(result ScanResult) ScanSummary {
@@ -30,9 +52,31 @@ func NewScanSummary(result ScanResult) ScanSummary {
 	summary.StartedAt = result.StartedAt
 	summary.Duration = result.Duration
 	summary.ScannedFiles = len(result.Files)
+	summary.SuppressedErrors = result.SuppressedErrors
 	return *summary
 }
 
+// ParseLabels parses key=value pairs, as given to scan --label, into a map. Keys must be
+// non-empty, neither part may contain a newline, and a repeated key keeps its last value.
+func ParseLabels(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label %q: want key=value", pair)
+		}
+		if strings.ContainsAny(pair, "\r\n") {
+			return nil, fmt.Errorf("invalid label %q: must not contain newlines", pair)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
 func NewServerReport(name string, path string, secrets []SecretFinding, localPolicy string) ServerReport {
 	sr := new(ServerReport)
 	sr.Name = name
@@ -43,65 +87,285 @@ func NewServerReport(name string, path string, secrets []SecretFinding, localPol
 }
 
 // GenerateSummary analyzes a single aggregated scan result and creates a summary.
-func GenerateSummary(result ScanResult) ScanSummary {
+// Servers matching a non-expired suppression are moved to Suppressed along with their secrets.
+// Servers with a canonical name (see WithNormalizedServerNames) are reported once: later
+// servers with the same canonical name add their secrets to the first one.
+func GenerateSummary(result ScanResult, suppressions ...Suppression) ScanSummary {
 	summary := NewScanSummary(result)
+	now := time.Now()
+	// byCanonicalName indexes summary.Servers by canonical name for deduplication.
+	byCanonicalName := make(map[string]int)
 
 	for _, file := range result.Files {
+		for _, name := range file.SkippedServers {
+			if !slices.Contains(summary.SkippedServers, name) {
+				summary.SkippedServers = append(summary.SkippedServers, name)
+			}
+		}
 		// Index secrets by server name for this file.
 		secretsByName := make(map[string][]SecretFinding)
 		for _, s := range file.SecretFindings {
-			// Collect for global secrets section.
-			summary.Secrets = append(summary.Secrets, s)
 			// Associate to server for per-server context (not used for risk grouping).
 			secretsByName[s.ServerName] = append(secretsByName[s.ServerName], s)
-			// Count severity now.
-			summary.TotalFindings++
 		}
+		suppressed := make(map[string]struct{})
 		for _, server := range file.Servers {
-			summary.TotalServers++
 			sr := ServerReport{
-				Name:        server.Name,
-				Path:        file.Path,
-				Secrets:     secretsByName[server.Name],
-				LocalPolicy: "", // TODO: figure out how this gets applied
-				Rating:      nil,
+				Name:          server.Name,
+				CanonicalName: server.CanonicalName,
+				Path:          file.Path,
+				Secrets:       secretsByName[server.Name],
+				LocalPolicy:   "", // TODO: figure out how this gets applied
+				Rating:        nil,
+				Category:      ServerCategory(file.Path, server.Name, server.Server),
+				Fingerprint:   server.Fingerprint,
+			}
+			if i, ok := byCanonicalName[sr.CanonicalName]; ok && sr.CanonicalName != "" {
+				summary.Servers[i].Secrets = append(summary.Servers[i].Secrets, sr.Secrets...)
+				continue
+			}
+			summary.TotalServers++
+			if sup, ok := findSuppression(suppressions, sr, now); ok {
+				sr.SuppressionReason = sup.Reason
+				summary.Suppressed = append(summary.Suppressed, sr)
+				suppressed[server.Name] = struct{}{}
+				delete(secretsByName, server.Name)
+				continue
+			}
+			if sr.CanonicalName != "" {
+				byCanonicalName[sr.CanonicalName] = len(summary.Servers)
 			}
 			summary.Servers = append(summary.Servers, sr)
 		}
+		for _, m := range file.MisconfigFindings {
+			if _, ok := suppressed[m.ServerName]; ok {
+				continue
+			}
+			summary.MisconfigFindings = append(summary.MisconfigFindings, m)
+		}
+		for _, s := range file.SecretFindings {
+			if _, ok := secretsByName[s.ServerName]; !ok {
+				continue // belongs to a suppressed server
+			}
+			// Collect for global secrets section.
+			summary.Secrets = append(summary.Secrets, s)
+			// Count severity now.
+			summary.TotalFindings++
+		}
 	}
 
 	return summary
 }
 
+// sortServers orders servers in place by SortByName, SortByRisk (highest risk score first)
+// or SortByPath, breaking ties by the other keys. Any other order leaves servers unchanged.
+func sortServers(servers []ServerReport, order string) {
+	riskScore := func(s ServerReport) float64 {
+		if s.Rating == nil {
+			return -1
+		}
+		return s.Rating.RiskScore
+	}
+	byName := func(a, b ServerReport) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.Path, b.Path))
+	}
+	switch order {
+	case SortByName:
+		slices.SortStableFunc(servers, byName)
+	case SortByRisk:
+		slices.SortStableFunc(servers, func(a, b ServerReport) int {
+			return cmp.Or(cmp.Compare(riskScore(b), riskScore(a)), byName(a, b))
+		})
+	case SortByPath:
+		slices.SortStableFunc(servers, func(a, b ServerReport) int {
+			return cmp.Or(strings.Compare(a.Path, b.Path), strings.Compare(a.Name, b.Name))
+		})
+	}
+}
+
+// firstPage returns the first perPage servers, or all of them when perPage is 0.
+func firstPage(servers []ServerReport, perPage int) []ServerReport {
+	if perPage <= 0 || len(servers) <= perPage {
+		return servers
+	}
+	return servers[:perPage]
+}
+
+// writePageNote tells how many of total servers a section listed when firstPage cut it short.
+func writePageNote(w io.Writer, total, perPage int) {
+	if perPage <= 0 || total <= perPage {
+		return
+	}
+	fmt.Fprintf(w, "\n(%d of %d shown — use --json for full output)\n", perPage, total)
+}
+
+// findSuppression returns the first non-expired suppression matching server.
+// Matching entries that have expired are reported as warnings.
+func findSuppression(suppressions []Suppression, server ServerReport, now time.Time) (Suppression, bool) {
+	for _, sup := range suppressions {
+		if !sup.matches(server) {
+			continue
+		}
+		if sup.Expired(now) {
+			logrus.Warnf("Suppression for server %q expired on %s; reporting its findings again", sup.Server, sup.Expires)
+			continue
+		}
+		return sup, true
+	}
+	return Suppression{}, false
+}
+
+// colorEnabled controls the ANSI colour of text output: the banner and the WriteScanDiff
+// highlights. The CLI clears it with SetColorEnabled before rendering.
+//
+//nolint:gochecknoglobals // Set once per command, before any output.
+var colorEnabled = true
+
+// SetColorEnabled turns ANSI colour in text output on or off, e.g. for --no-color.
+func SetColorEnabled(enabled bool) {
+	colorEnabled = enabled
+}
+
+// Orders accepted by TextLayout.SortBy for the servers within a risk tier.
+const (
+	SortByName = "name"
+	SortByRisk = "risk"
+	SortByPath = "path"
+)
+
+// ParseSortOrder validates a --sort value. The empty string keeps the order servers were found in.
+func ParseSortOrder(s string) (string, error) {
+	order := strings.ToLower(strings.TrimSpace(s))
+	switch order {
+	case "", SortByName, SortByRisk, SortByPath:
+		return order, nil
+	}
+	return "", fmt.Errorf("invalid sort order %q: must be one of name, risk, path", s)
+}
+
+// TextLayout controls how the risk tier sections of text output are rendered.
+type TextLayout struct {
+	// FindingsPerPage caps the servers listed in each risk tier section; 0 lists them all.
+	FindingsPerPage int
+	// SortBy orders the servers within a tier: SortByName, SortByRisk (highest first) or
+	// SortByPath. Empty keeps the scan order.
+	SortBy string
+}
+
+// textLayout is the layout of PrintSummary text output. The CLI sets it with SetTextLayout
+// before rendering.
+//
+//nolint:gochecknoglobals // Set once per command, before any output.
+var textLayout TextLayout
+
+// SetTextLayout sets the layout of PrintSummary text output, e.g. for --findings-per-page.
+// Reports written with WriteSummary are sorted the same way but never truncated.
+func SetTextLayout(layout TextLayout) {
+	textLayout = layout
+}
+
+// Output formats accepted by WriteSummary.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
 // PrintSummary outputs the results in the requested format.
 // If jsonOutput is true, it prints machine-readable JSON of the full results.
-// Otherwise, it prints a human-readable summary with ratings and recommendations.
-//
-//nolint:gocognit,gocyclo,cyclop,funlen // Verbose CLI rendering for readability; refactor deferred.
-func PrintSummary(summary ScanSummary, jsonOutput bool) {
+// Otherwise, it prints a human-readable summary with ratings and recommendations,
+// preceded by the ANSI banner unless noBanner is set.
+func PrintSummary(summary ScanSummary, jsonOutput bool, noBanner bool) {
 	if jsonOutput {
-		output, err := json.MarshalIndent(summary, "", "  ")
-		if err != nil {
+		if err := writeJSONSummary(os.Stdout, summary); err != nil {
 			fmt.Fprintln(os.Stderr, err)
-			return
 		}
-		fmt.Fprintln(os.Stdout, string(output))
 		return
 	}
+	fmt.Fprint(os.Stdout, Banner(noBanner))
+	writeTextSummary(os.Stdout, summary, textLayout)
+}
+
+// WriteSummary renders the summary to w in the given format (FormatJSON or FormatText).
+// Unlike PrintSummary, the text format omits the ANSI banner so it is suitable for files.
+func WriteSummary(w io.Writer, summary ScanSummary, format string) error {
+	switch format {
+	case FormatJSON:
+		return writeJSONSummary(w, summary)
+	case FormatText:
+		writeTextSummary(w, summary, TextLayout{SortBy: textLayout.SortBy})
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q (want %s or %s)", format, FormatJSON, FormatText)
+	}
+}
+
+func writeJSONSummary(w io.Writer, summary ScanSummary) error {
+	output, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// WriteVerboseJSON renders the full scan result, including every parsed server config, as
+// indented JSON. Secret findings keep their ValueHash but drop the (already redacted) value.
+// The shape follows ScanResult directly and is not a stable interface.
+func WriteVerboseJSON(w io.Writer, result ScanResult) error {
+	result.SecretFindings = withoutSecretValues(result.SecretFindings)
+	files := make([]FileResult, len(result.Files))
+	for i, f := range result.Files {
+		f.SecretFindings = withoutSecretValues(f.SecretFindings)
+		files[i] = f
+	}
+	result.Files = files
+
+	output, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
 
-	printRunMCPBanner()
+// withoutSecretValues returns a copy of findings with Value cleared.
+func withoutSecretValues(findings []SecretFinding) []SecretFinding {
+	if findings == nil {
+		return nil
+	}
+	out := make([]SecretFinding, len(findings))
+	for i, f := range findings {
+		f.Value = ""
+		out[i] = f
+	}
+	return out
+}
 
-	fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
-	fmt.Fprintln(os.Stdout, "RUN-MCP SCAN REPORT")
-	fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
-	fmt.Fprintf(os.Stdout, "Scan Time: %s\n", summary.StartedAt.Format("2006-01-02 15:04:05 MST"))
+// writeTextSummary prints a human-readable summary with ratings and recommendations. The risk
+// tier sections are sorted and truncated according to layout.
+//
+//nolint:gocognit,gocyclo,cyclop,funlen // Verbose CLI rendering for readability; refactor deferred.
+func writeTextSummary(w io.Writer, summary ScanSummary, layout TextLayout) {
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	fmt.Fprintln(w, "RUN-MCP SCAN REPORT")
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	fmt.Fprintf(w, "Scan Time: %s\n", summary.StartedAt.Format("2006-01-02 15:04:05 MST"))
+	if summary.Environment != "" {
+		fmt.Fprintf(w, "Environment: %s\n", summary.Environment)
+	}
 	fmt.Fprintf(
-		os.Stdout,
+		w,
 		"Scanned: %d files, %d servers detected (duration: %s)\n",
 		summary.ScannedFiles,
 		summary.TotalServers,
 		HumanDuration(summary.Duration),
 	)
+	if summary.SuppressedErrors > 0 {
+		fmt.Fprintf(w, "Skipped: %d missing or unreadable files\n", summary.SuppressedErrors)
+	}
+	if len(summary.SkippedServers) > 0 {
+		fmt.Fprintf(w, "Skipped servers: %s\n", strings.Join(summary.SkippedServers, ", "))
+	}
 
 	// Group servers by status and risk tiers.
 	critical, high, medium, low := []ServerReport{}, []ServerReport{}, []ServerReport{}, []ServerReport{}
@@ -137,184 +401,255 @@ func PrintSummary(summary ScanSummary, jsonOutput bool) {
 		// Not rated and no explicit policy => discovered (not submitted/unknown).
 		discovered = append(discovered, s)
 	}
+	for _, tier := range [][]ServerReport{critical, high, medium, low} {
+		sortServers(tier, layout.SortBy)
+	}
 
 	// Risk summary (computed from current buckets).
-	fmt.Fprintf(os.Stdout, "\n📊 RISK SUMMARY\n")
-	fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+	fmt.Fprintf(w, "\n📊 RISK SUMMARY\n")
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 	if len(critical) > 0 {
-		fmt.Fprintf(os.Stdout, "   🔴 Critical Risk : %d servers\n", len(critical))
+		fmt.Fprintf(w, "   🔴 Critical Risk : %d servers\n", len(critical))
 	}
 	if len(high) > 0 {
-		fmt.Fprintf(os.Stdout, "   🟠 High Risk     : %d servers\n", len(high))
+		fmt.Fprintf(w, "   🟠 High Risk     : %d servers\n", len(high))
 	}
 	if len(medium) > 0 {
-		fmt.Fprintf(os.Stdout, "   🟡 Medium Risk   : %d servers\n", len(medium))
+		fmt.Fprintf(w, "   🟡 Medium Risk   : %d servers\n", len(medium))
 	}
 	if len(low) > 0 {
-		fmt.Fprintf(os.Stdout, "   🟢 Low Risk      : %d servers\n", len(low))
+		fmt.Fprintf(w, "   🟢 Low Risk      : %d servers\n", len(low))
 	}
 	if len(pending) > 0 {
-		fmt.Fprintf(os.Stdout, "   ⏳ Pending       : %d servers\n", len(pending))
+		fmt.Fprintf(w, "   ⏳ Pending       : %d servers\n", len(pending))
 	}
 	if len(discovered) > 0 {
-		fmt.Fprintf(os.Stdout, "   🔎 Discovered    : %d servers\n", len(discovered))
+		fmt.Fprintf(w, "   🔎 Discovered    : %d servers\n", len(discovered))
 	}
 	if len(allowed) > 0 {
-		fmt.Fprintf(os.Stdout, "   ✅ Allowed       : %d servers\n", len(allowed))
+		fmt.Fprintf(w, "   ✅ Allowed       : %d servers\n", len(allowed))
 	}
 	if len(denied) > 0 {
-		fmt.Fprintf(os.Stdout, "   ⛔ Denied        : %d servers\n", len(denied))
+		fmt.Fprintf(w, "   ⛔ Denied        : %d servers\n", len(denied))
+	}
+	if len(summary.Suppressed) > 0 {
+		fmt.Fprintf(w, "   🙈 Suppressed    : %d servers\n", len(summary.Suppressed))
 	}
 	if len(summary.Secrets) > 0 {
-		fmt.Fprintf(os.Stdout, "   ☢️ Exposed secrets: %d\n", len(summary.Secrets))
+		fmt.Fprintf(w, "   ☢️ Exposed secrets: %d\n", len(summary.Secrets))
 	}
 
 	// Print Critical
 	if len(critical) > 0 {
-		fmt.Fprintf(os.Stdout, "\n🚨 CRITICAL FINDINGS\n")
-		fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+		fmt.Fprintf(w, "\n🚨 CRITICAL FINDINGS\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 		count := 1
-		for _, server := range critical {
-			fmt.Fprintf(os.Stdout, "\n[%d] Server: \"%s\" (%s)\n", count, server.Name, server.Path)
+		for _, server := range firstPage(critical, layout.FindingsPerPage) {
+			writeServerHeading(w, count, server)
 			if server.Rating != nil {
 				fmt.Fprintf(
-					os.Stdout,
+					w,
 					"    Risk Score: %.1f/10 - %s\n",
 					server.Rating.RiskScore,
 					server.Rating.Category,
 				)
 				if server.Rating.Version != "" {
-					fmt.Fprintf(os.Stdout, "    Source: %s@%s\n", server.Rating.Name, server.Rating.Version)
+					fmt.Fprintf(w, "    Source: %s@%s\n", server.Rating.Name, server.Rating.Version)
 				}
 				if len(server.Rating.Vulnerabilities) > 0 {
-					fmt.Fprintf(os.Stdout, "    \n    ⚠️  Detected Issues:\n")
+					fmt.Fprintf(w, "    \n    ⚠️  Detected Issues:\n")
 					for _, vuln := range server.Rating.Vulnerabilities {
-						fmt.Fprintf(os.Stdout, "    • %s\n", vuln)
+						fmt.Fprintf(w, "    • %s\n", vuln)
 					}
 				}
 			}
+			writeExplanation(w, "    ", server.Explanation, server.Remediation)
+			writeAnnotation(w, "    ", server.Annotation)
 			count++
 		}
+		writePageNote(w, len(critical), layout.FindingsPerPage)
 	}
 
 	// High
 	if len(high) > 0 {
-		fmt.Fprintf(os.Stdout, "\n🟠 HIGH RISK FINDINGS\n")
-		fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+		fmt.Fprintf(w, "\n🟠 HIGH RISK FINDINGS\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 		count := 1
-		for _, server := range high {
-			fmt.Fprintf(os.Stdout, "\n[%d] Server: \"%s\" (%s)\n", count, server.Name, server.Path)
+		for _, server := range firstPage(high, layout.FindingsPerPage) {
+			writeServerHeading(w, count, server)
 			if server.Rating != nil {
 				fmt.Fprintf(
-					os.Stdout,
+					w,
 					"    Risk Score: %.1f/10 - %s\n",
 					server.Rating.RiskScore,
 					server.Rating.Category,
 				)
 				if server.Rating.Version != "" {
-					fmt.Fprintf(os.Stdout, "    Source: %s@%s\n", server.Rating.Name, server.Rating.Version)
+					fmt.Fprintf(w, "    Source: %s@%s\n", server.Rating.Name, server.Rating.Version)
 				}
 				if len(server.Rating.Vulnerabilities) > 0 {
-					fmt.Fprintf(os.Stdout, "    \n    ⚠️  Detected Issues:\n")
+					fmt.Fprintf(w, "    \n    ⚠️  Detected Issues:\n")
 					for _, vuln := range server.Rating.Vulnerabilities {
-						fmt.Fprintf(os.Stdout, "    • %s\n", vuln)
+						fmt.Fprintf(w, "    • %s\n", vuln)
 					}
 				}
 			}
+			writeExplanation(w, "    ", server.Explanation, server.Remediation)
+			writeAnnotation(w, "    ", server.Annotation)
 			count++
 		}
+		writePageNote(w, len(high), layout.FindingsPerPage)
 	}
 
 	if len(medium) > 0 {
-		fmt.Fprintf(os.Stdout, "\n🟡 MEDIUM RISK FINDINGS\n")
-		fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+		fmt.Fprintf(w, "\n🟡 MEDIUM RISK FINDINGS\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 		count := 1
-		for _, server := range medium {
-			fmt.Fprintf(os.Stdout, "\n[%d] Server: \"%s\" (%s)\n", count, server.Name, server.Path)
+		for _, server := range firstPage(medium, layout.FindingsPerPage) {
+			writeServerHeading(w, count, server)
 			if server.Rating != nil {
 				fmt.Fprintf(
-					os.Stdout,
+					w,
 					"    Risk Score: %.1f/10 - %s\n",
 					server.Rating.RiskScore,
 					server.Rating.Category,
 				)
 			}
+			writeExplanation(w, "    ", server.Explanation, server.Remediation)
+			writeAnnotation(w, "    ", server.Annotation)
 			count++
 		}
+		writePageNote(w, len(medium), layout.FindingsPerPage)
 	}
 
 	// Low
 	if len(low) > 0 {
-		fmt.Fprintf(os.Stdout, "\n🟢 LOW RISK FINDINGS\n")
-		fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+		fmt.Fprintf(w, "\n🟢 LOW RISK FINDINGS\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 		count := 1
-		for _, server := range low {
-			fmt.Fprintf(os.Stdout, "\n[%d] Server: \"%s\" (%s)\n", count, server.Name, server.Path)
+		for _, server := range firstPage(low, layout.FindingsPerPage) {
+			writeServerHeading(w, count, server)
 			if server.Rating != nil {
 				fmt.Fprintf(
-					os.Stdout,
+					w,
 					"    Risk Score: %.1f/10 - %s\n",
 					server.Rating.RiskScore,
 					server.Rating.Category,
 				)
 			}
+			writeExplanation(w, "    ", server.Explanation, server.Remediation)
+			writeAnnotation(w, "    ", server.Annotation)
 			count++
 		}
+		writePageNote(w, len(low), layout.FindingsPerPage)
 	}
 
 	// Allowed servers
 	if len(allowed) > 0 {
-		fmt.Fprintf(os.Stdout, "\n✅ ALLOWED SERVERS\n")
-		fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+		fmt.Fprintf(w, "\n✅ ALLOWED SERVERS\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 		count := 1
 		for _, server := range allowed {
-			fmt.Fprintf(os.Stdout, "\n[%d] Server: \"%s\" (%s)\n", count, server.Name, server.Path)
+			writeServerHeading(w, count, server)
+			writeAnnotation(w, "    ", server.Annotation)
 			count++
 		}
 	}
 
 	// Denied servers
 	if len(denied) > 0 {
-		fmt.Fprintf(os.Stdout, "\n⛔ DENIED SERVERS\n")
-		fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+		fmt.Fprintf(w, "\n⛔ DENIED SERVERS\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 		count := 1
 		for _, server := range denied {
-			fmt.Fprintf(os.Stdout, "\n[%d] Server: \"%s\" (%s)\n", count, server.Name, server.Path)
+			writeServerHeading(w, count, server)
+			writeAnnotation(w, "    ", server.Annotation)
 			count++
 		}
 	}
 
 	// Pending servers
 	if len(pending) > 0 {
-		fmt.Fprintf(os.Stdout, "\n⏳ PENDING RATING\n")
-		fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+		fmt.Fprintf(w, "\n⏳ PENDING RATING\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 		count := 1
 		for _, server := range pending {
-			fmt.Fprintf(os.Stdout, "\n[%d] Server: \"%s\" (%s)\n", count, server.Name, server.Path)
+			writeServerHeading(w, count, server)
+			writeAnnotation(w, "    ", server.Annotation)
 			count++
 		}
 	}
 
 	// Discovered servers
 	if len(discovered) > 0 {
-		fmt.Fprintf(os.Stdout, "\n🔎 DISCOVERED (NOT SUBMITTED - OFFLINE MODE)\n")
-		fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+		fmt.Fprintf(w, "\n🔎 DISCOVERED (NOT SUBMITTED - OFFLINE MODE)\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 		count := 1
 		for _, server := range discovered {
-			fmt.Fprintf(os.Stdout, "\n[%d] Server: \"%s\" (%s)\n", count, server.Name, server.Path)
+			writeServerHeading(w, count, server)
+			writeAnnotation(w, "    ", server.Annotation)
+			count++
+		}
+	}
+
+	// Suppressed servers (accepted risk)
+	if len(summary.Suppressed) > 0 {
+		fmt.Fprintf(w, "\n🙈 SUPPRESSED (ACCEPTED RISK)\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+		count := 1
+		for _, server := range summary.Suppressed {
+			writeServerHeading(w, count, server)
+			if server.SuppressionReason != "" {
+				fmt.Fprintf(w, "    Reason: %s\n", server.SuppressionReason)
+			}
+			writeAnnotation(w, "    ", server.Annotation)
 			count++
 		}
 	}
 
+	// Custom policy findings from --rules-file
+	var custom []ServerReport
+	for _, s := range summary.Servers {
+		if len(s.CustomFindings) > 0 {
+			custom = append(custom, s)
+		}
+	}
+	if len(custom) > 0 {
+		fmt.Fprintf(w, "\n⚙️ CUSTOM POLICY FINDINGS\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+		for i, server := range custom {
+			writeServerHeading(w, i+1, server)
+			for _, f := range server.CustomFindings {
+				if f.Description != "" {
+					fmt.Fprintf(w, "    • [%s] %s: %s\n", f.Severity, f.Rule, f.Description)
+				} else {
+					fmt.Fprintf(w, "    • [%s] %s\n", f.Severity, f.Rule)
+				}
+			}
+		}
+	}
+
+	if len(summary.MisconfigFindings) > 0 {
+		fmt.Fprintf(w, "\n🛠️ LAUNCH MISCONFIGURATIONS\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+		for _, m := range summary.MisconfigFindings {
+			fmt.Fprintf(w, "    • [%s] %s: %s (%s)\n", m.Severity, m.ServerName, m.Description, m.Flag)
+			fmt.Fprintf(w, "      %s\n", m.Path)
+			if m.Hint != "" {
+				fmt.Fprintf(w, "      Hint: %s\n", m.Hint)
+			}
+		}
+	}
+
 	// Exposed secrets (if any)
 	if len(summary.Secrets) > 0 {
-		fmt.Fprintf(os.Stdout, "\n🔐 EXPOSED SECRETS\n")
-		fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+		fmt.Fprintf(w, "\n🔐 EXPOSED SECRETS\n")
+		fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 		for _, s := range summary.Secrets {
 			if s.Key != "" {
-				fmt.Fprintf(os.Stdout, "    • [%s] %s: %s = \"%s\"", s.ServerName, s.Kind, s.Key, s.Value)
+				fmt.Fprintf(w, "    • [%s] %s: %s = \"%s\"", s.ServerName, s.Kind, s.Key, s.Value)
 			} else {
-				fmt.Fprintf(os.Stdout, "    • [%s] %s: \"%s\"", s.ServerName, s.Kind, s.Value)
+				fmt.Fprintf(w, "    • [%s] %s: \"%s\"", s.ServerName, s.Kind, s.Value)
 			}
 			if len(s.Occurrences) > 0 {
 				// Print first file:line and count of the rest.
@@ -341,41 +676,365 @@ func PrintSummary(summary ScanSummary, jsonOutput bool) {
 					break
 				}
 				if extra > 0 {
-					fmt.Fprintf(os.Stdout, " (path: %s +%d more)", shown, extra)
+					fmt.Fprintf(w, " (path: %s +%d more)", shown, extra)
 				} else {
-					fmt.Fprintf(os.Stdout, " (path: %s)", shown)
+					fmt.Fprintf(w, " (path: %s)", shown)
 				}
 			}
-			fmt.Fprintln(os.Stdout)
+			fmt.Fprintln(w)
+			if s.Context != "" {
+				for _, line := range strings.Split(s.Context, "\n") {
+					fmt.Fprintf(w, "        %s\n", line)
+				}
+			}
+			writeExplanation(w, "      ", s.Explanation, s.Remediation)
+			writeAnnotation(w, "      ", s.Annotation)
 		}
 	}
 
+	if len(summary.ProfileReport) > 0 {
+		writeProfile(w, summary.ProfileReport)
+	}
+
 	// Recommendations
-	fmt.Fprintf(os.Stdout, "\n💡 SECURITY RECOMMENDATIONS\n")
-	fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+	fmt.Fprintf(w, "\n💡 SECURITY RECOMMENDATIONS\n")
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
 
 	if (summary.CriticalFindings > 0 || summary.HighFindings > 0) || len(summary.Secrets) > 0 {
-		fmt.Fprintln(os.Stdout, "\nIMMEDIATE ACTIONS:")
+		fmt.Fprintln(w, "\nIMMEDIATE ACTIONS:")
 		if summary.CriticalFindings > 0 {
-			fmt.Fprintf(os.Stdout, "1. Remove %d malicious servers identified above\n", summary.CriticalFindings)
+			fmt.Fprintf(w, "1. Remove %d malicious servers identified above\n", summary.CriticalFindings)
 		}
 		if len(summary.Secrets) > 0 {
-			fmt.Fprintf(os.Stdout, "2. Rotate %d exposed credentials:\n", len(summary.Secrets))
+			fmt.Fprintf(w, "2. Rotate %d exposed credentials:\n", len(summary.Secrets))
 			for _, secret := range summary.Secrets {
-				fmt.Fprintf(os.Stdout, "   - %s (used by %s)\n", secret.Kind, secret.ServerName)
+				fmt.Fprintf(w, "   - %s (used by %s)\n", secret.Kind, secret.ServerName)
 			}
 		}
 	}
-	PrintFooter()
+	printFooter(w)
 }
 
 const reportWidth = 80
 
+// PrintFooter prints the report footer to stdout.
+// writeServerHeading writes the numbered heading of a server entry in the text report,
+// flagging servers whose launch config changed since the previous scan.
+func writeServerHeading(w io.Writer, n int, server ServerReport) {
+	name := "\"" + server.Name + "\""
+	if server.CanonicalName != "" && server.CanonicalName != server.Name {
+		name = fmt.Sprintf("\"%s\" (as \"%s\")", server.CanonicalName, server.Name)
+	}
+	if !server.Changed {
+		fmt.Fprintf(w, "\n[%d] Server: %s (%s)\n", n, name, server.Path)
+		return
+	}
+	fmt.Fprintf(w, "\n[%d] Server: %s (%s) ⚠️ CHANGED\n", n, name, server.Path)
+	fmt.Fprintf(w, "    Command, args or env changed since the last scan (fingerprint %s → %s)\n",
+		shortFingerprint(server.PreviousFingerprint), shortFingerprint(server.Fingerprint))
+}
+
+// writeExplanation writes the --explain guidance of a finding, if any, at the given indent.
+func writeExplanation(w io.Writer, indent, explanation, remediation string) {
+	if explanation != "" {
+		fmt.Fprintf(w, "%sWhy: %s\n", indent, explanation)
+	}
+	if remediation != "" {
+		fmt.Fprintf(w, "%sFix: %s\n", indent, remediation)
+	}
+}
+
+// writeAnnotation writes the --annotations-file note of a finding, if any, at the given indent.
+func writeAnnotation(w io.Writer, indent string, a *Annotation) {
+	if a == nil {
+		return
+	}
+	fmt.Fprintf(w, "%sNote: %s\n", indent, a.Note)
+	if a.Owner != "" {
+		fmt.Fprintf(w, "%sOwner: %s\n", indent, a.Owner)
+	}
+	if a.Due != "" {
+		fmt.Fprintf(w, "%sDue: %s\n", indent, a.Due)
+	}
+}
+
+func shortFingerprint(f string) string {
+	const n = 12
+	if len(f) > n {
+		return f[:n]
+	}
+	return f
+}
+
 func PrintFooter() {
-	fmt.Fprintf(os.Stdout, "\nRun 'run-mcp scan --json' for detailed output\n")
-	// fmt.Fprintf(os.Stdout, "\nRun 'run-mcp scan --poll' with polling if you received results with status 'QUEUED_FOR_PROCESSING'\n") // TODO: add this back in once we have polling
-	fmt.Fprintf(os.Stdout, "Run 'run-mcp experimental allowlist add' to approve allowed servers\n")
-	fmt.Fprintln(os.Stdout, strings.Repeat("=", reportWidth))
+	printFooter(os.Stdout)
+}
+
+func printFooter(w io.Writer) {
+	fmt.Fprintf(w, "\nRun 'run-mcp scan --json' for detailed output\n")
+	// fmt.Fprintf(w, "\nRun 'run-mcp scan --poll' with polling if you received results with status 'QUEUED_FOR_PROCESSING'\n") // TODO: add this back in once we have polling
+	fmt.Fprintf(w, "Run 'run-mcp experimental allowlist add' to approve allowed servers\n")
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+}
+
+// ScanDiff describes how the security posture changed between a baseline and a current scan.
+type ScanDiff struct {
+	NewServers      []ServerReport     `json:"new_servers"`
+	RemovedServers  []ServerReport     `json:"removed_servers"`
+	TierChanges     []ServerTierChange `json:"tier_changes"`
+	NewSecrets      []SecretFinding    `json:"new_secrets"`
+	ResolvedSecrets []SecretFinding    `json:"resolved_secrets"`
+}
+
+// ServerTierChange records a server present in both scans whose risk tier changed.
+// Unrated servers have the tier "UNRATED".
+type ServerTierChange struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Before     string `json:"before"`
+	After      string `json:"after"`
+	Regression bool   `json:"regression"`
+}
+
+// Empty reports whether the two scans had no differences.
+func (d ScanDiff) Empty() bool {
+	return len(d.NewServers) == 0 && len(d.RemovedServers) == 0 && len(d.TierChanges) == 0 &&
+		len(d.NewSecrets) == 0 && len(d.ResolvedSecrets) == 0
+}
+
+// CompareSummaries diffs the current summary b against the baseline a. Servers are matched by
+// name and path; secrets by server, kind, key and value hash. Results keep the order of the
+// summary they come from.
+func CompareSummaries(a, b ScanSummary) ScanDiff {
+	type serverKey struct{ name, path string }
+	before := make(map[serverKey]ServerReport, len(a.Servers))
+	for _, s := range a.Servers {
+		before[serverKey{s.Name, s.Path}] = s
+	}
+	after := make(map[serverKey]struct{}, len(b.Servers))
+
+	diff := ScanDiff{
+		NewServers:      []ServerReport{},
+		RemovedServers:  []ServerReport{},
+		TierChanges:     []ServerTierChange{},
+		NewSecrets:      []SecretFinding{},
+		ResolvedSecrets: []SecretFinding{},
+	}
+	for _, s := range b.Servers {
+		key := serverKey{s.Name, s.Path}
+		after[key] = struct{}{}
+		old, ok := before[key]
+		if !ok {
+			diff.NewServers = append(diff.NewServers, s)
+			continue
+		}
+		if from, to := serverTier(old), serverTier(s); from != to {
+			diff.TierChanges = append(diff.TierChanges, ServerTierChange{
+				Name:       s.Name,
+				Path:       s.Path,
+				Before:     from,
+				After:      to,
+				Regression: severityRank[to] > severityRank[from],
+			})
+		}
+	}
+	for _, s := range a.Servers {
+		if _, ok := after[serverKey{s.Name, s.Path}]; !ok {
+			diff.RemovedServers = append(diff.RemovedServers, s)
+		}
+	}
+
+	oldSecrets := make(map[string]struct{}, len(a.Secrets))
+	for _, f := range a.Secrets {
+		oldSecrets[secretDiffKey(f)] = struct{}{}
+	}
+	newSecrets := make(map[string]struct{}, len(b.Secrets))
+	for _, f := range b.Secrets {
+		key := secretDiffKey(f)
+		newSecrets[key] = struct{}{}
+		if _, ok := oldSecrets[key]; !ok {
+			diff.NewSecrets = append(diff.NewSecrets, f)
+		}
+	}
+	for _, f := range a.Secrets {
+		if _, ok := newSecrets[secretDiffKey(f)]; !ok {
+			diff.ResolvedSecrets = append(diff.ResolvedSecrets, f)
+		}
+	}
+	return diff
+}
+
+// serverTier returns the risk tier of a rated server, or "UNRATED".
+func serverTier(s ServerReport) string {
+	if s.Rating == nil {
+		return "UNRATED"
+	}
+	return riskTierFromScore(s.Rating.RiskScore)
+}
+
+// secretDiffKey identifies a finding across scans. The redacted value stands in for the
+// hash when an older result has none.
+func secretDiffKey(f SecretFinding) string {
+	id := f.ValueHash
+	if id == "" {
+		id = f.Value
+	}
+	return strings.Join([]string{f.ServerName, f.Kind, f.Key, id}, "\x00")
+}
+
+// MergeSummaries combines the summaries of sharded scans into one. Servers and suppressed
+// servers are deduplicated by name and path, keeping the first occurrence; secrets are
+// deduplicated by server, kind, key and value hash with their occurrences combined, and
+// skipped server names are deduplicated.
+// TotalServers, ScannedFiles, SuppressedErrors, Duration and the severity counts are summed
+// across inputs, StartedAt is the earliest start and Environment is kept only when all inputs
+// agree.
+func MergeSummaries(summaries ...ScanSummary) ScanSummary {
+	merged := ScanSummary{Servers: []ServerReport{}, Secrets: []SecretFinding{}}
+	type serverKey struct{ name, path string }
+	seenServers := make(map[serverKey]struct{})
+	seenSuppressed := make(map[serverKey]struct{})
+	secretIndex := make(map[string]int)
+	seenMisconfigs := make(map[MisconfigFinding]struct{})
+
+	for i, s := range summaries {
+		for _, sr := range s.Servers {
+			key := serverKey{sr.Name, sr.Path}
+			if _, ok := seenServers[key]; !ok {
+				seenServers[key] = struct{}{}
+				merged.Servers = append(merged.Servers, sr)
+			}
+		}
+		for _, sr := range s.Suppressed {
+			key := serverKey{sr.Name, sr.Path}
+			if _, ok := seenSuppressed[key]; !ok {
+				seenSuppressed[key] = struct{}{}
+				merged.Suppressed = append(merged.Suppressed, sr)
+			}
+		}
+		for _, f := range s.Secrets {
+			key := secretDiffKey(f)
+			if j, ok := secretIndex[key]; ok {
+				merged.Secrets[j].Occurrences = mergeOccurrences(merged.Secrets[j].Occurrences, f.Occurrences)
+				continue
+			}
+			secretIndex[key] = len(merged.Secrets)
+			f.Occurrences = mergeOccurrences(nil, f.Occurrences)
+			merged.Secrets = append(merged.Secrets, f)
+		}
+		for _, m := range s.MisconfigFindings {
+			if _, ok := seenMisconfigs[m]; !ok {
+				seenMisconfigs[m] = struct{}{}
+				merged.MisconfigFindings = append(merged.MisconfigFindings, m)
+			}
+		}
+		for _, name := range s.SkippedServers {
+			if !slices.Contains(merged.SkippedServers, name) {
+				merged.SkippedServers = append(merged.SkippedServers, name)
+			}
+		}
+
+		merged.TotalServers += s.TotalServers
+		merged.ScannedFiles += s.ScannedFiles
+		merged.SuppressedErrors += s.SuppressedErrors
+		merged.Duration += s.Duration
+		merged.CriticalFindings += s.CriticalFindings
+		merged.HighFindings += s.HighFindings
+		merged.MediumFindings += s.MediumFindings
+		merged.LowFindings += s.LowFindings
+		if merged.StartedAt.IsZero() || (!s.StartedAt.IsZero() && s.StartedAt.Before(merged.StartedAt)) {
+			merged.StartedAt = s.StartedAt
+		}
+		if i == 0 {
+			merged.Environment = s.Environment
+		} else if s.Environment != merged.Environment {
+			merged.Environment = ""
+		}
+	}
+	merged.TotalFindings = len(merged.Secrets)
+	return merged
+}
+
+// mergeOccurrences returns a new occurrence map holding the file and line union of a and b.
+func mergeOccurrences(a, b map[string][]int) map[string][]int {
+	if a == nil && b == nil {
+		return nil
+	}
+	out := make(map[string][]int, len(a)+len(b))
+	for _, m := range []map[string][]int{a, b} {
+		for path, lines := range m {
+			out[path] = append(out[path], lines...)
+		}
+	}
+	for path, lines := range out {
+		slices.Sort(lines)
+		out[path] = slices.Compact(lines)
+	}
+	return out
+}
+
+// ANSI colours used by WriteScanDiff.
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
+// WriteScanDiff renders diff as text. Regressions are shown in red and improvements in
+// green when color is set and colour is enabled.
+func WriteScanDiff(w io.Writer, diff ScanDiff, color bool) {
+	paint := func(code, s string) string {
+		if !color || !colorEnabled {
+			return s
+		}
+		return code + s + ansiReset
+	}
+	bad := func(s string) string { return paint(ansiRed, s) }
+	good := func(s string) string { return paint(ansiGreen, s) }
+
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	fmt.Fprintln(w, "RUN-MCP SCAN COMPARISON")
+	fmt.Fprintln(w, strings.Repeat("=", reportWidth))
+	if diff.Empty() {
+		fmt.Fprintln(w, "No changes between the two scans")
+		return
+	}
+	if len(diff.NewServers) > 0 {
+		fmt.Fprintf(w, "\n➕ NEW SERVERS (%d)\n", len(diff.NewServers))
+		for _, s := range diff.NewServers {
+			fmt.Fprintln(w, bad(fmt.Sprintf("    + \"%s\" (%s) %s", s.Name, s.Path, serverTier(s))))
+		}
+	}
+	if len(diff.RemovedServers) > 0 {
+		fmt.Fprintf(w, "\n➖ REMOVED SERVERS (%d)\n", len(diff.RemovedServers))
+		for _, s := range diff.RemovedServers {
+			fmt.Fprintln(w, good(fmt.Sprintf("    - \"%s\" (%s) %s", s.Name, s.Path, serverTier(s))))
+		}
+	}
+	if len(diff.TierChanges) > 0 {
+		fmt.Fprintf(w, "\n🔀 RISK TIER CHANGES (%d)\n", len(diff.TierChanges))
+		for _, c := range diff.TierChanges {
+			line := fmt.Sprintf("    ~ \"%s\" (%s) %s -> %s", c.Name, c.Path, c.Before, c.After)
+			if c.Regression {
+				line = bad(line)
+			} else {
+				line = good(line)
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+	if len(diff.NewSecrets) > 0 {
+		fmt.Fprintf(w, "\n🔐 NEW SECRETS (%d)\n", len(diff.NewSecrets))
+		for _, f := range diff.NewSecrets {
+			fmt.Fprintln(w, bad(fmt.Sprintf("    + [%s] %s: %s", f.ServerName, f.Kind, f.Key)))
+		}
+	}
+	if len(diff.ResolvedSecrets) > 0 {
+		fmt.Fprintf(w, "\n✅ RESOLVED SECRETS (%d)\n", len(diff.ResolvedSecrets))
+		for _, f := range diff.ResolvedSecrets {
+			fmt.Fprintln(w, good(fmt.Sprintf("    - [%s] %s: %s", f.ServerName, f.Kind, f.Key)))
+		}
+	}
 }
 
 // HumanDuration returns a compact, human-readable duration string.
@@ -421,13 +1080,12 @@ func riskTierFromScore(score float64) string {
 	}
 }
 
-// PrintRunMCPBanner renders a RUN-MCP banner.
-func printRunMCPBanner() {
-	fmt.Fprint(os.Stdout, runMCPBanner())
-}
-
-// RunMCPBanner returns the RUN-MCP banner ANSI art as a string.
-func runMCPBanner() string {
+// Banner returns the RUN-MCP banner ANSI art as a string. It uses 24-bit colour escapes,
+// so it is empty when noColor is set or colour is disabled.
+func Banner(noColor bool) string {
+	if noColor || !colorEnabled {
+		return ""
+	}
 	return "\x1b[38;2;2;2;6;48;2;2;3;0m▄\x1b[38;2;143;26;53;48;2;17;0;5m▄\x1b[38;2;171;14;44;48;2;3;3;7m▄\x1b[38;2;184;12;46;48;2;4;2;0m▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄\x1b[38;2;149;28;53;48;2;10;3;0m▄\x1b[38;2;0;8;0;48;2;3;2;0m▄\x1b[m\n" +
 		"\x1b[38;2;0;12;0;48;2;6;0;4m▄\x1b[38;2;148;26;46;48;2;154;23;44m▄\x1b[38;2;188;7;37;48;2;181;13;27m▄\x1b[38;2;183;11;30;48;2;179;18;35m▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄\x1b[38;2;172;16;53;48;2;169;17;53m▄\x1b[38;2;0;3;0;48;2;0;9;0m▄\x1b[m\n" +
 		"\x1b[38;2;2;2;2;48;2;8;0;1m▄\x1b[38;2;2;2;2;48;2;117;20;43m▄\x1b[38;2;2;2;2;48;2;129;15;35m▄\x1b[38;2;15;15;15;48;2;134;14;31m▄\x1b[38;2;10;10;10;48;2;134;14;31m▄▄▄▄\x1b[38;2;1;1;1;48;2;134;14;31m▄\x1b[38;2;0;0;0;48;2;134;14;31m▄▄\x1b[38;2;7;7;7;48;2;134;14;31m▄\x1b[38;2;13;13;13;48;2;134;14;31m▄\x1b[38;2;2;2;2;48;2;134;14;31m▄▄▄▄▄\x1b[38;2;10;10;10;48;2;134;14;31m▄▄▄\x1b[38;2;2;2;2;48;2;134;14;31m▄▄▄▄▄\x1b[38;2;5;5;5;48;2;134;14;31m▄\x1b[38;2;8;8;8;48;2;134;14;31m▄\x1b[38;2;10;10;10;48;2;134;14;31m▄▄▄\x1b[38;2;2;2;2;48;2;134;14;31m▄▄\x1b[38;2;5;5;5;48;2;134;14;31m▄\x1b[38;2;10;10;10;48;2;134;14;31m▄▄▄\x1b[38;2;7;7;7;48;2;134;14;31m▄\x1b[38;2;2;2;2;48;2;134;14;31m▄▄▄▄\x1b[38;2;11;11;11;48;2;134;14;31m▄\x1b[38;2;10;10;10;48;2;134;14;31m▄▄▄\x1b[38;2;2;2;2;48;2;134;14;31m▄\x1b[38;2;2;2;2;48;2;100;31;38m▄\x1b[38;2;2;2;2;48;2;1;3;0m▄\x1b[m\n" +
@@ -0,0 +1,165 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func compareFixtures() (ScanSummary, ScanSummary) {
+	leaked := SecretFinding{ServerName: "github", Kind: "GitHub Token", Key: "env.GITHUB_TOKEN", ValueHash: "aaa"}
+	rotated := SecretFinding{ServerName: "github", Kind: "GitHub Token", Key: "env.GITHUB_TOKEN", ValueHash: "bbb"}
+	fixed := SecretFinding{ServerName: "slack", Kind: "Slack Token", Key: "env.SLACK_TOKEN", ValueHash: "ccc"}
+
+	baseline := ScanSummary{
+		Servers: []ServerReport{
+			{Name: "github", Path: "/a/mcp.json", Rating: &SecurityRating{RiskScore: 3}},
+			{Name: "slack", Path: "/a/mcp.json", Rating: &SecurityRating{RiskScore: 8}},
+			{Name: "old", Path: "/a/mcp.json"},
+			{Name: "same", Path: "/a/mcp.json", Rating: &SecurityRating{RiskScore: 5}},
+		},
+		Secrets: []SecretFinding{leaked, fixed},
+	}
+	current := ScanSummary{
+		Servers: []ServerReport{
+			{Name: "github", Path: "/a/mcp.json", Rating: &SecurityRating{RiskScore: 9.5}},
+			{Name: "slack", Path: "/a/mcp.json", Rating: &SecurityRating{RiskScore: 2}},
+			{Name: "same", Path: "/a/mcp.json", Rating: &SecurityRating{RiskScore: 5.5}},
+			{Name: "new", Path: "/b/mcp.json"},
+		},
+		Secrets: []SecretFinding{rotated},
+	}
+	return baseline, current
+}
+
+func TestCompareSummaries(t *testing.T) {
+	baseline, current := compareFixtures()
+	diff := CompareSummaries(baseline, current)
+
+	assert.Equal(t, []ServerReport{{Name: "new", Path: "/b/mcp.json"}}, diff.NewServers)
+	assert.Equal(t, []ServerReport{{Name: "old", Path: "/a/mcp.json"}}, diff.RemovedServers)
+	assert.Equal(t, []ServerTierChange{
+		{Name: "github", Path: "/a/mcp.json", Before: "LOW", After: "CRITICAL", Regression: true},
+		{Name: "slack", Path: "/a/mcp.json", Before: "HIGH", After: "LOW", Regression: false},
+	}, diff.TierChanges)
+	// A changed value hash is a new secret even under the same key.
+	assert.Equal(t, current.Secrets, diff.NewSecrets)
+	assert.Equal(t, baseline.Secrets, diff.ResolvedSecrets)
+
+	assert.True(t, CompareSummaries(current, current).Empty())
+}
+
+func TestWriteScanDiff(t *testing.T) {
+	baseline, current := compareFixtures()
+	diff := CompareSummaries(baseline, current)
+
+	var plain bytes.Buffer
+	WriteScanDiff(&plain, diff, false)
+	out := plain.String()
+	assert.Contains(t, out, `    + "new" (/b/mcp.json) UNRATED`)
+	assert.Contains(t, out, `    ~ "github" (/a/mcp.json) LOW -> CRITICAL`)
+	assert.Contains(t, out, "RESOLVED SECRETS (2)")
+	assert.NotContains(t, out, "\x1b[")
+
+	var colored bytes.Buffer
+	WriteScanDiff(&colored, diff, true)
+	assert.Contains(t, colored.String(), ansiRed+`    ~ "github" (/a/mcp.json) LOW -> CRITICAL`+ansiReset)
+	assert.Contains(t, colored.String(), ansiGreen+`    ~ "slack" (/a/mcp.json) HIGH -> LOW`+ansiReset)
+
+	var empty bytes.Buffer
+	WriteScanDiff(&empty, CompareSummaries(current, current), true)
+	assert.Contains(t, empty.String(), "No changes between the two scans")
+}
+
+func TestSetColorEnabled(t *testing.T) {
+	t.Cleanup(func() { SetColorEnabled(true) })
+	assert.NotEmpty(t, Banner(false))
+
+	SetColorEnabled(false)
+	assert.Empty(t, Banner(false), "the banner is all colour escapes")
+	var diff bytes.Buffer
+	WriteScanDiff(&diff, CompareSummaries(compareFixtures()), true)
+	assert.NotContains(t, diff.String(), "\x1b[")
+}
+
+func TestMergeSummaries(t *testing.T) {
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	shared := SecretFinding{ServerName: "github", Kind: "GitHub Token", Key: "env.GITHUB_TOKEN", ValueHash: "aaa",
+		Occurrences: map[string][]int{"/a/mcp.json": {4}}}
+	sharedElsewhere := shared
+	sharedElsewhere.Occurrences = map[string][]int{"/a/mcp.json": {4, 9}, "/b/mcp.json": {2}}
+	other := SecretFinding{ServerName: "slack", Kind: "Slack Token", Key: "env.SLACK_TOKEN", ValueHash: "bbb"}
+
+	a := ScanSummary{
+		Servers:      []ServerReport{{Name: "github", Path: "/a/mcp.json"}},
+		Secrets:      []SecretFinding{shared},
+		TotalServers: 1, ScannedFiles: 1, Duration: time.Second,
+		StartedAt: t0.Add(time.Minute), Environment: "ci",
+	}
+	b := ScanSummary{
+		Servers: []ServerReport{
+			{Name: "github", Path: "/a/mcp.json"},
+			{Name: "slack", Path: "/b/mcp.json"},
+		},
+		Suppressed:   []ServerReport{{Name: "noisy", Path: "/b/mcp.json"}},
+		Secrets:      []SecretFinding{sharedElsewhere, other},
+		TotalServers: 3, ScannedFiles: 2, Duration: 2 * time.Second,
+		StartedAt: t0, Environment: "ci",
+	}
+
+	merged := MergeSummaries(a, b)
+	assert.Equal(t, []ServerReport{{Name: "github", Path: "/a/mcp.json"}, {Name: "slack", Path: "/b/mcp.json"}}, merged.Servers)
+	assert.Equal(t, []ServerReport{{Name: "noisy", Path: "/b/mcp.json"}}, merged.Suppressed)
+	assert.Len(t, merged.Secrets, 2)
+	assert.Equal(t, map[string][]int{"/a/mcp.json": {4, 9}, "/b/mcp.json": {2}}, merged.Secrets[0].Occurrences)
+	assert.Equal(t, map[string][]int{"/a/mcp.json": {4}}, shared.Occurrences, "inputs are not modified")
+	assert.Equal(t, 2, merged.TotalFindings)
+	assert.Equal(t, 4, merged.TotalServers)
+	assert.Equal(t, 3, merged.ScannedFiles)
+	assert.Equal(t, 3*time.Second, merged.Duration)
+	assert.Equal(t, t0, merged.StartedAt)
+	assert.Equal(t, "ci", merged.Environment)
+
+	b.Environment = "prod"
+	assert.Empty(t, MergeSummaries(a, b).Environment, "differing environments are dropped")
+	assert.Empty(t, MergeSummaries().Servers)
+}
+
+func TestWriteTextSummary_Layout(t *testing.T) {
+	summary := ScanSummary{Servers: []ServerReport{
+		{Name: "b", Path: "/z/mcp.json", Rating: &SecurityRating{RiskScore: 9.1}},
+		{Name: "a", Path: "/y/mcp.json", Rating: &SecurityRating{RiskScore: 9.8}},
+		{Name: "c", Path: "/x/mcp.json", Rating: &SecurityRating{RiskScore: 9.5}},
+		{Name: "d", Path: "/x/mcp.json", Rating: &SecurityRating{RiskScore: 2}},
+	}}
+
+	firstCritical := func(layout TextLayout) string {
+		var buf bytes.Buffer
+		writeTextSummary(&buf, summary, layout)
+		out := buf.String()
+		section := out[strings.Index(out, "CRITICAL FINDINGS"):]
+		return section[strings.Index(section, "[1] Server: "):][:len(`[1] Server: "x"`)]
+	}
+	assert.Equal(t, `[1] Server: "b"`, firstCritical(TextLayout{}), "scan order by default")
+	assert.Equal(t, `[1] Server: "a"`, firstCritical(TextLayout{SortBy: SortByName}))
+	assert.Equal(t, `[1] Server: "a"`, firstCritical(TextLayout{SortBy: SortByRisk}))
+	assert.Equal(t, `[1] Server: "c"`, firstCritical(TextLayout{SortBy: SortByPath}))
+
+	var buf bytes.Buffer
+	writeTextSummary(&buf, summary, TextLayout{FindingsPerPage: 2, SortBy: SortByRisk})
+	out := buf.String()
+	assert.Contains(t, out, `[2] Server: "c"`)
+	assert.NotContains(t, out, `[3] Server:`)
+	assert.Contains(t, out, "(2 of 3 shown — use --json for full output)")
+	assert.Equal(t, 1, strings.Count(out, "shown —"), "sections within the limit have no note")
+
+	_, err := ParseSortOrder("size")
+	assert.Error(t, err)
+	order, err := ParseSortOrder(" Risk ")
+	assert.NoError(t, err)
+	assert.Equal(t, SortByRisk, order)
+}
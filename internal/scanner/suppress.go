@@ -0,0 +1,104 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultSuppressFile is the suppression file read from the working directory by default.
+const DefaultSuppressFile = ".run-mcp-suppressions.yaml"
+
+// suppressionDateLayout is the layout of Suppression.Expires.
+const suppressionDateLayout = "2006-01-02"
+
+// Suppression marks a server's findings as accepted risk until an optional expiry date.
+// An empty Kind matches the whole server; otherwise the server must have a secret of that kind.
+type Suppression struct {
+	Server  string `yaml:"server" json:"server"`
+	Kind    string `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Reason  string `yaml:"reason" json:"reason"`
+	Expires string `yaml:"expires,omitempty" json:"expires,omitempty"`
+}
+
+// Validate checks the required fields and the expiry date format.
+func (s Suppression) Validate() error {
+	if s.Server == "" {
+		return errors.New("suppression is missing a server name")
+	}
+	if s.Expires != "" {
+		if _, err := time.Parse(suppressionDateLayout, s.Expires); err != nil {
+			return fmt.Errorf("suppression for %q has invalid expires %q: want YYYY-MM-DD", s.Server, s.Expires)
+		}
+	}
+	return nil
+}
+
+// Expired reports whether the entry's expiry day has passed at now. Entries without expiry never expire.
+func (s Suppression) Expired(now time.Time) bool {
+	if s.Expires == "" {
+		return false
+	}
+	day, err := time.ParseInLocation(suppressionDateLayout, s.Expires, now.Location())
+	if err != nil {
+		return true
+	}
+	// The entry holds through the whole expiry day.
+	return !now.Before(day.AddDate(0, 0, 1))
+}
+
+// matches reports whether the entry applies to the server, ignoring expiry.
+func (s Suppression) matches(server ServerReport) bool {
+	if s.Server != server.Name {
+		return false
+	}
+	if s.Kind == "" {
+		return true
+	}
+	for _, f := range server.Secrets {
+		if f.Kind == s.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadSuppressions reads a YAML list of suppressions. A missing file yields no entries.
+func LoadSuppressions(path string) ([]Suppression, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []Suppression
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, e := range entries {
+		if err := e.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return entries, nil
+}
+
+// AppendSuppression adds entry to the suppression file at path, creating it if needed.
+func AppendSuppression(path string, entry Suppression) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	entries, err := LoadSuppressions(path)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(append(entries, entry))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
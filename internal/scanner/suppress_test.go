@@ -0,0 +1,85 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func suppressResult() ScanResult {
+	secret := NewSecretFinding("noisy", "OpenAI API Key", "env.KEY", "sk-proj-abcT3BlbkFJdef", "HIGH", "/a/mcp.json", 1, nil) //nolint:gosec // test data
+	return ScanResult{
+		Files: []FileResult{{
+			Path:           "/a/mcp.json",
+			Servers:        []ServerConfig{{Name: "noisy"}, {Name: "kept"}},
+			SecretFindings: []SecretFinding{secret},
+		}},
+	}
+}
+
+func TestSuppression_Expired(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.False(t, Suppression{Server: "a"}.Expired(now), "no expiry never expires")
+	assert.False(t, Suppression{Server: "a", Expires: "2025-06-01"}.Expired(now), "valid through the expiry day")
+	assert.True(t, Suppression{Server: "a", Expires: "2025-05-31"}.Expired(now))
+}
+
+func TestGenerateSummary_Suppressions(t *testing.T) {
+	tests := []struct {
+		name           string
+		suppression    Suppression
+		wantSuppressed bool
+	}{
+		{"whole server", Suppression{Server: "noisy", Reason: "vetted"}, true},
+		{"matching secret kind", Suppression{Server: "noisy", Kind: "OpenAI API Key", Reason: "vetted"}, true},
+		{"other secret kind", Suppression{Server: "noisy", Kind: "GitHub Token", Reason: "vetted"}, false},
+		{"expired", Suppression{Server: "noisy", Reason: "vetted", Expires: "2000-01-01"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := GenerateSummary(suppressResult(), tt.suppression)
+			assert.Equal(t, 2, summary.TotalServers)
+			if !tt.wantSuppressed {
+				assert.Empty(t, summary.Suppressed)
+				assert.Len(t, summary.Servers, 2)
+				assert.Len(t, summary.Secrets, 1)
+				return
+			}
+			require.Len(t, summary.Suppressed, 1)
+			assert.Equal(t, "noisy", summary.Suppressed[0].Name)
+			assert.Equal(t, "vetted", summary.Suppressed[0].SuppressionReason)
+			require.Len(t, summary.Servers, 1)
+			assert.Equal(t, "kept", summary.Servers[0].Name)
+			assert.Empty(t, summary.Secrets, "secrets of suppressed servers are not reported")
+			assert.Zero(t, summary.TotalFindings)
+		})
+	}
+}
+
+func TestLoadAndAppendSuppressions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultSuppressFile)
+
+	entries, err := LoadSuppressions(path)
+	require.NoError(t, err, "missing file is not an error")
+	assert.Empty(t, entries)
+
+	require.NoError(t, AppendSuppression(path, Suppression{Server: "a", Reason: "r1", Expires: "2030-01-01"}))
+	require.NoError(t, AppendSuppression(path, Suppression{Server: "b", Kind: "GitHub Token", Reason: "r2"}))
+	require.Error(t, AppendSuppression(path, Suppression{Server: "c", Expires: "next week"}))
+
+	entries, err = LoadSuppressions(path)
+	require.NoError(t, err)
+	assert.Equal(t, []Suppression{
+		{Server: "a", Reason: "r1", Expires: "2030-01-01"},
+		{Server: "b", Kind: "GitHub Token", Reason: "r2"},
+	}, entries)
+
+	require.NoError(t, os.WriteFile(path, []byte("- reason: no server\n"), 0o600))
+	_, err = LoadSuppressions(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// unmarshalTOML decodes a TOML config into v by way of JSON, so the config types need no
+// TOML-specific tags. Servers written as an array of tables ([[mcpServers]] with a name key)
+// are keyed by name like the [mcpServers.name] form.
+func unmarshalTOML(data []byte, v interface{}) error {
+	doc, err := decodeTOML(data)
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{"mcpServers", "servers"} {
+		if doc[key], err = tomlServersByName(doc[key]); err != nil {
+			return err
+		}
+		if doc[key] == nil {
+			delete(doc, key)
+		}
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// tomlServersByName turns a [[mcpServers]] array of tables into a map keyed by each
+// table's name. Other values are returned unchanged.
+func tomlServersByName(v interface{}) (interface{}, error) {
+	var list []map[string]interface{}
+	switch v := v.(type) {
+	case []map[string]interface{}:
+		list = v
+	case []interface{}:
+		for i, item := range v {
+			server, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("toml: server %d is not a table", i+1)
+			}
+			list = append(list, server)
+		}
+	default:
+		return v, nil
+	}
+	servers := make(map[string]interface{}, len(list))
+	for i, server := range list {
+		name, _ := server["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("toml: server %d has no name", i+1)
+		}
+		if _, dup := servers[name]; dup {
+			return nil, fmt.Errorf("toml: duplicate server %q", name)
+		}
+		rest := make(map[string]interface{}, len(server)-1)
+		for k, val := range server {
+			if k != "name" {
+				rest[k] = val
+			}
+		}
+		servers[name] = rest
+	}
+	return servers, nil
+}
+
+// decodeTOML decodes a TOML document into a generic map.
+func decodeTOML(data []byte) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
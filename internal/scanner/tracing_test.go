@@ -0,0 +1,77 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package scanner
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+
+	"github.com/ensigniasec/run-mcp/internal/telemetry"
+)
+
+// fakeCollector is a minimal OTLP gRPC trace receiver recording span names.
+type fakeCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	mu    sync.Mutex
+	names []string
+}
+
+func (f *fakeCollector) Export(_ context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, rs := range req.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, sp := range ss.GetSpans() {
+				f.names = append(f.names, sp.GetName())
+			}
+		}
+	}
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func (f *fakeCollector) spanNames() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.names...)
+}
+
+func startFakeCollector(t *testing.T) (*fakeCollector, string) {
+	t.Helper()
+	lis, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	fc := &fakeCollector{}
+	srv := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(srv, fc)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return fc, lis.Addr().String()
+}
+
+func TestScan_EmitsSpansToOTLPCollector(t *testing.T) {
+	fc, addr := startFakeCollector(t)
+
+	shutdown, err := telemetry.Setup(context.Background(), addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	_, err = NewMCPScanner([]string{"../../testdata/claude_desktop_config.json"}, "").Scan()
+	require.NoError(t, err)
+
+	// Shutdown flushes the batch processor synchronously.
+	require.NoError(t, shutdown(context.Background()))
+
+	names := fc.spanNames()
+	assert.Contains(t, names, telemetry.SpanScan)
+	assert.Contains(t, names, telemetry.SpanScanFile)
+}
@@ -0,0 +1,191 @@
+// Package signing signs and verifies scan JSON results with Ed25519 keys so that results
+// can be shown to be untampered.
+//
+// The signature covers SHA-256 of the canonical form of the document without its
+// "signature" field: the JSON re-encoded compactly with object keys sorted. Reformatting
+// or reordering a signed file therefore does not invalidate it, but any change to a
+// value does.
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// SignatureField is the top-level JSON field holding the base64 signature.
+const SignatureField = "signature"
+
+// PEM block types written by GenerateKey.
+const (
+	privateKeyPEMType = "PRIVATE KEY"
+	publicKeyPEMType  = "PUBLIC KEY"
+)
+
+// ErrNoSignature is returned by VerifyJSON when the document is not signed.
+var ErrNoSignature = errors.New("document has no signature")
+
+// ErrInvalidSignature is returned by VerifyJSON when the signature does not match.
+var ErrInvalidSignature = errors.New("signature does not match the document")
+
+// GenerateKey creates an Ed25519 key pair encoded as PKCS #8 and PKIX PEM blocks.
+func GenerateKey() (publicPEM, privatePEM []byte, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: publicKeyPEMType, Bytes: pubDER}),
+		pem.EncodeToMemory(&pem.Block{Type: privateKeyPEMType, Bytes: privDER}), nil
+}
+
+// LoadPrivateKey reads a PEM encoded PKCS #8 Ed25519 private key.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	der, err := readPEM(path, privateKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 private key", path)
+	}
+	return priv, nil
+}
+
+// LoadPublicKey reads a PEM encoded PKIX Ed25519 public key.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	der, err := readPEM(path, publicKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 public key", path)
+	}
+	return pub, nil
+}
+
+func readPEM(path, blockType string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != blockType {
+		return nil, fmt.Errorf("%s: expected a PEM %q block", path, blockType)
+	}
+	return block.Bytes, nil
+}
+
+// SignJSON signs a JSON object and returns it with the signature appended as a final
+// top-level field. The original formatting of doc is kept.
+func SignJSON(doc []byte, key ed25519.PrivateKey) ([]byte, error) {
+	fields, err := decodeObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fields[SignatureField]; ok {
+		return nil, errors.New("document is already signed")
+	}
+	digest, err := digest(fields)
+	if err != nil {
+		return nil, err
+	}
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(key, digest))
+
+	body := bytes.TrimRight(doc, " \t\r\n")
+	body = bytes.TrimSuffix(body, []byte("}"))
+	body = bytes.TrimRight(body, " \t\r\n")
+	sep := ",\n"
+	if bytes.HasSuffix(body, []byte("{")) {
+		sep = "\n"
+	}
+	out := make([]byte, 0, len(body)+len(sig)+32)
+	out = append(out, body...)
+	out = append(out, fmt.Sprintf("%s  %q: %q\n}\n", sep, SignatureField, sig)...)
+	return out, nil
+}
+
+// VerifyJSON checks the signature field of a JSON object signed by SignJSON.
+func VerifyJSON(doc []byte, key ed25519.PublicKey) error {
+	fields, err := decodeObject(doc)
+	if err != nil {
+		return err
+	}
+	raw, ok := fields[SignatureField]
+	if !ok {
+		return ErrNoSignature
+	}
+	delete(fields, SignatureField)
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err != nil {
+		return fmt.Errorf("signature is not a string: %w", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("signature is not valid base64: %w", err)
+	}
+	digest, err := digest(fields)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(key, digest, sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func decodeObject(doc []byte) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return nil, fmt.Errorf("expected a JSON object: %w", err)
+	}
+	if fields == nil {
+		return nil, errors.New("expected a JSON object")
+	}
+	return fields, nil
+}
+
+// digest returns SHA-256 of the canonical encoding of fields.
+func digest(fields map[string]json.RawMessage) ([]byte, error) {
+	generic := make(map[string]interface{}, len(fields))
+	for k, raw := range fields {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		generic[k] = v
+	}
+	// encoding/json sorts map keys, which makes this encoding canonical.
+	canonical, err := json.Marshal(generic)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(canonical)
+	return sum[:], nil
+}
@@ -0,0 +1,85 @@
+package signing
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKeys(t *testing.T) (pubPath, privPath string) {
+	t.Helper()
+	pub, priv, err := GenerateKey()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	pubPath, privPath = filepath.Join(dir, "k.pub"), filepath.Join(dir, "k.key")
+	require.NoError(t, os.WriteFile(pubPath, pub, 0o600))
+	require.NoError(t, os.WriteFile(privPath, priv, 0o600))
+	return pubPath, privPath
+}
+
+func TestSignAndVerifyJSON(t *testing.T) {
+	pubPath, privPath := writeKeys(t)
+	priv, err := LoadPrivateKey(privPath)
+	require.NoError(t, err)
+	pub, err := LoadPublicKey(pubPath)
+	require.NoError(t, err)
+
+	doc := []byte("{\n  \"Servers\": [],\n  \"TotalFindings\": 3,\n  \"Duration\": 12345678901\n}\n")
+	signed, err := SignJSON(doc, priv)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(signed, doc[:len(doc)-3]), "original formatting is kept")
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(signed, &fields))
+	assert.NotEmpty(t, fields[SignatureField])
+
+	require.NoError(t, VerifyJSON(signed, pub))
+
+	// Re-encoding the document does not change its canonical form.
+	var generic map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(signed, &generic))
+	compact, err := json.Marshal(generic)
+	require.NoError(t, err)
+	require.NoError(t, VerifyJSON(compact, pub))
+
+	tampered := bytes.Replace(signed, []byte(`"TotalFindings": 3`), []byte(`"TotalFindings": 0`), 1)
+	assert.ErrorIs(t, VerifyJSON(tampered, pub), ErrInvalidSignature)
+
+	otherPub, _ := writeKeys(t)
+	other, err := LoadPublicKey(otherPub)
+	require.NoError(t, err)
+	assert.ErrorIs(t, VerifyJSON(signed, other), ErrInvalidSignature)
+
+	assert.ErrorIs(t, VerifyJSON(doc, pub), ErrNoSignature)
+	_, err = SignJSON(signed, priv)
+	assert.Error(t, err, "already signed")
+}
+
+func TestSignJSON_EmptyObject(t *testing.T) {
+	pubPath, privPath := writeKeys(t)
+	priv, err := LoadPrivateKey(privPath)
+	require.NoError(t, err)
+	pub, err := LoadPublicKey(pubPath)
+	require.NoError(t, err)
+
+	signed, err := SignJSON([]byte("{}"), priv)
+	require.NoError(t, err)
+	require.NoError(t, VerifyJSON(signed, pub))
+}
+
+func TestLoadKeys_Errors(t *testing.T) {
+	pubPath, privPath := writeKeys(t)
+	_, err := LoadPrivateKey(pubPath)
+	assert.Error(t, err, "public key is not a private key")
+	_, err = LoadPublicKey(privPath)
+	assert.Error(t, err, "private key is not a public key")
+	_, err = LoadPublicKey(filepath.Join(t.TempDir(), "missing.pub"))
+	assert.Error(t, err)
+
+	_, err = SignJSON([]byte("[1]"), nil)
+	assert.Error(t, err)
+}
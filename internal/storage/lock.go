@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// LockTimeout bounds how long Save waits for another process to release the storage lock.
+//
+//nolint:gochecknoglobals // Tunable package default, overridden in tests.
+var LockTimeout = 5 * time.Second
+
+// ErrLockTimeout is returned by Save when the storage lock cannot be acquired within LockTimeout.
+var ErrLockTimeout = errors.New("timed out waiting for storage file lock")
+
+// errLockBusy is returned by the platform tryLock when another holder owns the lock.
+var errLockBusy = errors.New("lock held elsewhere")
+
+const lockRetryInterval = 10 * time.Millisecond
+
+// acquireLock takes an exclusive advisory lock on path+".lock", polling until timeout.
+// The lock file is left on disk; only the OS-level lock is released by the returned func.
+func acquireLock(path string, timeout time.Duration) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		err := tryLock(f)
+		if err == nil {
+			return func() {
+				_ = unlock(f)
+				_ = f.Close()
+			}, nil
+		}
+		if !errors.Is(err, errLockBusy) {
+			_ = f.Close()
+			return nil, fmt.Errorf("lock %s: %w", f.Name(), err)
+		}
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, fmt.Errorf("%w: %s", ErrLockTimeout, f.Name())
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
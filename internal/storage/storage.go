@@ -3,24 +3,83 @@ package storage
 import (
 	"bufio"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
+	apigen "github.com/ensigniasec/run-mcp/internal/api-gen"
 	"github.com/ensigniasec/run-mcp/internal/validate"
 )
 
 // Data represents the structure of the storage file.
 type Data struct {
+	// Version is the schema version the data was written with; see CurrentSchemaVersion.
+	Version         int                          `json:"version"`
 	ScannedEntities map[string]map[string]string `json:"scanned_entities"`
 	Allowlist       map[string][]string          `json:"allowlist"`
 	Denylist        map[string][]string          `json:"denylist"`
 	// TODO: add denylist functionality in cli
 	HostUUID string `json:"host_uuid,omitempty" validate:"omitempty,uuid_rfc4122"`
 	OrgUUID  string `json:"org_uuid,omitempty" validate:"omitempty,uuid_rfc4122"`
+	// AllowlistNames maps "type|hash" to the entity name given when it was allowlisted.
+	AllowlistNames map[string]string `json:"allowlist_names,omitempty"`
+	// RatingCache remembers ratings API results between scans, keyed by "kind|value" identifier.
+	RatingCache map[string]CachedRating `json:"rating_cache,omitempty"`
+	// ScanHistory records which servers each scan reported, oldest first.
+	ScanHistory []ScanHistoryEntry `json:"scan_history,omitempty"`
+	// LastUpdateCheck is when scan --update-check last looked up the latest release.
+	LastUpdateCheck time.Time `json:"last_update_check,omitzero"`
+}
+
+// MaxScanHistory caps the number of retained ScanHistory entries; older ones are dropped.
+const MaxScanHistory = 100
+
+// ScanHistoryEntry is the set of servers reported by one scan.
+type ScanHistoryEntry struct {
+	ScannedAt time.Time       `json:"scanned_at"`
+	Servers   []HistoryServer `json:"servers"`
+}
+
+// HistoryServer identifies a reported server and the rating it had at the time.
+type HistoryServer struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Rating string `json:"rating,omitempty"`
+}
+
+// AppendScanHistory records entry, keeping at most MaxScanHistory entries.
+func (s *Storage) AppendScanHistory(entry ScanHistoryEntry) {
+	s.Data.ScanHistory = append(s.Data.ScanHistory, entry)
+	if over := len(s.Data.ScanHistory) - MaxScanHistory; over > 0 {
+		s.Data.ScanHistory = s.Data.ScanHistory[over:]
+	}
+}
+
+// PruneScanHistory drops entries scanned before cutoff (all entries if cutoff is zero)
+// and returns how many were removed.
+func (s *Storage) PruneScanHistory(cutoff time.Time) int {
+	kept := s.Data.ScanHistory[:0]
+	for _, e := range s.Data.ScanHistory {
+		if !cutoff.IsZero() && !e.ScannedAt.Before(cutoff) {
+			kept = append(kept, e)
+		}
+	}
+	removed := len(s.Data.ScanHistory) - len(kept)
+	s.Data.ScanHistory = kept
+	return removed
+}
+
+// CachedRating is a ratings API result and the time it was fetched.
+type CachedRating struct {
+	Rating    *apigen.SecurityRating `json:"rating,omitempty"`
+	RatingURL string                 `json:"rating_url,omitempty"`
+	FetchedAt time.Time              `json:"fetched_at"`
 }
 
 // Storage handles the loading and saving of the storage file.
@@ -31,7 +90,7 @@ type Storage struct {
 
 // NewStorage creates a new Storage instance.
 func NewStorage(path string) (*Storage, error) {
-	expandedPath, err := expandTilde(path)
+	expandedPath, err := ExpandTilde(path)
 	if err != nil {
 		return nil, err
 	}
@@ -39,6 +98,7 @@ func NewStorage(path string) (*Storage, error) {
 	s := &Storage{
 		Path: expandedPath,
 		Data: Data{
+			Version: CurrentSchemaVersion,
 			ScannedEntities: make(
 				map[string]map[string]string,
 			), // TODO: consider unique identifier for each scanned entity - see: ID.md
@@ -76,8 +136,12 @@ func NewStorage(path string) (*Storage, error) {
 // NewOrExistingStorage returns existing storage if the file exists, or creates a new one otherwise.
 // When creating a new storage, it writes the initial structure to disk immediately.
 // Additionally, this ensures a HostUUID is present; if missing, it is generated and saved.
+// An empty path selects DefaultStoragePath.
 func NewOrExistingStorage(path string) (*Storage, error) {
-	expandedPath, err := expandTilde(path)
+	if path == "" {
+		path = DefaultStoragePath()
+	}
+	expandedPath, err := ExpandTilde(path)
 	if err != nil {
 		return nil, err
 	}
@@ -112,9 +176,15 @@ func (s *Storage) Load() error {
 		return err
 	}
 
+	// A file without a version key predates versioning, so the version must not carry over
+	// from the defaults set by NewStorage.
+	s.Data.Version = 0
 	if err := json.Unmarshal(data, &s.Data); err != nil {
 		return err
 	}
+	if err := s.Migrate(); err != nil {
+		return err
+	}
 
 	// Validate loaded data and self-heal when possible.
 	if err := validate.Struct(s.Data); err != nil {
@@ -139,6 +209,55 @@ func (s *Storage) Load() error {
 	return nil
 }
 
+// CurrentSchemaVersion is the Data schema version this build reads and writes.
+const CurrentSchemaVersion = 2
+
+// migrations upgrade Data one schema version at a time: migrations[i] turns version i+1
+// into version i+2.
+//
+//nolint:gochecknoglobals // Fixed migration chain.
+var migrations = []func(*Data){
+	migrateV1ToV2,
+}
+
+// Migrate upgrades data loaded from an older storage file to CurrentSchemaVersion in memory.
+// The file itself is only rewritten by the next Save, so read-only commands never write it.
+// Files written before the version field existed are treated as version 1.
+func (s *Storage) Migrate() error {
+	from := max(s.Data.Version, 1)
+	changed, err := migrateData(&s.Data)
+	if err != nil || !changed {
+		return err
+	}
+	logrus.Debugf("Migrated storage file %s from schema version %d to %d", s.Path, from, s.Data.Version)
+	return nil
+}
+
+// migrateData applies the migrations between d.Version and CurrentSchemaVersion in order and
+// reports whether any ran. Data from a newer run-mcp is rejected rather than downgraded.
+func migrateData(d *Data) (bool, error) {
+	if d.Version == 0 {
+		d.Version = 1
+	}
+	if d.Version > CurrentSchemaVersion {
+		return false, fmt.Errorf("storage schema version %d is newer than the supported version %d; upgrade run-mcp",
+			d.Version, CurrentSchemaVersion)
+	}
+	changed := false
+	for d.Version < CurrentSchemaVersion {
+		migrations[d.Version-1](d)
+		d.Version++
+		changed = true
+	}
+	return changed, nil
+}
+
+// migrateV1ToV2 fills in the maps that version 1 files may store as null or omit, so callers
+// can write to them without nil checks.
+func migrateV1ToV2(d *Data) {
+	ensureMaps(d)
+}
+
 // Save writes the storage data to the file.
 func (s *Storage) Save() error {
 	logrus.Debug("Saving storage file to: ", s.Path)
@@ -146,16 +265,36 @@ func (s *Storage) Save() error {
 	if err := os.MkdirAll(filepath.Dir(s.Path), 0o700); err != nil {
 		return err
 	}
+	// Serialize writers across processes; held only for the marshal+write cycle.
+	release, err := acquireLock(s.Path, LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	data, err := json.MarshalIndent(s.Data, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(s.Path, data, 0o600)
+	// Write to a sibling temp file and rename so concurrent readers never see a partial file.
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
 }
 
-// expandTilde expands the tilde in a path to the user's home directory.
-func expandTilde(path string) (string, error) {
+// ExpandTilde expands the tilde in a path to the user's home directory.
+func ExpandTilde(path string) (string, error) {
 	if len(path) == 0 || path[0] != '~' {
 		return path, nil
 	}
@@ -168,12 +307,74 @@ func expandTilde(path string) (string, error) {
 	return filepath.Join(home, path[1:]), nil
 }
 
+// legacyStoragePath is where storage lived on every OS before per-OS defaults.
+const legacyStoragePath = "~/Library/Application Support/run-mcp/results.json"
+
+// DefaultStoragePath returns the storage file location for this OS: $XDG_DATA_HOME/run-mcp
+// (falling back to ~/.local/share/run-mcp) on Linux, %APPDATA%\run-mcp on Windows and
+// ~/Library/Application Support/run-mcp on macOS. Elsewhere than macOS, an existing file at
+// the legacy macOS-style location keeps being used until the new location exists.
+func DefaultStoragePath() string {
+	path := defaultStoragePath(runtime.GOOS, os.Getenv)
+	if path == legacyStoragePath {
+		return path
+	}
+	if expanded, err := ExpandTilde(path); err == nil {
+		if _, err := os.Stat(expanded); err == nil {
+			return path
+		}
+	}
+	if legacy, err := ExpandTilde(legacyStoragePath); err == nil {
+		if _, err := os.Stat(legacy); err == nil {
+			logrus.Debugf("Using legacy storage file %s", legacy)
+			return legacyStoragePath
+		}
+	}
+	return path
+}
+
+// defaultStoragePath returns the conventional storage location for goos, reading
+// environment variables through getenv.
+func defaultStoragePath(goos string, getenv func(string) string) string {
+	switch goos {
+	case "linux":
+		// Per the XDG Base Directory spec, relative paths are invalid and ignored.
+		if dir := getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+			return filepath.Join(dir, "run-mcp", "results.json")
+		}
+		return "~/.local/share/run-mcp/results.json"
+	case "windows":
+		if dir := getenv("APPDATA"); dir != "" {
+			return filepath.Join(dir, "run-mcp", "results.json")
+		}
+		return "~/AppData/Roaming/run-mcp/results.json"
+	default:
+		return legacyStoragePath
+	}
+}
+
+// SystemConfigPath is the managed system-wide config read by readSystemManagedConfig.
+// It is a variable so tests can point it at a temporary file.
+//
+//nolint:gochecknoglobals // Overridden in tests.
+var SystemConfigPath = systemConfigPath(runtime.GOOS)
+
+// systemConfigPath returns the conventional managed config location for goos.
+func systemConfigPath(goos string) string {
+	switch goos {
+	case "windows":
+		return `C:\ProgramData\run-mcp\config.yaml`
+	case "linux":
+		return "/etc/run-mcp/config.yaml"
+	default:
+		return "/Library/Application Support/run-mcp/config.yaml"
+	}
+}
+
 // readSystemManagedConfig reads org_uuid and host_uuid from the managed system-wide config
-// at /Library/Application Support/run-mcp/config.yaml. YAML parsing here is intentionally
-// minimal for simple key: value pairs.
+// at SystemConfigPath. YAML parsing here is intentionally minimal for simple key: value pairs.
 func readSystemManagedConfig() (orgUUID string, hostUUID string) {
-	const systemConfigPath = "/Library/Application Support/run-mcp/config.yaml"
-	f, err := os.Open(systemConfigPath)
+	f, err := os.Open(SystemConfigPath)
 	if err != nil {
 		return "", ""
 	}
@@ -3,9 +3,14 @@ package storage
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -68,3 +73,263 @@ func TestStorage_OrgUUIDPersistenceAndValidation(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, s3.Data.OrgUUID)
 }
+
+func TestStorage_ConcurrentSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+
+	// Separate Storage instances mimic independent scanner processes.
+	const writers = 10
+	stores := make([]*Storage, writers)
+	for i := range stores {
+		s, err := NewStorage(path)
+		require.NoError(t, err)
+		// Differently sized payloads make interleaved writes detectable as corrupt JSON.
+		s.Data.ScannedEntities[fmt.Sprintf("entity-%d", i)] = map[string]string{"name": strings.Repeat("x", 4096*i)}
+		stores[i] = s
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for _, s := range stores {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.Save()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var data Data
+	require.NoError(t, json.Unmarshal(b, &data), "final file must be valid JSON")
+	require.Len(t, data.ScannedEntities, 1)
+}
+
+func TestStorage_SaveLockTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	s, err := NewStorage(path)
+	require.NoError(t, err)
+
+	release, err := acquireLock(path, time.Second)
+	require.NoError(t, err)
+	defer release()
+
+	orig := LockTimeout
+	LockTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { LockTimeout = orig })
+
+	require.ErrorIs(t, s.Save(), ErrLockTimeout)
+}
+
+func TestStorage_ScanHistory(t *testing.T) {
+	s := &Storage{}
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range MaxScanHistory + 5 {
+		s.AppendScanHistory(ScanHistoryEntry{ScannedAt: t0.Add(time.Duration(i) * time.Hour)})
+	}
+	require.Len(t, s.Data.ScanHistory, MaxScanHistory)
+	require.Equal(t, t0.Add(5*time.Hour), s.Data.ScanHistory[0].ScannedAt, "oldest entries are dropped first")
+
+	require.Equal(t, 5, s.PruneScanHistory(t0.Add(10*time.Hour)))
+	require.Len(t, s.Data.ScanHistory, MaxScanHistory-5)
+
+	require.Equal(t, MaxScanHistory-5, s.PruneScanHistory(time.Time{}))
+	require.Empty(t, s.Data.ScanHistory)
+}
+
+func TestStorage_MigrateV1(t *testing.T) {
+	fixture, err := os.ReadFile(filepath.Join("testdata", "results-v1.json"))
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(path, fixture, 0o600))
+
+	orig := SystemConfigPath
+	t.Cleanup(func() { SystemConfigPath = orig })
+	SystemConfigPath = filepath.Join(t.TempDir(), "config.yaml")
+
+	s, err := NewOrExistingStorage(path)
+	require.NoError(t, err)
+	require.Equal(t, Data{
+		Version: CurrentSchemaVersion,
+		ScannedEntities: map[string]map[string]string{
+			"server|/home/dev/.cursor/mcp.json|github": {
+				"name":        "github",
+				"path":        "/home/dev/.cursor/mcp.json",
+				"fingerprint": "3f1c9a",
+			},
+		},
+		Allowlist: map[string][]string{},
+		Denylist:  map[string][]string{},
+		HostUUID:  "00000000-0000-4000-8000-000000000000",
+	}, s.Data)
+
+	// Loading leaves the file alone; read-only commands must not write it.
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, fixture, b)
+
+	// The next Save persists the migrated data.
+	require.NoError(t, s.Save())
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(b, &raw))
+	require.InDelta(t, CurrentSchemaVersion, raw["version"], 0)
+	require.Equal(t, map[string]any{}, raw["allowlist"])
+}
+
+func TestStorage_MigrateRejectsNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	newer := fmt.Sprintf(`{"version": %d}`, CurrentSchemaVersion+1)
+	require.NoError(t, os.WriteFile(path, []byte(newer), 0o600))
+
+	_, err := NewStorage(path)
+	require.ErrorContains(t, err, "newer than the supported version")
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, newer, string(b), "a newer file is left untouched")
+}
+
+func TestSystemConfigPath(t *testing.T) {
+	require.Equal(t, "/Library/Application Support/run-mcp/config.yaml", systemConfigPath("darwin"))
+	require.Equal(t, "/etc/run-mcp/config.yaml", systemConfigPath("linux"))
+	require.Equal(t, `C:\ProgramData\run-mcp\config.yaml`, systemConfigPath("windows"))
+}
+
+func TestReadSystemManagedConfig(t *testing.T) {
+	orig := SystemConfigPath
+	t.Cleanup(func() { SystemConfigPath = orig })
+
+	SystemConfigPath = filepath.Join(t.TempDir(), "config.yaml")
+	orgUUID, hostUUID := readSystemManagedConfig()
+	require.Empty(t, orgUUID, "missing file yields nothing")
+	require.Empty(t, hostUUID)
+
+	content := "# managed by MDM\norg_uid: \"123e4567-e89b-12d3-a456-426614174000\"\nhost_uuid: 00000000-0000-4000-8000-000000000001\n"
+	require.NoError(t, os.WriteFile(SystemConfigPath, []byte(content), 0o600))
+	orgUUID, hostUUID = readSystemManagedConfig()
+	require.Equal(t, "123e4567-e89b-12d3-a456-426614174000", orgUUID)
+	require.Equal(t, "00000000-0000-4000-8000-000000000001", hostUUID)
+
+	s, err := NewStorage(filepath.Join(t.TempDir(), "results.json"))
+	require.NoError(t, err)
+	require.Equal(t, "123e4567-e89b-12d3-a456-426614174000", s.Data.OrgUUID)
+	require.Equal(t, "00000000-0000-4000-8000-000000000001", s.Data.HostUUID)
+}
+
+func TestDefaultStoragePath(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		want string
+	}{
+		{"darwin", "darwin", nil, "~/Library/Application Support/run-mcp/results.json"},
+		{"linux xdg", "linux", map[string]string{"XDG_DATA_HOME": "/data"}, filepath.Join("/data", "run-mcp", "results.json")},
+		{"linux fallback", "linux", nil, "~/.local/share/run-mcp/results.json"},
+		{"linux relative xdg ignored", "linux", map[string]string{"XDG_DATA_HOME": "data"}, "~/.local/share/run-mcp/results.json"},
+		{"windows", "windows", map[string]string{"APPDATA": "/appdata"}, filepath.Join("/appdata", "run-mcp", "results.json")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, defaultStoragePath(tt.goos, env(tt.env)))
+		})
+	}
+}
+
+func TestDefaultStoragePath_Legacy(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("legacy fallback is exercised through XDG_DATA_HOME on Linux")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	want := filepath.Join(home, "data", "run-mcp", "results.json")
+	require.Equal(t, want, DefaultStoragePath())
+
+	legacy := filepath.Join(home, "Library", "Application Support", "run-mcp", "results.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(legacy), 0o700))
+	require.NoError(t, os.WriteFile(legacy, []byte("{}"), 0o600))
+	require.Equal(t, legacyStoragePath, DefaultStoragePath(), "existing legacy file is kept")
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(want), 0o700))
+	require.NoError(t, os.WriteFile(want, []byte("{}"), 0o600))
+	require.Equal(t, want, DefaultStoragePath(), "new location wins once it exists")
+}
+
+func TestStorage_ExportImport(t *testing.T) {
+	src, err := NewStorage(filepath.Join(t.TempDir(), "results.json"))
+	require.NoError(t, err)
+	src.Data.HostUUID = "00000000-0000-4000-8000-000000000001"
+	src.Data.OrgUUID = "123e4567-e89b-12d3-a456-426614174000"
+	src.Data.Allowlist = map[string][]string{"server": {"h1", "h2"}}
+	src.Data.RatingCache = map[string]CachedRating{"purl|pkg:npm/a@1": {RatingURL: "https://example.test/a", FetchedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}}
+	src.Data.ScanHistory = []ScanHistoryEntry{{ScannedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Servers: []HistoryServer{{Name: "a", Path: "/a"}}}}
+
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			var buf strings.Builder
+			require.NoError(t, src.Export(&buf, format))
+
+			dst, err := NewStorage(filepath.Join(t.TempDir(), "results.json"))
+			require.NoError(t, err)
+			changes, err := dst.Import(strings.NewReader(buf.String()), false, false)
+			require.NoError(t, err)
+			require.Contains(t, changes, "allowlist: 0 -> 2 entries")
+			require.Equal(t, src.Data, dst.Data)
+		})
+	}
+
+	require.Error(t, src.Export(&strings.Builder{}, "xml"))
+}
+
+func TestStorage_ImportMergeAndDryRun(t *testing.T) {
+	s, err := NewStorage(filepath.Join(t.TempDir(), "results.json"))
+	require.NoError(t, err)
+	s.Data.HostUUID = "00000000-0000-4000-8000-000000000001"
+	s.Data.Allowlist = map[string][]string{"server": {"h1"}}
+	s.Data.ScanHistory = []ScanHistoryEntry{{ScannedAt: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)}}
+	require.NoError(t, s.Save())
+
+	in := `{"org_uuid": "123e4567-e89b-12d3-a456-426614174000", "allowlist": {"server": ["h1", "h2"]},
+		"scan_history": [{"scanned_at": "2025-06-01T00:00:00Z", "servers": []}]}`
+
+	changes, err := s.Import(strings.NewReader(in), true, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"org_uuid: (none) -> 123e4567-e89b-12d3-a456-426614174000",
+		"allowlist: 1 -> 2 entries",
+		"scan_history: 1 -> 2 entries",
+	}, changes)
+	require.Empty(t, s.Data.OrgUUID, "dry run leaves data untouched")
+
+	_, err = s.Import(strings.NewReader(in), true, false)
+	require.NoError(t, err)
+	reloaded, err := NewStorage(s.Path)
+	require.NoError(t, err)
+	require.Equal(t, "00000000-0000-4000-8000-000000000001", reloaded.Data.HostUUID, "merge keeps fields the import does not set")
+	require.Equal(t, "123e4567-e89b-12d3-a456-426614174000", reloaded.Data.OrgUUID)
+	require.Equal(t, []string{"h1", "h2"}, reloaded.Data.Allowlist["server"])
+	require.Len(t, reloaded.Data.ScanHistory, 2)
+	require.True(t, reloaded.Data.ScanHistory[0].ScannedAt.Before(reloaded.Data.ScanHistory[1].ScannedAt))
+}
+
+func TestStorage_ImportRejectsInvalidUUID(t *testing.T) {
+	s, err := NewStorage(filepath.Join(t.TempDir(), "results.json"))
+	require.NoError(t, err)
+	before := s.Data
+
+	_, err = s.Import(strings.NewReader(`{"org_uuid": "not-a-uuid"}`), false, false)
+	require.ErrorContains(t, err, "invalid org_uuid")
+	_, err = s.Import(strings.NewReader(""), false, false)
+	require.Error(t, err)
+	require.Equal(t, before, s.Data)
+}
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ensigniasec/run-mcp/internal/validate"
+)
+
+// Export formats accepted by Export.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Export writes the full storage data to w in the given format. YAML uses the same keys as
+// the JSON storage file.
+func (s *Storage) Export(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s.Data)
+	case FormatYAML:
+		// Round-trip through JSON so the YAML keys follow the json tags.
+		raw, err := json.Marshal(s.Data)
+		if err != nil {
+			return err
+		}
+		var generic map[string]interface{}
+		if err := json.Unmarshal(raw, &generic); err != nil {
+			return err
+		}
+		return yaml.NewEncoder(w).Encode(generic)
+	default:
+		return fmt.Errorf("unsupported storage format %q (want %s or %s)", format, FormatJSON, FormatYAML)
+	}
+}
+
+// ParseData decodes storage data exported as JSON or YAML, migrates it to
+// CurrentSchemaVersion and checks that its UUIDs are RFC 4122 compliant.
+func ParseData(r io.Reader) (Data, error) {
+	var generic map[string]interface{}
+	// YAML is a superset of JSON, so one decoder handles both formats.
+	if err := yaml.NewDecoder(r).Decode(&generic); err != nil && !errors.Is(err, io.EOF) {
+		return Data{}, fmt.Errorf("failed to parse storage export: %w", err)
+	}
+	if generic == nil {
+		return Data{}, errors.New("storage export is empty")
+	}
+	raw, err := json.Marshal(generic)
+	if err != nil {
+		return Data{}, err
+	}
+	var d Data
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return Data{}, fmt.Errorf("failed to parse storage export: %w", err)
+	}
+	if _, err := migrateData(&d); err != nil {
+		return Data{}, err
+	}
+	if d.HostUUID != "" {
+		if err := validate.Var(d.HostUUID, "uuid_rfc4122"); err != nil {
+			return Data{}, fmt.Errorf("invalid host_uuid %q: expected an RFC 4122 UUID", d.HostUUID)
+		}
+	}
+	if d.OrgUUID != "" {
+		if err := validate.Var(d.OrgUUID, "uuid_rfc4122"); err != nil {
+			return Data{}, fmt.Errorf("invalid org_uuid %q: expected an RFC 4122 UUID", d.OrgUUID)
+		}
+	}
+	return d, nil
+}
+
+// Import reads exported storage data from r and replaces the current data with it, or merges
+// it in when merge is set. It returns a description of what changed; nothing is saved when
+// dryRun is set or the input is invalid.
+func (s *Storage) Import(r io.Reader, merge, dryRun bool) ([]string, error) {
+	in, err := ParseData(r)
+	if err != nil {
+		return nil, err
+	}
+	next := in
+	if merge {
+		next = mergeData(s.Data, in)
+	}
+	ensureMaps(&next)
+
+	changes := diffData(s.Data, next)
+	if dryRun {
+		return changes, nil
+	}
+	s.Data = next
+	return changes, s.Save()
+}
+
+// mergeData overlays in onto cur. Identifiers and names from in win, list entries are
+// unioned, the newer cached rating is kept and scan history is combined in time order.
+func mergeData(cur, in Data) Data {
+	out := cur
+	if in.HostUUID != "" {
+		out.HostUUID = in.HostUUID
+	}
+	if in.OrgUUID != "" {
+		out.OrgUUID = in.OrgUUID
+	}
+	out.Allowlist = unionLists(cur.Allowlist, in.Allowlist)
+	out.Denylist = unionLists(cur.Denylist, in.Denylist)
+
+	out.ScannedEntities = maps.Clone(cur.ScannedEntities)
+	if out.ScannedEntities == nil {
+		out.ScannedEntities = map[string]map[string]string{}
+	}
+	for k, v := range in.ScannedEntities {
+		merged := maps.Clone(out.ScannedEntities[k])
+		if merged == nil {
+			merged = map[string]string{}
+		}
+		maps.Copy(merged, v)
+		out.ScannedEntities[k] = merged
+	}
+
+	out.AllowlistNames = maps.Clone(cur.AllowlistNames)
+	if len(in.AllowlistNames) > 0 && out.AllowlistNames == nil {
+		out.AllowlistNames = map[string]string{}
+	}
+	maps.Copy(out.AllowlistNames, in.AllowlistNames)
+
+	out.RatingCache = maps.Clone(cur.RatingCache)
+	if len(in.RatingCache) > 0 && out.RatingCache == nil {
+		out.RatingCache = map[string]CachedRating{}
+	}
+	for k, v := range in.RatingCache {
+		if existing, ok := out.RatingCache[k]; !ok || v.FetchedAt.After(existing.FetchedAt) {
+			out.RatingCache[k] = v
+		}
+	}
+
+	out.ScanHistory = mergeHistory(cur.ScanHistory, in.ScanHistory)
+	return out
+}
+
+// unionLists returns a copy of a with the values of b appended where missing.
+func unionLists(a, b map[string][]string) map[string][]string {
+	out := make(map[string][]string, len(a))
+	for k, v := range a {
+		out[k] = slices.Clone(v)
+	}
+	for k, vs := range b {
+		for _, v := range vs {
+			if !slices.Contains(out[k], v) {
+				out[k] = append(out[k], v)
+			}
+		}
+	}
+	return out
+}
+
+// mergeHistory combines two histories oldest first, dropping entries with the same
+// timestamp, and keeps at most MaxScanHistory entries.
+func mergeHistory(a, b []ScanHistoryEntry) []ScanHistoryEntry {
+	out := slices.Concat(a, b)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ScannedAt.Before(out[j].ScannedAt) })
+	out = slices.CompactFunc(out, func(x, y ScanHistoryEntry) bool { return x.ScannedAt.Equal(y.ScannedAt) })
+	if over := len(out) - MaxScanHistory; over > 0 {
+		out = out[over:]
+	}
+	return out
+}
+
+// ensureMaps initialises the maps that a freshly created storage always has.
+func ensureMaps(d *Data) {
+	if d.ScannedEntities == nil {
+		d.ScannedEntities = make(map[string]map[string]string)
+	}
+	if d.Allowlist == nil {
+		d.Allowlist = make(map[string][]string)
+	}
+	if d.Denylist == nil {
+		d.Denylist = make(map[string][]string)
+	}
+}
+
+// diffData describes the differences between old and next, one line per changed field.
+func diffData(old, next Data) []string {
+	var changes []string
+	orNone := func(s string) string {
+		if s == "" {
+			return "(none)"
+		}
+		return s
+	}
+	if old.HostUUID != next.HostUUID {
+		changes = append(changes, fmt.Sprintf("host_uuid: %s -> %s", orNone(old.HostUUID), orNone(next.HostUUID)))
+	}
+	if old.OrgUUID != next.OrgUUID {
+		changes = append(changes, fmt.Sprintf("org_uuid: %s -> %s", orNone(old.OrgUUID), orNone(next.OrgUUID)))
+	}
+	counts := []struct {
+		name      string
+		old, next int
+	}{
+		{"allowlist", countEntries(old.Allowlist), countEntries(next.Allowlist)},
+		{"denylist", countEntries(old.Denylist), countEntries(next.Denylist)},
+		{"scanned_entities", len(old.ScannedEntities), len(next.ScannedEntities)},
+		{"rating_cache", len(old.RatingCache), len(next.RatingCache)},
+		{"scan_history", len(old.ScanHistory), len(next.ScanHistory)},
+	}
+	for _, c := range counts {
+		if c.old != c.next {
+			changes = append(changes, fmt.Sprintf("%s: %d -> %d entries", c.name, c.old, c.next))
+		}
+	}
+	return changes
+}
+
+func countEntries(m map[string][]string) int {
+	n := 0
+	for _, v := range m {
+		n += len(v)
+	}
+	return n
+}
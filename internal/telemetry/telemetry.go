@@ -0,0 +1,61 @@
+// Package telemetry wires optional OpenTelemetry tracing for the scan pipeline.
+// Until Setup is called the global no-op tracer provider is in effect, so spans
+// created via Tracer cost next to nothing.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifies spans emitted by run-mcp.
+const InstrumentationName = "github.com/ensigniasec/run-mcp"
+
+// ServiceName is reported as the service.name resource attribute.
+const ServiceName = "run-mcp"
+
+// Span names emitted by the scan pipeline.
+const (
+	SpanScan         = "scan"
+	SpanScanFile     = "scan.file"
+	SpanDeliverBatch = "ratings.deliver_batch"
+	SpanPoll         = "ratings.poll"
+)
+
+// Tracer returns the run-mcp tracer from the global provider.
+func Tracer() trace.Tracer { //nolint:ireturn
+	return otel.Tracer(InstrumentationName)
+}
+
+// Setup installs a global tracer provider exporting spans over OTLP gRPC to endpoint.
+// A bare host:port is dialled without TLS; an endpoint with an http(s) scheme is used as-is.
+// The returned shutdown func flushes pending spans and must be called before exit.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	opts := []otlptracegrpc.Option{}
+	if strings.Contains(endpoint, "://") {
+		opts = append(opts, otlptracegrpc.WithEndpointURL(endpoint))
+	} else {
+		opts = append(opts, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", ServiceName))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
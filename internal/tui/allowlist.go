@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ensigniasec/run-mcp/internal/allowlist"
+	"github.com/ensigniasec/run-mcp/internal/storage"
+)
+
+// allowlistEntityType is the allowlist type servers are added under.
+const allowlistEntityType = "server"
+
+// allowlistHash formats a server fingerprint as an allowlist hash.
+func allowlistHash(fingerprint string) string {
+	if fingerprint == "" {
+		return ""
+	}
+	return "sha256:" + fingerprint
+}
+
+// selectedHost returns the host backing the highlighted results list row.
+func (m Model) selectedHost() (HostRow, bool) {
+	it, ok := m.resultsList.SelectedItem().(resultItem)
+	if !ok {
+		return HostRow{}, false
+	}
+	for _, h := range m.hosts {
+		if h.ID == it.ID {
+			return h, true
+		}
+	}
+	return HostRow{}, false
+}
+
+// startAllowlistConfirm opens the confirmation prompt for the selected server.
+func (m Model) startAllowlistConfirm() (Model, tea.Cmd) { // nolint:ireturn
+	host, ok := m.selectedHost()
+	if !ok {
+		return m, nil
+	}
+	if host.Hash == "" {
+		return m.setStatus(fmt.Sprintf("Cannot allowlist %q: its config has no hash", host.Name), true)
+	}
+	if m.namesRedacted {
+		return m.setStatus("Cannot allowlist while server names are redacted", true)
+	}
+	m.allowlistConfirm = true
+	m.confirmHost = host
+	m.confirmInput.Reset()
+	m.confirmInput.Prompt = fmt.Sprintf("Allowlist %q (%s)? ", host.Name, host.Hash)
+	return m, m.confirmInput.Focus()
+}
+
+// handleAllowlistConfirmKey routes keys to the confirmation prompt. Enter with "y" or "yes"
+// adds the server; any other answer or esc cancels.
+func (m Model) handleAllowlistConfirmKey(msg tea.KeyMsg) (Model, tea.Cmd) { // nolint:ireturn
+	switch {
+	case key.Matches(msg, m.keys.Escape):
+		m.closeAllowlistConfirm()
+		return m.setStatus("Allowlist cancelled", false)
+
+	case key.Matches(msg, m.keys.Confirm):
+		answer := strings.ToLower(strings.TrimSpace(m.confirmInput.Value()))
+		host := m.confirmHost
+		m.closeAllowlistConfirm()
+		if answer != "y" && answer != "yes" {
+			return m.setStatus("Allowlist cancelled", false)
+		}
+		return m, addToAllowlist(m.storagePath, host)
+	}
+
+	var cmd tea.Cmd
+	m.confirmInput, cmd = m.confirmInput.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) closeAllowlistConfirm() {
+	m.allowlistConfirm = false
+	m.confirmHost = HostRow{}
+	m.confirmInput.Blur()
+}
+
+// addToAllowlist saves host to the allowlist in the background.
+func addToAllowlist(storagePath string, host HostRow) tea.Cmd {
+	return func() tea.Msg {
+		if storagePath == "" {
+			storagePath = storage.DefaultStoragePath()
+		}
+		v, err := allowlist.NewVerifier(storagePath)
+		if err == nil {
+			err = v.AddToAllowlist(allowlistEntityType, host.Name, host.Hash)
+		}
+		return allowlistResultMsg{Name: host.Name, Err: err}
+	}
+}
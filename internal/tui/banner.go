@@ -1,35 +0,0 @@
-package tui
-
-import (
-	"fmt"
-	"os"
-)
-
-// PrintRunMCPBanner renders a RUN-MCP banner.
-func printRunMCPBanner() {
-	fmt.Fprint(os.Stdout, runMCPBanner())
-}
-
-// RunMCPBanner returns the RUN-MCP banner ANSI art as a string.
-func runMCPBanner() string {
-	return "\x1b[38;2;2;2;6;48;2;2;3;0m▄\x1b[38;2;143;26;53;48;2;17;0;5m▄\x1b[38;2;171;14;44;48;2;3;3;7m▄\x1b[38;2;184;12;46;48;2;4;2;0m▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄\x1b[38;2;149;28;53;48;2;10;3;0m▄\x1b[38;2;0;8;0;48;2;3;2;0m▄\x1b[m\n" +
-		"\x1b[38;2;0;12;0;48;2;6;0;4m▄\x1b[38;2;148;26;46;48;2;154;23;44m▄\x1b[38;2;188;7;37;48;2;181;13;27m▄\x1b[38;2;183;11;30;48;2;179;18;35m▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄▄\x1b[38;2;172;16;53;48;2;169;17;53m▄\x1b[38;2;0;3;0;48;2;0;9;0m▄\x1b[m\n" +
-		"\x1b[38;2;2;2;2;48;2;8;0;1m▄\x1b[38;2;2;2;2;48;2;117;20;43m▄\x1b[38;2;2;2;2;48;2;129;15;35m▄\x1b[38;2;15;15;15;48;2;134;14;31m▄\x1b[38;2;10;10;10;48;2;134;14;31m▄▄▄▄\x1b[38;2;1;1;1;48;2;134;14;31m▄\x1b[38;2;0;0;0;48;2;134;14;31m▄▄\x1b[38;2;7;7;7;48;2;134;14;31m▄\x1b[38;2;13;13;13;48;2;134;14;31m▄\x1b[38;2;2;2;2;48;2;134;14;31m▄▄▄▄▄\x1b[38;2;10;10;10;48;2;134;14;31m▄▄▄\x1b[38;2;2;2;2;48;2;134;14;31m▄▄▄▄▄\x1b[38;2;5;5;5;48;2;134;14;31m▄\x1b[38;2;8;8;8;48;2;134;14;31m▄\x1b[38;2;10;10;10;48;2;134;14;31m▄▄▄\x1b[38;2;2;2;2;48;2;134;14;31m▄▄\x1b[38;2;5;5;5;48;2;134;14;31m▄\x1b[38;2;10;10;10;48;2;134;14;31m▄▄▄\x1b[38;2;7;7;7;48;2;134;14;31m▄\x1b[38;2;2;2;2;48;2;134;14;31m▄▄▄▄\x1b[38;2;11;11;11;48;2;134;14;31m▄\x1b[38;2;10;10;10;48;2;134;14;31m▄▄▄\x1b[38;2;2;2;2;48;2;134;14;31m▄\x1b[38;2;2;2;2;48;2;100;31;38m▄\x1b[38;2;2;2;2;48;2;1;3;0m▄\x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[38;2;240;240;240;48;2;241;241;241m▄\x1b[48;2;255;255;255m    \x1b[38;2;255;255;255;48;2;248;248;248m▄\x1b[38;2;255;255;255;48;2;253;253;253m▄\x1b[38;2;255;255;255;48;2;252;252;252m▄\x1b[38;2;255;255;255;48;2;248;248;248m▄\x1b[38;2;255;255;255;48;2;233;233;233m▄\x1b[38;2;254;254;254;48;2;2;2;2m▄\x1b[38;2;91;91;91;48;2;1;1;1m▄\x1b[38;2;1;1;1;48;2;3;3;3m▄\x1b[48;2;2;2;2m \x1b[48;2;0;0;0m \x1b[48;2;255;255;255m   \x1b[48;2;248;248;248m \x1b[48;2;2;2;2m    \x1b[38;2;3;3;3;48;2;9;9;9m▄\x1b[48;2;254;254;254m \x1b[48;2;255;255;255m  \x1b[38;2;255;255;255;48;2;253;253;253m▄\x1b[48;2;2;2;2m  \x1b[38;2;55;55;55;48;2;61;61;61m▄\x1b[48;2;255;255;255m   \x1b[38;2;245;245;245;48;2;249;249;249m▄\x1b[38;2;12;12;12;48;2;7;7;7m▄\x1b[48;2;2;2;2m   \x1b[38;2;32;32;32;48;2;43;43;43m▄\x1b[48;2;255;255;255m   \x1b[48;2;0;0;0m \x1b[48;2;2;2;2m  \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;240;240;240m \x1b[48;2;255;255;255m  \x1b[38;2;255;255;255;48;2;254;254;254m▄\x1b[48;2;2;2;2m \x1b[38;2;2;2;2;48;2;4;4;4m▄\x1b[38;2;2;2;2;48;2;1;1;1m▄\x1b[38;2;1;1;1;48;2;97;97;97m▄\x1b[38;2;208;208;208;48;2;244;244;244m▄\x1b[48;2;255;255;255m  \x1b[38;2;251;251;251;48;2;254;254;254m▄\x1b[38;2;0;0;0;48;2;5;5;5m▄\x1b[48;2;2;2;2m \x1b[48;2;0;0;0m \x1b[48;2;255;255;255m   \x1b[48;2;248;248;248m \x1b[48;2;2;2;2m    \x1b[48;2;3;3;3m \x1b[48;2;254;254;254m \x1b[48;2;255;255;255m   \x1b[48;2;2;2;2m  \x1b[48;2;55;55;55m \x1b[48;2;255;255;255m   \x1b[38;2;245;245;245;48;2;255;255;255m▄\x1b[38;2;255;255;255;48;2;250;250;250m▄\x1b[38;2;17;17;17;48;2;2;2;2m▄\x1b[38;2;0;0;0;48;2;2;2;2m▄\x1b[48;2;2;2;2m \x1b[48;2;32;32;32m \x1b[48;2;255;255;255m   \x1b[48;2;0;0;0m \x1b[48;2;2;2;2m  \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;240;240;240m \x1b[48;2;255;255;255m   \x1b[48;2;2;2;2m  \x1b[48;2;0;0;0m \x1b[38;2;39;39;39;48;2;11;11;11m▄\x1b[38;2;236;236;236;48;2;196;196;196m▄\x1b[48;2;255;255;255m  \x1b[38;2;251;251;251;48;2;253;253;253m▄\x1b[38;2;1;1;1;48;2;5;5;5m▄\x1b[48;2;2;2;2m \x1b[48;2;0;0;0m \x1b[48;2;255;255;255m   \x1b[48;2;248;248;248m \x1b[48;2;2;2;2m    \x1b[48;2;3;3;3m \x1b[48;2;254;254;254m \x1b[48;2;255;255;255m   \x1b[48;2;2;2;2m  \x1b[48;2;55;55;55m \x1b[48;2;255;255;255m   \x1b[38;2;4;4;4;48;2;254;254;254m▄\x1b[48;2;255;255;255m \x1b[38;2;253;253;253;48;2;249;249;249m▄\x1b[38;2;50;50;50;48;2;1;1;1m▄\x1b[48;2;2;2;2m \x1b[48;2;32;32;32m \x1b[48;2;255;255;255m   \x1b[48;2;0;0;0m \x1b[48;2;2;2;2m  \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;240;240;240m \x1b[48;2;255;255;255m  \x1b[48;2;254;254;254m \x1b[38;2;252;252;252;48;2;254;254;254m▄\x1b[38;2;250;250;250;48;2;254;254;254m▄\x1b[38;2;255;255;255;48;2;254;254;254m▄▄\x1b[48;2;255;255;255m \x1b[38;2;151;151;151;48;2;251;251;251m▄\x1b[38;2;9;9;9;48;2;242;242;242m▄\x1b[38;2;2;2;2;48;2;4;4;4m▄\x1b[38;2;2;2;2;48;2;3;3;3m▄\x1b[48;2;2;2;2m \x1b[48;2;0;0;0m \x1b[48;2;255;255;255m   \x1b[48;2;248;248;248m \x1b[48;2;2;2;2m    \x1b[48;2;3;3;3m \x1b[48;2;254;254;254m \x1b[48;2;255;255;255m   \x1b[48;2;2;2;2m  \x1b[48;2;55;55;55m \x1b[48;2;255;255;255m   \x1b[48;2;0;0;0m \x1b[38;2;2;2;2;48;2;253;253;253m▄\x1b[48;2;255;255;255m \x1b[38;2;255;255;255;48;2;252;252;252m▄\x1b[38;2;58;58;58;48;2;8;8;8m▄\x1b[38;2;28;28;28;48;2;32;32;32m▄\x1b[48;2;255;255;255m   \x1b[48;2;0;0;0m \x1b[48;2;2;2;2m  \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;240;240;240m \x1b[48;2;255;255;255m   \x1b[48;2;2;2;2m \x1b[38;2;5;5;5;48;2;3;3;3m▄\x1b[38;2;4;4;4;48;2;222;222;222m▄\x1b[38;2;243;243;243;48;2;253;253;253m▄\x1b[48;2;255;255;255m  \x1b[38;2;252;252;252;48;2;85;85;85m▄\x1b[48;2;2;2;2m   \x1b[38;2;8;8;8;48;2;6;6;6m▄\x1b[48;2;255;255;255m   \x1b[48;2;244;244;244m \x1b[48;2;2;2;2m    \x1b[48;2;5;5;5m \x1b[48;2;255;255;255m   \x1b[38;2;249;249;249;48;2;242;242;242m▄\x1b[48;2;2;2;2m  \x1b[48;2;55;55;55m \x1b[48;2;255;255;255m   \x1b[48;2;0;0;0m \x1b[48;2;2;2;2m \x1b[38;2;5;5;5;48;2;239;239;239m▄\x1b[38;2;252;252;252;48;2;253;253;253m▄\x1b[48;2;255;255;255m \x1b[38;2;106;106;106;48;2;34;34;34m▄\x1b[48;2;255;255;255m   \x1b[48;2;0;0;0m \x1b[48;2;2;2;2m  \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;240;240;240m \x1b[48;2;255;255;255m   \x1b[48;2;2;2;2m   \x1b[38;2;0;0;0;48;2;9;9;9m▄\x1b[38;2;86;86;86;48;2;254;254;254m▄\x1b[38;2;253;253;253;48;2;255;255;255m▄\x1b[38;2;255;255;255;48;2;254;254;254m▄\x1b[38;2;254;254;254;48;2;244;244;244m▄\x1b[38;2;112;112;112;48;2;5;5;5m▄\x1b[38;2;3;3;3;48;2;2;2;2m▄\x1b[48;2;2;2;2m \x1b[38;2;5;5;5;48;2;175;175;175m▄\x1b[38;2;251;251;251;48;2;250;250;250m▄\x1b[48;2;255;255;255m \x1b[38;2;255;255;255;48;2;251;251;251m▄\x1b[38;2;252;252;252;48;2;16;16;16m▄\x1b[38;2;240;240;240;48;2;2;2;2m▄\x1b[38;2;194;194;194;48;2;2;2;2m▄\x1b[38;2;239;239;239;48;2;2;2;2m▄\x1b[38;2;255;255;255;48;2;6;6;6m▄\x1b[38;2;255;255;255;48;2;254;254;254m▄\x1b[48;2;255;255;255m \x1b[38;2;254;254;254;48;2;255;255;255m▄\x1b[38;2;0;0;0;48;2;173;173;173m▄\x1b[48;2;2;2;2m  \x1b[48;2;55;55;55m \x1b[48;2;255;255;255m   \x1b[48;2;0;0;0m \x1b[48;2;2;2;2m \x1b[38;2;2;2;2;48;2;19;19;19m▄\x1b[38;2;1;1;1;48;2;226;226;226m▄\x1b[38;2;248;248;248;48;2;255;255;255m▄\x1b[48;2;255;255;255m    \x1b[48;2;0;0;0m \x1b[48;2;2;2;2m  \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[38;2;222;222;222;48;2;240;240;240m▄\x1b[38;2;215;215;215;48;2;253;253;253m▄▄\x1b[38;2;218;218;218;48;2;254;254;254m▄\x1b[38;2;4;4;4;48;2;1;1;1m▄\x1b[48;2;2;2;2m    \x1b[38;2;12;12;12;48;2;252;252;252m▄\x1b[38;2;215;215;215;48;2;253;253;253m▄▄▄\x1b[38;2;215;215;215;48;2;1;1;1m▄\x1b[48;2;2;2;2m \x1b[38;2;0;0;0;48;2;5;5;5m▄\x1b[38;2;1;1;1;48;2;66;66;66m▄\x1b[38;2;8;8;8;48;2;234;234;234m▄\x1b[38;2;100;100;100;48;2;253;253;253m▄\x1b[48;2;255;255;255m    \x1b[38;2;254;254;254;48;2;253;253;253m▄\x1b[38;2;83;83;83;48;2;254;254;254m▄\x1b[38;2;5;5;5;48;2;255;255;255m▄\x1b[38;2;30;30;30;48;2;67;67;67m▄\x1b[48;2;2;2;2m   \x1b[38;2;48;48;48;48;2;54;54;54m▄\x1b[38;2;215;215;215;48;2;253;253;253m▄▄▄\x1b[48;2;0;0;0m \x1b[48;2;2;2;2m  \x1b[48;2;1;1;1m \x1b[38;2;4;4;4;48;2;202;202;202m▄\x1b[38;2;209;209;209;48;2;249;249;249m▄\x1b[38;2;215;215;215;48;2;253;253;253m▄▄▄\x1b[48;2;0;0;0m \x1b[48;2;2;2;2m  \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[38;2;253;253;253;48;2;0;0;0m▄\x1b[38;2;255;255;255;48;2;2;2;2m▄▄\x1b[38;2;254;254;254;48;2;2;2;2m▄\x1b[38;2;23;23;23;48;2;0;0;0m▄\x1b[48;2;2;2;2m  \x1b[38;2;9;9;9;48;2;0;0;0m▄\x1b[38;2;249;249;249;48;2;1;1;1m▄\x1b[38;2;255;255;255;48;2;0;0;0m▄\x1b[38;2;255;255;255;48;2;2;2;2m▄▄▄\x1b[38;2;2;2;2;48;2;0;0;0m▄\x1b[48;2;2;2;2m    \x1b[38;2;11;11;11;48;2;0;0;0m▄\x1b[38;2;240;240;240;48;2;5;5;5m▄\x1b[38;2;248;248;248;48;2;10;10;10m▄\x1b[38;2;255;255;255;48;2;9;9;9m▄\x1b[38;2;255;255;255;48;2;18;18;18m▄\x1b[38;2;255;255;255;48;2;17;17;17m▄\x1b[38;2;255;255;255;48;2;12;12;12m▄\x1b[38;2;255;255;255;48;2;1;1;1m▄\x1b[38;2;229;229;229;48;2;0;0;0m▄\x1b[38;2;3;3;3;48;2;2;2;2m▄\x1b[48;2;2;2;2m   \x1b[38;2;248;248;248;48;2;4;4;4m▄\x1b[38;2;255;255;255;48;2;2;2;2m▄▄\x1b[38;2;255;255;255;48;2;0;0;0m▄▄▄\x1b[38;2;253;253;253;48;2;0;0;0m▄\x1b[38;2;240;240;240;48;2;2;2;2m▄\x1b[38;2;252;252;252;48;2;0;0;0m▄\x1b[38;2;205;205;205;48;2;3;3;3m▄\x1b[38;2;1;1;1;48;2;3;3;3m▄\x1b[38;2;5;5;5;48;2;3;3;3m▄\x1b[48;2;2;2;2m   \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;253;253;253m \x1b[48;2;255;255;255m \x1b[38;2;253;253;253;48;2;255;255;255m▄\x1b[48;2;255;255;255m \x1b[38;2;251;251;251;48;2;229;229;229m▄\x1b[38;2;7;7;7;48;2;1;1;1m▄\x1b[38;2;6;6;6;48;2;2;2;2m▄\x1b[38;2;132;132;132;48;2;0;0;0m▄\x1b[38;2;253;253;253;48;2;250;250;250m▄\x1b[38;2;250;250;250;48;2;255;255;255m▄\x1b[48;2;255;255;255m   \x1b[48;2;4;4;4m \x1b[48;2;2;2;2m   \x1b[38;2;255;255;255;48;2;22;22;22m▄\x1b[48;2;255;255;255m \x1b[38;2;251;251;251;48;2;255;255;255m▄▄\x1b[38;2;101;101;101;48;2;253;253;253m▄\x1b[38;2;0;0;0;48;2;252;252;252m▄\x1b[38;2;0;0;0;48;2;255;255;255m▄\x1b[38;2;6;6;6;48;2;248;248;248m▄\x1b[38;2;38;38;38;48;2;252;252;252m▄\x1b[38;2;252;252;252;48;2;250;250;250m▄\x1b[38;2;1;1;1;48;2;0;0;0m▄\x1b[48;2;2;2;2m   \x1b[48;2;246;246;246m \x1b[48;2;255;255;255m  \x1b[38;2;253;253;253;48;2;254;254;254m▄\x1b[38;2;2;2;2;48;2;252;252;252m▄\x1b[38;2;3;3;3;48;2;252;252;252m▄\x1b[38;2;3;3;3;48;2;253;253;253m▄\x1b[38;2;103;103;103;48;2;252;252;252m▄\x1b[38;2;252;252;252;48;2;255;255;255m▄\x1b[48;2;255;255;255m \x1b[38;2;255;255;255;48;2;240;240;240m▄\x1b[38;2;251;251;251;48;2;42;42;42m▄\x1b[38;2;5;5;5;48;2;1;1;1m▄\x1b[48;2;2;2;2m  \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;253;253;253m \x1b[48;2;255;255;255m \x1b[38;2;255;255;255;48;2;247;247;247m▄\x1b[38;2;88;88;88;48;2;254;254;254m▄\x1b[38;2;250;250;250;48;2;255;255;255m▄\x1b[38;2;244;244;244;48;2;77;77;77m▄\x1b[48;2;1;1;1m \x1b[48;2;254;254;254m \x1b[38;2;255;255;255;48;2;251;251;251m▄\x1b[38;2;33;33;33;48;2;26;26;26m▄\x1b[48;2;255;255;255m   \x1b[48;2;4;4;4m \x1b[48;2;2;2;2m \x1b[38;2;6;6;6;48;2;0;0;0m▄\x1b[38;2;255;255;255;48;2;238;238;238m▄\x1b[48;2;255;255;255m  \x1b[38;2;252;252;252;48;2;255;255;255m▄\x1b[38;2;1;1;1;48;2;50;50;50m▄\x1b[38;2;2;2;2;48;2;12;12;12m▄\x1b[48;2;2;2;2m    \x1b[38;2;2;2;2;48;2;3;3;3m▄\x1b[38;2;2;2;2;48;2;1;1;1m▄\x1b[48;2;2;2;2m   \x1b[48;2;246;246;246m \x1b[48;2;255;255;255m  \x1b[48;2;253;253;253m \x1b[48;2;2;2;2m  \x1b[38;2;2;2;2;48;2;1;1;1m▄\x1b[48;2;2;2;2m \x1b[38;2;188;188;188;48;2;202;202;202m▄\x1b[48;2;255;255;255m   \x1b[38;2;3;3;3;48;2;2;2;2m▄\x1b[48;2;2;2;2m  \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;253;253;253m \x1b[48;2;255;255;255m  \x1b[48;2;4;4;4m \x1b[38;2;239;239;239;48;2;247;247;247m▄\x1b[38;2;255;255;255;48;2;246;246;246m▄\x1b[38;2;254;254;254;48;2;170;170;170m▄\x1b[38;2;254;254;254;48;2;252;252;252m▄\x1b[38;2;41;41;41;48;2;254;254;254m▄\x1b[38;2;0;0;0;48;2;14;14;14m▄\x1b[48;2;255;255;255m   \x1b[48;2;4;4;4m \x1b[48;2;2;2;2m \x1b[38;2;9;9;9;48;2;10;10;10m▄\x1b[38;2;251;251;251;48;2;253;253;253m▄\x1b[48;2;255;255;255m  \x1b[38;2;187;187;187;48;2;226;226;226m▄\x1b[38;2;0;0;0;48;2;3;3;3m▄\x1b[48;2;2;2;2m          \x1b[48;2;246;246;246m \x1b[48;2;255;255;255m  \x1b[48;2;254;254;254m \x1b[38;2;254;254;254;48;2;0;0;0m▄▄\x1b[38;2;254;254;254;48;2;3;3;3m▄\x1b[38;2;254;254;254;48;2;1;1;1m▄\x1b[48;2;255;255;255m \x1b[38;2;254;254;254;48;2;255;255;255m▄\x1b[38;2;225;225;225;48;2;254;254;254m▄\x1b[38;2;169;169;169;48;2;252;252;252m▄\x1b[38;2;2;2;2;48;2;5;5;5m▄\x1b[48;2;2;2;2m  \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;253;253;253m \x1b[48;2;255;255;255m  \x1b[48;2;4;4;4m \x1b[38;2;0;0;0;48;2;92;92;92m▄\x1b[38;2;181;181;181;48;2;255;255;255m▄▄\x1b[38;2;170;170;170;48;2;255;255;255m▄\x1b[38;2;1;1;1;48;2;0;0;0m▄\x1b[48;2;0;0;0m \x1b[48;2;255;255;255m   \x1b[48;2;4;4;4m \x1b[48;2;2;2;2m \x1b[48;2;0;0;0m \x1b[38;2;253;253;253;48;2;248;248;248m▄\x1b[48;2;255;255;255m  \x1b[38;2;248;248;248;48;2;207;207;207m▄\x1b[38;2;0;0;0;48;2;3;3;3m▄\x1b[48;2;2;2;2m          \x1b[48;2;246;246;246m \x1b[48;2;255;255;255m  \x1b[38;2;254;254;254;48;2;255;255;255m▄\x1b[38;2;21;21;21;48;2;255;255;255m▄\x1b[38;2;25;25;25;48;2;255;255;255m▄\x1b[38;2;11;11;11;48;2;255;255;255m▄\x1b[38;2;3;3;3;48;2;255;255;255m▄\x1b[38;2;1;1;1;48;2;255;255;255m▄\x1b[38;2;0;0;0;48;2;253;253;253m▄\x1b[38;2;1;1;1;48;2;109;109;109m▄\x1b[38;2;2;2;2;48;2;0;0;0m▄\x1b[48;2;2;2;2m   \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;253;253;253m \x1b[48;2;255;255;255m  \x1b[48;2;5;5;5m \x1b[48;2;2;2;2m     \x1b[48;2;0;0;0m \x1b[48;2;255;255;255m   \x1b[48;2;4;4;4m \x1b[48;2;2;2;2m \x1b[38;2;0;0;0;48;2;4;4;4m▄\x1b[38;2;14;14;14;48;2;248;248;248m▄\x1b[48;2;255;255;255m  \x1b[38;2;255;255;255;48;2;251;251;251m▄\x1b[38;2;255;255;255;48;2;3;3;3m▄\x1b[38;2;6;6;6;48;2;8;8;8m▄\x1b[38;2;3;3;3;48;2;2;2;2m▄▄\x1b[38;2;8;8;8;48;2;2;2;2m▄\x1b[38;2;5;5;5;48;2;2;2;2m▄\x1b[38;2;79;79;79;48;2;10;10;10m▄\x1b[38;2;0;0;0;48;2;2;2;2m▄\x1b[48;2;2;2;2m   \x1b[48;2;246;246;246m \x1b[48;2;255;255;255m  \x1b[48;2;253;253;253m \x1b[48;2;2;2;2m           \x1b[m\n" +
-		"\x1b[48;2;2;2;2m   \x1b[48;2;253;253;253m \x1b[48;2;255;255;255m  \x1b[48;2;5;5;5m \x1b[48;2;2;2;2m     \x1b[48;2;0;0;0m \x1b[48;2;255;255;255m   \x1b[48;2;4;4;4m \x1b[48;2;2;2;2m \x1b[38;2;2;2;2;48;2;0;0;0m▄▄\x1b[38;2;1;1;1;48;2;231;231;231m▄\x1b[38;2;151;151;151;48;2;255;255;255m▄\x1b[48;2;255;255;255m \x1b[38;2;255;255;255;48;2;250;250;250m▄\x1b[38;2;255;255;255;48;2;253;253;253m▄\x1b[38;2;255;255;255;48;2;251;251;251m▄\x1b[38;2;255;255;255;48;2;222;222;222m▄\x1b[38;2;255;255;255;48;2;251;251;251m▄\x1b[38;2;255;255;255;48;2;252;252;252m▄\x1b[38;2;255;255;255;48;2;248;248;248m▄\x1b[48;2;0;0;0m \x1b[48;2;2;2;2m   \x1b[48;2;246;246;246m \x1b[48;2;255;255;255m  \x1b[48;2;253;253;253m \x1b[48;2;2;2;2m           \x1b[m\n" +
-		"\x1b[38;2;11;0;6;48;2;0;7;0m▄\x1b[38;2;132;30;51;48;2;7;0;5m▄\x1b[38;2;145;26;42;48;2;0;8;7m▄\x1b[38;2;142;26;45;48;2;102;106;107m▄\x1b[38;2;142;26;45;48;2;91;95;96m▄\x1b[38;2;142;26;45;48;2;99;103;104m▄\x1b[38;2;142;26;45;48;2;1;3;4m▄\x1b[38;2;142;26;45;48;2;0;3;4m▄▄\x1b[38;2;142;26;45;48;2;0;2;4m▄▄▄\x1b[38;2;142;26;45;48;2;0;0;2m▄\x1b[38;2;142;26;45;48;2;92;95;96m▄\x1b[38;2;142;26;45;48;2;91;95;96m▄▄\x1b[38;2;142;26;45;48;2;0;1;2m▄\x1b[38;2;142;26;45;48;2;0;3;4m▄▄▄▄▄\x1b[38;2;142;26;45;48;2;4;8;9m▄\x1b[38;2;142;26;45;48;2;54;58;59m▄\x1b[38;2;142;26;45;48;2;239;242;243m▄\x1b[38;2;142;26;45;48;2;250;253;254m▄\x1b[38;2;142;26;45;48;2;252;254;255m▄\x1b[38;2;142;26;45;48;2;250;253;253m▄\x1b[38;2;142;26;45;48;2;178;181;182m▄\x1b[38;2;142;26;45;48;2;23;25;27m▄\x1b[38;2;142;26;45;48;2;0;1;2m▄\x1b[38;2;142;26;45;48;2;0;2;4m▄▄\x1b[38;2;142;26;45;48;2;0;3;4m▄\x1b[38;2;142;26;45;48;2;77;81;82m▄\x1b[38;2;142;26;45;48;2;91;95;96m▄▄\x1b[38;2;142;26;45;48;2;99;104;104m▄\x1b[38;2;142;26;45;48;2;0;3;4m▄▄▄\x1b[38;2;142;26;45;48;2;0;2;4m▄▄▄▄▄▄\x1b[38;2;116;23;38;48;2;4;3;0m▄\x1b[38;2;0;8;0;48;2;0;4;0m▄\x1b[m\n" +
-		"\x1b[38;2;0;5;5;48;2;0;9;8m▄\x1b[38;2;160;19;48;48;2;142;30;46m▄\x1b[38;2;197;2;35;48;2;179;15;29m▄\x1b[38;2;184;10;37;48;2;189;8;34m▄\x1b[38;2;184;10;37;48;2;189;8;33m▄\x1b[38;2;184;10;37;48;2;189;8;34m▄▄\x1b[38;2;184;9;37;48;2;189;8;34m▄\x1b[38;2;184;9;37;48;2;190;8;34m▄\x1b[38;2;184;9;37;48;2;190;8;35m▄\x1b[38;2;184;9;38;48;2;190;8;35m▄\x1b[38;2;184;9;38;48;2;190;7;35m▄\x1b[38;2;184;9;38;48;2;191;7;35m▄\x1b[38;2;184;9;38;48;2;190;7;35m▄\x1b[38;2;184;9;38;48;2;190;8;35m▄\x1b[38;2;184;9;37;48;2;190;8;35m▄\x1b[38;2;184;9;37;48;2;190;8;34m▄\x1b[38;2;184;9;37;48;2;189;8;34m▄\x1b[38;2;184;10;37;48;2;189;8;34m▄▄\x1b[38;2;184;10;37;48;2;189;8;33m▄\x1b[38;2;184;10;37;48;2;189;8;34m▄▄\x1b[38;2;184;9;37;48;2;189;8;34m▄\x1b[38;2;184;9;37;48;2;190;8;34m▄▄\x1b[38;2;184;9;38;48;2;190;8;35m▄▄\x1b[38;2;184;9;38;48;2;191;7;35m▄▄\x1b[38;2;184;9;38;48;2;190;8;35m▄▄\x1b[38;2;184;9;37;48;2;190;8;34m▄▄\x1b[38;2;184;9;37;48;2;189;8;34m▄\x1b[38;2;184;10;37;48;2;189;8;34m▄\x1b[38;2;184;10;37;48;2;189;8;33m▄▄\x1b[38;2;184;10;37;48;2;189;8;34m▄\x1b[38;2;184;9;37;48;2;189;8;34m▄\x1b[38;2;184;9;37;48;2;190;8;34m▄▄\x1b[38;2;184;9;38;48;2;190;8;35m▄▄\x1b[38;2;184;9;38;48;2;191;7;35m▄▄\x1b[38;2;184;9;38;48;2;190;8;35m▄\x1b[38;2;165;20;47;48;2;175;27;59m▄\x1b[38;2;1;0;10;48;2;0;7;1m▄\x1b[m\n" +
-		"\x1b[38;2;2;2;2;48;2;1;2;5m▄\x1b[38;2;2;2;2;48;2;140;28;47m▄\x1b[38;2;2;2;2;48;2;166;17;36m▄\x1b[38;2;2;2;2;48;2;177;9;45m▄▄▄▄▄▄\x1b[38;2;2;2;2;48;2;177;9;46m▄▄▄▄▄▄▄\x1b[38;2;2;2;2;48;2;177;9;45m▄▄▄▄▄▄▄▄▄▄\x1b[38;2;2;2;2;48;2;177;9;46m▄▄▄▄▄▄\x1b[38;2;2;2;2;48;2;177;9;45m▄▄▄▄▄▄▄▄▄▄\x1b[38;2;2;2;2;48;2;177;9;46m▄▄▄▄▄\x1b[38;2;2;2;2;48;2;133;30;43m▄\x1b[38;2;2;2;2;48;2;0;2;10m▄\x1b[m\n"
-}
@@ -0,0 +1,38 @@
+//go:build clipboard
+
+package tui
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardCommands lists the clipboard tools tried in order on each OS.
+//
+//nolint:gochecknoglobals // Fixed lookup table.
+var clipboardCommands = map[string][][]string{
+	"darwin":  {{"pbcopy"}},
+	"windows": {{"clip.exe"}},
+	"linux":   {{"wl-copy"}, {"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}, {"clip.exe"}},
+}
+
+// copyToClipboard writes text to the system clipboard using the first available tool:
+// pbcopy on macOS, clip.exe on Windows and WSL, and wl-copy, xclip or xsel elsewhere.
+func copyToClipboard(text string) error {
+	cmds, ok := clipboardCommands[runtime.GOOS]
+	if !ok {
+		cmds = clipboardCommands["linux"]
+	}
+	for _, args := range cmds {
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, args[1:]...) //nolint:gosec // Fixed clipboard tools.
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return errors.New("no clipboard tool found (install wl-copy, xclip or xsel)")
+}
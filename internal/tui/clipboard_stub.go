@@ -0,0 +1,11 @@
+//go:build !clipboard
+
+package tui
+
+import "errors"
+
+// copyToClipboard is unavailable unless built with the clipboard tag, which keeps headless
+// builds free of clipboard tooling.
+func copyToClipboard(string) error {
+	return errors.New("clipboard support not built in (rebuild with -tags clipboard)")
+}
@@ -8,6 +8,8 @@ const (
 	defaultDeadlineSeconds = 30
 	resultsPollIntervalMS  = 50
 	countdownTickSeconds   = 1
+	statusMessageSeconds   = 3
+	copiedMessageSeconds   = 2
 	sortModesCount         = 3
 	// viewport height for the transient scanning list (small, fast fly-out).
 	scanningViewportLines = 3
@@ -22,4 +24,6 @@ const (
 	defaultDeadlineDuration = time.Duration(defaultDeadlineSeconds) * time.Second
 	resultsPollInterval     = time.Duration(resultsPollIntervalMS) * time.Millisecond
 	countdownTickInterval   = time.Duration(countdownTickSeconds) * time.Second
+	statusMessageDuration   = time.Duration(statusMessageSeconds) * time.Second
+	copiedMessageDuration   = time.Duration(copiedMessageSeconds) * time.Second
 )
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// startFilter focuses the filter input, keeping any filter already applied.
+func (m Model) startFilter() (Model, tea.Cmd) { // nolint:ireturn
+	m.filterActive = true
+	m.filterInput.SetValue(m.filter)
+	m.filterInput.CursorEnd()
+	return m, m.filterInput.Focus()
+}
+
+// handleFilterKey routes keys to the filter input and refilters the results list whenever
+// its value changes. Enter keeps the filter and returns to the list; esc clears it.
+func (m Model) handleFilterKey(msg tea.KeyMsg) (Model, tea.Cmd) { // nolint:ireturn
+	switch {
+	case key.Matches(msg, m.keys.Escape):
+		m.clearFilter()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Confirm):
+		m.filterActive = false
+		m.filterInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	if v := m.filterInput.Value(); v != m.filter {
+		m.filter = v
+		m.syncResultsListItems()
+		m.resultsList.ResetSelected()
+	}
+	return m, cmd
+}
+
+// clearFilter closes the filter input and restores every host to the list.
+func (m *Model) clearFilter() {
+	m.filterActive = false
+	m.filter = ""
+	m.filterInput.Reset()
+	m.filterInput.Blur()
+	m.syncResultsListItems()
+}
+
+// renderFilterLine renders the filter input while it is focused, or the applied filter.
+func renderFilterLine(m Model) string {
+	switch {
+	case m.filterActive:
+		return m.filterInput.View()
+	case m.filter != "":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("69")).Render("/"+m.filter) +
+			lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("  (esc to clear)")
+	default:
+		return ""
+	}
+}
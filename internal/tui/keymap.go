@@ -6,18 +6,22 @@ import (
 
 // keyMap defines global key bindings used across the TUI.
 type keyMap struct {
-	Quit     key.Binding
-	Help     key.Binding
-	Sort     key.Binding
-	Repoll   key.Binding
-	Up       key.Binding
-	Down     key.Binding
-	PageUp   key.Binding
-	PageDown key.Binding
-	Home     key.Binding
-	End      key.Binding
-	Search   key.Binding
-	Escape   key.Binding
+	Quit      key.Binding
+	Help      key.Binding
+	Sort      key.Binding
+	Repoll    key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	PageUp    key.Binding
+	PageDown  key.Binding
+	Home      key.Binding
+	End       key.Binding
+	Search    key.Binding
+	Escape    key.Binding
+	Allowlist key.Binding
+	Confirm   key.Binding
+	Secrets   key.Binding
+	Copy      key.Binding
 }
 
 func newKeyMap() keyMap {
@@ -54,5 +58,22 @@ func newKeyMap() keyMap {
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "clear"),
 		),
+		Allowlist: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "allowlist server"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "confirm"),
+		),
+		// Secrets shares "s" with Sort, which is only handled while scanning.
+		Secrets: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "toggle secrets"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "copy name and hash"),
+		),
 	}
 }
@@ -1,6 +1,10 @@
 package tui
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/ensigniasec/run-mcp/internal/scanner"
+)
 
 // Message types for Bubble Tea update loop.
 
@@ -16,8 +20,25 @@ type resultsMsg struct {
 	Status  Status
 	Message string
 	Err     error
+	// Hash is the server's allowlist hash, set when the server is discovered.
+	Hash string
+	// Secrets are secret findings from a scanned file; a message with an empty HostID
+	// carries only secrets.
+	Secrets []scanner.SecretFinding
+}
+
+// allowlistResultMsg reports the outcome of adding a server to the allowlist.
+type allowlistResultMsg struct {
+	Name string
+	Err  error
 }
 
+// clipboardResultMsg reports the outcome of copying a server to the clipboard.
+type clipboardResultMsg struct{ Err error }
+
+// clearStatusMsg hides the ephemeral status line set by seq, unless a newer one replaced it.
+type clearStatusMsg struct{ seq int }
+
 // fileScanMsg carries per-file scanning progress for the scanning phase.
 type fileScanMsg struct {
 	Path     string
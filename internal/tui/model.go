@@ -6,7 +6,10 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ensigniasec/run-mcp/internal/scanner"
 )
 
 // Status represents per-host lifecycle.
@@ -29,6 +32,8 @@ type HostRow struct {
 	Spinner     spinner.Model
 	LastMessage string
 	Error       string
+	// Hash identifies the server's launch config for the allowlist, e.g. "sha256:<hex>".
+	Hash string
 }
 
 // SortMode controls row ordering.
@@ -72,12 +77,43 @@ type Model struct {
 	_seenStarts    map[string]struct{}
 
 	// mode flags for header rendering
-	offline   bool
-	anonymous bool
+	offline     bool
+	anonymous   bool
+	environment string
+	label       string
+	noBanner    bool
+	noColor     bool
 
 	// results list view (search, pagination, highlight)
 	resultsList list.Model
 
+	// secrets are the secret findings streamed from scanned files, shown below the results
+	// list unless hidden with the Secrets key.
+	secrets       []scanner.SecretFinding
+	secretsHidden bool
+
+	// filterActive is set while the "/" filter input has focus; filter is the applied,
+	// case-insensitive name substring.
+	filterActive bool
+	filter       string
+	filterInput  textinput.Model
+
+	// allowlistConfirm is set while the prompt to allowlist confirmHost is shown.
+	allowlistConfirm bool
+	confirmHost      HostRow
+	confirmInput     textinput.Model
+	// storagePath is the storage file the allowlist is saved to; empty uses the default.
+	storagePath string
+	// namesRedacted is set when server names are shown as hashes, which cannot be allowlisted.
+	namesRedacted bool
+	// statusMessage is an ephemeral result line shown below the list.
+	statusMessage string
+	statusIsError bool
+	statusSeq     int
+
+	// clipboard copies text to the system clipboard; replaced in tests.
+	clipboard func(string) error
+
 	// keymap for consistent keybindings
 	keys keyMap
 }
@@ -89,9 +125,16 @@ func NewModel(deadline time.Time, initialHosts []HostRow, resultsCh chan results
 	delegate := resultsDelegate{}
 	lst := list.New([]list.Item{}, delegate, 0, 0)
 	lst.SetShowStatusBar(true)
-	lst.SetFilteringEnabled(true)
+	// Filtering is done by the model on host names, see visibleHosts.
+	lst.SetFilteringEnabled(false)
 	lst.SetShowHelp(false)
 	lst.SetShowPagination(true)
+	ti := textinput.New()
+	ti.Placeholder = "y/N"
+	ti.CharLimit = 3
+	fi := textinput.New()
+	fi.Prompt = "/"
+	fi.Placeholder = "filter by name"
 	return Model{
 		deadline:      deadline,
 		now:           time.Now(),
@@ -103,6 +146,9 @@ func NewModel(deadline time.Time, initialHosts []HostRow, resultsCh chan results
 		helpVisible:   false,
 		scanCompleted: false,
 		resultsList:   lst,
+		confirmInput:  ti,
+		filterInput:   fi,
+		clipboard:     copyToClipboard,
 		keys:          newKeyMap(),
 	}
 }
@@ -13,14 +13,39 @@ import (
 	"github.com/ensigniasec/run-mcp/internal/scanner"
 )
 
+// Options controls how the TUI is rendered.
+type Options struct {
+	// Environment, when set, is shown next to the mode badge.
+	Environment string
+	// Label, when set, is shown right after the ONLINE/OFFLINE badge; the CLI passes the value
+	// of the first --label.
+	Label string
+	// NoBanner hides the 24-bit colour ANSI banner.
+	NoBanner bool
+	// NoColor strips ANSI colour and style sequences from every render.
+	NoColor bool
+	// Deadline is how long the scan may take before pending hosts are marked as timed out.
+	// Zero uses the default of 30 seconds.
+	Deadline time.Duration
+	// StoragePath is the storage file servers are allowlisted in; empty uses the default location.
+	StoragePath string
+	// RedactServerNames shows servers under scanner.RedactServerName hashes salted with
+	// RedactSalt. Allowlisting is disabled, since it needs the real name.
+	RedactServerNames bool
+	RedactSalt        string
+}
+
 // Run starts the Bubble Tea TUI program, wiring the scanner stream to messages.
-func Run(ctx context.Context, configPaths []string, s *scanner.MCPScanner, rc *scanner.RatingsCollector) error {
+func Run(ctx context.Context, configPaths []string, s *scanner.MCPScanner, rc *scanner.RatingsCollector, opts Options) error {
 	// Shared results channel between adapter and model.
 	resultsCh := make(chan resultsMsg, channelBufferSize)
 	fileCh := make(chan fileScanMsg, channelBufferSize)
 
 	// Build initial model – start with empty hosts; they will stream in.
-	deadline := time.Now().Add(defaultDeadlineDuration)
+	if opts.Deadline <= 0 {
+		opts.Deadline = defaultDeadlineDuration
+	}
+	deadline := time.Now().Add(opts.Deadline)
 	model := NewModel(deadline, nil, resultsCh, fileCh)
 
 	// Determine mode flags for display.
@@ -29,24 +54,39 @@ func Run(ctx context.Context, configPaths []string, s *scanner.MCPScanner, rc *s
 		model.anonymous = true
 	}
 	model.offline = isOffline
+	model.environment = opts.Environment
+	model.label = opts.Label
+	model.noBanner = opts.NoBanner
+	model.noColor = opts.NoColor
+	model.storagePath = opts.StoragePath
+	model.namesRedacted = opts.RedactServerNames
+	displayName := func(name string) string { return name }
+	if opts.RedactServerNames {
+		displayName = func(name string) string { return scanner.RedactServerName(name, opts.RedactSalt) }
+	}
 
 	// Wire collector stage notifiers to results updates (even if offline at start).
 	if rc != nil {
 		rc.WithStageNotifiers(
 			func(serverName string) {
-				resultsCh <- resultsMsg{HostID: serverName, Status: Running, Message: "submitted"}
+				resultsCh <- resultsMsg{HostID: displayName(serverName), Status: Running, Message: "submitted"}
 			},
 			func(serverName string) {
-				resultsCh <- resultsMsg{HostID: serverName, Status: Running, Message: "processing"}
+				resultsCh <- resultsMsg{HostID: displayName(serverName), Status: Running, Message: "processing"}
 			},
 			func(serverName string) {
-				resultsCh <- resultsMsg{HostID: serverName, Status: OK, Message: "results received"}
+				resultsCh <- resultsMsg{HostID: displayName(serverName), Status: OK, Message: "results received"}
 			},
 		)
 	}
 
 	// Bridge: stream file results to TUI messages.
 	s.WithStreamingCallback(func(filePath string, fileResult *scanner.FileResult, err error) {
+		if fileResult != nil && opts.RedactServerNames {
+			redacted := *fileResult
+			scanner.RedactFileServerNames(&redacted, opts.RedactSalt)
+			fileResult = &redacted
+		}
 		handleFileCallback(fileCh, resultsCh, isOffline, filePath, fileResult, err)
 	})
 
@@ -79,15 +119,19 @@ func handleFileCallback(fileCh chan fileScanMsg, resultsCh chan resultsMsg, isOf
 		return
 	}
 	found := len(fileResult.Servers) > 0
+	if len(fileResult.SecretFindings) > 0 {
+		resultsCh <- resultsMsg{Secrets: fileResult.SecretFindings}
+	}
 	fileCh <- fileScanMsg{Path: filePath, Complete: false}
 	fileCh <- fileScanMsg{Path: filePath, Found: found, Complete: true}
 	for _, server := range fileResult.Servers {
 		hostID := server.Name
+		hash := allowlistHash(server.Fingerprint)
 		if isOffline {
-			resultsCh <- resultsMsg{HostID: hostID, Status: OK, Message: "discovered (offline)"}
+			resultsCh <- resultsMsg{HostID: hostID, Status: OK, Message: "discovered (offline)", Hash: hash}
 			continue
 		}
-		resultsCh <- resultsMsg{HostID: hostID, Status: Running, Message: "discovered"}
+		resultsCh <- resultsMsg{HostID: hostID, Status: Running, Message: "discovered", Hash: hash}
 	}
 }
 
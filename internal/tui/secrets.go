@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ensigniasec/run-mcp/internal/scanner"
+)
+
+// renderSecrets renders the secrets section shown below the results list, one red row per
+// finding: [SERVER] KIND: KEY = VALUE (file:line). It is empty when there are no findings
+// or the section is hidden.
+func renderSecrets(m Model) string {
+	if m.secretsHidden || len(m.secrets) == 0 {
+		return ""
+	}
+	row := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("🔐 SECRETS (%d)", len(m.secrets))))
+	for _, f := range m.secrets {
+		b.WriteString("\n")
+		b.WriteString(row.Render(secretRow(f)))
+	}
+	return b.String()
+}
+
+func secretRow(f scanner.SecretFinding) string {
+	line := fmt.Sprintf("[%s] %s: %s = %s", f.ServerName, f.Kind, f.Key, f.Value)
+	if locs := secretLocations(f.Occurrences); locs != "" {
+		line += " (" + locs + ")"
+	}
+	return line
+}
+
+// secretLocations lists each occurrence as file:line, sorted by file.
+func secretLocations(occurrences map[string][]int) string {
+	files := make([]string, 0, len(occurrences))
+	for file := range occurrences {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	var locs []string
+	for _, file := range files {
+		if len(occurrences[file]) == 0 {
+			locs = append(locs, file)
+		}
+		for _, line := range occurrences[file] {
+			locs = append(locs, fmt.Sprintf("%s:%d", file, line))
+		}
+	}
+	return strings.Join(locs, ", ")
+}
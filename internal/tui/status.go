@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// setStatus shows an ephemeral status line and schedules its removal.
+func (m Model) setStatus(text string, isError bool) (Model, tea.Cmd) { // nolint:ireturn
+	return m.setStatusFor(text, isError, statusMessageDuration)
+}
+
+// setStatusFor shows an ephemeral status line for d.
+func (m Model) setStatusFor(text string, isError bool, d time.Duration) (Model, tea.Cmd) { // nolint:ireturn
+	m.statusSeq++
+	m.statusMessage = text
+	m.statusIsError = isError
+	seq := m.statusSeq
+	return m, tea.Tick(d, func(time.Time) tea.Msg { return clearStatusMsg{seq: seq} })
+}
+
+// renderStatusLine renders the allowlist prompt or the current status message, if any.
+func renderStatusLine(m Model) string {
+	switch {
+	case m.allowlistConfirm:
+		return m.confirmInput.View()
+	case m.statusMessage == "":
+		return ""
+	case m.statusIsError:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("✗ " + m.statusMessage)
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render("✓ " + m.statusMessage)
+	}
+}
+
+// copySelected copies the selected server's name and hash, separated by a space, to the clipboard.
+func (m Model) copySelected() tea.Cmd {
+	host, ok := m.selectedHost()
+	if !ok {
+		return nil
+	}
+	text := strings.TrimSpace(host.Name + " " + host.Hash)
+	clip := m.clipboard
+	return func() tea.Msg { return clipboardResultMsg{Err: clip(text)} }
+}
@@ -1,8 +1,10 @@
 package tui
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -14,7 +16,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { // nolint:ireturn,goco
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.allowlistConfirm {
+			return m.handleAllowlistConfirmKey(x)
+		}
+		if m.filterActive {
+			return m.handleFilterKey(x)
+		}
 		if m.scanCompleted {
+			switch {
+			case key.Matches(x, m.keys.Allowlist):
+				return m.startAllowlistConfirm()
+			case key.Matches(x, m.keys.Search):
+				return m.startFilter()
+			case key.Matches(x, m.keys.Copy):
+				return m, m.copySelected()
+			case key.Matches(x, m.keys.Secrets):
+				m.secretsHidden = !m.secretsHidden
+				return m, nil
+			case key.Matches(x, m.keys.Escape) && m.filter != "":
+				m.clearFilter()
+				return m, nil
+			}
 			// Results mode: let list handle the key
 			var cmd tea.Cmd
 			m.resultsList, cmd = m.resultsList.Update(x)
@@ -37,6 +59,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { // nolint:ireturn,goco
 		return m, nil
 
 	case resultsMsg:
+		m.secrets = append(m.secrets, x.Secrets...)
+		if x.HostID == "" {
+			return m, m.listenForResults()
+		}
 		m.applyResult(x)
 		// Keep list items in sync when hosts change
 		m.syncResultsListItems()
@@ -83,6 +109,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { // nolint:ireturn,goco
 		}
 		return m, m.listenForFileResults()
 
+	case allowlistResultMsg:
+		if x.Err != nil {
+			return m.setStatus(fmt.Sprintf("Failed to allowlist %q: %v", x.Name, x.Err), true)
+		}
+		return m.setStatus(fmt.Sprintf("Added %q to the allowlist", x.Name), false)
+
+	case clipboardResultMsg:
+		if x.Err != nil {
+			return m.setStatus("Copy failed: "+x.Err.Error(), true)
+		}
+		return m.setStatusFor("Copied to clipboard", false, copiedMessageDuration)
+
+	case clearStatusMsg:
+		if x.seq == m.statusSeq {
+			m.statusMessage = ""
+		}
+		return m, nil
+
 	case scanCompleteMsg:
 		m.scanCompleted = true
 		_ = m.progress.SetPercent(1.0)
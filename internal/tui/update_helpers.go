@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -61,10 +63,13 @@ func (m *Model) applyResult(x resultsMsg) {
 			if m.hosts[i].ID == x.HostID {
 				m.hosts[i].Status = x.Status
 				m.hosts[i].LastMessage = x.Message
+				if x.Hash != "" {
+					m.hosts[i].Hash = x.Hash
+				}
 				return
 			}
 		}
-		m.hosts = append(m.hosts, HostRow{ID: x.HostID, Name: x.HostID, Status: x.Status, LastMessage: x.Message, Error: errString(x.Err)})
+		m.hosts = append(m.hosts, HostRow{ID: x.HostID, Name: x.HostID, Status: x.Status, LastMessage: x.Message, Error: errString(x.Err), Hash: x.Hash})
 		return
 	}
 	// Only render OK discoveries as rows for final state.
@@ -76,6 +81,9 @@ func (m *Model) applyResult(x resultsMsg) {
 		if m.hosts[i].ID == x.HostID {
 			m.hosts[i].Status = x.Status
 			m.hosts[i].LastMessage = x.Message
+			if x.Hash != "" {
+				m.hosts[i].Hash = x.Hash
+			}
 			if x.Err != nil {
 				m.hosts[i].Error = x.Err.Error()
 			}
@@ -83,7 +91,7 @@ func (m *Model) applyResult(x resultsMsg) {
 			return
 		}
 	}
-	m.hosts = append(m.hosts, HostRow{ID: x.HostID, Name: x.HostID, Status: x.Status, LastMessage: x.Message, Error: errString(x.Err)})
+	m.hosts = append(m.hosts, HostRow{ID: x.HostID, Name: x.HostID, Status: x.Status, LastMessage: x.Message, Error: errString(x.Err), Hash: x.Hash})
 	m.bumpCounters(x.Status)
 }
 
@@ -98,11 +106,31 @@ func (m *Model) bumpCounters(status Status) {
 	}
 }
 
-// syncResultsListItems rebuilds the list items from current hosts.
+// syncResultsListItems rebuilds the list items from the hosts matching the filter.
 func (m *Model) syncResultsListItems() {
-	items := make([]list.Item, 0, len(m.hosts))
+	m.resultsList.SetItems(resultItems(m.visibleHosts()))
+}
+
+// visibleHosts returns the hosts whose name contains the filter, ignoring case.
+func (m Model) visibleHosts() []HostRow {
+	if m.filter == "" {
+		return m.hosts
+	}
+	needle := strings.ToLower(m.filter)
+	out := make([]HostRow, 0, len(m.hosts))
 	for _, h := range m.hosts {
+		if strings.Contains(strings.ToLower(h.Name), needle) {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// resultItems converts hosts to results list items.
+func resultItems(hosts []HostRow) []list.Item {
+	items := make([]list.Item, 0, len(hosts))
+	for _, h := range hosts {
 		items = append(items, resultItem{ID: h.ID, Name: h.Name, Status: h.Status, Message: h.LastMessage, ErrText: h.Error})
 	}
-	m.resultsList.SetItems(items)
+	return items
 }
@@ -5,16 +5,26 @@ import (
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/ensigniasec/run-mcp/internal/scanner"
 )
 
 func (m Model) View() string {
+	view := m.render()
+	if m.noColor {
+		return ansi.Strip(view)
+	}
+	return view
+}
+
+func (m Model) render() string {
 	if m.quitting {
 		return "Shutting down...\n"
 	}
 
-	left := runMCPBanner()
+	left := scanner.Banner(m.noBanner)
 	leftWidth := lipgloss.Width(left)
 	leftHeight := lipgloss.Height(left)
 	if leftHeight == 0 {
@@ -26,7 +36,7 @@ func (m Model) View() string {
 
 	// Constrain right to the same height as the banner, with footer pinned at bottom.
 	rightStyled := lipgloss.NewStyle().Height(leftHeight).Render(
-		pinFooter(right, renderFooter(), leftHeight),
+		pinFooter(right, renderFooter(m), leftHeight),
 	)
 
 	// If we have a window width, size the right column but cap to 90 cols.
@@ -39,7 +49,7 @@ func (m Model) View() string {
 			available = rightViewportMax
 		}
 		rightStyled = lipgloss.NewStyle().MarginLeft(gap).Width(available).Height(leftHeight).Render(
-			pinFooter(right, renderFooter(), leftHeight),
+			pinFooter(right, renderFooter(m), leftHeight),
 		)
 		return lipgloss.JoinHorizontal(lipgloss.Top, left, rightStyled)
 	}
@@ -62,7 +72,7 @@ func renderMainContent(m Model) string {
 	b.WriteString("\n")
 
 	// Compute right column width similar to View and cap to 90.
-	leftBanner := runMCPBanner()
+	leftBanner := scanner.Banner(m.noBanner)
 	leftWidth := lipgloss.Width(leftBanner)
 	leftHeight := lipgloss.Height(leftBanner)
 	if leftHeight == 0 {
@@ -102,21 +112,41 @@ func renderMainContent(m Model) string {
 	if !m.scanCompleted {
 		b.WriteString(renderScanningList(m))
 	} else {
-		// Build list items from hosts (already filtered to OK in applyResult).
-		items := make([]list.Item, 0, len(m.hosts))
-		for _, h := range m.hosts {
-			items = append(items, resultItem{ID: h.ID, Name: h.Name, Status: h.Status, Message: h.LastMessage, ErrText: h.Error})
-		}
+		// Build list items from hosts (already filtered to OK in applyResult) matching the filter.
 		lst := m.resultsList
-		lst.SetItems(items)
+		lst.SetItems(resultItems(m.visibleHosts()))
+		filterLine := renderFilterLine(m)
+		if filterLine != "" {
+			b.WriteString(filterLine)
+			b.WriteString("\n")
+		}
 		// Constrain list height to fit within the banner height minus header/progress lines.
 		// Header+countdown+progress+spacer roughly consume listOverheadLines; keep at least listMinHeight for list.
 		listHeight := leftHeight - listOverheadLines
+		statusLine := renderStatusLine(m)
+		if statusLine != "" {
+			listHeight--
+		}
+		if filterLine != "" {
+			listHeight--
+		}
+		secrets := renderSecrets(m)
+		if secrets != "" {
+			listHeight -= lipgloss.Height(secrets) + 1
+		}
 		if listHeight < listMinHeight {
 			listHeight = listMinHeight
 		}
 		lst.SetSize(rightMax, listHeight)
 		b.WriteString(lst.View())
+		if statusLine != "" {
+			b.WriteString("\n")
+			b.WriteString(statusLine)
+		}
+		if secrets != "" {
+			b.WriteString("\n\n")
+			b.WriteString(secrets)
+		}
 	}
 
 	return b.String()
@@ -154,6 +184,17 @@ func renderCountdown(deadline, now time.Time) string {
 }
 
 func modeBadge(m Model) string {
+	badge := connectionBadge(m)
+	if m.label != "" {
+		badge += lipgloss.NewStyle().Foreground(lipgloss.Color("244")).PaddingRight(1).Render(m.label)
+	}
+	if m.environment != "" {
+		badge += lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Bold(true).Render(m.environment)
+	}
+	return badge
+}
+
+func connectionBadge(m Model) string {
 	style := lipgloss.NewStyle().Bold(true).Padding(0, 1)
 	switch {
 	case m.offline && m.anonymous:
@@ -200,7 +241,7 @@ func renderResults(m Model) string { //nolint:funlen,gocognit,gocyclo,cyclop WIP
 		return b.String()
 	}
 	// Compute right column width to right-justify the message, capped to 90.
-	leftWidth := lipgloss.Width(runMCPBanner())
+	leftWidth := lipgloss.Width(scanner.Banner(m.noBanner))
 	gap := 2
 	rightMax := rightViewportMax
 	if m.width > 0 && m.width > leftWidth+gap {
@@ -266,8 +307,12 @@ func renderStatus(s Status) string {
 	}
 }
 
-func renderFooter() string {
-	return lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("esc/q: quit • s: sort • r: repoll • ↑/↓ or j/k: move • h/?: help")
+func renderFooter(m Model) string {
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	if m.scanCompleted {
+		return style.Render("esc/q: quit • ↑/↓ or j/k: move • /: filter • a: allowlist • c: copy • s: secrets")
+	}
+	return style.Render("esc/q: quit • s: sort • r: repoll • ↑/↓ or j/k: move • h/?: help")
 }
 
 func renderHelp(m Model) string {
@@ -279,6 +324,10 @@ func renderHelp(m Model) string {
 		"q/ctrl+c: quit",
 		"s: cycle sort (status, duration, name)",
 		"r: repoll failed/timeouts (future)",
+		"/: filter servers by name (esc clears)",
+		"s: show or hide secrets once results are listed",
+		"c: copy the selected server's name and hash (needs -tags clipboard)",
+		"a: add the selected server to the allowlist",
 	}
 	return border.Render(strings.Join(content, "\n"))
 }
@@ -0,0 +1,102 @@
+// Package update checks GitHub for a newer run-mcp release than the running one.
+package update
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ensigniasec/run-mcp/internal/storage"
+)
+
+// LatestReleaseURL redirects to the tag page of the latest release.
+const LatestReleaseURL = "https://github.com/ensigniasec/run-mcp/releases/latest"
+
+// CheckInterval is the minimum time between two checks.
+const CheckInterval = 24 * time.Hour
+
+// requestTimeout bounds the release lookup so a slow network never delays a scan noticeably.
+const requestTimeout = time.Second
+
+// Latest returns the tag of the latest release, read from the Location header of the
+// redirect served at url.
+func Latest(ctx context.Context, url string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return "", err
+	}
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", errors.New("no redirect to the latest release")
+	}
+	return path.Base(strings.TrimSuffix(location, "/")), nil
+}
+
+// Newer reports whether the release tag latest is a later version than current. Versions
+// are compared as dotted numbers with an optional "v" prefix; pre-release and build suffixes
+// are ignored. Development builds and unparsable versions are never outdated.
+func Newer(latest, current string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) ([3]int, bool) {
+	var out [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) > len(out) {
+		return out, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
+
+// Check looks up the latest release at url unless st records a check within CheckInterval
+// of now, and returns its tag when it is newer than current. The check time is recorded in
+// st, which the caller saves. Network errors are ignored: Check then reports no update.
+func Check(ctx context.Context, st *storage.Storage, url, current string, now time.Time) (string, bool) {
+	if now.Sub(st.Data.LastUpdateCheck) < CheckInterval {
+		return "", false
+	}
+	st.Data.LastUpdateCheck = now
+	latest, err := Latest(ctx, url)
+	if err != nil || !Newer(latest, current) {
+		return "", false
+	}
+	return latest, true
+}
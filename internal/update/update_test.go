@@ -0,0 +1,84 @@
+//nolint:testpackage // White-box tests require access to unexported identifiers in this package.
+package update
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ensigniasec/run-mcp/internal/storage"
+)
+
+// releaseServer redirects every request to the tag page of tag and counts the requests.
+func releaseServer(t *testing.T, tag string) (*httptest.Server, *int) {
+	t.Helper()
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodHead, r.Method)
+		http.Redirect(w, r, "/ensigniasec/run-mcp/releases/tag/"+tag, http.StatusFound)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestLatest(t *testing.T) {
+	srv, _ := releaseServer(t, "v1.4.0")
+	latest, err := Latest(t.Context(), srv.URL+"/releases/latest")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0", latest)
+
+	noRedirect := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer noRedirect.Close()
+	_, err = Latest(t.Context(), noRedirect.URL)
+	require.Error(t, err)
+}
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v1.4.0", "1.3.9", true},
+		{"v1.4.0", "v1.4.0", false},
+		{"v1.10.0", "v1.9.2", true},
+		{"v2", "v1.99.99", true},
+		{"v1.3.0", "v1.4.0-rc.1", false},
+		{"v1.4.1", "v1.4.0+abc123", true},
+		{"v1.4.0", "dev", false},
+		{"latest", "v1.0.0", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Newer(tt.latest, tt.current), "%s vs %s", tt.latest, tt.current)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	srv, requests := releaseServer(t, "v1.4.0")
+	st := &storage.Storage{}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	latest, ok := Check(t.Context(), st, srv.URL, "v1.3.0", now)
+	require.True(t, ok)
+	assert.Equal(t, "v1.4.0", latest)
+	assert.Equal(t, now, st.Data.LastUpdateCheck)
+
+	_, ok = Check(t.Context(), st, srv.URL, "v1.3.0", now.Add(CheckInterval-time.Minute))
+	assert.False(t, ok, "checked within the last day")
+	assert.Equal(t, 1, *requests)
+
+	_, ok = Check(t.Context(), st, srv.URL, "v1.3.0", now.Add(CheckInterval))
+	assert.True(t, ok)
+	assert.Equal(t, 2, *requests)
+
+	// Network errors are silent but still recorded, so offline hosts are not slowed down daily.
+	srv.Close()
+	later := now.Add(3 * CheckInterval)
+	_, ok = Check(t.Context(), st, srv.URL, "v1.3.0", later)
+	assert.False(t, ok)
+	assert.Equal(t, later, st.Data.LastUpdateCheck)
+}